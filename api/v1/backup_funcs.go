@@ -126,6 +126,36 @@ func (backup *Backup) IsCompletedVolumeSnapshot() bool {
 		backup.Status.Phase == BackupPhaseCompleted
 }
 
+// IsCompletedPersistentVolumeBackup checks if a backup is a completed base
+// backup stored in the backup volume of a cluster
+func (backup *Backup) IsCompletedPersistentVolumeBackup() bool {
+	return backup != nil &&
+		backup.Spec.Method == BackupMethodPersistentVolume &&
+		backup.Status.Phase == BackupPhaseCompleted &&
+		backup.Status.PersistentVolumeBackupStatus != nil
+}
+
+// IsExported checks if the backup has been exported from another namespace
+// by a BackupExport, which is in charge of its status
+func (backup *Backup) IsExported() bool {
+	_, ok := backup.Annotations[utils.BackupExportedFromAnnotationName]
+	return ok
+}
+
+// IsPluginBased checks if the backup is handled by a plugin, either as
+// a plugin backup or as a logical backup whose dumps are handed over
+// to a plugin
+func (backup *Backup) IsPluginBased() bool {
+	switch backup.Spec.Method {
+	case BackupMethodPlugin:
+		return true
+	case BackupMethodLogical:
+		return !backup.Spec.PluginConfiguration.IsEmpty()
+	default:
+		return false
+	}
+}
+
 // IsInProgress check if a certain backup is in progress or not
 func (backupStatus *BackupStatus) IsInProgress() bool {
 	return backupStatus.Phase == BackupPhasePending ||
@@ -182,6 +212,59 @@ func (list *BackupList) CanExecuteBackup(backupName string) bool {
 	return true
 }
 
+// GetLatestPersistentVolumeBackup gets the most recent completed base backup
+// of the passed cluster stored in the backup volume, or nil if there is none
+func (list BackupList) GetLatestPersistentVolumeBackup(clusterName string) *Backup {
+	var latest *Backup
+	for i := range list.Items {
+		backup := &list.Items[i]
+		if backup.Spec.Cluster.Name != clusterName || !backup.IsCompletedPersistentVolumeBackup() {
+			continue
+		}
+		if backup.Status.StoppedAt == nil {
+			continue
+		}
+		if latest == nil || backup.Status.StoppedAt.After(latest.Status.StoppedAt.Time) {
+			latest = backup
+		}
+	}
+
+	return latest
+}
+
+// IsRecoverableFromBackupSource checks if the backup can be recovered by
+// referring to it in the backup source of a recovery bootstrap, which
+// is supported for the barmanObjectStore and persistentVolume methods
+func (backup *Backup) IsRecoverableFromBackupSource() bool {
+	return backup.Spec.Method == BackupMethodBarmanObjectStore ||
+		backup.Spec.Method == BackupMethodPersistentVolume
+}
+
+// GetLatestRecoverableBackup gets the most recent completed backup of the
+// passed cluster that can be recovered through the backup source of a
+// recovery bootstrap, ended before the passed time when not nil, or nil
+// if there is none
+func (list BackupList) GetLatestRecoverableBackup(clusterName string, before *time.Time) *Backup {
+	var latest *Backup
+	for i := range list.Items {
+		backup := &list.Items[i]
+		if backup.Spec.Cluster.Name != clusterName || backup.Status.Phase != BackupPhaseCompleted {
+			continue
+		}
+		if !backup.IsRecoverableFromBackupSource() || backup.Status.StoppedAt == nil {
+			continue
+		}
+		if before != nil && backup.Status.StoppedAt.After(*before) {
+			continue
+		}
+		if latest == nil || backup.Status.StoppedAt.After(latest.Status.StoppedAt.Time) {
+			latest = backup
+		}
+	}
+
+	return latest
+}
+
 // SortByName sorts the backup items in alphabetical order
 func (list *BackupList) SortByName() {
 	// Sort the list of backups in alphabetical order
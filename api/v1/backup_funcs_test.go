@@ -418,6 +418,16 @@ var _ = Describe("IsCompletedVolumeSnapshot", func() {
 	})
 })
 
+var _ = Describe("IsExported", func() {
+	It("detects the backups exported from another namespace", func() {
+		backup := Backup{}
+		Expect(backup.IsExported()).To(BeFalse())
+
+		backup.Annotations = map[string]string{utils.BackupExportedFromAnnotationName: "default/backup"}
+		Expect(backup.IsExported()).To(BeTrue())
+	})
+})
+
 var _ = Describe("GetVolumeSnapshotConfiguration", func() {
 	var (
 		backup          *Backup
@@ -480,3 +490,106 @@ var _ = Describe("GetVolumeSnapshotConfiguration", func() {
 		})
 	})
 })
+
+var _ = Describe("IsPluginBased", func() {
+	It("is true for plugin backups", func() {
+		backup := Backup{Spec: BackupSpec{Method: BackupMethodPlugin}}
+		Expect(backup.IsPluginBased()).To(BeTrue())
+	})
+
+	It("is true for logical backups handed over to a plugin", func() {
+		backup := Backup{Spec: BackupSpec{
+			Method:              BackupMethodLogical,
+			PluginConfiguration: &BackupPluginConfiguration{Name: "cnpg-i.example.com"},
+		}}
+		Expect(backup.IsPluginBased()).To(BeTrue())
+	})
+
+	It("is false for logical backups stored in a volume", func() {
+		backup := Backup{Spec: BackupSpec{Method: BackupMethodLogical}}
+		Expect(backup.IsPluginBased()).To(BeFalse())
+	})
+
+	It("is false for the other methods", func() {
+		backup := Backup{Spec: BackupSpec{
+			Method:              BackupMethodBarmanObjectStore,
+			PluginConfiguration: &BackupPluginConfiguration{Name: "cnpg-i.example.com"},
+		}}
+		Expect(backup.IsPluginBased()).To(BeFalse())
+	})
+})
+
+var _ = Describe("GetLatestPersistentVolumeBackup", func() {
+	newBackup := func(name, clusterName string, phase BackupPhase, stoppedAt time.Time) Backup {
+		return Backup{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: BackupSpec{
+				Cluster: LocalObjectReference{Name: clusterName},
+				Method:  BackupMethodPersistentVolume,
+			},
+			Status: BackupStatus{
+				Phase:                        phase,
+				StoppedAt:                    ptr.To(metav1.NewTime(stoppedAt)),
+				PersistentVolumeBackupStatus: &PersistentVolumeBackupStatus{},
+			},
+		}
+	}
+
+	now := time.Now()
+
+	It("returns the most recent completed backup of the cluster", func() {
+		list := BackupList{Items: []Backup{
+			newBackup("old", "cluster-example", BackupPhaseCompleted, now.Add(-2*time.Hour)),
+			newBackup("latest", "cluster-example", BackupPhaseCompleted, now.Add(-time.Hour)),
+			newBackup("failed", "cluster-example", BackupPhaseFailed, now),
+			newBackup("other", "another-cluster", BackupPhaseCompleted, now),
+		}}
+		Expect(list.GetLatestPersistentVolumeBackup("cluster-example").Name).To(Equal("latest"))
+	})
+
+	It("ignores the backups taken with other methods", func() {
+		backup := newBackup("snapshot", "cluster-example", BackupPhaseCompleted, now)
+		backup.Spec.Method = BackupMethodVolumeSnapshot
+		list := BackupList{Items: []Backup{backup}}
+		Expect(list.GetLatestPersistentVolumeBackup("cluster-example")).To(BeNil())
+	})
+})
+
+var _ = Describe("GetLatestRecoverableBackup", func() {
+	newBackup := func(name string, method BackupMethod, phase BackupPhase, stoppedAt time.Time) Backup {
+		return Backup{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: BackupSpec{
+				Cluster: LocalObjectReference{Name: "cluster-example"},
+				Method:  method,
+			},
+			Status: BackupStatus{
+				Phase:     phase,
+				StoppedAt: ptr.To(metav1.NewTime(stoppedAt)),
+			},
+		}
+	}
+
+	now := time.Now()
+	list := BackupList{Items: []Backup{
+		newBackup("old", BackupMethodBarmanObjectStore, BackupPhaseCompleted, now.Add(-3*time.Hour)),
+		newBackup("volume", BackupMethodPersistentVolume, BackupPhaseCompleted, now.Add(-150*time.Minute)),
+		newBackup("snapshot", BackupMethodVolumeSnapshot, BackupPhaseCompleted, now.Add(-2*time.Hour)),
+		newBackup("plugin", BackupMethodPlugin, BackupPhaseCompleted, now.Add(-90*time.Minute)),
+		newBackup("failed", BackupMethodBarmanObjectStore, BackupPhaseFailed, now.Add(-time.Hour)),
+		newBackup("logical", BackupMethodLogical, BackupPhaseCompleted, now),
+	}}
+
+	It("returns the most recent completed recoverable backup of the cluster", func() {
+		Expect(list.GetLatestRecoverableBackup("cluster-example", nil).Name).To(Equal("volume"))
+		Expect(list.GetLatestRecoverableBackup("another-cluster", nil)).To(BeNil())
+	})
+
+	It("returns the most recent backup ended before the passed time", func() {
+		before := now.Add(-160 * time.Minute)
+		Expect(list.GetLatestRecoverableBackup("cluster-example", &before).Name).To(Equal("old"))
+
+		before = now.Add(-4 * time.Hour)
+		Expect(list.GetLatestRecoverableBackup("cluster-example", &before)).To(BeNil())
+	})
+})
@@ -31,6 +31,11 @@ const (
 	// BackupPhasePending means that the backup is still waiting to be started
 	BackupPhasePending = "pending"
 
+	// BackupPhaseQueued means that the backup is waiting for the number
+	// of concurrent backups to go below the limits set in the operator
+	// configuration
+	BackupPhaseQueued = "queued"
+
 	// BackupPhaseStarted means that the backup is now running
 	BackupPhaseStarted = "started"
 
@@ -116,6 +121,14 @@ const (
 	// BackupMethodPlugin means that this backup should be handled by
 	// a plugin
 	BackupMethodPlugin BackupMethod = "plugin"
+
+	// BackupMethodLogical means taking a logical backup of the
+	// databases with pg_dump
+	BackupMethodLogical BackupMethod = "logical"
+
+	// BackupMethodPersistentVolume means taking a physical base backup
+	// with pg_basebackup into the backup volume of the cluster
+	BackupMethodPersistentVolume BackupMethod = "persistentVolume"
 )
 
 // BackupSpec defines the desired state of Backup
@@ -134,16 +147,30 @@ type BackupSpec struct {
 	Target BackupTarget `json:"target,omitempty"`
 
 	// The backup method to be used, possible options are `barmanObjectStore`,
-	// `volumeSnapshot` or `plugin`. Defaults to: `barmanObjectStore`.
+	// `volumeSnapshot`, `plugin`, `logical` or `persistentVolume`.
+	// Defaults to: `barmanObjectStore`.
 	// +optional
-	// +kubebuilder:validation:Enum=barmanObjectStore;volumeSnapshot;plugin
+	// +kubebuilder:validation:Enum=barmanObjectStore;volumeSnapshot;plugin;logical;persistentVolume
 	// +kubebuilder:default:=barmanObjectStore
 	Method BackupMethod `json:"method,omitempty"`
 
-	// Configuration parameters passed to the plugin managing this backup
+	// Configuration parameters passed to the plugin managing this backup.
+	// With the `logical` method, the dumps are handed over to this plugin
+	// instead of being written in the logical backup volume of the cluster
 	// +optional
 	PluginConfiguration *BackupPluginConfiguration `json:"pluginConfiguration,omitempty"`
 
+	// Configuration parameters of the logical backup, used only
+	// when the backup method is `logical`
+	// +optional
+	Logical *LogicalBackupOptions `json:"logical,omitempty"`
+
+	// The backup this one is based on. When specified, an incremental
+	// backup is taken against the manifest of the parent backup. Supported
+	// only by the `persistentVolume` method, with PostgreSQL 17 or later
+	// +optional
+	ParentBackup *LocalObjectReference `json:"parentBackup,omitempty"`
+
 	// Whether the default type of backup with volume snapshots is
 	// online/hot (`true`, default) or offline/cold (`false`)
 	// Overrides the default setting specified in the cluster field '.spec.backup.volumeSnapshot.online'
@@ -168,6 +195,68 @@ type BackupPluginConfiguration struct {
 	Parameters map[string]string `json:"parameters,omitempty"`
 }
 
+// LogicalBackupOptions contains the options of a logical backup
+type LogicalBackupOptions struct {
+	// The databases to be dumped. When empty, every database accepting
+	// connections is dumped, except the templates and `postgres`
+	// +optional
+	Databases []string `json:"databases,omitempty"`
+
+	// List of custom options to pass to the `pg_dump` command. IMPORTANT:
+	// Use these options with caution and at your own risk, as the operator
+	// does not validate their content. Be aware that certain options may
+	// conflict with the operator's intended functionality or design.
+	// +optional
+	PgDumpExtraOptions []string `json:"pgDumpExtraOptions,omitempty"`
+}
+
+// LogicalBackupStatus contains the fields exclusive to the logical method backup
+type LogicalBackupStatus struct {
+	// The name of the PersistentVolumeClaim containing the dumps. Empty when
+	// the dumps have been handed over to a plugin
+	// +optional
+	VolumeClaimName string `json:"volumeClaimName,omitempty"`
+
+	// The directory containing the dumps, relative to the root of the volume
+	// +optional
+	Path string `json:"path,omitempty"`
+
+	// The list of the dumped databases
+	// +optional
+	Databases []LogicalBackupDatabaseStatus `json:"databases,omitempty"`
+}
+
+// LogicalBackupDatabaseStatus is a database dumped by a logical backup
+type LogicalBackupDatabaseStatus struct {
+	// The name of the database
+	Name string `json:"name"`
+
+	// The size of the dump, in bytes
+	Size int64 `json:"size"`
+
+	// How long it took to dump the database
+	Duration metav1.Duration `json:"duration"`
+}
+
+// PersistentVolumeBackupStatus contains the fields exclusive to the
+// persistentVolume method backup
+type PersistentVolumeBackupStatus struct {
+	// The name of the PersistentVolumeClaim containing the base backup
+	VolumeClaimName string `json:"volumeClaimName"`
+
+	// The directory containing the base backup, relative to the root of the volume
+	Path string `json:"path"`
+
+	// The format of the base backup
+	// +optional
+	Format BaseBackupFormat `json:"format,omitempty"`
+
+	// The name of the backup this incremental backup is based on.
+	// Empty for full backups
+	// +optional
+	ParentBackupName string `json:"parentBackupName,omitempty"`
+}
+
 // BackupSnapshotStatus the fields exclusive to the volumeSnapshot method backup
 type BackupSnapshotStatus struct {
 	// The elements list, populated with the gathered volume snapshots
@@ -284,6 +373,14 @@ type BackupStatus struct {
 	// +optional
 	BackupSnapshotStatus BackupSnapshotStatus `json:"snapshotBackupStatus,omitempty"`
 
+	// Status of the logical backup
+	// +optional
+	LogicalBackupStatus *LogicalBackupStatus `json:"logicalBackupStatus,omitempty"`
+
+	// Status of the persistentVolume backup
+	// +optional
+	PersistentVolumeBackupStatus *PersistentVolumeBackupStatus `json:"persistentVolumeBackupStatus,omitempty"`
+
 	// The backup method being used
 	// +optional
 	Method BackupMethod `json:"method,omitempty"`
@@ -295,6 +392,12 @@ type BackupStatus struct {
 	// A map containing the plugin metadata
 	// +optional
 	PluginMetadata map[string]string `json:"pluginMetadata,omitempty"`
+
+	// The first WAL segment between the begin and the end WAL of this
+	// backup that the WAL archive continuity check couldn't find in the
+	// WAL archive. When set, the backup can't be restored
+	// +optional
+	MissingWAL string `json:"missingWAL,omitempty"`
 }
 
 // InstanceID contains the information to identify an instance
@@ -0,0 +1,69 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+// GetTargetBackupName gets the name of the backup created in the target
+// namespace
+func (backupExport *BackupExport) GetTargetBackupName() string {
+	if backupExport.Spec.TargetBackupName != "" {
+		return backupExport.Spec.TargetBackupName
+	}
+	return backupExport.Spec.Backup.Name
+}
+
+// GetTargetClusterName gets the name of the cluster the exported backup
+// belongs to, given the source backup
+func (backupExport *BackupExport) GetTargetClusterName(backup *Backup) string {
+	if backupExport.Spec.TargetClusterName != "" {
+		return backupExport.Spec.TargetClusterName
+	}
+	return backup.Spec.Cluster.Name
+}
+
+// GetSnapshotContentName gets the name of the VolumeSnapshotContent
+// binding a source snapshot to the target namespace. VolumeSnapshotContents
+// are cluster-wide, so the name includes the target namespace
+func (backupExport *BackupExport) GetSnapshotContentName(snapshotName string) string {
+	return backupExport.Spec.TargetNamespace + "-" + snapshotName
+}
+
+// IsDone checks if the backup export is completed or failed
+func (backupExportStatus *BackupExportStatus) IsDone() bool {
+	return backupExportStatus.Phase == BackupExportPhaseCompleted ||
+		backupExportStatus.Phase == BackupExportPhaseFailed
+}
+
+// SetPhase sets the phase of the backup export with a message describing
+// it, recording when the export starts and stops
+func (backupExportStatus *BackupExportStatus) SetPhase(phase BackupExportPhase, message string) {
+	backupExportStatus.Phase = phase
+	backupExportStatus.Message = message
+	if backupExportStatus.StartedAt == nil {
+		backupExportStatus.StartedAt = ptr.To(metav1.Now())
+	}
+	if backupExportStatus.IsDone() {
+		backupExportStatus.StoppedAt = ptr.To(metav1.Now())
+	}
+}
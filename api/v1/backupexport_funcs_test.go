@@ -0,0 +1,65 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package v1
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("BackupExport", func() {
+	backupExport := BackupExport{
+		Spec: BackupExportSpec{
+			Backup:          LocalObjectReference{Name: "backup"},
+			TargetNamespace: "dr",
+		},
+	}
+	backup := &Backup{
+		Spec: BackupSpec{
+			Cluster: LocalObjectReference{Name: "cluster-example"},
+		},
+	}
+
+	It("defaults the target backup and cluster to the source ones", func() {
+		Expect(backupExport.GetTargetBackupName()).To(Equal("backup"))
+		Expect(backupExport.GetTargetClusterName(backup)).To(Equal("cluster-example"))
+
+		renamed := backupExport.DeepCopy()
+		renamed.Spec.TargetBackupName = "backup-dr"
+		renamed.Spec.TargetClusterName = "cluster-dr"
+		Expect(renamed.GetTargetBackupName()).To(Equal("backup-dr"))
+		Expect(renamed.GetTargetClusterName(backup)).To(Equal("cluster-dr"))
+	})
+
+	It("includes the target namespace in the name of the snapshot contents", func() {
+		Expect(backupExport.GetSnapshotContentName("backup-1")).To(Equal("dr-backup-1"))
+	})
+
+	It("records when the export starts and stops", func() {
+		status := BackupExportStatus{}
+		status.SetPhase(BackupExportPhaseExporting, "")
+		Expect(status.StartedAt).ToNot(BeNil())
+		Expect(status.IsDone()).To(BeFalse())
+
+		status.SetPhase(BackupExportPhaseCompleted, "")
+		Expect(status.StoppedAt).ToNot(BeNil())
+		Expect(status.IsDone()).To(BeTrue())
+	})
+})
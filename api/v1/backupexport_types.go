@@ -0,0 +1,132 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// BackupExportPhase is the phase of a backup export
+type BackupExportPhase string
+
+const (
+	// BackupExportPhasePending means that the source backup is not
+	// completed yet
+	BackupExportPhasePending = BackupExportPhase("pending")
+
+	// BackupExportPhaseExporting means that the volume snapshots are
+	// being imported into the target namespace
+	BackupExportPhaseExporting = BackupExportPhase("exporting")
+
+	// BackupExportPhaseCompleted means that the exported backup can be
+	// used to bootstrap a cluster in the target namespace
+	BackupExportPhaseCompleted = BackupExportPhase("completed")
+
+	// BackupExportPhaseFailed means that the backup can't be exported
+	BackupExportPhaseFailed = BackupExportPhase("failed")
+)
+
+// BackupExportSpec defines the volume snapshot backup to be exported and
+// where
+// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="spec is immutable"
+type BackupExportSpec struct {
+	// The volume snapshot backup to be exported
+	Backup LocalObjectReference `json:"backup"`
+
+	// The namespace where the backup is exported
+	// +kubebuilder:validation:MinLength=1
+	TargetNamespace string `json:"targetNamespace"`
+
+	// The name of the exported backup. Defaults to the name of the
+	// source backup
+	// +optional
+	TargetBackupName string `json:"targetBackupName,omitempty"`
+
+	// The name of the cluster the exported backup belongs to in the
+	// target namespace. Defaults to the cluster of the source backup
+	// +optional
+	TargetClusterName string `json:"targetClusterName,omitempty"`
+}
+
+// BackupExportStatus defines the observed state of a BackupExport
+type BackupExportStatus struct {
+	// The current phase of the export
+	// +optional
+	Phase BackupExportPhase `json:"phase,omitempty"`
+
+	// A human-readable description of the progress or of the failure
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// The name of the backup created in the target namespace
+	// +optional
+	BackupName string `json:"backupName,omitempty"`
+
+	// The names of the VolumeSnapshotContents created for the exported
+	// snapshots
+	// +optional
+	SnapshotContents []string `json:"snapshotContents,omitempty"`
+
+	// When the export was started
+	// +optional
+	StartedAt *metav1.Time `json:"startedAt,omitempty"`
+
+	// When the export was completed or failed
+	// +optional
+	StoppedAt *metav1.Time `json:"stoppedAt,omitempty"`
+}
+
+// +genclient
+// +kubebuilder:object:root=true
+// +kubebuilder:storageversion
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:printcolumn:name="Backup",type="string",JSONPath=".spec.backup.name"
+// +kubebuilder:printcolumn:name="Target Namespace",type="string",JSONPath=".spec.targetNamespace"
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase"
+// +kubebuilder:printcolumn:name="Message",type="string",JSONPath=".status.message"
+
+// BackupExport is the Schema for the backupexports API
+type BackupExport struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata"`
+
+	// Specification of the desired BackupExport.
+	// More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#spec-and-status
+	Spec BackupExportSpec `json:"spec"`
+	// Most recently observed status of the BackupExport. This data may not be up to
+	// date. Populated by the system. Read-only.
+	// More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#spec-and-status
+	// +optional
+	Status BackupExportStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// BackupExportList contains a list of BackupExport
+type BackupExportList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []BackupExport `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&BackupExport{}, &BackupExportList{})
+}
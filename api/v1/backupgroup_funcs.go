@@ -0,0 +1,58 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+// GetBackupName gets the name of the backup of a cluster of the group
+func (backupGroup *BackupGroup) GetBackupName(clusterName string) string {
+	return backupGroup.Name + "-" + clusterName
+}
+
+// GetRestorePointName gets the name of the restore point shared by the
+// clusters of the group
+func (backupGroup *BackupGroup) GetRestorePointName() string {
+	if backupGroup.Spec.RestorePointName != "" {
+		return backupGroup.Spec.RestorePointName
+	}
+	return backupGroup.Name
+}
+
+// IsDone checks if the backup group is completed or failed
+func (backupGroupStatus *BackupGroupStatus) IsDone() bool {
+	return backupGroupStatus.Phase == BackupGroupPhaseCompleted ||
+		backupGroupStatus.Phase == BackupGroupPhaseFailed
+}
+
+// SetPhase sets the phase of the backup group with a message describing
+// it, recording when the group starts and stops
+func (backupGroupStatus *BackupGroupStatus) SetPhase(phase BackupGroupPhase, message string) {
+	backupGroupStatus.Phase = phase
+	backupGroupStatus.Message = message
+	if backupGroupStatus.StartedAt == nil {
+		backupGroupStatus.StartedAt = ptr.To(metav1.Now())
+	}
+	if backupGroupStatus.IsDone() {
+		backupGroupStatus.StoppedAt = ptr.To(metav1.Now())
+	}
+}
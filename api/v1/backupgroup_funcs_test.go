@@ -0,0 +1,55 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("BackupGroup", func() {
+	backupGroup := BackupGroup{
+		ObjectMeta: metav1.ObjectMeta{Name: "group"},
+	}
+
+	It("gets the name of the backups of the clusters", func() {
+		Expect(backupGroup.GetBackupName("orders")).To(Equal("group-orders"))
+	})
+
+	It("gets the name of the restore point, defaulting to the name of the group", func() {
+		Expect(backupGroup.GetRestorePointName()).To(Equal("group"))
+		withName := backupGroup.DeepCopy()
+		withName.Spec.RestorePointName = "before-migration"
+		Expect(withName.GetRestorePointName()).To(Equal("before-migration"))
+	})
+
+	It("records when the group starts and stops", func() {
+		status := BackupGroupStatus{}
+		status.SetPhase(BackupGroupPhaseRunning, "")
+		Expect(status.StartedAt).ToNot(BeNil())
+		Expect(status.IsDone()).To(BeFalse())
+
+		status.SetPhase(BackupGroupPhaseFailed, "failed")
+		Expect(status.StoppedAt).ToNot(BeNil())
+		Expect(status.IsDone()).To(BeTrue())
+	})
+})
@@ -0,0 +1,153 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// BackupGroupPhase is the phase of a backup group
+type BackupGroupPhase string
+
+const (
+	// BackupGroupPhaseRunning means that the backups of the clusters are
+	// being taken
+	BackupGroupPhaseRunning = BackupGroupPhase("running")
+
+	// BackupGroupPhaseCompleted means that all the backups are completed
+	// and the restore point has been created on every cluster
+	BackupGroupPhaseCompleted = BackupGroupPhase("completed")
+
+	// BackupGroupPhaseFailed means that a backup or the creation of the
+	// restore point failed
+	BackupGroupPhaseFailed = BackupGroupPhase("failed")
+)
+
+// BackupGroupSpec defines the clusters to be backed up together
+// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="spec is immutable"
+type BackupGroupSpec struct {
+	// The clusters to back up
+	// +kubebuilder:validation:MinItems=1
+	// +listType=map
+	// +listMapKey=name
+	Clusters []LocalObjectReference `json:"clusters"`
+
+	// The backup method to be used, possible options are `barmanObjectStore`,
+	// `volumeSnapshot`, `plugin` or `persistentVolume`.
+	// Defaults to: `barmanObjectStore`.
+	// +optional
+	// +kubebuilder:validation:Enum=barmanObjectStore;volumeSnapshot;plugin;persistentVolume
+	// +kubebuilder:default:=barmanObjectStore
+	Method BackupMethod `json:"method,omitempty"`
+
+	// Configuration parameters passed to the plugin managing the backups
+	// +optional
+	PluginConfiguration *BackupPluginConfiguration `json:"pluginConfiguration,omitempty"`
+
+	// The name of the restore point created on every cluster once all the
+	// backups are completed. Defaults to the name of the BackupGroup
+	// +kubebuilder:validation:MaxLength=63
+	// +optional
+	RestorePointName string `json:"restorePointName,omitempty"`
+}
+
+// BackupGroupMemberStatus is the status of the backup of a cluster of
+// the group
+type BackupGroupMemberStatus struct {
+	// The name of the cluster
+	Cluster string `json:"cluster"`
+
+	// The name of the backup of the cluster
+	BackupName string `json:"backupName"`
+
+	// The LSN of the restore point created on the cluster
+	// +optional
+	RestorePointLSN string `json:"restorePointLSN,omitempty"`
+}
+
+// BackupGroupStatus defines the observed state of a BackupGroup
+type BackupGroupStatus struct {
+	// The current phase of the backup group
+	// +optional
+	Phase BackupGroupPhase `json:"phase,omitempty"`
+
+	// A human-readable description of the progress or of the failure
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// The backups of the clusters of the group
+	// +optional
+	Backups []BackupGroupMemberStatus `json:"backups,omitempty"`
+
+	// The name of the restore point shared by all the clusters, to be used
+	// as `targetName` when recovering them
+	// +optional
+	RestorePointName string `json:"restorePointName,omitempty"`
+
+	// When the restore point was created
+	// +optional
+	RestorePointTime *metav1.Time `json:"restorePointTime,omitempty"`
+
+	// When the backup group was started
+	// +optional
+	StartedAt *metav1.Time `json:"startedAt,omitempty"`
+
+	// When the backup group was completed or failed
+	// +optional
+	StoppedAt *metav1.Time `json:"stoppedAt,omitempty"`
+}
+
+// +genclient
+// +kubebuilder:object:root=true
+// +kubebuilder:storageversion
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:printcolumn:name="Method",type="string",JSONPath=".spec.method"
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase"
+// +kubebuilder:printcolumn:name="Restore Point",type="string",JSONPath=".status.restorePointName"
+// +kubebuilder:printcolumn:name="Message",type="string",JSONPath=".status.message"
+
+// BackupGroup is the Schema for the backupgroups API
+type BackupGroup struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata"`
+
+	// Specification of the desired BackupGroup.
+	// More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#spec-and-status
+	Spec BackupGroupSpec `json:"spec"`
+	// Most recently observed status of the BackupGroup. This data may not be up to
+	// date. Populated by the system. Read-only.
+	// More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#spec-and-status
+	// +optional
+	Status BackupGroupStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// BackupGroupList contains a list of BackupGroup
+type BackupGroupList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []BackupGroup `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&BackupGroup{}, &BackupGroupList{})
+}
@@ -0,0 +1,51 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package v1
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+// GetSecretName gets the name of the secret where the certificate is stored
+func (clientCertificate *ClientCertificate) GetSecretName() string {
+	if clientCertificate.Spec.SecretName != "" {
+		return clientCertificate.Spec.SecretName
+	}
+	return clientCertificate.Name
+}
+
+// SetAsFailed sets the client certificate as failed with the given error
+func (clientCertificate *ClientCertificate) SetAsFailed(err error) {
+	clientCertificate.Status.Ready = false
+	clientCertificate.Status.Message = err.Error()
+}
+
+// SetAsReady sets the client certificate as issued, with the given
+// expiration and renewal times
+func (clientCertificate *ClientCertificate) SetAsReady(expiration, renewalTime time.Time) {
+	clientCertificate.Status.Ready = true
+	clientCertificate.Status.Message = ""
+	clientCertificate.Status.ObservedGeneration = clientCertificate.Generation
+	clientCertificate.Status.Expiration = ptr.To(metav1.NewTime(expiration))
+	clientCertificate.Status.RenewalTime = ptr.To(metav1.NewTime(renewalTime))
+}
@@ -0,0 +1,118 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package v1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ClientCertificateSpec is the specification of a client certificate
+// issued by the client CA of a PostgreSQL cluster, to be used to
+// authenticate a PostgreSQL role
+type ClientCertificateSpec struct {
+	// The name of the PostgreSQL cluster whose client CA issues the
+	// certificate
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="cluster is immutable"
+	ClusterRef corev1.LocalObjectReference `json:"cluster"`
+
+	// The name of the PostgreSQL role, used as the common name of the
+	// certificate. The cnpg-operator name is reserved for the certificate
+	// the operator uses to authenticate against the instance manager, and
+	// the roles reserved for PostgreSQL and the operator, such as
+	// `postgres`, `streaming_replica` and the ones starting with `pg_` or
+	// `cnpg_`, cannot be used
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:XValidation:rule="self != 'cnpg-operator'",message="the cnpg-operator role is reserved"
+	// +kubebuilder:validation:XValidation:rule="!(self in ['postgres', 'streaming_replica'])",message="the role is reserved for the operator"
+	// +kubebuilder:validation:XValidation:rule="!self.startsWith('pg_') && !self.startsWith('cnpg_')",message="the role is reserved for PostgreSQL or the operator"
+	Role string `json:"role"`
+
+	// The name of the secret of type kubernetes.io/tls where the
+	// certificate and its private key are stored. Defaults to the name
+	// of the ClientCertificate
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="secretName is immutable"
+	// +optional
+	SecretName string `json:"secretName,omitempty"`
+}
+
+// ClientCertificateStatus defines the observed state of a ClientCertificate
+type ClientCertificateStatus struct {
+	// A sequence number representing the latest
+	// desired state that was synchronized
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Ready is true if the certificate has been issued and stored in the
+	// secret
+	// +optional
+	Ready bool `json:"ready,omitempty"`
+
+	// Message is the reconciliation output message
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// The expiration time of the current certificate
+	// +optional
+	Expiration *metav1.Time `json:"expiration,omitempty"`
+
+	// When the current certificate will be renewed
+	// +optional
+	RenewalTime *metav1.Time `json:"renewalTime,omitempty"`
+}
+
+// +genclient
+// +kubebuilder:object:root=true
+// +kubebuilder:storageversion
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:printcolumn:name="Cluster",type="string",JSONPath=".spec.cluster.name"
+// +kubebuilder:printcolumn:name="Role",type="string",JSONPath=".spec.role"
+// +kubebuilder:printcolumn:name="Ready",type="boolean",JSONPath=".status.ready"
+// +kubebuilder:printcolumn:name="Expiration",type="date",JSONPath=".status.expiration"
+// +kubebuilder:printcolumn:name="Message",type="string",JSONPath=".status.message",description="Latest reconciliation message"
+
+// ClientCertificate is the Schema for the clientcertificates API
+type ClientCertificate struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata"`
+
+	// Specification of the desired ClientCertificate.
+	// More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#spec-and-status
+	Spec ClientCertificateSpec `json:"spec"`
+	// Most recently observed status of the ClientCertificate. This data may not be up to
+	// date. Populated by the system. Read-only.
+	// More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#spec-and-status
+	// +optional
+	Status ClientCertificateStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ClientCertificateList contains a list of ClientCertificate
+type ClientCertificateList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClientCertificate `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ClientCertificate{}, &ClientCertificateList{})
+}
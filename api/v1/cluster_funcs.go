@@ -22,6 +22,7 @@ package v1
 import (
 	"context"
 	"fmt"
+	"maps"
 	"regexp"
 	"slices"
 	"strconv"
@@ -33,6 +34,7 @@ import (
 	pgTime "github.com/cloudnative-pg/machinery/pkg/postgres/time"
 	"github.com/cloudnative-pg/machinery/pkg/postgres/version"
 	"github.com/cloudnative-pg/machinery/pkg/stringset"
+	"github.com/robfig/cron"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -40,6 +42,7 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 
 	"github.com/cloudnative-pg/cloudnative-pg/internal/configuration"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/system"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/versions"
@@ -85,6 +88,28 @@ func GetPluginConfigurationEnabledPluginNames(pluginList []PluginConfiguration)
 	return pluginNames
 }
 
+// defaultWalPrefetchMaxSpoolSize is the default maximum size of the WAL
+// files prefetched in the spool
+var defaultWalPrefetchMaxSpoolSize = resource.MustParse("1Gi")
+
+// GetMaxParallel gets the number of WAL files to be restored at the same
+// time through the plugins, 1 meaning that prefetching is disabled
+func (configuration *WalPrefetchConfiguration) GetMaxParallel() int {
+	if configuration == nil || configuration.MaxParallel < 1 {
+		return 1
+	}
+	return configuration.MaxParallel
+}
+
+// GetMaxSpoolSize gets the maximum size in bytes of the WAL files
+// prefetched in the spool
+func (configuration *WalPrefetchConfiguration) GetMaxSpoolSize() int64 {
+	if configuration == nil || configuration.MaxSpoolSize == nil {
+		return defaultWalPrefetchMaxSpoolSize.Value()
+	}
+	return configuration.MaxSpoolSize.Value()
+}
+
 // GetExternalClustersEnabledPluginNames gets the name of the plugins that are
 // involved in the reconciliation of this external cluster list. This
 // list is usually composed by the plugins that need to be active to
@@ -117,6 +142,16 @@ func (e *EphemeralVolumesSizeLimitConfiguration) GetTemporaryDataLimit() *resour
 	return e.TemporaryData
 }
 
+// GetLogicalBackupStagingLimit gets the size limit of the logical
+// backup staging volume
+func (e *EphemeralVolumesSizeLimitConfiguration) GetLogicalBackupStagingLimit() *resource.Quantity {
+	if e == nil {
+		return nil
+	}
+
+	return e.LogicalBackupStaging
+}
+
 // MergeMetadata adds the passed custom annotations and labels in the service account.
 func (st *ServiceAccountTemplate) MergeMetadata(sa *corev1.ServiceAccount) {
 	if st == nil {
@@ -344,6 +379,12 @@ func (config *PluginConfiguration) IsEnabled() bool {
 	return *config.Enabled
 }
 
+// IsEnabledCredentialsProvider returns true when this plugin is enabled
+// and declared as a credentials provider
+func (config *PluginConfiguration) IsEnabledCredentialsProvider() bool {
+	return config.IsEnabled() && config.IsCredentialsProvider != nil && *config.IsCredentialsProvider
+}
+
 // GetRoleSecretsName gets the name of the secret which is used to store the role's password
 func (roleConfiguration *RoleConfiguration) GetRoleSecretsName() string {
 	if roleConfiguration.PasswordSecret != nil {
@@ -352,6 +393,19 @@ func (roleConfiguration *RoleConfiguration) GetRoleSecretsName() string {
 	return ""
 }
 
+// IsPasswordRotationAlternating checks if the password of the role is
+// rotated using the alternating strategy
+func (roleConfiguration *RoleConfiguration) IsPasswordRotationAlternating() bool {
+	return roleConfiguration.PasswordRotation != nil &&
+		roleConfiguration.PasswordRotation.Strategy == PasswordRotationStrategyAlternating
+}
+
+// GetAlternateRoleName gets the name of the twin role used by the
+// alternating password rotation strategy
+func (roleConfiguration *RoleConfiguration) GetAlternateRoleName() string {
+	return roleConfiguration.Name + AlternateRoleSuffix
+}
+
 // GetRoleInherit return the inherit attribute of a roleConfiguration
 func (roleConfiguration *RoleConfiguration) GetRoleInherit() bool {
 	if roleConfiguration.Inherit != nil {
@@ -433,6 +487,19 @@ func (cluster *Cluster) GetSuperuserSecretName() string {
 	return fmt.Sprintf("%v%v", cluster.Name, SuperUserSecretSuffix)
 }
 
+// GetSuperuserGrantSecretName gets the name of the secret containing the
+// credentials of the temporary superuser access
+func (cluster *Cluster) GetSuperuserGrantSecretName() string {
+	return fmt.Sprintf("%v%v", cluster.Name, SuperuserGrantSecretSuffix)
+}
+
+// IsSuperuserGrantActive checks if a temporary superuser access is
+// currently granted
+func (cluster *Cluster) IsSuperuserGrantActive(now time.Time) bool {
+	grant := cluster.Status.SuperuserGrant
+	return grant != nil && now.Before(grant.ExpiresAt.Time)
+}
+
 // GetEnableLDAPAuth return true if bind or bind+search method are
 // configured in the cluster configuration
 func (cluster *Cluster) GetEnableLDAPAuth() bool {
@@ -569,9 +636,19 @@ func (cluster *Cluster) GetServerCASecretName() string {
 	if cluster.Spec.Certificates != nil && cluster.Spec.Certificates.ServerCASecret != "" {
 		return cluster.Spec.Certificates.ServerCASecret
 	}
+	if cluster.IsCertManagerEnabled() {
+		// cert-manager stores the CA inside the issued secret
+		return cluster.GetServerTLSSecretName()
+	}
 	return fmt.Sprintf("%v%v", cluster.Name, DefaultServerCaSecretSuffix)
 }
 
+// IsCertManagerEnabled checks if the certificates of the cluster are
+// issued by cert-manager
+func (cluster *Cluster) IsCertManagerEnabled() bool {
+	return cluster.Spec.Certificates != nil && cluster.Spec.Certificates.CertManager != nil
+}
+
 // GetServerTLSSecretName get the name of the secret containing the
 // certificate that is used for the PostgreSQL servers
 func (cluster *Cluster) GetServerTLSSecretName() string {
@@ -590,6 +667,13 @@ func (cluster *Cluster) GetClientCASecretName() string {
 	return fmt.Sprintf("%v%v", cluster.Name, ClientCaSecretSuffix)
 }
 
+// GetOperatorClientCASecretName get the name of the secret containing the
+// CA issuing the client certificate of the operator. This CA is always
+// generated by the operator, which needs its private key
+func (cluster *Cluster) GetOperatorClientCASecretName() string {
+	return fmt.Sprintf("%v%v", cluster.Name, OperatorClientCaSecretSuffix)
+}
+
 // GetFixedInheritedAnnotations gets the annotations that should be
 // inherited by all resources according to the cluster spec and the operator version
 func (cluster *Cluster) GetFixedInheritedAnnotations() map[string]string {
@@ -684,6 +768,159 @@ func (cluster *Cluster) GetMaxSwitchoverDelay() int32 {
 	return DefaultMaxSwitchoverDelay
 }
 
+// IsFailoverQuorumEnabled checks if the quorum-based failover is active
+func (cluster *Cluster) IsFailoverQuorumEnabled() bool {
+	return cluster.Spec.FailoverQuorum != nil && cluster.Spec.FailoverQuorum.Enabled
+}
+
+// GetPrimaryIsolationTimeout gets the amount of time an isolated primary
+// waits before fencing itself. A zero value means that the primary
+// never fences itself.
+func (cluster *Cluster) GetPrimaryIsolationTimeout() time.Duration {
+	if !cluster.IsFailoverQuorumEnabled() {
+		return 0
+	}
+	return time.Duration(cluster.Spec.FailoverQuorum.PrimaryIsolationTimeout) * time.Second
+}
+
+// GetPrimaryPlacementScore computes how much the passed node is preferred
+// to run the primary instance, as the sum of the weights of the matching
+// preferences
+func (cluster *Cluster) GetPrimaryPlacementScore(nodeLabels map[string]string) int32 {
+	if cluster.Spec.PrimaryPlacement == nil {
+		return 0
+	}
+
+	var score int32
+	for _, preference := range cluster.Spec.PrimaryPlacement.Preferences {
+		matches := len(preference.NodeLabels) > 0
+		for key, value := range preference.NodeLabels {
+			if nodeValue, ok := nodeLabels[key]; !ok || nodeValue != value {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			score += preference.Weight
+		}
+	}
+
+	return score
+}
+
+// IsPrimarySwitchbackEnabled checks if the operator should switch the
+// primary back to a preferred node
+func (cluster *Cluster) IsPrimarySwitchbackEnabled() bool {
+	return cluster.Spec.PrimaryPlacement != nil &&
+		len(cluster.Spec.PrimaryPlacement.Preferences) > 0 &&
+		cluster.Spec.PrimaryPlacement.Switchback != nil &&
+		cluster.Spec.PrimaryPlacement.Switchback.Enabled
+}
+
+// GetPrimarySwitchbackMaximumLag gets the maximum lag, in bytes, a
+// switchback candidate can have
+func (cluster *Cluster) GetPrimarySwitchbackMaximumLag() uint64 {
+	if cluster.Spec.PrimaryPlacement == nil ||
+		cluster.Spec.PrimaryPlacement.Switchback == nil ||
+		cluster.Spec.PrimaryPlacement.Switchback.MaximumLag == nil {
+		return DefaultPrimarySwitchbackMaximumLag
+	}
+
+	return uint64(max(cluster.Spec.PrimaryPlacement.Switchback.MaximumLag.Value(), 0))
+}
+
+// IsCanaryRolloutEnabled checks if the new PostgreSQL images are rolled
+// out with the canary strategy
+func (cluster *Cluster) IsCanaryRolloutEnabled() bool {
+	return cluster.Spec.CanaryRollout != nil && cluster.Spec.CanaryRollout.Enabled
+}
+
+// GetCanarySoakTime gets how long the canary instance is checked before
+// continuing the rollout
+func (cluster *Cluster) GetCanarySoakTime() time.Duration {
+	if cluster.Spec.CanaryRollout == nil || cluster.Spec.CanaryRollout.SoakTime == nil {
+		return DefaultCanarySoakTimeSeconds * time.Second
+	}
+
+	return cluster.Spec.CanaryRollout.SoakTime.Duration
+}
+
+// GetCanaryMaximumLag gets the maximum replay lag, in bytes, of the
+// canary instance
+func (cluster *Cluster) GetCanaryMaximumLag() uint64 {
+	if cluster.Spec.CanaryRollout == nil || cluster.Spec.CanaryRollout.MaximumLag == nil {
+		return DefaultCanaryMaximumLag
+	}
+
+	return uint64(max(cluster.Spec.CanaryRollout.MaximumLag.Value(), 0))
+}
+
+// GetCanaryMaximumLogErrors gets the maximum number of error log records
+// the canary instance can emit during the soak time
+func (cluster *Cluster) GetCanaryMaximumLogErrors() int64 {
+	if cluster.Spec.CanaryRollout == nil || cluster.Spec.CanaryRollout.MaximumLogErrors == nil {
+		return DefaultCanaryMaximumLogErrors
+	}
+
+	return int64(*cluster.Spec.CanaryRollout.MaximumLogErrors)
+}
+
+// IsCanaryRolloutInProgress checks if a canary instance is being
+// updated or checked
+func (cluster *Cluster) IsCanaryRolloutInProgress() bool {
+	return cluster.Status.CanaryRollout != nil &&
+		cluster.Status.CanaryRollout.Phase == CanaryRolloutPhaseInProgress
+}
+
+// GetCanaryProbes gets the SQL probes to be executed by the passed
+// instance, which are defined only while it is the canary of a rollout.
+// Probes not specifying a database are executed in the application one
+func (cluster *Cluster) GetCanaryProbes(instanceName string) []CanaryProbe {
+	if !cluster.IsCanaryRolloutInProgress() ||
+		cluster.Spec.CanaryRollout == nil ||
+		cluster.Status.CanaryRollout.Instance != instanceName {
+		return nil
+	}
+
+	probes := make([]CanaryProbe, len(cluster.Spec.CanaryRollout.Probes))
+	for idx, probe := range cluster.Spec.CanaryRollout.Probes {
+		if probe.Database == "" {
+			probe.Database = cluster.GetApplicationDatabaseName()
+		}
+		probes[idx] = probe
+	}
+
+	return probes
+}
+
+// IsOpen checks if the maintenance window is open at the passed time
+func (window MaintenanceWindow) IsOpen(now time.Time) (bool, error) {
+	schedule, err := cron.Parse(window.Schedule)
+	if err != nil {
+		return false, err
+	}
+
+	// The window is open if it started in the last `duration`
+	start := schedule.Next(now.Add(-window.Duration.Duration))
+	return !start.After(now), nil
+}
+
+// IsInMaintenanceWindow checks if the passed time is included in at
+// least one of the passed maintenance windows
+func IsInMaintenanceWindow(windows []MaintenanceWindow, now time.Time) (bool, error) {
+	for _, window := range windows {
+		open, err := window.IsOpen(now)
+		if err != nil {
+			return false, fmt.Errorf("invalid maintenance window schedule %q: %w", window.Schedule, err)
+		}
+		if open {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
 // GetPrimaryUpdateStrategy get the cluster primary update strategy,
 // defaulting to unsupervised
 func (cluster *Cluster) GetPrimaryUpdateStrategy() PrimaryUpdateStrategy {
@@ -906,6 +1143,67 @@ func (cluster *Cluster) ShouldCreateProjectedVolume() bool {
 	return cluster.Spec.ProjectedVolumeTemplate != nil
 }
 
+// ShouldCreateLogicalBackupStagingVolume returns true when the instances
+// need the ephemeral volume where the logical backups are written before
+// being handed over to a plugin, which is the case when a plugin is enabled
+func (cluster *Cluster) ShouldCreateLogicalBackupStagingVolume() bool {
+	return len(GetPluginConfigurationEnabledPluginNames(cluster.Spec.Plugins)) > 0
+}
+
+// GetLogicalBackupVolumeClaimName gets the name of the PersistentVolumeClaim
+// where the logical backups are stored, or an empty string if the logical
+// backup volume is not configured
+func (cluster *Cluster) GetLogicalBackupVolumeClaimName() string {
+	if cluster.Spec.Backup == nil || cluster.Spec.Backup.Logical == nil {
+		return ""
+	}
+	return cluster.Spec.Backup.Logical.VolumeClaimName
+}
+
+// GetPersistentVolumeBackupClaimName gets the name of the PersistentVolumeClaim
+// where the physical base backups are stored, or an empty string if the
+// backup volume is not configured
+func (cluster *Cluster) GetPersistentVolumeBackupClaimName() string {
+	if cluster.Spec.Backup == nil || cluster.Spec.Backup.PersistentVolume == nil {
+		return ""
+	}
+	if cluster.Spec.Backup.PersistentVolume.Storage != nil {
+		return cluster.Name + BackupVolumeSuffix
+	}
+	return cluster.Spec.Backup.PersistentVolume.VolumeClaimName
+}
+
+// GetPersistentVolumeBackupFormat gets the format of the physical base
+// backups stored in the backup volume, defaulting to the plain format
+func (cluster *Cluster) GetPersistentVolumeBackupFormat() BaseBackupFormat {
+	if cluster.Spec.Backup == nil || cluster.Spec.Backup.PersistentVolume == nil ||
+		cluster.Spec.Backup.PersistentVolume.Format == "" {
+		return BaseBackupFormatPlain
+	}
+	return cluster.Spec.Backup.PersistentVolume.Format
+}
+
+// DefaultWALContinuityCheckInterval is the default interval between two
+// checks of the continuity of the WAL archive
+const DefaultWALContinuityCheckInterval = 5 * time.Minute
+
+// GetWALContinuityCheckInterval gets the interval between two checks of
+// the continuity of the WAL archive. A zero value means that the check
+// is disabled
+func (cluster *Cluster) GetWALContinuityCheckInterval() time.Duration {
+	if cluster.Spec.Backup == nil || cluster.Spec.Backup.WALContinuityCheckInterval == nil {
+		return DefaultWALContinuityCheckInterval
+	}
+	return cluster.Spec.Backup.WALContinuityCheckInterval.Duration
+}
+
+// IsPersistentVolumeBackupOwned returns true if the backup volume is created
+// by the operator and owned by the cluster
+func (cluster *Cluster) IsPersistentVolumeBackupOwned() bool {
+	return cluster.Spec.Backup != nil && cluster.Spec.Backup.PersistentVolume != nil &&
+		cluster.Spec.Backup.PersistentVolume.Storage != nil
+}
+
 // ShouldCreateWalArchiveVolume returns whether we should create the wal archive volume
 func (cluster *Cluster) ShouldCreateWalArchiveVolume() bool {
 	return cluster.Spec.WalStorage != nil
@@ -1101,6 +1399,46 @@ func (cluster *Cluster) UsesConfigMap(config string) (ok bool) {
 	return false
 }
 
+// GetPostgresParameters returns the PostgreSQL parameters requested for
+// this cluster, including the ones generated from the audit configuration
+func (cluster *Cluster) GetPostgresParameters() map[string]string {
+	audit := cluster.Spec.PostgresConfiguration.Audit
+	if audit == nil {
+		return cluster.Spec.PostgresConfiguration.Parameters
+	}
+
+	parameters := make(map[string]string, len(cluster.Spec.PostgresConfiguration.Parameters)+1)
+	maps.Copy(parameters, cluster.Spec.PostgresConfiguration.Parameters)
+	parameters[postgres.ParameterPgAuditLog] = RenderPgAuditClasses(audit.Classes)
+	return parameters
+}
+
+// IsPgAuditOutputSeparate checks if the pgaudit records need to be
+// written separately from the other logs of the instance
+func (cluster *Cluster) IsPgAuditOutputSeparate() bool {
+	audit := cluster.Spec.PostgresConfiguration.Audit
+	return audit != nil && audit.Output == PgAuditOutputSeparate
+}
+
+// RenderPgAuditClasses renders a list of pgaudit classes as the value
+// of the `pgaudit.log` parameter
+func RenderPgAuditClasses(classes []PgAuditClass) string {
+	if len(classes) == 0 {
+		return "none"
+	}
+
+	values := make([]string, len(classes))
+	for i, class := range classes {
+		values[i] = string(class)
+	}
+	return strings.Join(values, ",")
+}
+
+// IsNetworkPolicyEnabled checks if the NetworkPolicy object needs to be created
+func (cluster *Cluster) IsNetworkPolicyEnabled() bool {
+	return cluster.Spec.NetworkPolicy != nil && cluster.Spec.NetworkPolicy.Enabled
+}
+
 // IsPodMonitorEnabled checks if the PodMonitor object needs to be created
 func (cluster *Cluster) IsPodMonitorEnabled() bool {
 	if cluster.Spec.Monitoring != nil {
@@ -1257,6 +1595,11 @@ func (cluster *Cluster) GetServerCASecretObjectKey() types.NamespacedName {
 	return types.NamespacedName{Namespace: cluster.Namespace, Name: cluster.GetServerCASecretName()}
 }
 
+// GetOperatorClientCASecretObjectKey returns a types.NamespacedName pointing to the secret
+func (cluster *Cluster) GetOperatorClientCASecretObjectKey() types.NamespacedName {
+	return types.NamespacedName{Namespace: cluster.Namespace, Name: cluster.GetOperatorClientCASecretName()}
+}
+
 // IsBarmanBackupConfigured returns true if one of the possible backup destination
 // is configured, false otherwise
 func (backupConfiguration *BackupConfiguration) IsBarmanBackupConfigured() bool {
@@ -1493,3 +1836,24 @@ func (cluster *Cluster) GetEnabledWALArchivePluginName() string {
 
 	return ""
 }
+
+// GetCredentialsProviderPluginNames gets the names of the enabled plugins
+// declared as credentials providers
+func (cluster *Cluster) GetCredentialsProviderPluginNames() []string {
+	result := make([]string, 0, len(cluster.Spec.Plugins))
+	for i := range cluster.Spec.Plugins {
+		if cluster.Spec.Plugins[i].IsEnabledCredentialsProvider() {
+			result = append(result, cluster.Spec.Plugins[i].Name)
+		}
+	}
+
+	return result
+}
+
+// GetPosition gets the position of the rule relative to the operator rules
+func (rule HBARule) GetPosition() HBARulePosition {
+	if rule.Position == "" {
+		return HBARulePositionAfterOperatorRules
+	}
+	return rule.Position
+}
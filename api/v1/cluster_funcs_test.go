@@ -1737,3 +1737,282 @@ var _ = Describe("Probes configuration", func() {
 			"configured probe should not be modified with zero values")
 	})
 })
+
+var _ = Describe("Primary placement", func() {
+	cluster := &Cluster{
+		Spec: ClusterSpec{
+			PrimaryPlacement: &PrimaryPlacementConfiguration{
+				Preferences: []PrimaryPlacementPreference{
+					{
+						Weight:     50,
+						NodeLabels: map[string]string{"topology.kubernetes.io/zone": "zone-a"},
+					},
+					{
+						Weight: 10,
+						NodeLabels: map[string]string{
+							"topology.kubernetes.io/zone": "zone-a",
+							"disktype":                    "ssd",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	It("computes the score of a node as the sum of the matching preferences", func() {
+		Expect(cluster.GetPrimaryPlacementScore(nil)).To(BeZero())
+		Expect(cluster.GetPrimaryPlacementScore(map[string]string{
+			"topology.kubernetes.io/zone": "zone-b",
+		})).To(BeZero())
+		Expect(cluster.GetPrimaryPlacementScore(map[string]string{
+			"topology.kubernetes.io/zone": "zone-a",
+		})).To(BeEquivalentTo(50))
+		Expect(cluster.GetPrimaryPlacementScore(map[string]string{
+			"topology.kubernetes.io/zone": "zone-a",
+			"disktype":                    "ssd",
+		})).To(BeEquivalentTo(60))
+	})
+
+	It("enables the switchback only when there are preferences", func() {
+		Expect(cluster.IsPrimarySwitchbackEnabled()).To(BeFalse())
+		switchbackCluster := cluster.DeepCopy()
+		switchbackCluster.Spec.PrimaryPlacement.Switchback = &PrimarySwitchbackConfiguration{Enabled: true}
+		Expect(switchbackCluster.IsPrimarySwitchbackEnabled()).To(BeTrue())
+		switchbackCluster.Spec.PrimaryPlacement.Preferences = nil
+		Expect(switchbackCluster.IsPrimarySwitchbackEnabled()).To(BeFalse())
+	})
+
+	It("uses the default maximum lag for the switchback", func() {
+		Expect(cluster.GetPrimarySwitchbackMaximumLag()).To(BeEquivalentTo(DefaultPrimarySwitchbackMaximumLag))
+		switchbackCluster := cluster.DeepCopy()
+		switchbackCluster.Spec.PrimaryPlacement.Switchback = &PrimarySwitchbackConfiguration{
+			MaximumLag: ptr.To(resource.MustParse("1Mi")),
+		}
+		Expect(switchbackCluster.GetPrimarySwitchbackMaximumLag()).To(BeEquivalentTo(1024 * 1024))
+		switchbackCluster.Spec.PrimaryPlacement.Switchback.MaximumLag = ptr.To(resource.MustParse("1G"))
+		Expect(switchbackCluster.GetPrimarySwitchbackMaximumLag()).To(BeEquivalentTo(1000 * 1000 * 1000))
+		switchbackCluster.Spec.PrimaryPlacement.Switchback.MaximumLag = ptr.To(resource.MustParse("-1"))
+		Expect(switchbackCluster.GetPrimarySwitchbackMaximumLag()).To(BeZero())
+	})
+})
+
+var _ = Describe("Maintenance windows", func() {
+	// Every day at 02:00, for two hours
+	window := MaintenanceWindow{
+		Schedule: "0 0 2 * * *",
+		Duration: metav1.Duration{Duration: 2 * time.Hour},
+	}
+
+	It("detects when the window is open", func() {
+		open, err := window.IsOpen(time.Date(2024, 1, 1, 3, 0, 0, 0, time.Local))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(open).To(BeTrue())
+
+		open, err = window.IsOpen(time.Date(2024, 1, 1, 2, 0, 0, 0, time.Local))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(open).To(BeTrue())
+	})
+
+	It("detects when the window is closed", func() {
+		open, err := window.IsOpen(time.Date(2024, 1, 1, 4, 30, 0, 0, time.Local))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(open).To(BeFalse())
+
+		open, err = window.IsOpen(time.Date(2024, 1, 1, 1, 59, 0, 0, time.Local))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(open).To(BeFalse())
+	})
+
+	It("checks a list of windows", func() {
+		windows := []MaintenanceWindow{
+			window,
+			{Schedule: "0 0 12 * * *", Duration: metav1.Duration{Duration: time.Hour}},
+		}
+		open, err := IsInMaintenanceWindow(windows, time.Date(2024, 1, 1, 12, 30, 0, 0, time.Local))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(open).To(BeTrue())
+
+		open, err = IsInMaintenanceWindow(nil, time.Now())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(open).To(BeFalse())
+
+		_, err = IsInMaintenanceWindow([]MaintenanceWindow{{Schedule: "wrong"}}, time.Now())
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+var _ = Describe("Canary rollout", func() {
+	It("uses the default values when not configured", func() {
+		cluster := &Cluster{}
+		Expect(cluster.IsCanaryRolloutEnabled()).To(BeFalse())
+		Expect(cluster.GetCanarySoakTime()).To(Equal(10 * time.Minute))
+		Expect(cluster.GetCanaryMaximumLag()).To(BeEquivalentTo(DefaultCanaryMaximumLag))
+		Expect(cluster.GetCanaryMaximumLogErrors()).To(BeEquivalentTo(DefaultCanaryMaximumLogErrors))
+	})
+
+	It("gets the maximum lag in bytes", func() {
+		cluster := &Cluster{
+			Spec: ClusterSpec{
+				CanaryRollout: &CanaryRolloutConfiguration{MaximumLag: ptr.To(resource.MustParse("1G"))},
+			},
+		}
+		Expect(cluster.GetCanaryMaximumLag()).To(BeEquivalentTo(1000 * 1000 * 1000))
+		cluster.Spec.CanaryRollout.MaximumLag = ptr.To(resource.MustParse("512Mi"))
+		Expect(cluster.GetCanaryMaximumLag()).To(BeEquivalentTo(512 * 1024 * 1024))
+	})
+
+	It("gives the SQL probes only to the canary instance", func() {
+		cluster := &Cluster{
+			Spec: ClusterSpec{
+				Bootstrap: &BootstrapConfiguration{InitDB: &BootstrapInitDB{Database: "orders"}},
+				CanaryRollout: &CanaryRolloutConfiguration{
+					Enabled: true,
+					Probes: []CanaryProbe{
+						{Name: "app", Query: "SELECT true"},
+						{Name: "catalog", Query: "SELECT true", Database: "postgres"},
+					},
+				},
+			},
+			Status: ClusterStatus{
+				CanaryRollout: &CanaryRolloutStatus{
+					Phase:    CanaryRolloutPhaseInProgress,
+					Instance: "cluster-example-3",
+				},
+			},
+		}
+		Expect(cluster.GetCanaryProbes("cluster-example-2")).To(BeEmpty())
+
+		probes := cluster.GetCanaryProbes("cluster-example-3")
+		Expect(probes).To(HaveLen(2))
+		Expect(probes[0].Database).To(Equal("orders"))
+		Expect(probes[1].Database).To(Equal("postgres"))
+		Expect(cluster.Spec.CanaryRollout.Probes[0].Database).To(BeEmpty())
+
+		cluster.Status.CanaryRollout.Phase = CanaryRolloutPhaseSucceeded
+		Expect(cluster.GetCanaryProbes("cluster-example-3")).To(BeEmpty())
+	})
+})
+
+var _ = Describe("audit configuration", func() {
+	It("returns the user parameters when audit is not configured", func() {
+		cluster := Cluster{Spec: ClusterSpec{PostgresConfiguration: PostgresConfiguration{
+			Parameters: map[string]string{"work_mem": "8MB"},
+		}}}
+		Expect(cluster.GetPostgresParameters()).To(Equal(map[string]string{"work_mem": "8MB"}))
+		Expect(cluster.IsPgAuditOutputSeparate()).To(BeFalse())
+	})
+
+	It("adds the audit classes to the parameters without changing the spec", func() {
+		cluster := Cluster{Spec: ClusterSpec{PostgresConfiguration: PostgresConfiguration{
+			Parameters: map[string]string{"work_mem": "8MB"},
+			Audit: &AuditConfiguration{
+				Classes: []PgAuditClass{"ddl", "role"},
+				Output:  PgAuditOutputSeparate,
+			},
+		}}}
+		Expect(cluster.GetPostgresParameters()).To(Equal(map[string]string{
+			"work_mem":    "8MB",
+			"pgaudit.log": "ddl,role",
+		}))
+		Expect(cluster.Spec.PostgresConfiguration.Parameters).ToNot(HaveKey("pgaudit.log"))
+		Expect(cluster.IsPgAuditOutputSeparate()).To(BeTrue())
+	})
+
+	It("disables the global audit when no class is declared", func() {
+		cluster := Cluster{Spec: ClusterSpec{PostgresConfiguration: PostgresConfiguration{
+			Audit: &AuditConfiguration{},
+		}}}
+		Expect(cluster.GetPostgresParameters()).To(HaveKeyWithValue("pgaudit.log", "none"))
+	})
+})
+
+var _ = Describe("Backup volume", func() {
+	It("is not configured by default", func() {
+		cluster := Cluster{}
+		Expect(cluster.GetPersistentVolumeBackupClaimName()).To(BeEmpty())
+		Expect(cluster.GetPersistentVolumeBackupFormat()).To(Equal(BaseBackupFormatPlain))
+		Expect(cluster.IsPersistentVolumeBackupOwned()).To(BeFalse())
+	})
+
+	It("uses the existing volume claim", func() {
+		cluster := Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster-example"},
+			Spec: ClusterSpec{Backup: &BackupConfiguration{
+				PersistentVolume: &PersistentVolumeBackupConfiguration{
+					VolumeClaimName: "shared-backups",
+					Format:          BaseBackupFormatTar,
+				},
+			}},
+		}
+		Expect(cluster.GetPersistentVolumeBackupClaimName()).To(Equal("shared-backups"))
+		Expect(cluster.GetPersistentVolumeBackupFormat()).To(Equal(BaseBackupFormatTar))
+		Expect(cluster.IsPersistentVolumeBackupOwned()).To(BeFalse())
+	})
+
+	It("uses the volume owned by the cluster when storage is configured", func() {
+		cluster := Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster-example"},
+			Spec: ClusterSpec{Backup: &BackupConfiguration{
+				PersistentVolume: &PersistentVolumeBackupConfiguration{
+					Storage: &StorageConfiguration{Size: "10Gi"},
+				},
+			}},
+		}
+		Expect(cluster.GetPersistentVolumeBackupClaimName()).To(Equal("cluster-example-backups"))
+		Expect(cluster.IsPersistentVolumeBackupOwned()).To(BeTrue())
+	})
+})
+
+var _ = Describe("WAL prefetch configuration", func() {
+	It("disables prefetching and caps the spool at 1Gi by default", func() {
+		var configuration *WalPrefetchConfiguration
+		Expect(configuration.GetMaxParallel()).To(Equal(1))
+		Expect(configuration.GetMaxSpoolSize()).To(Equal(int64(1024 * 1024 * 1024)))
+	})
+
+	It("uses the configured values", func() {
+		configuration := &WalPrefetchConfiguration{
+			MaxParallel:  8,
+			MaxSpoolSize: ptr.To(resource.MustParse("256Mi")),
+		}
+		Expect(configuration.GetMaxParallel()).To(Equal(8))
+		Expect(configuration.GetMaxSpoolSize()).To(Equal(int64(256 * 1024 * 1024)))
+	})
+})
+
+var _ = Describe("WAL continuity check interval", func() {
+	It("defaults to five minutes", func() {
+		cluster := Cluster{}
+		Expect(cluster.GetWALContinuityCheckInterval()).To(Equal(DefaultWALContinuityCheckInterval))
+
+		cluster.Spec.Backup = &BackupConfiguration{}
+		Expect(cluster.GetWALContinuityCheckInterval()).To(Equal(DefaultWALContinuityCheckInterval))
+	})
+
+	It("uses the configured interval, and zero to disable the check", func() {
+		cluster := Cluster{Spec: ClusterSpec{Backup: &BackupConfiguration{
+			WALContinuityCheckInterval: &metav1.Duration{Duration: time.Hour},
+		}}}
+		Expect(cluster.GetWALContinuityCheckInterval()).To(Equal(time.Hour))
+
+		cluster.Spec.Backup.WALContinuityCheckInterval = &metav1.Duration{}
+		Expect(cluster.GetWALContinuityCheckInterval()).To(BeZero())
+	})
+})
+
+var _ = Describe("credentials provider plugins", func() {
+	It("only reports the enabled plugins declared as credentials providers", func() {
+		cluster := Cluster{Spec: ClusterSpec{Plugins: []PluginConfiguration{
+			{Name: "wal-archiver", IsWALArchiver: ptr.To(true)},
+			{Name: "vault", IsCredentialsProvider: ptr.To(true)},
+			{Name: "disabled-vault", Enabled: ptr.To(false), IsCredentialsProvider: ptr.To(true)},
+			{Name: "not-a-provider", IsCredentialsProvider: ptr.To(false)},
+		}}}
+		Expect(cluster.GetCredentialsProviderPluginNames()).To(ConsistOf("vault"))
+	})
+
+	It("is empty when no plugin is declared as a credentials provider", func() {
+		cluster := Cluster{}
+		Expect(cluster.GetCredentialsProviderPluginNames()).To(BeEmpty())
+	})
+})
@@ -45,6 +45,10 @@ const (
 	// get the name of the application user secret
 	ApplicationUserSecretSuffix = "-app"
 
+	// SuperuserGrantSecretSuffix is the suffix appended to the cluster name to
+	// get the name of the secret containing the temporary superuser credentials
+	SuperuserGrantSecretSuffix = "-superuser-grant" // #nosec
+
 	// DefaultServerCaSecretSuffix is the suffix appended to the secret containing
 	// the generated CA for the cluster
 	DefaultServerCaSecretSuffix = "-ca"
@@ -53,6 +57,11 @@ const (
 	// the generated CA for the client certificates
 	ClientCaSecretSuffix = "-ca"
 
+	// OperatorClientCaSecretSuffix is the suffix appended to the secret
+	// containing the generated CA for the client certificate the operator
+	// uses to authenticate against the instance manager
+	OperatorClientCaSecretSuffix = "-operator-ca"
+
 	// ServerSecretSuffix is the suffix appended to the secret containing
 	// the generated server secret for PostgreSQL
 	ServerSecretSuffix = "-server"
@@ -86,10 +95,19 @@ const (
 	// and tablespace name to get the name of PVC for a certain tablespace
 	TablespaceVolumeInfix = "-tbs-"
 
+	// BackupVolumeSuffix is the suffix appended to the cluster name to
+	// get the name of the PVC dedicated to the base backups, when its
+	// storage is managed by the operator
+	BackupVolumeSuffix = "-backups"
+
 	// StreamingReplicationUser is the name of the user we'll use for
 	// streaming replication purposes
 	StreamingReplicationUser = "streaming_replica"
 
+	// SuperuserGrantRoleName is the name of the role created by the
+	// operator when a temporary superuser access is granted
+	SuperuserGrantRoleName = "cnpg_break_glass"
+
 	// DefaultPostgresUID is the default UID which is used by PostgreSQL
 	DefaultPostgresUID = 26
 
@@ -359,6 +377,17 @@ type ClusterSpec struct {
 	// +optional
 	FailoverDelay int32 `json:"failoverDelay,omitempty"`
 
+	// Configuration of the quorum-based failover, which prevents a
+	// promotion unless the majority of the instances confirm that
+	// they lost the current primary
+	// +optional
+	FailoverQuorum *FailoverQuorumConfiguration `json:"failoverQuorum,omitempty"`
+
+	// The nodes where the primary instance should preferably run, and
+	// the optional switchback to them after a failover
+	// +optional
+	PrimaryPlacement *PrimaryPlacementConfiguration `json:"primaryPlacement,omitempty"`
+
 	// LivenessProbeTimeout is the time (in seconds) that is allowed for a PostgreSQL instance
 	// to successfully respond to the liveness probe (default 30).
 	// The Liveness probe failure threshold is derived from this value using the formula:
@@ -410,6 +439,11 @@ type ClusterSpec struct {
 	// +optional
 	PrimaryUpdateMethod PrimaryUpdateMethod `json:"primaryUpdateMethod,omitempty"`
 
+	// The canary strategy to follow when the PostgreSQL image of the
+	// cluster changes, within the same major version
+	// +optional
+	CanaryRollout *CanaryRolloutConfiguration `json:"canaryRollout,omitempty"`
+
 	// The configuration to be used for backups
 	// +optional
 	Backup *BackupConfiguration `json:"backup,omitempty"`
@@ -418,6 +452,12 @@ type ClusterSpec struct {
 	// +optional
 	NodeMaintenanceWindow *NodeMaintenanceWindow `json:"nodeMaintenanceWindow,omitempty"`
 
+	// The windows in which the operator is allowed to perform disruptive
+	// actions, such as rolling updates, switchovers and instance manager
+	// upgrades. If empty, these actions are performed as soon as needed
+	// +optional
+	MaintenanceWindows []MaintenanceWindow `json:"maintenanceWindows,omitempty"`
+
 	// The configuration of the monitoring infrastructure of this cluster
 	// +optional
 	Monitoring *MonitoringConfiguration `json:"monitoring,omitempty"`
@@ -426,6 +466,11 @@ type ClusterSpec struct {
 	// +optional
 	ExternalClusters []ExternalCluster `json:"externalClusters,omitempty"`
 
+	// The configuration of the NetworkPolicy isolating the instances
+	// of this cluster
+	// +optional
+	NetworkPolicy *NetworkPolicyConfiguration `json:"networkPolicy,omitempty"`
+
 	// The instances' log level, one of the following values: error, warning, info (default), debug, trace
 	// +kubebuilder:default:=info
 	// +kubebuilder:validation:Enum:=error;warning;info;debug;trace
@@ -479,12 +524,149 @@ type ClusterSpec struct {
 	// +optional
 	Plugins []PluginConfiguration `json:"plugins,omitempty"`
 
+	// The configuration of the prefetching of the WAL files restored
+	// through the plugins
+	// +optional
+	WalPrefetch *WalPrefetchConfiguration `json:"walPrefetch,omitempty"`
+
 	// The configuration of the probes to be injected
 	// in the PostgreSQL Pods.
 	// +optional
 	Probes *ProbesConfiguration `json:"probes,omitempty"`
 }
 
+// FailoverQuorumConfiguration contains the settings of the quorum-based
+// failover and of the self-fencing of an isolated primary
+type FailoverQuorumConfiguration struct {
+	// If enabled, a failover is triggered only when the majority of the
+	// instances in the cluster report that they are reachable and that they
+	// are not streaming from the current primary anymore.
+	// Requires at least three instances.
+	// +kubebuilder:default:=false
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+
+	// The time (in seconds) after which a primary that can reach neither
+	// the Kubernetes API server nor any of its replicas fails its liveness
+	// probe, fencing itself. Set to 0 to disable the self-fencing.
+	// +kubebuilder:default:=30
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	PrimaryIsolationTimeout int32 `json:"primaryIsolationTimeout,omitempty"`
+}
+
+// PrimaryPlacementConfiguration contains the preferences about the
+// placement of the primary instance
+type PrimaryPlacementConfiguration struct {
+	// The list of node preferences. The score of a node is the sum of the
+	// weights of the preferences it matches, and the operator prefers
+	// nodes with the highest score when bootstrapping the cluster and when
+	// choosing among equally up-to-date instances during a failover
+	// +optional
+	Preferences []PrimaryPlacementPreference `json:"preferences,omitempty"`
+
+	// The configuration of the automatic switchback to an instance running
+	// on a preferred node
+	// +optional
+	Switchback *PrimarySwitchbackConfiguration `json:"switchback,omitempty"`
+}
+
+// PrimaryPlacementPreference is a weighted set of node labels
+type PrimaryPlacementPreference struct {
+	// The weight of this preference, in the range 1-100
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	Weight int32 `json:"weight"`
+
+	// The labels a node must have to match this preference, i.e.
+	// `topology.kubernetes.io/zone: eu-west-1a`
+	// +kubebuilder:validation:MinProperties=1
+	NodeLabels map[string]string `json:"nodeLabels"`
+}
+
+// PrimarySwitchbackConfiguration contains the settings of the planned
+// switchover back to an instance running on a preferred node
+type PrimarySwitchbackConfiguration struct {
+	// If enabled, the operator performs a switchover to a healthy and
+	// caught up instance running on a node with a higher score than the one
+	// of the current primary
+	// +kubebuilder:default:=false
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+
+	// The maximum replay lag, measured against the current LSN of the
+	// primary, that a candidate can have to be switched over to.
+	// Defaults to 16Mi
+	// +optional
+	MaximumLag *resource.Quantity `json:"maximumLag,omitempty"`
+
+	// The windows in which the switchback is allowed to happen. If empty,
+	// the switchback happens as soon as a candidate is available
+	// +optional
+	MaintenanceWindows []MaintenanceWindow `json:"maintenanceWindows,omitempty"`
+}
+
+// CanaryRolloutConfiguration contains the settings of the canary
+// strategy used to roll out a new PostgreSQL image
+type CanaryRolloutConfiguration struct {
+	// If enabled, the operator updates a single replica to the new image
+	// and checks its health for the soak time, before continuing with the
+	// rest of the cluster. If the canary instance is unhealthy, the
+	// operator rolls it back to the previous image
+	// +kubebuilder:default:=false
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+
+	// How long the canary instance is checked before continuing
+	// the rollout. Defaults to 10m
+	// +optional
+	SoakTime *metav1.Duration `json:"soakTime,omitempty"`
+
+	// The maximum replay lag, measured against the current LSN of the
+	// primary, that the canary instance can have. Defaults to 16Mi
+	// +optional
+	MaximumLag *resource.Quantity `json:"maximumLag,omitempty"`
+
+	// The maximum number of log records with severity `ERROR` or higher
+	// that the canary instance can emit during the soak time. Defaults to 10
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaximumLogErrors *int32 `json:"maximumLogErrors,omitempty"`
+
+	// A list of SQL probes to be executed on the canary instance
+	// during the soak time
+	// +optional
+	Probes []CanaryProbe `json:"probes,omitempty"`
+}
+
+// CanaryProbe is a SQL query checking the health of the canary instance
+type CanaryProbe struct {
+	// The name of the probe
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// The database where the query is executed. Defaults to the
+	// application database
+	// +optional
+	Database string `json:"database,omitempty"`
+
+	// The query to be executed. It must return a single boolean value,
+	// and the probe fails when the value is not `true`
+	// +kubebuilder:validation:MinLength=1
+	Query string `json:"query"`
+}
+
+// MaintenanceWindow is a recurring period of time in which
+// disruptive operations are allowed
+type MaintenanceWindow struct {
+	// The schedule of the start of the window, in Cron format
+	// (with seconds), see https://pkg.go.dev/github.com/robfig/cron#hdr-CRON_Expression_Format
+	Schedule string `json:"schedule"`
+
+	// How long the window stays open after each start
+	Duration metav1.Duration `json:"duration"`
+}
+
 // ProbesConfiguration represent the configuration for the probes
 // to be injected in the PostgreSQL Pods
 type ProbesConfiguration struct {
@@ -589,6 +771,10 @@ const (
 	// PhaseMajorUpgrade major version upgrade in process
 	PhaseMajorUpgrade = "Upgrading Postgres major version"
 
+	// PhaseCanaryRollout is set while a canary instance running a new
+	// image is being checked
+	PhaseCanaryRollout = "Canary rollout in progress"
+
 	// PhaseUpgradeDelayed is set when a cluster needs to be upgraded,
 	// but the operation is being delayed by the operator configuration
 	PhaseUpgradeDelayed = "Cluster upgrade delayed"
@@ -634,6 +820,10 @@ const (
 
 	// PhaseCannotCreateClusterObjects is set by the operator when is unable to create cluster resources
 	PhaseCannotCreateClusterObjects = "Unable to create required cluster objects"
+
+	// PhaseWaitingForCertificates is set by the operator while waiting for
+	// cert-manager to issue the certificates of the cluster
+	PhaseWaitingForCertificates = "Waiting for the certificates to be issued"
 )
 
 // EphemeralVolumesSizeLimitConfiguration contains the configuration of the ephemeral
@@ -646,6 +836,11 @@ type EphemeralVolumesSizeLimitConfiguration struct {
 	// TemporaryData is the size limit of the temporary data volume
 	// +optional
 	TemporaryData *resource.Quantity `json:"temporaryData,omitempty"`
+
+	// LogicalBackupStaging is the size limit of the volume where the
+	// logical backups are written before being handed over to a plugin
+	// +optional
+	LogicalBackupStaging *resource.Quantity `json:"logicalBackupStaging,omitempty"`
 }
 
 // ServiceAccountTemplate contains the template needed to generate the service accounts
@@ -703,6 +898,13 @@ type PasswordState struct {
 	// the resource version of the password secret
 	// +optional
 	SecretResourceVersion string `json:"resourceVersion,omitempty"`
+	// the time when the operator last rotated the password of the role
+	// +optional
+	LastRotation *metav1.Time `json:"lastRotation,omitempty"`
+	// the name of the role whose credentials are currently stored in the
+	// password secret, when using the alternating rotation strategy
+	// +optional
+	ActiveRole string `json:"activeRole,omitempty"`
 }
 
 // ManagedRoles tracks the status of a cluster's managed roles
@@ -963,6 +1165,162 @@ type ClusterStatus struct {
 	// WAL file, and Time of latest checkpoint
 	// +optional
 	DemotionToken string `json:"demotionToken,omitempty"`
+
+	// CanaryRollout is the status of the latest canary rollout
+	// +optional
+	CanaryRollout *CanaryRolloutStatus `json:"canaryRollout,omitempty"`
+
+	// PendingRollout is the disruptive action which is waiting for the
+	// next maintenance window to be applied
+	// +optional
+	PendingRollout *PendingRollout `json:"pendingRollout,omitempty"`
+
+	// PrimaryHistory is the list of the latest primary changes, starting
+	// from the oldest one. At most MaxPrimaryHistoryEntries are kept.
+	// +optional
+	PrimaryHistory []PrimaryChange `json:"primaryHistory,omitempty"`
+
+	// SuperuserGrant is the temporary superuser access currently granted,
+	// as requested with the `cnpg.io/superuserGrantUntil` annotation
+	// +optional
+	SuperuserGrant *SuperuserGrant `json:"superuserGrant,omitempty"`
+}
+
+// SuperuserGrant is a temporary superuser access to the cluster
+type SuperuserGrant struct {
+	// The name of the PostgreSQL role having superuser access
+	RoleName string `json:"roleName"`
+
+	// The name of the secret containing the credentials of the role
+	SecretName string `json:"secretName"`
+
+	// When the access has been granted
+	GrantedAt metav1.Time `json:"grantedAt"`
+
+	// When the access expires. The role will be dropped afterwards
+	ExpiresAt metav1.Time `json:"expiresAt"`
+}
+
+// MaxPrimaryHistoryEntries is the maximum number of primary changes kept
+// in the cluster status
+const MaxPrimaryHistoryEntries = 10
+
+// PrimaryChangeReason is the reason why the primary instance changed
+type PrimaryChangeReason string
+
+const (
+	// PrimaryChangeReasonFailover means that the operator promoted a replica
+	// because the primary instance was not healthy
+	PrimaryChangeReasonFailover PrimaryChangeReason = "Failover"
+
+	// PrimaryChangeReasonSwitchover means that the operator promoted a replica
+	// while the primary instance was healthy, e.g. during a rolling update
+	PrimaryChangeReasonSwitchover PrimaryChangeReason = "Switchover"
+
+	// PrimaryChangeReasonPromote means that the target primary has been
+	// changed by the user, e.g. via `kubectl cnpg promote`
+	PrimaryChangeReasonPromote PrimaryChangeReason = "Promote"
+)
+
+// PrimaryChange describes a change of the primary instance
+type PrimaryChange struct {
+	// When the new primary has been elected
+	Time metav1.Time `json:"time"`
+
+	// The primary instance before the change
+	// +optional
+	OldPrimary string `json:"oldPrimary,omitempty"`
+
+	// The elected primary instance
+	NewPrimary string `json:"newPrimary"`
+
+	// Why the primary changed
+	Reason PrimaryChangeReason `json:"reason"`
+
+	// The timeline ID of the new primary after the promotion. It is
+	// empty until the promotion is completed
+	// +optional
+	TimelineID int `json:"timelineID,omitempty"`
+
+	// The LSN received by the elected instance when it was chosen
+	// +optional
+	LSN string `json:"lsn,omitempty"`
+
+	// The estimated amount of WAL, in bytes, written by the old primary
+	// and not yet received by the elected instance. It is empty when
+	// the old primary was not reachable
+	// +optional
+	EstimatedDataLossBytes *int64 `json:"estimatedDataLossBytes,omitempty"`
+}
+
+// CanaryRolloutPhase is the phase of a canary rollout
+type CanaryRolloutPhase string
+
+const (
+	// CanaryRolloutPhaseInProgress means that the canary instance is
+	// being updated or checked
+	CanaryRolloutPhaseInProgress CanaryRolloutPhase = "InProgress"
+
+	// CanaryRolloutPhaseSucceeded means that the canary instance passed
+	// every check, and the new image is being rolled out to the cluster
+	CanaryRolloutPhaseSucceeded CanaryRolloutPhase = "Succeeded"
+
+	// CanaryRolloutPhaseRolledBack means that the canary instance failed
+	// a check, and the cluster went back to the previous image
+	CanaryRolloutPhaseRolledBack CanaryRolloutPhase = "RolledBack"
+)
+
+// CanaryRolloutStatus contains the status of a canary rollout
+type CanaryRolloutStatus struct {
+	// The current phase of the canary rollout
+	Phase CanaryRolloutPhase `json:"phase"`
+
+	// The image the cluster was running before the rollout
+	PreviousImage string `json:"previousImage"`
+
+	// The image being rolled out
+	TargetImage string `json:"targetImage"`
+
+	// The name of the canary instance
+	// +optional
+	Instance string `json:"instance,omitempty"`
+
+	// When the canary instance started running the target image
+	// +optional
+	SoakStartedAt *metav1.Time `json:"soakStartedAt,omitempty"`
+
+	// The number of error log records emitted by the canary instance
+	// during the soak time, up to the last check
+	// +optional
+	LogErrors int64 `json:"logErrors,omitempty"`
+
+	// The error log records counter of the instance manager of the canary
+	// instance at the last check. The counter starts from zero when the
+	// PostgreSQL container restarts
+	// +optional
+	LogErrorsCounter int64 `json:"logErrorsCounter,omitempty"`
+
+	// The restart count of the PostgreSQL container of the canary
+	// instance at the last check
+	// +optional
+	ContainerRestarts int32 `json:"containerRestarts,omitempty"`
+
+	// A human-readable description of the outcome of the rollout
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// PendingRollout describes a disruptive action which has been postponed
+// because the cluster is outside its maintenance windows
+type PendingRollout struct {
+	// The name of the instance the action is waiting to be applied to
+	Target string `json:"target"`
+
+	// Why the action is needed
+	Reason string `json:"reason"`
+
+	// When the action has been postponed for the first time
+	Since metav1.Time `json:"since"`
 }
 
 // SwitchReplicaClusterStatus contains all the statuses regarding the switch of a cluster to a replica cluster
@@ -993,6 +1351,16 @@ const (
 	ConditionBackup ClusterConditionType = "LastBackupSucceeded"
 	// ConditionClusterReady represents whether a cluster is Ready
 	ConditionClusterReady ClusterConditionType = "Ready"
+	// ConditionCanaryRollout represents the outcome of the latest canary rollout
+	ConditionCanaryRollout ClusterConditionType = "CanaryRollout"
+	// ConditionPgHBARules represents whether PostgreSQL accepted the rules in pg_hba.conf
+	ConditionPgHBARules ClusterConditionType = "PgHBARulesApplied"
+	// ConditionWALArchiveContinuity represents whether the WAL archive contains
+	// every WAL segment from the oldest archived one to the newest
+	ConditionWALArchiveContinuity ClusterConditionType = "WALArchiveContinuity"
+	// ConditionClientCertificateSigning represents whether the operator can
+	// sign client certificates with the client CA of the cluster
+	ConditionClientCertificateSigning ClusterConditionType = "ClientCertificateSigning"
 )
 
 // ConditionStatus defines conditions of resources
@@ -1041,6 +1409,51 @@ const (
 
 	// DetachedVolume is the reason that is set when we do a rolling upgrade to add a PVC volume to a cluster
 	DetachedVolume ConditionReason = "DetachedVolume"
+
+	// ConditionReasonCanaryInProgress means that the canary instance is
+	// being updated or checked
+	ConditionReasonCanaryInProgress ConditionReason = "CanaryInProgress"
+
+	// ConditionReasonCanarySucceeded means that the canary instance passed every check
+	ConditionReasonCanarySucceeded ConditionReason = "CanarySucceeded"
+
+	// ConditionReasonCanaryRolledBack means that the canary instance failed a
+	// check and has been rolled back to the previous image
+	ConditionReasonCanaryRolledBack ConditionReason = "CanaryRolledBack"
+
+	// ConditionReasonPgHBARulesApplied means that PostgreSQL accepted every
+	// rule in pg_hba.conf
+	ConditionReasonPgHBARulesApplied ConditionReason = "PgHBARulesApplied"
+
+	// ConditionReasonPgHBARulesRejected means that PostgreSQL rejected some
+	// rules in pg_hba.conf, and kept using the previous configuration
+	ConditionReasonPgHBARulesRejected ConditionReason = "PgHBARulesRejected"
+
+	// ConditionReasonWALArchiveContinuous means that no WAL segment is missing
+	// from the WAL archive
+	ConditionReasonWALArchiveContinuous ConditionReason = "WALArchiveContinuous"
+
+	// ConditionReasonWALArchiveGapDetected means that some WAL segments or
+	// timeline history files are missing from the WAL archive
+	ConditionReasonWALArchiveGapDetected ConditionReason = "WALArchiveGapDetected"
+
+	// ConditionReasonWALArchiveRangeOnly means that the content of the WAL
+	// archive can't be listed, and only its oldest and newest WAL segments
+	// are known
+	ConditionReasonWALArchiveRangeOnly ConditionReason = "WALArchiveRangeOnly"
+
+	// ConditionReasonClientCAKeyAvailable means that the private key of the
+	// client CA is available to the operator
+	ConditionReasonClientCAKeyAvailable ConditionReason = "ClientCAKeyAvailable"
+
+	// ConditionReasonClientCAKeyMissing means that the client CA secret
+	// provided by the user doesn't contain the private key of the CA
+	ConditionReasonClientCAKeyMissing ConditionReason = "ClientCAKeyMissing"
+
+	// ConditionReasonUnsupportedWithCertManager means that the client CA has
+	// been issued by cert-manager and its private key is not available, so
+	// the operator can't sign client certificates with it
+	ConditionReasonUnsupportedWithCertManager ConditionReason = "UnsupportedWithCertManager"
 )
 
 // EmbeddedObjectMetadata contains metadata to be inherited by all resources related to a Cluster
@@ -1251,6 +1664,22 @@ const (
 	// is gracefully shutdown during a switchover.
 	DefaultMaxSwitchoverDelay = 3600
 
+	// DefaultPrimarySwitchbackMaximumLag is the default maximum replay lag, in bytes,
+	// of an instance to be switched over to by the primary switchback
+	DefaultPrimarySwitchbackMaximumLag = 16 * 1024 * 1024
+
+	// DefaultCanarySoakTimeSeconds is the default time, in seconds, the canary
+	// instance is checked before continuing a canary rollout
+	DefaultCanarySoakTimeSeconds = 600
+
+	// DefaultCanaryMaximumLag is the default maximum replay lag, in bytes,
+	// of the canary instance
+	DefaultCanaryMaximumLag = 16 * 1024 * 1024
+
+	// DefaultCanaryMaximumLogErrors is the default maximum number of error
+	// log records the canary instance can emit during the soak time
+	DefaultCanaryMaximumLogErrors = 10
+
 	// DefaultStartupDelay is the default value for startupDelay, startupDelay will be used to calculate the
 	// FailureThreshold of startupProbe, the formula is `FailureThreshold = ceiling(startDelay / periodSeconds)`,
 	// the minimum value is 1
@@ -1331,6 +1760,113 @@ type SynchronousReplicaConfiguration struct {
 	DataDurability DataDurabilityLevel `json:"dataDurability,omitempty"`
 }
 
+// HBARulePosition is the position of a structured Host Based
+// Authentication rule relative to the rules generated by the operator
+type HBARulePosition string
+
+const (
+	// HBARulePositionAfterOperatorRules places the rule after the fixed
+	// rules generated by the operator for its own users
+	HBARulePositionAfterOperatorRules HBARulePosition = "afterOperatorRules"
+
+	// HBARulePositionBeforeOperatorRules places the rule before the fixed
+	// rules generated by the operator, giving it precedence over them
+	HBARulePositionBeforeOperatorRules HBARulePosition = "beforeOperatorRules"
+)
+
+// HBARule is a structured Host Based Authentication rule
+type HBARule struct {
+	// The connection type
+	// +kubebuilder:validation:Enum=local;host;hostssl;hostnossl;hostgssenc;hostnogssenc
+	Type string `json:"type"`
+
+	// The names of the databases the rule applies to, including keywords
+	// like `all`, `sameuser`, `samerole` and `replication`
+	// +kubebuilder:validation:MinItems=1
+	Databases []string `json:"databases"`
+
+	// The names of the users the rule applies to, including the `all`
+	// keyword and group names prefixed by `+`
+	// +kubebuilder:validation:MinItems=1
+	Users []string `json:"users"`
+
+	// The client address, as a CIDR, a host name, or one of the `all`,
+	// `samehost` and `samenet` keywords. Not allowed with `local` rules
+	// +optional
+	Address string `json:"address,omitempty"`
+
+	// The authentication method
+	// +kubebuilder:validation:Enum=trust;reject;scram-sha-256;md5;password;gss;sspi;ident;peer;ldap;radius;cert;pam;bsd
+	Method string `json:"method"`
+
+	// The options of the authentication method
+	// +optional
+	Options map[string]string `json:"options,omitempty"`
+
+	// The position of the rule relative to the rules generated by the
+	// operator, `afterOperatorRules` (default) or `beforeOperatorRules`
+	// +kubebuilder:validation:Enum=afterOperatorRules;beforeOperatorRules
+	// +kubebuilder:default:=afterOperatorRules
+	// +optional
+	Position HBARulePosition `json:"position,omitempty"`
+}
+
+// PgAuditClass is a class of statements logged by pgaudit
+// +kubebuilder:validation:Enum=read;write;function;role;ddl;misc;misc_set;all
+type PgAuditClass string
+
+// PgAuditOutput is the stream where the pgaudit records are written
+type PgAuditOutput string
+
+const (
+	// PgAuditOutputDefault writes the pgaudit records together with the
+	// other logs of the instance, in the log stream of the instance manager
+	PgAuditOutputDefault PgAuditOutput = "default"
+
+	// PgAuditOutputSeparate writes the pgaudit records to the
+	// `/controller/log/pgaudit.json` file, separately from the other logs
+	PgAuditOutputSeparate PgAuditOutput = "separate"
+)
+
+// AuditConfiguration is the declarative configuration of pgaudit
+type AuditConfiguration struct {
+	// The classes of statements logged for every session. If empty, no
+	// statement is logged unless configured for a role or a database
+	// +optional
+	Classes []PgAuditClass `json:"classes,omitempty"`
+
+	// The classes of statements logged for the sessions of specific roles,
+	// overriding the global and the database ones
+	// +optional
+	Roles []AuditScopeConfiguration `json:"roles,omitempty"`
+
+	// The classes of statements logged for the sessions connected to
+	// specific databases, overriding the global ones
+	// +optional
+	Databases []AuditScopeConfiguration `json:"databases,omitempty"`
+
+	// Where the pgaudit records are written: `default` writes them
+	// together with the other logs of the instance, `separate` writes them
+	// to the `/controller/log/pgaudit.json` file, which is rotated when it
+	// reaches 100MB and can be read by a sidecar container
+	// +kubebuilder:validation:Enum=default;separate
+	// +kubebuilder:default:=default
+	// +optional
+	Output PgAuditOutput `json:"output,omitempty"`
+}
+
+// AuditScopeConfiguration contains the classes of statements logged
+// for a certain role or database
+type AuditScopeConfiguration struct {
+	// The name of the role or of the database
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// The classes of statements logged. If empty, no statement is logged
+	// +optional
+	Classes []PgAuditClass `json:"classes,omitempty"`
+}
+
 // PostgresConfiguration defines the PostgreSQL configuration
 type PostgresConfiguration struct {
 	// PostgreSQL configuration options (postgresql.conf)
@@ -1346,6 +1882,12 @@ type PostgresConfiguration struct {
 	// +optional
 	PgHBA []string `json:"pg_hba,omitempty"`
 
+	// Structured PostgreSQL Host Based Authentication rules, validated by
+	// the operator. The rules placed after the operator rules precede the
+	// ones in `pg_hba`
+	// +optional
+	PgHBARules []HBARule `json:"pg_hba_rules,omitempty"`
+
 	// PostgreSQL User Name Maps rules (lines to be appended
 	// to the pg_ident.conf file)
 	// +optional
@@ -1364,6 +1906,11 @@ type PostgresConfiguration struct {
 	// +optional
 	LDAP *LDAPConfig `json:"ldap,omitempty"`
 
+	// Declarative configuration of the audit logging provided by the
+	// pgaudit extension
+	// +optional
+	Audit *AuditConfiguration `json:"audit,omitempty"`
+
 	// Specifies the maximum number of seconds to wait when promoting an instance to primary.
 	// Default value is 40000000, greater than one year in seconds,
 	// big enough to simulate an infinite timeout
@@ -1509,6 +2056,50 @@ type CertificatesConfiguration struct {
 	// The list of the server alternative DNS names to be added to the generated server TLS certificates, when required.
 	// +optional
 	ServerAltDNSNames []string `json:"serverAltDNSNames,omitempty"`
+
+	// The cert-manager issuer to be used to generate the server and client
+	// certificates, in place of the CA managed by the operator. When set,
+	// the operator creates and owns the cert-manager `Certificate` resources,
+	// and the CA is read from the `ca.crt` key of the issued secrets.
+	// The client CA secret only contains the CA certificate of the issuer,
+	// so the operator can't sign client certificates with it.
+	// Cannot be used together with the other secret names.
+	// +optional
+	CertManager *CertManagerConfiguration `json:"certManager,omitempty"`
+}
+
+// CertManagerConfiguration contains the configuration of the certificates
+// issued by cert-manager
+type CertManagerConfiguration struct {
+	// The reference to the cert-manager issuer
+	IssuerRef CertManagerIssuerReference `json:"issuerRef"`
+
+	// The requested duration of the certificates. Defaults to the
+	// one configured in the issuer
+	// +optional
+	Duration *metav1.Duration `json:"duration,omitempty"`
+
+	// How long before the expiration the certificates should be renewed.
+	// Defaults to the one chosen by cert-manager
+	// +optional
+	RenewBefore *metav1.Duration `json:"renewBefore,omitempty"`
+}
+
+// CertManagerIssuerReference is a reference to a cert-manager issuer
+type CertManagerIssuerReference struct {
+	// The name of the issuer
+	Name string `json:"name"`
+
+	// The kind of the issuer, `Issuer` or `ClusterIssuer` for the ones
+	// provided by cert-manager
+	// +kubebuilder:default:=Issuer
+	// +optional
+	Kind string `json:"kind,omitempty"`
+
+	// The API group of the issuer
+	// +kubebuilder:default:=cert-manager.io
+	// +optional
+	Group string `json:"group,omitempty"`
 }
 
 // CertificatesStatus contains configuration certificates and related expiration dates.
@@ -1519,6 +2110,22 @@ type CertificatesStatus struct {
 	// Expiration dates for all certificates.
 	// +optional
 	Expirations map[string]string `json:"expirations,omitempty"`
+
+	// The CA rotations in progress, indexed by the name of the secret
+	// containing the CA
+	// +optional
+	CARotations map[string]CARotationStatus `json:"caRotations,omitempty"`
+}
+
+// CARotationStatus contains the status of the staged rotation of a CA
+type CARotationStatus struct {
+	// The current phase of the rotation
+	// +kubebuilder:validation:Enum=TrustBundlePublished;NewCAActive;LeavesReissued
+	Phase string `json:"phase"`
+
+	// When the rotation entered the current phase
+	// +optional
+	Since *metav1.Time `json:"since,omitempty"`
 }
 
 // BootstrapInitDB is the configuration of the bootstrap process when
@@ -1709,9 +2316,17 @@ type Import struct {
 }
 
 // ImportSource describes the source for the logical snapshot
+// +kubebuilder:validation:XValidation:rule="has(self.externalCluster) != has(self.backup)",message="exactly one of externalCluster and backup must be specified"
 type ImportSource struct {
 	// The name of the externalCluster used for import
-	ExternalCluster string `json:"externalCluster"`
+	// +optional
+	ExternalCluster string `json:"externalCluster,omitempty"`
+
+	// The name of a completed logical backup of this namespace to import
+	// the databases from. The dumps are read from the logical backup volume
+	// of the cluster, that needs to be the one containing the backup
+	// +optional
+	Backup *LocalObjectReference `json:"backup,omitempty"`
 }
 
 // SQLRefs holds references to ConfigMaps or Secrets
@@ -2023,6 +2638,15 @@ type BackupConfiguration struct {
 	// +optional
 	BarmanObjectStore *BarmanObjectStoreConfiguration `json:"barmanObjectStore,omitempty"`
 
+	// The configuration of the logical backups taken with pg_dump
+	// +optional
+	Logical *LogicalBackupConfiguration `json:"logical,omitempty"`
+
+	// The configuration of the physical base backups taken with
+	// pg_basebackup into a PersistentVolumeClaim
+	// +optional
+	PersistentVolume *PersistentVolumeBackupConfiguration `json:"persistentVolume,omitempty"`
+
 	// RetentionPolicy is the retention policy to be used for backups
 	// and WALs (i.e. '60d'). The retention policy is expressed in the form
 	// of `XXu` where `XX` is a positive integer and `u` is in `[dwm]` -
@@ -2040,6 +2664,63 @@ type BackupConfiguration struct {
 	// +kubebuilder:default:=prefer-standby
 	// +optional
 	Target BackupTarget `json:"target,omitempty"`
+
+	// The interval between two checks of the continuity of the WAL
+	// archive, i.e. '5m'. The check runs on the primary instance, looks
+	// for gaps in the archived WAL segments and marks the backups which
+	// can't be restored because of them. It's currently applicable to
+	// the WAL archive in the backup volume and to the WAL archiver plugins.
+	// Set it to '0s' to disable the check. Defaults to '5m'
+	// +optional
+	WALContinuityCheckInterval *metav1.Duration `json:"walContinuityCheckInterval,omitempty"`
+}
+
+// LogicalBackupConfiguration contains the configuration of the logical
+// backups of a cluster
+type LogicalBackupConfiguration struct {
+	// The name of the PersistentVolumeClaim where the logical backups are
+	// stored. The volume is mounted in every instance, and needs to support
+	// the `ReadWriteMany` access mode when the cluster has more than one
+	// instance
+	// +kubebuilder:validation:MinLength=1
+	VolumeClaimName string `json:"volumeClaimName"`
+}
+
+// BaseBackupFormat is the format used by pg_basebackup to write a base backup
+type BaseBackupFormat string
+
+const (
+	// BaseBackupFormatPlain writes the base backup as a plain data directory
+	BaseBackupFormatPlain BaseBackupFormat = "plain"
+
+	// BaseBackupFormatTar writes the base backup as a set of tar files
+	BaseBackupFormatTar BaseBackupFormat = "tar"
+)
+
+// PersistentVolumeBackupConfiguration contains the configuration of the
+// physical base backups stored in a PersistentVolumeClaim
+// +kubebuilder:validation:XValidation:rule="has(self.volumeClaimName) != has(self.storage)",message="exactly one of volumeClaimName and storage must be specified"
+type PersistentVolumeBackupConfiguration struct {
+	// The name of an existing PersistentVolumeClaim where the base backups are
+	// stored. The volume is mounted in every instance, and needs to support
+	// the `ReadWriteMany` access mode when the cluster has more than one
+	// instance
+	// +kubebuilder:validation:MinLength=1
+	// +optional
+	VolumeClaimName string `json:"volumeClaimName,omitempty"`
+
+	// The configuration of a dedicated PersistentVolumeClaim, named
+	// `<cluster>-backups`, created by the operator and owned by the cluster.
+	// The backups and the WAL files stored in it are deleted together with
+	// the cluster
+	// +optional
+	Storage *StorageConfiguration `json:"storage,omitempty"`
+
+	// The format of the base backups, `plain` (default) or `tar`
+	// +kubebuilder:validation:Enum=plain;tar
+	// +kubebuilder:default:=plain
+	// +optional
+	Format BaseBackupFormat `json:"format,omitempty"`
 }
 
 // MonitoringConfiguration is the type containing all the monitoring
@@ -2207,6 +2888,23 @@ type ManagedConfiguration struct {
 	Services *ManagedServices `json:"services,omitempty"`
 }
 
+// WalPrefetchConfiguration configures the prefetching of the WAL files
+// restored through the plugins
+type WalPrefetchConfiguration struct {
+	// Number of WAL files to be restored at the same time, including the
+	// one requested by PostgreSQL. The following ones are kept in the
+	// spool until PostgreSQL requests them. 1 disables prefetching
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default:=1
+	// +optional
+	MaxParallel int `json:"maxParallel,omitempty"`
+
+	// The maximum size of the WAL files kept in the spool. No more WAL
+	// files are prefetched once it is reached. Defaults to `1Gi`
+	// +optional
+	MaxSpoolSize *resource.Quantity `json:"maxSpoolSize,omitempty"`
+}
+
 // PluginConfiguration specifies a plugin that need to be loaded for this
 // cluster to be reconciled
 type PluginConfiguration struct {
@@ -2224,6 +2922,14 @@ type PluginConfiguration struct {
 	// +optional
 	IsWALArchiver *bool `json:"isWALArchiver,omitempty"`
 
+	// IsCredentialsProvider is true when the plugin supplies the content
+	// of the secrets used by the cluster, replacing the Kubernetes ones.
+	// The instance manager and the operator only ask the plugins declared
+	// as credentials providers for the content of those secrets.
+	// +kubebuilder:default:=false
+	// +optional
+	IsCredentialsProvider *bool `json:"isCredentialsProvider,omitempty"`
+
 	// Parameters is the configuration of the plugin
 	// +optional
 	Parameters map[string]string `json:"parameters,omitempty"`
@@ -2318,6 +3024,11 @@ type RoleConfiguration struct {
 	// +optional
 	DisablePassword bool `json:"disablePassword,omitempty"`
 
+	// The policy used by the operator to periodically generate a new password
+	// for the role, storing it in the password secret
+	// +optional
+	PasswordRotation *PasswordRotationConfiguration `json:"passwordRotation,omitempty"`
+
 	// Whether the role is a `superuser` who can override all access
 	// restrictions within the database - superuser status is dangerous and
 	// should be used only when really needed. You must yourself be a
@@ -2360,6 +3071,40 @@ type RoleConfiguration struct {
 	BypassRLS bool `json:"bypassrls,omitempty"` // Row-Level Security
 }
 
+// PasswordRotationStrategy is the strategy used to rotate the password of
+// a managed role
+// +kubebuilder:validation:Enum=single;alternating
+type PasswordRotationStrategy string
+
+const (
+	// PasswordRotationStrategySingle replaces the password of the role
+	// in place
+	PasswordRotationStrategySingle PasswordRotationStrategy = "single"
+
+	// PasswordRotationStrategyAlternating rotates the password of a twin
+	// role, named after the role with the AlternateRoleSuffix, and switches
+	// the password secret to it. The credentials of the previous rotation
+	// stay valid until the next one
+	PasswordRotationStrategyAlternating PasswordRotationStrategy = "alternating"
+)
+
+// AlternateRoleSuffix is the suffix of the name of the twin role created
+// by the alternating password rotation strategy
+const AlternateRoleSuffix = "_alt"
+
+// PasswordRotationConfiguration is the policy used to periodically
+// rotate the password of a managed role
+type PasswordRotationConfiguration struct {
+	// The interval between two rotations of the password, i.e. `720h`
+	Interval metav1.Duration `json:"interval"`
+
+	// The strategy used to rotate the password, `single` (default) or
+	// `alternating`
+	// +kubebuilder:default:=single
+	// +optional
+	Strategy PasswordRotationStrategy `json:"strategy,omitempty"`
+}
+
 // +genclient
 // +kubebuilder:object:root=true
 // +kubebuilder:storageversion
@@ -19,6 +19,10 @@ SPDX-License-Identifier: Apache-2.0
 
 package v1
 
+import (
+	networkingv1 "k8s.io/api/networking/v1"
+)
+
 // VolumeSnapshotKind this is a strongly typed reference to the kind used by the volumesnapshot package
 const VolumeSnapshotKind = "VolumeSnapshot"
 
@@ -45,3 +49,22 @@ type Metadata struct {
 	// +optional
 	Annotations map[string]string `json:"annotations,omitempty"`
 }
+
+// NetworkPolicyConfiguration controls the NetworkPolicy generated
+// by the operator to isolate the pods of a resource
+type NetworkPolicyConfiguration struct {
+	// Enable the generation of a NetworkPolicy allowing only the
+	// traffic needed by the operator and by the declared clients
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+
+	// The peers allowed to connect to the PostgreSQL port of the
+	// pods. If empty, no application traffic is allowed
+	// +optional
+	Clients []networkingv1.NetworkPolicyPeer `json:"clients,omitempty"`
+
+	// The peers allowed to scrape the metrics exposed by the pods.
+	// If empty, the metrics port is not reachable
+	// +optional
+	MonitoringClients []networkingv1.NetworkPolicyPeer `json:"monitoringClients,omitempty"`
+}
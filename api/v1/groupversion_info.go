@@ -48,6 +48,18 @@ const (
 
 	// DatabaseKind is the kind name of databases
 	DatabaseKind = "Database"
+
+	// ClientCertificateKind is the kind name of client certificates
+	ClientCertificateKind = "ClientCertificate"
+
+	// ObjectRestoreKind is the kind name of object restores
+	ObjectRestoreKind = "ObjectRestore"
+
+	// BackupGroupKind is the kind name of backup groups
+	BackupGroupKind = "BackupGroup"
+
+	// BackupExportKind is the kind name of backup exports
+	BackupExportKind = "BackupExport"
 )
 
 var (
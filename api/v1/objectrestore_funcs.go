@@ -0,0 +1,92 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package v1
+
+import (
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+const (
+	// ObjectRestoreRecoveryClusterSuffix is the suffix appended to the name
+	// of an object restore to get the name of its recovery cluster
+	ObjectRestoreRecoveryClusterSuffix = "-recovery"
+
+	// ObjectRestoreJobSuffix is the suffix appended to the name of an
+	// object restore to get the name of its job
+	ObjectRestoreJobSuffix = "-restore"
+
+	// DefaultObjectRestoreSchemaSuffix is the default suffix appended to
+	// the name of the restored schemas
+	DefaultObjectRestoreSchemaSuffix = "_restored"
+)
+
+// GetRecoveryClusterName gets the name of the temporary cluster the
+// objects are dumped from
+func (objectRestore *ObjectRestore) GetRecoveryClusterName() string {
+	return objectRestore.Name + ObjectRestoreRecoveryClusterSuffix
+}
+
+// GetJobName gets the name of the job dumping and restoring the objects
+func (objectRestore *ObjectRestore) GetJobName() string {
+	return objectRestore.Name + ObjectRestoreJobSuffix
+}
+
+// GetSchemaSuffix gets the suffix appended to the name of the restored schemas
+func (objectRestore *ObjectRestore) GetSchemaSuffix() string {
+	if objectRestore.Spec.SchemaSuffix != "" {
+		return objectRestore.Spec.SchemaSuffix
+	}
+	return DefaultObjectRestoreSchemaSuffix
+}
+
+// GetTables gets the schema and the name of the tables to restore
+func (objectRestore *ObjectRestore) GetTables() [][2]string {
+	tables := make([][2]string, 0, len(objectRestore.Spec.Tables))
+	for _, table := range objectRestore.Spec.Tables {
+		schema, name, found := strings.Cut(table, ".")
+		if !found {
+			schema, name = "public", table
+		}
+		tables = append(tables, [2]string{schema, name})
+	}
+	return tables
+}
+
+// IsDone checks if the restore is completed or failed
+func (objectRestoreStatus *ObjectRestoreStatus) IsDone() bool {
+	return objectRestoreStatus.Phase == ObjectRestorePhaseCompleted ||
+		objectRestoreStatus.Phase == ObjectRestorePhaseFailed
+}
+
+// SetPhase sets the phase of the restore with a message describing it,
+// recording when the restore starts and stops
+func (objectRestoreStatus *ObjectRestoreStatus) SetPhase(phase ObjectRestorePhase, message string) {
+	objectRestoreStatus.Phase = phase
+	objectRestoreStatus.Message = message
+	if objectRestoreStatus.StartedAt == nil {
+		objectRestoreStatus.StartedAt = ptr.To(metav1.Now())
+	}
+	if objectRestoreStatus.IsDone() {
+		objectRestoreStatus.StoppedAt = ptr.To(metav1.Now())
+	}
+}
@@ -0,0 +1,67 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ObjectRestore", func() {
+	objectRestore := ObjectRestore{
+		ObjectMeta: metav1.ObjectMeta{Name: "restore"},
+		Spec: ObjectRestoreSpec{
+			Tables: []string{"sales.orders", "customers"},
+		},
+	}
+
+	It("gets the names of the objects it creates", func() {
+		Expect(objectRestore.GetRecoveryClusterName()).To(Equal("restore-recovery"))
+		Expect(objectRestore.GetJobName()).To(Equal("restore-restore"))
+	})
+
+	It("gets the tables to restore, in the public schema by default", func() {
+		Expect(objectRestore.GetTables()).To(Equal([][2]string{
+			{"sales", "orders"},
+			{"public", "customers"},
+		}))
+	})
+
+	It("gets the suffix of the restored schemas", func() {
+		Expect(objectRestore.GetSchemaSuffix()).To(Equal(DefaultObjectRestoreSchemaSuffix))
+		withSuffix := objectRestore.DeepCopy()
+		withSuffix.Spec.SchemaSuffix = "_old"
+		Expect(withSuffix.GetSchemaSuffix()).To(Equal("_old"))
+	})
+
+	It("records when the restore starts and stops", func() {
+		status := ObjectRestoreStatus{}
+		status.SetPhase(ObjectRestorePhaseRecovering, "recovering")
+		Expect(status.StartedAt).ToNot(BeNil())
+		Expect(status.StoppedAt).To(BeNil())
+		Expect(status.IsDone()).To(BeFalse())
+
+		status.SetPhase(ObjectRestorePhaseCompleted, "")
+		Expect(status.StoppedAt).ToNot(BeNil())
+		Expect(status.IsDone()).To(BeTrue())
+	})
+})
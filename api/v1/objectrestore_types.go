@@ -0,0 +1,167 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ObjectRestorePhase is the phase of an object restore
+type ObjectRestorePhase string
+
+const (
+	// ObjectRestorePhasePending means that the restore has not started yet
+	ObjectRestorePhasePending = ObjectRestorePhase("pending")
+
+	// ObjectRestorePhaseRecovering means that the temporary recovery
+	// cluster is being created
+	ObjectRestorePhaseRecovering = ObjectRestorePhase("recovering")
+
+	// ObjectRestorePhaseRestoring means that the objects are being dumped
+	// from the recovery cluster and restored into the cluster
+	ObjectRestorePhaseRestoring = ObjectRestorePhase("restoring")
+
+	// ObjectRestorePhaseCompleted means that the objects have been restored
+	ObjectRestorePhaseCompleted = ObjectRestorePhase("completed")
+
+	// ObjectRestorePhaseFailed means that the restore failed
+	ObjectRestorePhaseFailed = ObjectRestorePhase("failed")
+)
+
+// ObjectRestoreSpec defines the tables and schemas to be restored from a
+// backup into a running PostgreSQL cluster
+// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="spec is immutable"
+// +kubebuilder:validation:XValidation:rule="has(self.tables) || has(self.schemas)",message="at least one table or schema is required"
+// +kubebuilder:validation:XValidation:rule="!has(self.tables) || has(self.targetSchema)",message="targetSchema is required to restore tables"
+type ObjectRestoreSpec struct {
+	// The cluster where the objects are restored
+	ClusterRef LocalObjectReference `json:"cluster"`
+
+	// The physical backup of the cluster from which the objects are
+	// recovered. Defaults to the latest completed backup of the cluster
+	// preceding the recovery target time
+	// +optional
+	Backup *LocalObjectReference `json:"backup,omitempty"`
+
+	// The point in time the objects are recovered to. Defaults to the end
+	// of the WAL archive
+	// +optional
+	RecoveryTarget *RecoveryTarget `json:"recoveryTarget,omitempty"`
+
+	// The database containing the objects to restore
+	// +kubebuilder:validation:MinLength=1
+	Database string `json:"database"`
+
+	// The tables to restore, in the `schema.table` format. Tables without
+	// a schema are searched in the `public` schema. They are restored in
+	// `targetSchema` with their original name
+	// +optional
+	Tables []string `json:"tables,omitempty"`
+
+	// The schema where the tables are restored. It is created when it
+	// doesn't exist, and must not contain tables with the same name
+	// +optional
+	TargetSchema string `json:"targetSchema,omitempty"`
+
+	// The schemas to restore. Each schema is restored with its whole
+	// content under a new name, made by appending `schemaSuffix`
+	// +optional
+	Schemas []string `json:"schemas,omitempty"`
+
+	// The suffix appended to the name of the restored schemas
+	// +kubebuilder:default:="_restored"
+	// +kubebuilder:validation:MinLength=1
+	// +optional
+	SchemaSuffix string `json:"schemaSuffix,omitempty"`
+
+	// The role owning the restored objects. Defaults to the role used to
+	// connect to the cluster
+	// +optional
+	Owner string `json:"owner,omitempty"`
+}
+
+// ObjectRestoreStatus defines the observed state of an ObjectRestore
+type ObjectRestoreStatus struct {
+	// The current phase of the restore
+	// +optional
+	Phase ObjectRestorePhase `json:"phase,omitempty"`
+
+	// A human-readable description of the progress or of the failure
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// The backup from which the objects are recovered
+	// +optional
+	BackupName string `json:"backupName,omitempty"`
+
+	// The name of the temporary cluster the objects are dumped from
+	// +optional
+	RecoveryClusterName string `json:"recoveryClusterName,omitempty"`
+
+	// The name of the job dumping and restoring the objects
+	// +optional
+	JobName string `json:"jobName,omitempty"`
+
+	// When the restore was started
+	// +optional
+	StartedAt *metav1.Time `json:"startedAt,omitempty"`
+
+	// When the restore was completed or failed
+	// +optional
+	StoppedAt *metav1.Time `json:"stoppedAt,omitempty"`
+}
+
+// +genclient
+// +kubebuilder:object:root=true
+// +kubebuilder:storageversion
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:printcolumn:name="Cluster",type="string",JSONPath=".spec.cluster.name"
+// +kubebuilder:printcolumn:name="Database",type="string",JSONPath=".spec.database"
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase"
+// +kubebuilder:printcolumn:name="Message",type="string",JSONPath=".status.message"
+
+// ObjectRestore is the Schema for the objectrestores API
+type ObjectRestore struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata"`
+
+	// Specification of the desired ObjectRestore.
+	// More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#spec-and-status
+	Spec ObjectRestoreSpec `json:"spec"`
+	// Most recently observed status of the ObjectRestore. This data may not be up to
+	// date. Populated by the system. Read-only.
+	// More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#spec-and-status
+	// +optional
+	Status ObjectRestoreStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ObjectRestoreList contains a list of ObjectRestore
+type ObjectRestoreList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ObjectRestore `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ObjectRestore{}, &ObjectRestoreList{})
+}
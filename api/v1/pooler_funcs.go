@@ -58,3 +58,8 @@ func (in *Pooler) IsAutomatedIntegration() bool {
 	}
 	return true
 }
+
+// IsNetworkPolicyEnabled checks if the NetworkPolicy object needs to be created
+func (in *Pooler) IsNetworkPolicyEnabled() bool {
+	return in.Spec.NetworkPolicy != nil && in.Spec.NetworkPolicy.Enabled
+}
@@ -91,6 +91,11 @@ type PoolerSpec struct {
 	// Template for the Service to be created
 	// +optional
 	ServiceTemplate *ServiceTemplateSpec `json:"serviceTemplate,omitempty"`
+
+	// The configuration of the NetworkPolicy isolating the PgBouncer
+	// pods of this pooler
+	// +optional
+	NetworkPolicy *NetworkPolicyConfiguration `json:"networkPolicy,omitempty"`
 }
 
 // PoolerMonitoringConfiguration is the type containing all the monitoring
@@ -181,6 +186,12 @@ type PgBouncerSpec struct {
 	// +optional
 	PgHBA []string `json:"pg_hba,omitempty"`
 
+	// Structured Host Based Authentication rules, validated by the
+	// operator. The rules placed after the operator rules precede the
+	// ones in `pg_hba`
+	// +optional
+	PgHBARules []HBARule `json:"pg_hba_rules,omitempty"`
+
 	// When set to `true`, PgBouncer will disconnect from the PostgreSQL
 	// server, first waiting for all queries to complete, and pause all new
 	// client connections until this value is set to `false` (default). Internally,
@@ -20,6 +20,8 @@ SPDX-License-Identifier: Apache-2.0
 package v1
 
 import (
+	"time"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/cloudnative-pg/cloudnative-pg/internal/configuration"
@@ -78,6 +80,7 @@ func (scheduledBackup *ScheduledBackup) CreateBackup(name string) *Backup {
 			Online:              scheduledBackup.Spec.Online,
 			OnlineConfiguration: scheduledBackup.Spec.OnlineConfiguration,
 			PluginConfiguration: scheduledBackup.Spec.PluginConfiguration,
+			Logical:             scheduledBackup.Spec.Logical,
 		},
 	}
 	utils.InheritAnnotations(&backup.ObjectMeta, scheduledBackup.Annotations, nil, configuration.Current)
@@ -92,3 +95,42 @@ func (scheduledBackup *ScheduledBackup) CreateBackup(name string) *Backup {
 
 	return &backup
 }
+
+// GetCount gets the number of completed backups to be kept for a tier
+func (retention *BackupTierRetention) GetCount(tier BackupTier) int {
+	switch tier {
+	case BackupTierMonthly:
+		return retention.Monthly
+	case BackupTierWeekly:
+		return retention.Weekly
+	default:
+		return retention.Daily
+	}
+}
+
+// GetTier gets the tier of a backup taken at the passed time, given the
+// times of the previous backups of the same scheduled backup. A backup is
+// promoted to the monthly or weekly tier when it's the first one of its
+// month or ISO week, and that tier is retained
+func (retention *BackupTierRetention) GetTier(backupTime time.Time, previousBackupTimes []time.Time) BackupTier {
+	backupYear, backupWeek := backupTime.ISOWeek()
+
+	isFirstOfMonth, isFirstOfWeek := true, true
+	for _, previousTime := range previousBackupTimes {
+		if previousTime.Year() == backupTime.Year() && previousTime.Month() == backupTime.Month() {
+			isFirstOfMonth = false
+		}
+		if year, week := previousTime.ISOWeek(); year == backupYear && week == backupWeek {
+			isFirstOfWeek = false
+		}
+	}
+
+	switch {
+	case retention.Monthly > 0 && isFirstOfMonth:
+		return BackupTierMonthly
+	case retention.Weekly > 0 && isFirstOfWeek:
+		return BackupTierWeekly
+	default:
+		return BackupTierDaily
+	}
+}
@@ -20,6 +20,8 @@ SPDX-License-Identifier: Apache-2.0
 package v1
 
 import (
+	"time"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/cloudnative-pg/cloudnative-pg/internal/configuration"
@@ -85,3 +87,45 @@ var _ = Describe("Scheduled backup", func() {
 		Expect(backup.Spec.Target).To(BeEquivalentTo(BackupTargetPrimary))
 	})
 })
+
+var _ = Describe("Backup tier retention", func() {
+	// Wednesday, 2025-01-15
+	backupTime := time.Date(2025, time.January, 15, 0, 0, 0, 0, time.UTC)
+
+	It("gets the number of backups to keep for each tier", func() {
+		retention := BackupTierRetention{Daily: 7, Weekly: 4, Monthly: 12}
+		Expect(retention.GetCount(BackupTierDaily)).To(Equal(7))
+		Expect(retention.GetCount(BackupTierWeekly)).To(Equal(4))
+		Expect(retention.GetCount(BackupTierMonthly)).To(Equal(12))
+	})
+
+	It("promotes the first backup of the month to the monthly tier", func() {
+		retention := BackupTierRetention{Daily: 7, Weekly: 4, Monthly: 12}
+		previous := []time.Time{backupTime.AddDate(0, 0, -20)}
+		Expect(retention.GetTier(backupTime, previous)).To(Equal(BackupTierMonthly))
+	})
+
+	It("promotes the first backup of the week to the weekly tier", func() {
+		retention := BackupTierRetention{Daily: 7, Weekly: 4, Monthly: 12}
+		// Friday, 2025-01-10
+		previous := []time.Time{backupTime.AddDate(0, 0, -5)}
+		Expect(retention.GetTier(backupTime, previous)).To(Equal(BackupTierWeekly))
+	})
+
+	It("assigns the other backups to the daily tier", func() {
+		retention := BackupTierRetention{Daily: 7, Weekly: 4, Monthly: 12}
+		// Monday, 2025-01-13
+		previous := []time.Time{backupTime.AddDate(0, 0, -2)}
+		Expect(retention.GetTier(backupTime, previous)).To(Equal(BackupTierDaily))
+	})
+
+	It("doesn't promote backups to tiers that are not retained", func() {
+		retention := BackupTierRetention{Daily: 7}
+		Expect(retention.GetTier(backupTime, nil)).To(Equal(BackupTierDaily))
+
+		retention = BackupTierRetention{Daily: 7, Weekly: 4}
+		previous := []time.Time{backupTime.AddDate(0, 0, -2)}
+		Expect(retention.GetTier(backupTime, nil)).To(Equal(BackupTierWeekly))
+		Expect(retention.GetTier(backupTime, previous)).To(Equal(BackupTierDaily))
+	})
+})
@@ -61,9 +61,10 @@ type ScheduledBackupSpec struct {
 	Target BackupTarget `json:"target,omitempty"`
 
 	// The backup method to be used, possible options are `barmanObjectStore`,
-	// `volumeSnapshot` or `plugin`. Defaults to: `barmanObjectStore`.
+	// `volumeSnapshot`, `plugin`, `logical` or `persistentVolume`.
+	// Defaults to: `barmanObjectStore`.
 	// +optional
-	// +kubebuilder:validation:Enum=barmanObjectStore;volumeSnapshot;plugin
+	// +kubebuilder:validation:Enum=barmanObjectStore;volumeSnapshot;plugin;logical;persistentVolume
 	// +kubebuilder:default:=barmanObjectStore
 	Method BackupMethod `json:"method,omitempty"`
 
@@ -71,6 +72,18 @@ type ScheduledBackupSpec struct {
 	// +optional
 	PluginConfiguration *BackupPluginConfiguration `json:"pluginConfiguration,omitempty"`
 
+	// Configuration parameters of the logical backup, used only
+	// when the backup method is `logical`
+	// +optional
+	Logical *LogicalBackupOptions `json:"logical,omitempty"`
+
+	// When enabled, each backup is taken incrementally against the latest
+	// completed `persistentVolume` backup of the cluster, falling back to a
+	// full backup when there is none. Supported only by the
+	// `persistentVolume` method, with PostgreSQL 17 or later
+	// +optional
+	Incremental bool `json:"incremental,omitempty"`
+
 	// Whether the default type of backup with volume snapshots is
 	// online/hot (`true`, default) or offline/cold (`false`)
 	// Overrides the default setting specified in the cluster field '.spec.backup.volumeSnapshot.online'
@@ -81,6 +94,50 @@ type ScheduledBackupSpec struct {
 	// Overrides the default settings specified in the cluster '.backup.volumeSnapshot.onlineConfiguration' stanza
 	// +optional
 	OnlineConfiguration *OnlineConfiguration `json:"onlineConfiguration,omitempty"`
+
+	// The grandfather-father-son retention policy of the backups created
+	// by this scheduled backup. Each backup is assigned to a tier, and
+	// the completed backups exceeding the count of their tier are deleted
+	// +optional
+	Retention *BackupTierRetention `json:"retention,omitempty"`
+}
+
+// BackupTier is the retention tier a scheduled backup is assigned to
+type BackupTier string
+
+const (
+	// BackupTierDaily is the tier of the backups that are not the first
+	// ones of their week or month
+	BackupTierDaily BackupTier = "daily"
+
+	// BackupTierWeekly is the tier of the first backup of each week
+	BackupTierWeekly BackupTier = "weekly"
+
+	// BackupTierMonthly is the tier of the first backup of each month
+	BackupTierMonthly BackupTier = "monthly"
+)
+
+// BackupTierRetention is the number of completed backups to be kept
+// for each retention tier
+type BackupTierRetention struct {
+	// The number of daily backups to keep. Backups that are not
+	// promoted to a higher tier are deleted as soon as they are replaced
+	// by a newer one when this is zero
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	Daily int `json:"daily,omitempty"`
+
+	// The number of weekly backups to keep. When zero, the first backup
+	// of each week is not promoted to the weekly tier
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	Weekly int `json:"weekly,omitempty"`
+
+	// The number of monthly backups to keep. When zero, the first backup
+	// of each month is not promoted to the monthly tier
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	Monthly int `json:"monthly,omitempty"`
 }
 
 // ScheduledBackupStatus defines the observed state of ScheduledBackup
@@ -27,6 +27,7 @@ import (
 	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
@@ -80,6 +81,60 @@ func (in *AffinityConfiguration) DeepCopy() *AffinityConfiguration {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuditConfiguration) DeepCopyInto(out *AuditConfiguration) {
+	*out = *in
+	if in.Classes != nil {
+		in, out := &in.Classes, &out.Classes
+		*out = make([]PgAuditClass, len(*in))
+		copy(*out, *in)
+	}
+	if in.Roles != nil {
+		in, out := &in.Roles, &out.Roles
+		*out = make([]AuditScopeConfiguration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Databases != nil {
+		in, out := &in.Databases, &out.Databases
+		*out = make([]AuditScopeConfiguration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuditConfiguration.
+func (in *AuditConfiguration) DeepCopy() *AuditConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(AuditConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuditScopeConfiguration) DeepCopyInto(out *AuditScopeConfiguration) {
+	*out = *in
+	if in.Classes != nil {
+		in, out := &in.Classes, &out.Classes
+		*out = make([]PgAuditClass, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuditScopeConfiguration.
+func (in *AuditScopeConfiguration) DeepCopy() *AuditScopeConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(AuditScopeConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AvailableArchitecture) DeepCopyInto(out *AvailableArchitecture) {
 	*out = *in
@@ -135,6 +190,21 @@ func (in *BackupConfiguration) DeepCopyInto(out *BackupConfiguration) {
 		*out = new(BarmanObjectStoreConfiguration)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Logical != nil {
+		in, out := &in.Logical, &out.Logical
+		*out = new(LogicalBackupConfiguration)
+		**out = **in
+	}
+	if in.PersistentVolume != nil {
+		in, out := &in.PersistentVolume, &out.PersistentVolume
+		*out = new(PersistentVolumeBackupConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.WALContinuityCheckInterval != nil {
+		in, out := &in.WALContinuityCheckInterval, &out.WALContinuityCheckInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupConfiguration.
@@ -147,6 +217,240 @@ func (in *BackupConfiguration) DeepCopy() *BackupConfiguration {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupExport) DeepCopyInto(out *BackupExport) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupExport.
+func (in *BackupExport) DeepCopy() *BackupExport {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupExport)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BackupExport) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupExportList) DeepCopyInto(out *BackupExportList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]BackupExport, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupExportList.
+func (in *BackupExportList) DeepCopy() *BackupExportList {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupExportList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BackupExportList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupExportSpec) DeepCopyInto(out *BackupExportSpec) {
+	*out = *in
+	in.Backup.DeepCopyInto(&out.Backup)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupExportSpec.
+func (in *BackupExportSpec) DeepCopy() *BackupExportSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupExportSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupExportStatus) DeepCopyInto(out *BackupExportStatus) {
+	*out = *in
+	if in.SnapshotContents != nil {
+		in, out := &in.SnapshotContents, &out.SnapshotContents
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.StartedAt != nil {
+		in, out := &in.StartedAt, &out.StartedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.StoppedAt != nil {
+		in, out := &in.StoppedAt, &out.StoppedAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupExportStatus.
+func (in *BackupExportStatus) DeepCopy() *BackupExportStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupExportStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupGroup) DeepCopyInto(out *BackupGroup) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupGroup.
+func (in *BackupGroup) DeepCopy() *BackupGroup {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupGroup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BackupGroup) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupGroupList) DeepCopyInto(out *BackupGroupList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]BackupGroup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupGroupList.
+func (in *BackupGroupList) DeepCopy() *BackupGroupList {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupGroupList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BackupGroupList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupGroupMemberStatus) DeepCopyInto(out *BackupGroupMemberStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupGroupMemberStatus.
+func (in *BackupGroupMemberStatus) DeepCopy() *BackupGroupMemberStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupGroupMemberStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupGroupSpec) DeepCopyInto(out *BackupGroupSpec) {
+	*out = *in
+	if in.Clusters != nil {
+		in, out := &in.Clusters, &out.Clusters
+		*out = make([]LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.PluginConfiguration != nil {
+		in, out := &in.PluginConfiguration, &out.PluginConfiguration
+		*out = new(BackupPluginConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupGroupSpec.
+func (in *BackupGroupSpec) DeepCopy() *BackupGroupSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupGroupSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupGroupStatus) DeepCopyInto(out *BackupGroupStatus) {
+	*out = *in
+	if in.Backups != nil {
+		in, out := &in.Backups, &out.Backups
+		*out = make([]BackupGroupMemberStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.RestorePointTime != nil {
+		in, out := &in.RestorePointTime, &out.RestorePointTime
+		*out = (*in).DeepCopy()
+	}
+	if in.StartedAt != nil {
+		in, out := &in.StartedAt, &out.StartedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.StoppedAt != nil {
+		in, out := &in.StoppedAt, &out.StoppedAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupGroupStatus.
+func (in *BackupGroupStatus) DeepCopy() *BackupGroupStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupGroupStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BackupList) DeepCopyInto(out *BackupList) {
 	*out = *in
@@ -266,6 +570,16 @@ func (in *BackupSpec) DeepCopyInto(out *BackupSpec) {
 		*out = new(BackupPluginConfiguration)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Logical != nil {
+		in, out := &in.Logical, &out.Logical
+		*out = new(LogicalBackupOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ParentBackup != nil {
+		in, out := &in.ParentBackup, &out.ParentBackup
+		*out = new(LocalObjectReference)
+		**out = **in
+	}
 	if in.Online != nil {
 		in, out := &in.Online, &out.Online
 		*out = new(bool)
@@ -321,6 +635,16 @@ func (in *BackupStatus) DeepCopyInto(out *BackupStatus) {
 		**out = **in
 	}
 	in.BackupSnapshotStatus.DeepCopyInto(&out.BackupSnapshotStatus)
+	if in.LogicalBackupStatus != nil {
+		in, out := &in.LogicalBackupStatus, &out.LogicalBackupStatus
+		*out = new(LogicalBackupStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PersistentVolumeBackupStatus != nil {
+		in, out := &in.PersistentVolumeBackupStatus, &out.PersistentVolumeBackupStatus
+		*out = new(PersistentVolumeBackupStatus)
+		**out = **in
+	}
 	if in.Online != nil {
 		in, out := &in.Online, &out.Online
 		*out = new(bool)
@@ -345,6 +669,21 @@ func (in *BackupStatus) DeepCopy() *BackupStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupTierRetention) DeepCopyInto(out *BackupTierRetention) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupTierRetention.
+func (in *BackupTierRetention) DeepCopy() *BackupTierRetention {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupTierRetention)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BootstrapConfiguration) DeepCopyInto(out *BootstrapConfiguration) {
 	*out = *in
@@ -496,28 +835,162 @@ func (in *BootstrapRecovery) DeepCopy() *BootstrapRecovery {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CatalogImage) DeepCopyInto(out *CatalogImage) {
+func (in *CARotationStatus) DeepCopyInto(out *CARotationStatus) {
 	*out = *in
+	if in.Since != nil {
+		in, out := &in.Since, &out.Since
+		*out = (*in).DeepCopy()
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CatalogImage.
-func (in *CatalogImage) DeepCopy() *CatalogImage {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CARotationStatus.
+func (in *CARotationStatus) DeepCopy() *CARotationStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(CatalogImage)
+	out := new(CARotationStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CertificatesConfiguration) DeepCopyInto(out *CertificatesConfiguration) {
+func (in *CanaryProbe) DeepCopyInto(out *CanaryProbe) {
 	*out = *in
-	if in.ServerAltDNSNames != nil {
-		in, out := &in.ServerAltDNSNames, &out.ServerAltDNSNames
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CanaryProbe.
+func (in *CanaryProbe) DeepCopy() *CanaryProbe {
+	if in == nil {
+		return nil
+	}
+	out := new(CanaryProbe)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CanaryRolloutConfiguration) DeepCopyInto(out *CanaryRolloutConfiguration) {
+	*out = *in
+	if in.SoakTime != nil {
+		in, out := &in.SoakTime, &out.SoakTime
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.MaximumLag != nil {
+		in, out := &in.MaximumLag, &out.MaximumLag
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.MaximumLogErrors != nil {
+		in, out := &in.MaximumLogErrors, &out.MaximumLogErrors
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Probes != nil {
+		in, out := &in.Probes, &out.Probes
+		*out = make([]CanaryProbe, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CanaryRolloutConfiguration.
+func (in *CanaryRolloutConfiguration) DeepCopy() *CanaryRolloutConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(CanaryRolloutConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CanaryRolloutStatus) DeepCopyInto(out *CanaryRolloutStatus) {
+	*out = *in
+	if in.SoakStartedAt != nil {
+		in, out := &in.SoakStartedAt, &out.SoakStartedAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CanaryRolloutStatus.
+func (in *CanaryRolloutStatus) DeepCopy() *CanaryRolloutStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CanaryRolloutStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CatalogImage) DeepCopyInto(out *CatalogImage) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CatalogImage.
+func (in *CatalogImage) DeepCopy() *CatalogImage {
+	if in == nil {
+		return nil
+	}
+	out := new(CatalogImage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertManagerConfiguration) DeepCopyInto(out *CertManagerConfiguration) {
+	*out = *in
+	out.IssuerRef = in.IssuerRef
+	if in.Duration != nil {
+		in, out := &in.Duration, &out.Duration
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.RenewBefore != nil {
+		in, out := &in.RenewBefore, &out.RenewBefore
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertManagerConfiguration.
+func (in *CertManagerConfiguration) DeepCopy() *CertManagerConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(CertManagerConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertManagerIssuerReference) DeepCopyInto(out *CertManagerIssuerReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertManagerIssuerReference.
+func (in *CertManagerIssuerReference) DeepCopy() *CertManagerIssuerReference {
+	if in == nil {
+		return nil
+	}
+	out := new(CertManagerIssuerReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificatesConfiguration) DeepCopyInto(out *CertificatesConfiguration) {
+	*out = *in
+	if in.ServerAltDNSNames != nil {
+		in, out := &in.ServerAltDNSNames, &out.ServerAltDNSNames
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.CertManager != nil {
+		in, out := &in.CertManager, &out.CertManager
+		*out = new(CertManagerConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificatesConfiguration.
@@ -541,6 +1014,13 @@ func (in *CertificatesStatus) DeepCopyInto(out *CertificatesStatus) {
 			(*out)[key] = val
 		}
 	}
+	if in.CARotations != nil {
+		in, out := &in.CARotations, &out.CARotations
+		*out = make(map[string]CARotationStatus, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificatesStatus.
@@ -553,6 +1033,104 @@ func (in *CertificatesStatus) DeepCopy() *CertificatesStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClientCertificate) DeepCopyInto(out *ClientCertificate) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClientCertificate.
+func (in *ClientCertificate) DeepCopy() *ClientCertificate {
+	if in == nil {
+		return nil
+	}
+	out := new(ClientCertificate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClientCertificate) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClientCertificateList) DeepCopyInto(out *ClientCertificateList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClientCertificate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClientCertificateList.
+func (in *ClientCertificateList) DeepCopy() *ClientCertificateList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClientCertificateList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClientCertificateList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClientCertificateSpec) DeepCopyInto(out *ClientCertificateSpec) {
+	*out = *in
+	out.ClusterRef = in.ClusterRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClientCertificateSpec.
+func (in *ClientCertificateSpec) DeepCopy() *ClientCertificateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClientCertificateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClientCertificateStatus) DeepCopyInto(out *ClientCertificateStatus) {
+	*out = *in
+	if in.Expiration != nil {
+		in, out := &in.Expiration, &out.Expiration
+		*out = (*in).DeepCopy()
+	}
+	if in.RenewalTime != nil {
+		in, out := &in.RenewalTime, &out.RenewalTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClientCertificateStatus.
+func (in *ClientCertificateStatus) DeepCopy() *ClientCertificateStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClientCertificateStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Cluster) DeepCopyInto(out *Cluster) {
 	*out = *in
@@ -755,6 +1333,16 @@ func (in *ClusterSpec) DeepCopyInto(out *ClusterSpec) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.FailoverQuorum != nil {
+		in, out := &in.FailoverQuorum, &out.FailoverQuorum
+		*out = new(FailoverQuorumConfiguration)
+		**out = **in
+	}
+	if in.PrimaryPlacement != nil {
+		in, out := &in.PrimaryPlacement, &out.PrimaryPlacement
+		*out = new(PrimaryPlacementConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.LivenessProbeTimeout != nil {
 		in, out := &in.LivenessProbeTimeout, &out.LivenessProbeTimeout
 		*out = new(int32)
@@ -774,6 +1362,11 @@ func (in *ClusterSpec) DeepCopyInto(out *ClusterSpec) {
 		*out = new(EphemeralVolumesSizeLimitConfiguration)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.CanaryRollout != nil {
+		in, out := &in.CanaryRollout, &out.CanaryRollout
+		*out = new(CanaryRolloutConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Backup != nil {
 		in, out := &in.Backup, &out.Backup
 		*out = new(BackupConfiguration)
@@ -784,6 +1377,11 @@ func (in *ClusterSpec) DeepCopyInto(out *ClusterSpec) {
 		*out = new(NodeMaintenanceWindow)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.MaintenanceWindows != nil {
+		in, out := &in.MaintenanceWindows, &out.MaintenanceWindows
+		*out = make([]MaintenanceWindow, len(*in))
+		copy(*out, *in)
+	}
 	if in.Monitoring != nil {
 		in, out := &in.Monitoring, &out.Monitoring
 		*out = new(MonitoringConfiguration)
@@ -796,6 +1394,11 @@ func (in *ClusterSpec) DeepCopyInto(out *ClusterSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.NetworkPolicy != nil {
+		in, out := &in.NetworkPolicy, &out.NetworkPolicy
+		*out = new(NetworkPolicyConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.ProjectedVolumeTemplate != nil {
 		in, out := &in.ProjectedVolumeTemplate, &out.ProjectedVolumeTemplate
 		*out = new(corev1.ProjectedVolumeSource)
@@ -844,6 +1447,11 @@ func (in *ClusterSpec) DeepCopyInto(out *ClusterSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.WalPrefetch != nil {
+		in, out := &in.WalPrefetch, &out.WalPrefetch
+		*out = new(WalPrefetchConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Probes != nil {
 		in, out := &in.Probes, &out.Probes
 		*out = new(ProbesConfiguration)
@@ -971,6 +1579,28 @@ func (in *ClusterStatus) DeepCopyInto(out *ClusterStatus) {
 		}
 	}
 	out.SwitchReplicaClusterStatus = in.SwitchReplicaClusterStatus
+	if in.CanaryRollout != nil {
+		in, out := &in.CanaryRollout, &out.CanaryRollout
+		*out = new(CanaryRolloutStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PendingRollout != nil {
+		in, out := &in.PendingRollout, &out.PendingRollout
+		*out = new(PendingRollout)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PrimaryHistory != nil {
+		in, out := &in.PrimaryHistory, &out.PrimaryHistory
+		*out = make([]PrimaryChange, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.SuperuserGrant != nil {
+		in, out := &in.SuperuserGrant, &out.SuperuserGrant
+		*out = new(SuperuserGrant)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterStatus.
@@ -1250,6 +1880,11 @@ func (in *EphemeralVolumesSizeLimitConfiguration) DeepCopyInto(out *EphemeralVol
 		x := (*in).DeepCopy()
 		*out = &x
 	}
+	if in.LogicalBackupStaging != nil {
+		in, out := &in.LogicalBackupStaging, &out.LogicalBackupStaging
+		x := (*in).DeepCopy()
+		*out = &x
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EphemeralVolumesSizeLimitConfiguration.
@@ -1330,6 +1965,53 @@ func (in *ExternalCluster) DeepCopy() *ExternalCluster {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FailoverQuorumConfiguration) DeepCopyInto(out *FailoverQuorumConfiguration) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FailoverQuorumConfiguration.
+func (in *FailoverQuorumConfiguration) DeepCopy() *FailoverQuorumConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(FailoverQuorumConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HBARule) DeepCopyInto(out *HBARule) {
+	*out = *in
+	if in.Databases != nil {
+		in, out := &in.Databases, &out.Databases
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Users != nil {
+		in, out := &in.Users, &out.Users
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Options != nil {
+		in, out := &in.Options, &out.Options
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HBARule.
+func (in *HBARule) DeepCopy() *HBARule {
+	if in == nil {
+		return nil
+	}
+	out := new(HBARule)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ImageCatalog) DeepCopyInto(out *ImageCatalog) {
 	*out = *in
@@ -1427,7 +2109,7 @@ func (in *ImageCatalogSpec) DeepCopy() *ImageCatalogSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Import) DeepCopyInto(out *Import) {
 	*out = *in
-	out.Source = in.Source
+	in.Source.DeepCopyInto(&out.Source)
 	if in.Databases != nil {
 		in, out := &in.Databases, &out.Databases
 		*out = make([]string, len(*in))
@@ -1468,6 +2150,11 @@ func (in *Import) DeepCopy() *Import {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ImportSource) DeepCopyInto(out *ImportSource) {
 	*out = *in
+	if in.Backup != nil {
+		in, out := &in.Backup, &out.Backup
+		*out = new(LocalObjectReference)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImportSource.
@@ -1571,20 +2258,112 @@ func (in *LDAPConfig) DeepCopy() *LDAPConfig {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ManagedConfiguration) DeepCopyInto(out *ManagedConfiguration) {
+func (in *LogicalBackupConfiguration) DeepCopyInto(out *LogicalBackupConfiguration) {
 	*out = *in
-	if in.Roles != nil {
-		in, out := &in.Roles, &out.Roles
-		*out = make([]RoleConfiguration, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.Services != nil {
-		in, out := &in.Services, &out.Services
-		*out = new(ManagedServices)
-		(*in).DeepCopyInto(*out)
-	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogicalBackupConfiguration.
+func (in *LogicalBackupConfiguration) DeepCopy() *LogicalBackupConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(LogicalBackupConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LogicalBackupDatabaseStatus) DeepCopyInto(out *LogicalBackupDatabaseStatus) {
+	*out = *in
+	out.Duration = in.Duration
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogicalBackupDatabaseStatus.
+func (in *LogicalBackupDatabaseStatus) DeepCopy() *LogicalBackupDatabaseStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(LogicalBackupDatabaseStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LogicalBackupOptions) DeepCopyInto(out *LogicalBackupOptions) {
+	*out = *in
+	if in.Databases != nil {
+		in, out := &in.Databases, &out.Databases
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PgDumpExtraOptions != nil {
+		in, out := &in.PgDumpExtraOptions, &out.PgDumpExtraOptions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogicalBackupOptions.
+func (in *LogicalBackupOptions) DeepCopy() *LogicalBackupOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(LogicalBackupOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LogicalBackupStatus) DeepCopyInto(out *LogicalBackupStatus) {
+	*out = *in
+	if in.Databases != nil {
+		in, out := &in.Databases, &out.Databases
+		*out = make([]LogicalBackupDatabaseStatus, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogicalBackupStatus.
+func (in *LogicalBackupStatus) DeepCopy() *LogicalBackupStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(LogicalBackupStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceWindow) DeepCopyInto(out *MaintenanceWindow) {
+	*out = *in
+	out.Duration = in.Duration
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceWindow.
+func (in *MaintenanceWindow) DeepCopy() *MaintenanceWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManagedConfiguration) DeepCopyInto(out *ManagedConfiguration) {
+	*out = *in
+	if in.Roles != nil {
+		in, out := &in.Roles, &out.Roles
+		*out = make([]RoleConfiguration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Services != nil {
+		in, out := &in.Services, &out.Services
+		*out = new(ManagedServices)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagedConfiguration.
@@ -1632,202 +2411,420 @@ func (in *ManagedRoles) DeepCopyInto(out *ManagedRoles) {
 			(*out)[key] = outVal
 		}
 	}
-	if in.PasswordStatus != nil {
-		in, out := &in.PasswordStatus, &out.PasswordStatus
-		*out = make(map[string]PasswordState, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
+	if in.PasswordStatus != nil {
+		in, out := &in.PasswordStatus, &out.PasswordStatus
+		*out = make(map[string]PasswordState, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagedRoles.
+func (in *ManagedRoles) DeepCopy() *ManagedRoles {
+	if in == nil {
+		return nil
+	}
+	out := new(ManagedRoles)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManagedService) DeepCopyInto(out *ManagedService) {
+	*out = *in
+	in.ServiceTemplate.DeepCopyInto(&out.ServiceTemplate)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagedService.
+func (in *ManagedService) DeepCopy() *ManagedService {
+	if in == nil {
+		return nil
+	}
+	out := new(ManagedService)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManagedServices) DeepCopyInto(out *ManagedServices) {
+	*out = *in
+	if in.DisabledDefaultServices != nil {
+		in, out := &in.DisabledDefaultServices, &out.DisabledDefaultServices
+		*out = make([]ServiceSelectorType, len(*in))
+		copy(*out, *in)
+	}
+	if in.Additional != nil {
+		in, out := &in.Additional, &out.Additional
+		*out = make([]ManagedService, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagedServices.
+func (in *ManagedServices) DeepCopy() *ManagedServices {
+	if in == nil {
+		return nil
+	}
+	out := new(ManagedServices)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Metadata) DeepCopyInto(out *Metadata) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Metadata.
+func (in *Metadata) DeepCopy() *Metadata {
+	if in == nil {
+		return nil
+	}
+	out := new(Metadata)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MonitoringConfiguration) DeepCopyInto(out *MonitoringConfiguration) {
+	*out = *in
+	if in.DisableDefaultQueries != nil {
+		in, out := &in.DisableDefaultQueries, &out.DisableDefaultQueries
+		*out = new(bool)
+		**out = **in
+	}
+	if in.CustomQueriesConfigMap != nil {
+		in, out := &in.CustomQueriesConfigMap, &out.CustomQueriesConfigMap
+		*out = make([]ConfigMapKeySelector, len(*in))
+		copy(*out, *in)
+	}
+	if in.CustomQueriesSecret != nil {
+		in, out := &in.CustomQueriesSecret, &out.CustomQueriesSecret
+		*out = make([]SecretKeySelector, len(*in))
+		copy(*out, *in)
+	}
+	if in.TLSConfig != nil {
+		in, out := &in.TLSConfig, &out.TLSConfig
+		*out = new(ClusterMonitoringTLSConfiguration)
+		**out = **in
+	}
+	if in.PodMonitorMetricRelabelConfigs != nil {
+		in, out := &in.PodMonitorMetricRelabelConfigs, &out.PodMonitorMetricRelabelConfigs
+		*out = make([]monitoringv1.RelabelConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PodMonitorRelabelConfigs != nil {
+		in, out := &in.PodMonitorRelabelConfigs, &out.PodMonitorRelabelConfigs
+		*out = make([]monitoringv1.RelabelConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonitoringConfiguration.
+func (in *MonitoringConfiguration) DeepCopy() *MonitoringConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(MonitoringConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkPolicyConfiguration) DeepCopyInto(out *NetworkPolicyConfiguration) {
+	*out = *in
+	if in.Clients != nil {
+		in, out := &in.Clients, &out.Clients
+		*out = make([]networkingv1.NetworkPolicyPeer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.MonitoringClients != nil {
+		in, out := &in.MonitoringClients, &out.MonitoringClients
+		*out = make([]networkingv1.NetworkPolicyPeer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkPolicyConfiguration.
+func (in *NetworkPolicyConfiguration) DeepCopy() *NetworkPolicyConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkPolicyConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeMaintenanceWindow) DeepCopyInto(out *NodeMaintenanceWindow) {
+	*out = *in
+	if in.ReusePVC != nil {
+		in, out := &in.ReusePVC, &out.ReusePVC
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeMaintenanceWindow.
+func (in *NodeMaintenanceWindow) DeepCopy() *NodeMaintenanceWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeMaintenanceWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectRestore) DeepCopyInto(out *ObjectRestore) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectRestore.
+func (in *ObjectRestore) DeepCopy() *ObjectRestore {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectRestore)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ObjectRestore) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectRestoreList) DeepCopyInto(out *ObjectRestoreList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ObjectRestore, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectRestoreList.
+func (in *ObjectRestoreList) DeepCopy() *ObjectRestoreList {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectRestoreList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ObjectRestoreList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectRestoreSpec) DeepCopyInto(out *ObjectRestoreSpec) {
+	*out = *in
+	in.ClusterRef.DeepCopyInto(&out.ClusterRef)
+	if in.Backup != nil {
+		in, out := &in.Backup, &out.Backup
+		*out = new(LocalObjectReference)
+		**out = **in
+	}
+	if in.RecoveryTarget != nil {
+		in, out := &in.RecoveryTarget, &out.RecoveryTarget
+		*out = new(RecoveryTarget)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Tables != nil {
+		in, out := &in.Tables, &out.Tables
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Schemas != nil {
+		in, out := &in.Schemas, &out.Schemas
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagedRoles.
-func (in *ManagedRoles) DeepCopy() *ManagedRoles {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectRestoreSpec.
+func (in *ObjectRestoreSpec) DeepCopy() *ObjectRestoreSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ManagedRoles)
+	out := new(ObjectRestoreSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ManagedService) DeepCopyInto(out *ManagedService) {
+func (in *ObjectRestoreStatus) DeepCopyInto(out *ObjectRestoreStatus) {
 	*out = *in
-	in.ServiceTemplate.DeepCopyInto(&out.ServiceTemplate)
+	if in.StartedAt != nil {
+		in, out := &in.StartedAt, &out.StartedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.StoppedAt != nil {
+		in, out := &in.StoppedAt, &out.StoppedAt
+		*out = (*in).DeepCopy()
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagedService.
-func (in *ManagedService) DeepCopy() *ManagedService {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectRestoreStatus.
+func (in *ObjectRestoreStatus) DeepCopy() *ObjectRestoreStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ManagedService)
+	out := new(ObjectRestoreStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ManagedServices) DeepCopyInto(out *ManagedServices) {
+func (in *OnlineConfiguration) DeepCopyInto(out *OnlineConfiguration) {
 	*out = *in
-	if in.DisabledDefaultServices != nil {
-		in, out := &in.DisabledDefaultServices, &out.DisabledDefaultServices
-		*out = make([]ServiceSelectorType, len(*in))
-		copy(*out, *in)
+	if in.WaitForArchive != nil {
+		in, out := &in.WaitForArchive, &out.WaitForArchive
+		*out = new(bool)
+		**out = **in
 	}
-	if in.Additional != nil {
-		in, out := &in.Additional, &out.Additional
-		*out = make([]ManagedService, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.ImmediateCheckpoint != nil {
+		in, out := &in.ImmediateCheckpoint, &out.ImmediateCheckpoint
+		*out = new(bool)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagedServices.
-func (in *ManagedServices) DeepCopy() *ManagedServices {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OnlineConfiguration.
+func (in *OnlineConfiguration) DeepCopy() *OnlineConfiguration {
 	if in == nil {
 		return nil
 	}
-	out := new(ManagedServices)
+	out := new(OnlineConfiguration)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Metadata) DeepCopyInto(out *Metadata) {
+func (in *PasswordRotationConfiguration) DeepCopyInto(out *PasswordRotationConfiguration) {
 	*out = *in
-	if in.Labels != nil {
-		in, out := &in.Labels, &out.Labels
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
-	if in.Annotations != nil {
-		in, out := &in.Annotations, &out.Annotations
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
+	out.Interval = in.Interval
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Metadata.
-func (in *Metadata) DeepCopy() *Metadata {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PasswordRotationConfiguration.
+func (in *PasswordRotationConfiguration) DeepCopy() *PasswordRotationConfiguration {
 	if in == nil {
 		return nil
 	}
-	out := new(Metadata)
+	out := new(PasswordRotationConfiguration)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MonitoringConfiguration) DeepCopyInto(out *MonitoringConfiguration) {
+func (in *PasswordState) DeepCopyInto(out *PasswordState) {
 	*out = *in
-	if in.DisableDefaultQueries != nil {
-		in, out := &in.DisableDefaultQueries, &out.DisableDefaultQueries
-		*out = new(bool)
-		**out = **in
-	}
-	if in.CustomQueriesConfigMap != nil {
-		in, out := &in.CustomQueriesConfigMap, &out.CustomQueriesConfigMap
-		*out = make([]ConfigMapKeySelector, len(*in))
-		copy(*out, *in)
-	}
-	if in.CustomQueriesSecret != nil {
-		in, out := &in.CustomQueriesSecret, &out.CustomQueriesSecret
-		*out = make([]SecretKeySelector, len(*in))
-		copy(*out, *in)
-	}
-	if in.TLSConfig != nil {
-		in, out := &in.TLSConfig, &out.TLSConfig
-		*out = new(ClusterMonitoringTLSConfiguration)
-		**out = **in
-	}
-	if in.PodMonitorMetricRelabelConfigs != nil {
-		in, out := &in.PodMonitorMetricRelabelConfigs, &out.PodMonitorMetricRelabelConfigs
-		*out = make([]monitoringv1.RelabelConfig, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.PodMonitorRelabelConfigs != nil {
-		in, out := &in.PodMonitorRelabelConfigs, &out.PodMonitorRelabelConfigs
-		*out = make([]monitoringv1.RelabelConfig, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.LastRotation != nil {
+		in, out := &in.LastRotation, &out.LastRotation
+		*out = (*in).DeepCopy()
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonitoringConfiguration.
-func (in *MonitoringConfiguration) DeepCopy() *MonitoringConfiguration {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PasswordState.
+func (in *PasswordState) DeepCopy() *PasswordState {
 	if in == nil {
 		return nil
 	}
-	out := new(MonitoringConfiguration)
+	out := new(PasswordState)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NodeMaintenanceWindow) DeepCopyInto(out *NodeMaintenanceWindow) {
+func (in *PendingRollout) DeepCopyInto(out *PendingRollout) {
 	*out = *in
-	if in.ReusePVC != nil {
-		in, out := &in.ReusePVC, &out.ReusePVC
-		*out = new(bool)
-		**out = **in
-	}
+	in.Since.DeepCopyInto(&out.Since)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeMaintenanceWindow.
-func (in *NodeMaintenanceWindow) DeepCopy() *NodeMaintenanceWindow {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PendingRollout.
+func (in *PendingRollout) DeepCopy() *PendingRollout {
 	if in == nil {
 		return nil
 	}
-	out := new(NodeMaintenanceWindow)
+	out := new(PendingRollout)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OnlineConfiguration) DeepCopyInto(out *OnlineConfiguration) {
+func (in *PersistentVolumeBackupConfiguration) DeepCopyInto(out *PersistentVolumeBackupConfiguration) {
 	*out = *in
-	if in.WaitForArchive != nil {
-		in, out := &in.WaitForArchive, &out.WaitForArchive
-		*out = new(bool)
-		**out = **in
-	}
-	if in.ImmediateCheckpoint != nil {
-		in, out := &in.ImmediateCheckpoint, &out.ImmediateCheckpoint
-		*out = new(bool)
-		**out = **in
+	if in.Storage != nil {
+		in, out := &in.Storage, &out.Storage
+		*out = new(StorageConfiguration)
+		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OnlineConfiguration.
-func (in *OnlineConfiguration) DeepCopy() *OnlineConfiguration {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PersistentVolumeBackupConfiguration.
+func (in *PersistentVolumeBackupConfiguration) DeepCopy() *PersistentVolumeBackupConfiguration {
 	if in == nil {
 		return nil
 	}
-	out := new(OnlineConfiguration)
+	out := new(PersistentVolumeBackupConfiguration)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PasswordState) DeepCopyInto(out *PasswordState) {
+func (in *PersistentVolumeBackupStatus) DeepCopyInto(out *PersistentVolumeBackupStatus) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PasswordState.
-func (in *PasswordState) DeepCopy() *PasswordState {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PersistentVolumeBackupStatus.
+func (in *PersistentVolumeBackupStatus) DeepCopy() *PersistentVolumeBackupStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(PasswordState)
+	out := new(PersistentVolumeBackupStatus)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -1982,6 +2979,13 @@ func (in *PgBouncerSpec) DeepCopyInto(out *PgBouncerSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.PgHBARules != nil {
+		in, out := &in.PgHBARules, &out.PgHBARules
+		*out = make([]HBARule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.Paused != nil {
 		in, out := &in.Paused, &out.Paused
 		*out = new(bool)
@@ -2012,6 +3016,11 @@ func (in *PluginConfiguration) DeepCopyInto(out *PluginConfiguration) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.IsCredentialsProvider != nil {
+		in, out := &in.IsCredentialsProvider, &out.IsCredentialsProvider
+		*out = new(bool)
+		**out = **in
+	}
 	if in.Parameters != nil {
 		in, out := &in.Parameters, &out.Parameters
 		*out = make(map[string]string, len(*in))
@@ -2270,6 +3279,11 @@ func (in *PoolerSpec) DeepCopyInto(out *PoolerSpec) {
 		*out = new(ServiceTemplateSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.NetworkPolicy != nil {
+		in, out := &in.NetworkPolicy, &out.NetworkPolicy
+		*out = new(NetworkPolicyConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PoolerSpec.
@@ -2322,6 +3336,13 @@ func (in *PostgresConfiguration) DeepCopyInto(out *PostgresConfiguration) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.PgHBARules != nil {
+		in, out := &in.PgHBARules, &out.PgHBARules
+		*out = make([]HBARule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.PgIdent != nil {
 		in, out := &in.PgIdent, &out.PgIdent
 		*out = make([]string, len(*in))
@@ -2338,6 +3359,11 @@ func (in *PostgresConfiguration) DeepCopyInto(out *PostgresConfiguration) {
 		*out = new(LDAPConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Audit != nil {
+		in, out := &in.Audit, &out.Audit
+		*out = new(AuditConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgresConfiguration.
@@ -2350,6 +3376,101 @@ func (in *PostgresConfiguration) DeepCopy() *PostgresConfiguration {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrimaryChange) DeepCopyInto(out *PrimaryChange) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+	if in.EstimatedDataLossBytes != nil {
+		in, out := &in.EstimatedDataLossBytes, &out.EstimatedDataLossBytes
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrimaryChange.
+func (in *PrimaryChange) DeepCopy() *PrimaryChange {
+	if in == nil {
+		return nil
+	}
+	out := new(PrimaryChange)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrimaryPlacementConfiguration) DeepCopyInto(out *PrimaryPlacementConfiguration) {
+	*out = *in
+	if in.Preferences != nil {
+		in, out := &in.Preferences, &out.Preferences
+		*out = make([]PrimaryPlacementPreference, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Switchback != nil {
+		in, out := &in.Switchback, &out.Switchback
+		*out = new(PrimarySwitchbackConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrimaryPlacementConfiguration.
+func (in *PrimaryPlacementConfiguration) DeepCopy() *PrimaryPlacementConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(PrimaryPlacementConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrimaryPlacementPreference) DeepCopyInto(out *PrimaryPlacementPreference) {
+	*out = *in
+	if in.NodeLabels != nil {
+		in, out := &in.NodeLabels, &out.NodeLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrimaryPlacementPreference.
+func (in *PrimaryPlacementPreference) DeepCopy() *PrimaryPlacementPreference {
+	if in == nil {
+		return nil
+	}
+	out := new(PrimaryPlacementPreference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrimarySwitchbackConfiguration) DeepCopyInto(out *PrimarySwitchbackConfiguration) {
+	*out = *in
+	if in.MaximumLag != nil {
+		in, out := &in.MaximumLag, &out.MaximumLag
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.MaintenanceWindows != nil {
+		in, out := &in.MaintenanceWindows, &out.MaintenanceWindows
+		*out = make([]MaintenanceWindow, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrimarySwitchbackConfiguration.
+func (in *PrimarySwitchbackConfiguration) DeepCopy() *PrimarySwitchbackConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(PrimarySwitchbackConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Probe) DeepCopyInto(out *Probe) {
 	*out = *in
@@ -2703,6 +3824,11 @@ func (in *RoleConfiguration) DeepCopyInto(out *RoleConfiguration) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.PasswordRotation != nil {
+		in, out := &in.PasswordRotation, &out.PasswordRotation
+		*out = new(PasswordRotationConfiguration)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleConfiguration.
@@ -2818,6 +3944,11 @@ func (in *ScheduledBackupSpec) DeepCopyInto(out *ScheduledBackupSpec) {
 		*out = new(BackupPluginConfiguration)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Logical != nil {
+		in, out := &in.Logical, &out.Logical
+		*out = new(LogicalBackupOptions)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Online != nil {
 		in, out := &in.Online, &out.Online
 		*out = new(bool)
@@ -2828,6 +3959,11 @@ func (in *ScheduledBackupSpec) DeepCopyInto(out *ScheduledBackupSpec) {
 		*out = new(OnlineConfiguration)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Retention != nil {
+		in, out := &in.Retention, &out.Retention
+		*out = new(BackupTierRetention)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScheduledBackupSpec.
@@ -3099,6 +4235,23 @@ func (in *SubscriptionStatus) DeepCopy() *SubscriptionStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SuperuserGrant) DeepCopyInto(out *SuperuserGrant) {
+	*out = *in
+	in.GrantedAt.DeepCopyInto(&out.GrantedAt)
+	in.ExpiresAt.DeepCopyInto(&out.ExpiresAt)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SuperuserGrant.
+func (in *SuperuserGrant) DeepCopy() *SuperuserGrant {
+	if in == nil {
+		return nil
+	}
+	out := new(SuperuserGrant)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SwitchReplicaClusterStatus) DeepCopyInto(out *SwitchReplicaClusterStatus) {
 	*out = *in
@@ -3296,3 +4449,23 @@ func (in *VolumeSnapshotConfiguration) DeepCopy() *VolumeSnapshotConfiguration {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WalPrefetchConfiguration) DeepCopyInto(out *WalPrefetchConfiguration) {
+	*out = *in
+	if in.MaxSpoolSize != nil {
+		in, out := &in.MaxSpoolSize, &out.MaxSpoolSize
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WalPrefetchConfiguration.
+func (in *WalPrefetchConfiguration) DeepCopy() *WalPrefetchConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(WalPrefetchConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
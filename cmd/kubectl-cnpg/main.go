@@ -48,8 +48,11 @@ import (
 	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin/reload"
 	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin/report"
 	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin/restart"
+	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin/restoreobjects"
+	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin/rollout"
 	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin/snapshot"
 	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin/status"
+	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin/superuser"
 	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/versions"
 
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
@@ -130,9 +133,12 @@ func main() {
 		reload.NewCmd(),
 		report.NewCmd(),
 		restart.NewCmd(),
+		restoreobjects.NewCmd(),
+		rollout.NewCmd(),
 		snapshot.NewCmd(),
 		status.NewCmd(),
 		subscription.NewCmd(),
+		superuser.NewCmd(),
 		versions.NewCmd(),
 	}
 
@@ -203,6 +203,12 @@ func RunController(
 		return err
 	}
 
+	// Detect if we are running under a system that provides cert-manager
+	if err = utils.DetectCertManagerExist(discoveryClient); err != nil {
+		setupLog.Error(err, "unable to detect the if the cluster have the cert-manager CRDs installed")
+		return err
+	}
+
 	// Detect the available architectures
 	if err = utils.DetectAvailableArchitectures(); err != nil {
 		setupLog.Error(err, "unable to detect the available instance's architectures")
@@ -212,6 +218,7 @@ func RunController(
 	setupLog.Info("Kubernetes system metadata",
 		"haveSCC", utils.HaveSecurityContextConstraints(),
 		"haveVolumeSnapshot", utils.HaveVolumeSnapshot(),
+		"haveCertManager", utils.HaveCertManager(),
 		"availableArchitectures", utils.GetAvailableArchitectures(),
 	)
 
@@ -271,6 +278,39 @@ func RunController(
 		return err
 	}
 
+	if err = (&controller.ClientCertificateReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor("cloudnative-pg-clientcertificate"),
+	}).SetupWithManager(mgr, maxConcurrentReconciles); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ClientCertificate")
+		return err
+	}
+
+	if err = controller.NewBackupGroupReconciler(mgr).
+		SetupWithManager(mgr, maxConcurrentReconciles); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "BackupGroup")
+		return err
+	}
+
+	if err = (&controller.BackupExportReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor("cloudnative-pg-backupexport"),
+	}).SetupWithManager(mgr, maxConcurrentReconciles); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "BackupExport")
+		return err
+	}
+
+	if err = (&controller.ObjectRestoreReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor("cloudnative-pg-objectrestore"),
+	}).SetupWithManager(mgr, maxConcurrentReconciles); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ObjectRestore")
+		return err
+	}
+
 	if err = (&controller.PoolerReconciler{
 		Client:          mgr.GetClient(),
 		DiscoveryClient: discoveryClient,
@@ -42,11 +42,14 @@ import (
 
 	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
 	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/manager/instance/run/lifecycle"
+	pluginClient "github.com/cloudnative-pg/cloudnative-pg/internal/cnpi/plugin/client"
+	"github.com/cloudnative-pg/cloudnative-pg/internal/configuration"
 	"github.com/cloudnative-pg/cloudnative-pg/internal/management/controller"
 	"github.com/cloudnative-pg/cloudnative-pg/internal/management/controller/externalservers"
 	"github.com/cloudnative-pg/cloudnative-pg/internal/management/controller/roles"
 	"github.com/cloudnative-pg/cloudnative-pg/internal/management/controller/slots/runner"
 	"github.com/cloudnative-pg/cloudnative-pg/internal/management/controller/tablespaces"
+	"github.com/cloudnative-pg/cloudnative-pg/internal/management/controller/walarchive"
 	"github.com/cloudnative-pg/cloudnative-pg/internal/management/istio"
 	"github.com/cloudnative-pg/cloudnative-pg/internal/management/linkerd"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/concurrency"
@@ -209,8 +212,17 @@ func runSubCommand(ctx context.Context, instance *postgres.Instance) error {
 	postgresStartConditions := concurrency.MultipleExecuted{}
 	exitedConditions := concurrency.MultipleExecuted{}
 
+	// The plugins running as sidecars are loaded once, and their
+	// connections are shared by the reconcilers of the instance manager
+	localPlugins, err := pluginClient.LoadLocalPlugins(configuration.Current.PluginSocketDir)
+	if err != nil {
+		contextLogger.Error(err, "unable to load local plugins")
+		return err
+	}
+	defer localPlugins.Close()
+
 	metricsExporter := metricserver.NewExporter(instance)
-	reconciler := controller.NewInstanceReconciler(instance, mgr.GetClient(), metricsExporter)
+	reconciler := controller.NewInstanceReconciler(instance, mgr.GetClient(), metricsExporter, localPlugins)
 	err = ctrl.NewControllerManagedBy(mgr).
 		For(&apiv1.Cluster{}).
 		Named("instance-cluster").
@@ -288,12 +300,18 @@ func runSubCommand(ctx context.Context, instance *postgres.Instance) error {
 		return err
 	}
 
-	roleSynchronizer := roles.NewRoleSynchronizer(instance, reconciler.GetClient())
+	roleSynchronizer := roles.NewRoleSynchronizer(instance, reconciler.GetClient(), localPlugins)
 	if err = mgr.Add(roleSynchronizer); err != nil {
 		contextLogger.Error(err, "unable to create role synchronizer")
 		return err
 	}
 
+	walArchiveContinuityChecker := walarchive.NewContinuityChecker(instance, reconciler.GetClient(), localPlugins)
+	if err = mgr.Add(walArchiveContinuityChecker); err != nil {
+		contextLogger.Error(err, "unable to create WAL archive continuity checker")
+		return err
+	}
+
 	// onlineUpgradeCtx is a child context of the postgres context.
 	// onlineUpgradeCtx will be the context passed to all the manager handled Runnables via Start(ctx),
 	// its deletion will imply all Runnables to stop, but will be handled
@@ -341,7 +359,7 @@ func runSubCommand(ctx context.Context, instance *postgres.Instance) error {
 	}
 
 	contextLogger.Info("starting external server manager")
-	if err := externalservers.NewReconciler(instance, mgr.GetClient()).
+	if err := externalservers.NewReconciler(instance, mgr.GetClient(), localPlugins).
 		SetupWithManager(mgr); err != nil {
 		contextLogger.Error(err, "unable to create external servers reconciler")
 		return err
@@ -246,7 +246,9 @@ func run(ctx context.Context, pgData string, podName string, args []string) erro
 
 // restoreWALViaPlugins requests every capable plugin to restore the passed
 // WAL file, and returns an error if every plugin failed. It will not return
-// an error if there's no plugin capable of WAL archiving too
+// an error if there's no plugin capable of WAL archiving too.
+// When WAL prefetching is enabled, the following WAL files are restored
+// into the spool at the same time
 func restoreWALViaPlugins(
 	ctx context.Context,
 	cluster *apiv1.Cluster,
@@ -282,7 +284,30 @@ func restoreWALViaPlugins(
 	}
 	defer client.Close(ctx)
 
-	return client.RestoreWAL(ctx, cluster, walName, destinationPathName)
+	if cluster.Spec.WalPrefetch.GetMaxParallel() <= 1 {
+		return client.RestoreWAL(ctx, cluster, walName, destinationPathName)
+	}
+
+	prefetcher, err := newWALPrefetcher(client, cluster, PluginSpoolDirectory)
+	if err != nil {
+		return false, fmt.Errorf("while creating the WAL prefetcher: %w", err)
+	}
+
+	result, err := prefetcher.restore(ctx, walName, destinationPathName)
+	if err != nil || !result.found {
+		return false, err
+	}
+
+	contextLogger.Info("Restored WAL file via plugins",
+		"walName", walName,
+		"fromSpool", result.hit,
+		"prefetched", result.prefetched,
+		"maxParallel", prefetcher.maxParallel)
+	if err := local.NewClient().Cluster().RecordWALPrefetch(ctx, result.hit, result.prefetched); err != nil {
+		contextLogger.Warning("Cannot record the WAL prefetch statistics", "err", err.Error())
+	}
+
+	return true, nil
 }
 
 // checkEndOfWALStreamFlag returns ErrEndOfWALStreamReached if the flag is set in the restorer
@@ -0,0 +1,240 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package walrestore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+	"sync"
+
+	"github.com/cloudnative-pg/barman-cloud/pkg/spool"
+	"github.com/cloudnative-pg/machinery/pkg/log"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	pluginClient "github.com/cloudnative-pg/cloudnative-pg/internal/cnpi/plugin/client"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
+)
+
+const (
+	// PluginSpoolDirectory is the directory where we spool the WAL files
+	// that were prefetched through the plugins
+	PluginSpoolDirectory = postgres.ScratchDataDirectory + "/wal-restore-plugin-spool"
+
+	// partialSuffix is the suffix of the WAL files being prefetched, which
+	// are moved to their final name in the spool once completely restored
+	partialSuffix = ".partial"
+)
+
+// walPrefetcher restores the WAL files requested by PostgreSQL through the
+// plugins, prefetching the following ones into the spool
+type walPrefetcher struct {
+	restorer       pluginClient.WalCapabilities
+	cluster        *apiv1.Cluster
+	spool          *spool.WALSpool
+	spoolDirectory string
+	maxParallel    int
+	maxSpoolSize   int64
+}
+
+// walPrefetchResult is the outcome of the restore of a WAL file through
+// the plugins
+type walPrefetchResult struct {
+	// found is true when the WAL file has been restored
+	found bool
+
+	// hit is true when the WAL file was found in the spool
+	hit bool
+
+	// prefetched is the number of WAL files restored into the spool
+	prefetched int
+}
+
+// newWALPrefetcher creates a prefetcher for the WAL files of the passed
+// cluster, using the spool in the passed directory
+func newWALPrefetcher(
+	restorer pluginClient.WalCapabilities,
+	cluster *apiv1.Cluster,
+	spoolDirectory string,
+) (*walPrefetcher, error) {
+	walSpool, err := spool.New(spoolDirectory)
+	if err != nil {
+		return nil, err
+	}
+
+	return &walPrefetcher{
+		restorer:       restorer,
+		cluster:        cluster,
+		spool:          walSpool,
+		spoolDirectory: spoolDirectory,
+		maxParallel:    cluster.Spec.WalPrefetch.GetMaxParallel(),
+		maxSpoolSize:   cluster.Spec.WalPrefetch.GetMaxSpoolSize(),
+	}, nil
+}
+
+// restore restores the requested WAL file into the destination, taking it
+// from the spool when it has already been prefetched. Once restored, the
+// following WAL files are prefetched into the spool, within its size cap
+func (p *walPrefetcher) restore(
+	ctx context.Context,
+	walName string,
+	destinationPathName string,
+) (walPrefetchResult, error) {
+	var result walPrefetchResult
+
+	err := p.spool.MoveOut(walName, destinationPathName)
+	switch {
+	case err == nil:
+		result.found = true
+		result.hit = true
+	case errors.Is(err, spool.ErrorNonExistentFile):
+		result.found, err = p.restorer.RestoreWAL(ctx, p.cluster, walName, destinationPathName)
+		if err != nil || !result.found {
+			return result, err
+		}
+	default:
+		return result, fmt.Errorf("while restoring a file from the spool directory: %w", err)
+	}
+
+	walList, err := p.getWALFilesToPrefetch(walName, destinationPathName)
+	if err != nil {
+		log.FromContext(ctx).Warning("Cannot prefetch WAL files", "walName", walName, "err", err.Error())
+		return result, nil
+	}
+	result.prefetched = p.prefetch(ctx, walList)
+
+	return result, nil
+}
+
+// getWALFilesToPrefetch gets the WAL files following the passed one that
+// aren't in the spool yet, as many as the size cap of the spool allows,
+// given the size of the WAL file that has just been restored
+func (p *walPrefetcher) getWALFilesToPrefetch(walName string, restoredPathName string) ([]string, error) {
+	if p.maxParallel <= 1 || !postgres.IsWALFile(walName) {
+		return nil, nil
+	}
+
+	walList, err := gatherWALFilesToRestore(walName, p.maxParallel)
+	if err != nil {
+		return nil, err
+	}
+
+	restoredFile, err := os.Stat(restoredPathName)
+	if err != nil {
+		return nil, err
+	}
+	if restoredFile.Size() == 0 {
+		return nil, nil
+	}
+
+	spoolSize, err := p.cleanSpool(walName)
+	if err != nil {
+		return nil, err
+	}
+	available := (p.maxSpoolSize - spoolSize) / restoredFile.Size()
+
+	result := make([]string, 0, len(walList))
+	for _, name := range walList[1:] {
+		if int64(len(result)) >= available {
+			break
+		}
+		contained, err := p.spool.Contains(name)
+		if err != nil {
+			return nil, err
+		}
+		if !contained {
+			result = append(result, name)
+		}
+	}
+
+	return result, nil
+}
+
+// cleanSpool removes from the spool the WAL files preceding the passed
+// one, including the partially restored ones, as PostgreSQL won't request
+// them anymore, and gets the size of the remaining files
+func (p *walPrefetcher) cleanSpool(walName string) (int64, error) {
+	entries, err := os.ReadDir(p.spoolDirectory)
+	if err != nil {
+		return 0, err
+	}
+
+	var size int64
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() {
+			continue
+		}
+		spooledWALName := strings.TrimSuffix(entry.Name(), partialSuffix)
+		if postgres.IsWALFile(spooledWALName) && spooledWALName < walName {
+			if err := os.Remove(path.Join(p.spoolDirectory, entry.Name())); err != nil && !os.IsNotExist(err) {
+				return 0, err
+			}
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return 0, err
+		}
+		size += info.Size()
+	}
+
+	return size, nil
+}
+
+// prefetch restores the passed WAL files into the spool at the same time,
+// returning how many of them have been restored. A WAL file is visible in
+// the spool only once it has been completely restored
+func (p *walPrefetcher) prefetch(ctx context.Context, walList []string) int {
+	contextLogger := log.FromContext(ctx)
+
+	restored := make([]bool, len(walList))
+	var wg sync.WaitGroup
+	for idx := range walList {
+		wg.Add(1)
+		go func(idx int) {
+			defer wg.Done()
+
+			walName := walList[idx]
+			partialPathName := p.spool.FileName(walName) + partialSuffix
+			found, err := p.restorer.RestoreWAL(ctx, p.cluster, walName, partialPathName)
+			if err == nil && found {
+				err = os.Rename(partialPathName, p.spool.FileName(walName))
+			}
+			if err != nil || !found {
+				contextLogger.Debug("WAL file not prefetched", "walName", walName, "err", err)
+				_ = os.Remove(partialPathName)
+				return
+			}
+			restored[idx] = true
+		}(idx)
+	}
+	wg.Wait()
+
+	prefetched := 0
+	for _, ok := range restored {
+		if ok {
+			prefetched++
+		}
+	}
+	return prefetched
+}
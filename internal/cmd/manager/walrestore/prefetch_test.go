@@ -0,0 +1,160 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package walrestore
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	pluginClient "github.com/cloudnative-pg/cloudnative-pg/internal/cnpi/plugin/client"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// fakeWALRestorer is a plugin restoring the WAL files of a fake archive
+type fakeWALRestorer struct {
+	mu       sync.Mutex
+	archive  map[string]string
+	restored []string
+}
+
+func (f *fakeWALRestorer) ArchiveWAL(context.Context, client.Object, string) error {
+	return nil
+}
+
+func (f *fakeWALRestorer) RestoreWAL(
+	_ context.Context,
+	_ client.Object,
+	sourceWALName string,
+	destinationFileName string,
+) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	content, ok := f.archive[sourceWALName]
+	if !ok {
+		return false, fmt.Errorf("WAL file %s not found", sourceWALName)
+	}
+	f.restored = append(f.restored, sourceWALName)
+	return true, os.WriteFile(destinationFileName, []byte(content), 0o600)
+}
+
+func (f *fakeWALRestorer) GetWALArchiveStatus(context.Context, client.Object) (*pluginClient.WALArchiveStatus, error) {
+	return nil, nil
+}
+
+var _ = Describe("WAL prefetching via plugins", func() {
+	var restorer *fakeWALRestorer
+	var cluster *apiv1.Cluster
+	var spoolDirectory, destinationDirectory string
+
+	BeforeEach(func() {
+		restorer = &fakeWALRestorer{
+			archive: map[string]string{
+				"000000010000000000000001": "wal-1",
+				"000000010000000000000002": "wal-2",
+				"000000010000000000000003": "wal-3",
+				"000000010000000000000004": "wal-4",
+			},
+		}
+		cluster = &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				WalPrefetch: &apiv1.WalPrefetchConfiguration{MaxParallel: 3},
+			},
+		}
+		spoolDirectory = GinkgoT().TempDir()
+		destinationDirectory = GinkgoT().TempDir()
+	})
+
+	restore := func(ctx context.Context, walName string) walPrefetchResult {
+		prefetcher, err := newWALPrefetcher(restorer, cluster, spoolDirectory)
+		Expect(err).ToNot(HaveOccurred())
+		destination := path.Join(destinationDirectory, "RECOVERYXLOG")
+		result, err := prefetcher.restore(ctx, walName, destination)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.found).To(BeTrue())
+		Expect(os.ReadFile(destination)).To(BeEquivalentTo(restorer.archive[walName]))
+		return result
+	}
+
+	It("prefetches the following WAL files and serves them from the spool", func(ctx context.Context) {
+		result := restore(ctx, "000000010000000000000001")
+		Expect(result.hit).To(BeFalse())
+		Expect(result.prefetched).To(Equal(2))
+		Expect(path.Join(spoolDirectory, "000000010000000000000002")).To(BeAnExistingFile())
+		Expect(path.Join(spoolDirectory, "000000010000000000000003")).To(BeAnExistingFile())
+
+		result = restore(ctx, "000000010000000000000002")
+		Expect(result.hit).To(BeTrue())
+		Expect(result.prefetched).To(Equal(1))
+		Expect(path.Join(spoolDirectory, "000000010000000000000002")).ToNot(BeAnExistingFile())
+		Expect(path.Join(spoolDirectory, "000000010000000000000004")).To(BeAnExistingFile())
+
+		Expect(restorer.restored).To(ConsistOf(
+			"000000010000000000000001",
+			"000000010000000000000002",
+			"000000010000000000000003",
+			"000000010000000000000004",
+		))
+	})
+
+	It("doesn't leave the WAL files that can't be restored in the spool", func(ctx context.Context) {
+		delete(restorer.archive, "000000010000000000000003")
+
+		result := restore(ctx, "000000010000000000000001")
+		Expect(result.prefetched).To(Equal(1))
+		entries, err := os.ReadDir(spoolDirectory)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(entries).To(HaveLen(1))
+		Expect(entries[0].Name()).To(Equal("000000010000000000000002"))
+	})
+
+	It("stops prefetching when the spool is full", func(ctx context.Context) {
+		cluster.Spec.WalPrefetch.MaxSpoolSize = ptr.To(resource.MustParse("5"))
+
+		result := restore(ctx, "000000010000000000000001")
+		Expect(result.prefetched).To(Equal(1))
+		Expect(path.Join(spoolDirectory, "000000010000000000000002")).To(BeAnExistingFile())
+	})
+
+	It("removes the WAL files that won't be requested anymore", func(ctx context.Context) {
+		Expect(os.WriteFile(path.Join(spoolDirectory, "000000010000000000000001"), []byte("old"), 0o600)).
+			To(Succeed())
+
+		restore(ctx, "000000010000000000000002")
+		Expect(path.Join(spoolDirectory, "000000010000000000000001")).ToNot(BeAnExistingFile())
+	})
+
+	It("doesn't prefetch files other than WAL segments", func(ctx context.Context) {
+		restorer.archive["00000002.history"] = "history"
+
+		result := restore(ctx, "00000002.history")
+		Expect(result.prefetched).To(BeZero())
+	})
+})
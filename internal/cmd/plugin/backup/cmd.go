@@ -50,6 +50,8 @@ type backupCommandOptions struct {
 	waitForArchive      *bool
 	pluginName          string
 	pluginParameters    pluginParameters
+	databases           []string
+	parentBackup        string
 }
 
 func (options backupCommandOptions) getOnlineConfiguration() *apiv1.OnlineConfiguration {
@@ -67,11 +69,15 @@ func (options backupCommandOptions) getOnlineConfiguration() *apiv1.OnlineConfig
 func NewCmd() *cobra.Command {
 	var backupName, backupTarget, backupMethod, online, immediateCheckpoint, waitForArchive, pluginName string
 	var pluginParameters pluginParameters
+	var databases []string
+	var parentBackup string
 
 	backupMethods := []string{
 		string(apiv1.BackupMethodBarmanObjectStore),
 		string(apiv1.BackupMethodVolumeSnapshot),
 		string(apiv1.BackupMethodPlugin),
+		string(apiv1.BackupMethodLogical),
+		string(apiv1.BackupMethodPersistentVolume),
 	}
 
 	backupSubcommand := &cobra.Command{
@@ -110,18 +116,28 @@ func NewCmd() *cobra.Command {
 				return fmt.Errorf("backup-method: %s is not supported by the backup command", backupMethod)
 			}
 
-			if backupMethod != string(apiv1.BackupMethodPlugin) {
+			if backupMethod != string(apiv1.BackupMethodPlugin) && backupMethod != string(apiv1.BackupMethodLogical) {
 				if len(pluginName) > 0 {
-					return fmt.Errorf("plugin-name is allowed only when backup method in %s",
-						apiv1.BackupMethodPlugin)
+					return fmt.Errorf("plugin-name is allowed only when backup method in %s, %s",
+						apiv1.BackupMethodPlugin, apiv1.BackupMethodLogical)
 				}
 
 				if len(pluginParameters) > 0 {
-					return fmt.Errorf("plugin-parameters is allowed only when backup method in %s",
-						apiv1.BackupMethodPlugin)
+					return fmt.Errorf("plugin-parameters is allowed only when backup method in %s, %s",
+						apiv1.BackupMethodPlugin, apiv1.BackupMethodLogical)
 				}
 			}
 
+			if backupMethod != string(apiv1.BackupMethodLogical) && len(databases) > 0 {
+				return fmt.Errorf("databases is allowed only when backup method is %s",
+					apiv1.BackupMethodLogical)
+			}
+
+			if backupMethod != string(apiv1.BackupMethodPersistentVolume) && len(parentBackup) > 0 {
+				return fmt.Errorf("parent-backup is allowed only when backup method is %s",
+					apiv1.BackupMethodPersistentVolume)
+			}
+
 			var cluster apiv1.Cluster
 			// check if the cluster exists
 			err := plugin.Client.Get(
@@ -161,6 +177,8 @@ func NewCmd() *cobra.Command {
 					waitForArchive:      parsedWaitForArchive,
 					pluginName:          pluginName,
 					pluginParameters:    pluginParameters,
+					databases:           databases,
+					parentBackup:        parentBackup,
 				})
 		},
 	}
@@ -214,13 +232,23 @@ func NewCmd() *cobra.Command {
 
 	backupSubcommand.Flags().StringVar(&pluginName, "plugin-name", "",
 		"The name of the plugin that should take the backup. This option "+
-			"is allowed only when the backup method is set to 'plugin'",
+			"is allowed only when the backup method is set to 'plugin' or 'logical'",
 	)
 
 	backupSubcommand.Flags().VarP(&pluginParameters, "plugin-parameters", "",
 		"The set of plugin parameters that should be passed to the backup plugin "+
 			" i.e. param-one=value,param-two=value. This option "+
-			"is allowed only when the backup method is set to 'plugin'",
+			"is allowed only when the backup method is set to 'plugin' or 'logical'",
+	)
+
+	backupSubcommand.Flags().StringSliceVar(&databases, "databases", nil,
+		"The databases to be dumped, defaulting to every database. This option "+
+			"is allowed only when the backup method is set to 'logical'",
+	)
+
+	backupSubcommand.Flags().StringVar(&parentBackup, "parent-backup", "",
+		"The name of the Backup resource this incremental backup is based on. This option "+
+			"is allowed only when the backup method is set to 'persistentVolume'",
 	)
 
 	return backupSubcommand
@@ -245,6 +273,18 @@ func createBackup(ctx context.Context, options backupCommandOptions) error {
 	}
 	utils.LabelClusterName(&backup.ObjectMeta, options.clusterName)
 
+	if len(options.databases) > 0 {
+		backup.Spec.Logical = &apiv1.LogicalBackupOptions{
+			Databases: options.databases,
+		}
+	}
+
+	if len(options.parentBackup) > 0 {
+		backup.Spec.ParentBackup = &apiv1.LocalObjectReference{
+			Name: options.parentBackup,
+		}
+	}
+
 	if len(options.pluginName) > 0 {
 		backup.Spec.PluginConfiguration = &apiv1.BackupPluginConfiguration{
 			Name:       options.pluginName,
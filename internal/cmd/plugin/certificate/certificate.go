@@ -54,6 +54,11 @@ func Generate(ctx context.Context, params Params, dryRun bool, format plugin.Out
 		return err
 	}
 
+	if cluster.IsCertManagerEnabled() {
+		return fmt.Errorf("cluster %s uses cert-manager: client certificates are unsupported with cert-manager, "+
+			"as the private key of the client CA is not available", cluster.Name)
+	}
+
 	err = plugin.Client.Get(
 		ctx,
 		client.ObjectKey{Namespace: params.Namespace, Name: cluster.GetClientCASecretName()},
@@ -0,0 +1,87 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package restoreobjects
+
+import (
+	"fmt"
+	"time"
+
+	pgTime "github.com/cloudnative-pg/machinery/pkg/postgres/time"
+	"github.com/spf13/cobra"
+
+	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin"
+)
+
+// NewCmd creates the new "restore-objects" command
+func NewCmd() *cobra.Command {
+	var options restoreObjectsOptions
+
+	cmd := &cobra.Command{
+		Use:   "restore-objects CLUSTER",
+		Short: "Restores tables and schemas from a backup into a running cluster",
+		Long: `Creates an ObjectRestore resource. The operator recovers a temporary
+cluster from a backup, dumps the requested objects from it and restores them
+into the cluster, in the target schema for tables and under a new name for
+schemas. The temporary cluster is deleted at the end of the restore.`,
+		Example: `  # Restore the public.orders table, as it was at the given time,
+  # into the restored schema of the app database
+  kubectl cnpg restore-objects cluster-example --database app \
+    --table public.orders --target-schema restored \
+    --target-time "2025-01-15 10:00:00.00000+00"`,
+		GroupID: plugin.GroupIDDatabase,
+		Args:    plugin.RequiresArguments(1),
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			return plugin.CompleteClusters(cmd.Context(), args, toComplete), cobra.ShellCompDirectiveNoFileComp
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			options.clusterName = args[0]
+			if options.name == "" {
+				options.name = fmt.Sprintf("%s-%s", options.clusterName, pgTime.ToCompactISO8601(time.Now()))
+			}
+			if len(options.tables) == 0 && len(options.schemas) == 0 {
+				return fmt.Errorf("at least one table or schema is required")
+			}
+			if len(options.tables) > 0 && options.targetSchema == "" {
+				return fmt.Errorf("target-schema is required to restore tables")
+			}
+
+			return restoreObjects(cmd.Context(), options)
+		},
+	}
+
+	cmd.Flags().StringVar(&options.name, "name", "",
+		"The name of the ObjectRestore resource that will be created, "+
+			"defaults to \"CLUSTER-CURRENT_TIMESTAMP\"")
+	cmd.Flags().StringVar(&options.backupName, "backup", "",
+		"The backup to recover from, defaults to the latest completed backup preceding the target time")
+	cmd.Flags().StringVar(&options.targetTime, "target-time", "",
+		"The time the objects are recovered to, defaults to the end of the WAL archive")
+	cmd.Flags().StringVar(&options.database, "database", "", "The database containing the objects")
+	cmd.Flags().StringSliceVar(&options.tables, "table", nil,
+		"A table to restore, in the schema.table format. Can be repeated")
+	cmd.Flags().StringVar(&options.targetSchema, "target-schema", "", "The schema where the tables are restored")
+	cmd.Flags().StringSliceVar(&options.schemas, "schema", nil, "A schema to restore. Can be repeated")
+	cmd.Flags().StringVar(&options.schemaSuffix, "schema-suffix", "",
+		"The suffix appended to the name of the restored schemas, defaults to \"_restored\"")
+	cmd.Flags().StringVar(&options.owner, "owner", "", "The role owning the restored objects")
+	_ = cmd.MarkFlagRequired("database")
+
+	return cmd
+}
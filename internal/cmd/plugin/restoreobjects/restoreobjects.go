@@ -0,0 +1,86 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package restoreobjects implements the kubectl-cnpg restore-objects sub-command
+package restoreobjects
+
+import (
+	"context"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin"
+)
+
+// restoreObjectsOptions are the options of the restore-objects command
+type restoreObjectsOptions struct {
+	name         string
+	clusterName  string
+	backupName   string
+	targetTime   string
+	database     string
+	tables       []string
+	targetSchema string
+	schemas      []string
+	schemaSuffix string
+	owner        string
+}
+
+// restoreObjects creates the ObjectRestore resource
+func restoreObjects(ctx context.Context, options restoreObjectsOptions) error {
+	var cluster apiv1.Cluster
+	err := plugin.Client.Get(ctx, client.ObjectKey{Namespace: plugin.Namespace, Name: options.clusterName}, &cluster)
+	if err != nil {
+		return fmt.Errorf("while getting cluster %s: %w", options.clusterName, err)
+	}
+
+	objectRestore := apiv1.ObjectRestore{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: plugin.Namespace,
+			Name:      options.name,
+		},
+		Spec: apiv1.ObjectRestoreSpec{
+			ClusterRef:   apiv1.LocalObjectReference{Name: options.clusterName},
+			Database:     options.database,
+			Tables:       options.tables,
+			TargetSchema: options.targetSchema,
+			Schemas:      options.schemas,
+			SchemaSuffix: options.schemaSuffix,
+			Owner:        options.owner,
+		},
+	}
+	if options.backupName != "" {
+		objectRestore.Spec.Backup = &apiv1.LocalObjectReference{Name: options.backupName}
+	}
+	if options.targetTime != "" {
+		objectRestore.Spec.RecoveryTarget = &apiv1.RecoveryTarget{TargetTime: options.targetTime}
+	}
+
+	if err := plugin.Client.Create(ctx, &objectRestore); err != nil {
+		return err
+	}
+
+	fmt.Printf("objectrestore/%v created\n", objectRestore.Name)
+	fmt.Printf("Follow its progress with: kubectl get objectrestore -n %v %v\n",
+		objectRestore.Namespace, objectRestore.Name)
+	return nil
+}
@@ -0,0 +1,52 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package rollout
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin"
+)
+
+// NewCmd creates the new "rollout" command
+func NewCmd() *cobra.Command {
+	rolloutCmd := &cobra.Command{
+		Use:     "rollout [run-now]",
+		Short:   "Manages the rollouts waiting for a maintenance window",
+		GroupID: plugin.GroupIDCluster,
+	}
+
+	rolloutCmd.AddCommand(&cobra.Command{
+		Use:   "run-now CLUSTER",
+		Short: "Applies the pending rollout without waiting for the next maintenance window",
+		Long: `The operator will apply the rollout that is currently pending, as reported
+in the cluster status, even if the cluster is outside its maintenance windows.
+Rollouts that will be postponed later will wait again for a maintenance window.`,
+		Args: plugin.RequiresArguments(1),
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			return plugin.CompleteClusters(cmd.Context(), args, toComplete), cobra.ShellCompDirectiveNoFileComp
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runNow(cmd.Context(), args[0])
+		},
+	})
+
+	return rolloutCmd
+}
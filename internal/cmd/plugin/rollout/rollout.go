@@ -0,0 +1,69 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package rollout implements the kubectl-cnpg rollout sub-command
+package rollout
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+)
+
+// runNow requests the operator to apply the pending rollout outside
+// the maintenance windows. The request refers to the pending rollout
+// through the time the operator postponed it, so that only the operator
+// clock is involved, and the operator decides which actions it covers
+func runNow(ctx context.Context, clusterName string) error {
+	var cluster apiv1.Cluster
+
+	err := plugin.Client.Get(ctx, client.ObjectKey{Namespace: plugin.Namespace, Name: clusterName}, &cluster)
+	if err != nil {
+		return fmt.Errorf("while trying to get cluster %v: %w", clusterName, err)
+	}
+
+	if cluster.Status.PendingRollout == nil {
+		fmt.Printf("%s has no pending rollout\n", cluster.Name)
+		return nil
+	}
+
+	updatedCluster := cluster.DeepCopy()
+	if updatedCluster.Annotations == nil {
+		updatedCluster.Annotations = make(map[string]string)
+	}
+	updatedCluster.Annotations[utils.RunPendingRolloutAnnotationName] =
+		cluster.Status.PendingRollout.Since.Format(time.RFC3339)
+	updatedCluster.ManagedFields = nil
+
+	if err := plugin.Client.Patch(ctx, updatedCluster, client.MergeFrom(&cluster)); err != nil {
+		return fmt.Errorf("while patching cluster %v: %w", clusterName, err)
+	}
+
+	fmt.Printf("Requested the pending rollout of %s (instance %s: %s)\n",
+		cluster.Name,
+		cluster.Status.PendingRollout.Target,
+		cluster.Status.PendingRollout.Reason)
+	return nil
+}
@@ -151,6 +151,7 @@ func Status(
 		}
 		status.printInstancesStatus()
 	}
+	status.printPrimaryHistory()
 	status.printPluginStatus(verbosity)
 
 	if len(errs) > 0 {
@@ -327,6 +328,27 @@ func (fullStatus *PostgresqlStatus) printBasicInfo(ctx context.Context, k8sClien
 		summary.AddLine("Current Write LSN:", lsnInfo)
 	}
 
+	if canary := cluster.Status.CanaryRollout; canary != nil {
+		message := fmt.Sprintf("%s (%s)", canary.Phase, canary.Message)
+		switch canary.Phase {
+		case apiv1.CanaryRolloutPhaseRolledBack:
+			summary.AddLine("Canary rollout:", aurora.Red(message))
+		case apiv1.CanaryRolloutPhaseInProgress:
+			summary.AddLine("Canary rollout:", aurora.Yellow(message))
+		default:
+			summary.AddLine("Canary rollout:", aurora.Green(message))
+		}
+	}
+
+	if pending := cluster.Status.PendingRollout; pending != nil {
+		summary.AddLine("Pending rollout:", aurora.Yellow(fmt.Sprintf(
+			"%s (%s), waiting for a maintenance window since %s",
+			pending.Target,
+			pending.Reason,
+			pending.Since.UTC().Format(time.RFC3339),
+		)))
+	}
+
 	if cluster.IsReplica() {
 		fmt.Println(aurora.Yellow("Replica Cluster Summary"))
 	} else {
@@ -836,6 +858,31 @@ func (fullStatus *PostgresqlStatus) printCertificatesStatus() {
 	fmt.Println(color("Certificates Status"))
 	status.Print()
 	fmt.Println()
+
+	caRotations := fullStatus.Cluster.Status.Certificates.CARotations
+	if len(caRotations) == 0 {
+		return
+	}
+
+	secretNames := make([]string, 0, len(caRotations))
+	for secretName := range caRotations {
+		secretNames = append(secretNames, secretName)
+	}
+	sort.Strings(secretNames)
+
+	rotations := tabby.New()
+	rotations.AddHeader("CA Secret Name", "Rotation Phase", "Since")
+	for _, secretName := range secretNames {
+		since := "-"
+		if rotation := caRotations[secretName]; rotation.Since != nil {
+			since = rotation.Since.Format(time.RFC3339)
+		}
+		rotations.AddLine(secretName, caRotations[secretName].Phase, since)
+	}
+
+	fmt.Println(aurora.Yellow("CA Rotations"))
+	rotations.Print()
+	fmt.Println()
 }
 
 func (fullStatus *PostgresqlStatus) tryGetPrimaryInstance() *postgres.PostgresqlStatus {
@@ -1018,6 +1065,56 @@ func (fullStatus *PostgresqlStatus) printPodDisruptionBudgetStatus() {
 	fmt.Println()
 }
 
+func (fullStatus *PostgresqlStatus) printPrimaryHistory() {
+	const header = "Primary history"
+
+	history := fullStatus.Cluster.Status.PrimaryHistory
+	if len(history) == 0 {
+		return
+	}
+
+	fmt.Println(aurora.Green(header))
+
+	status := tabby.New()
+	status.AddHeader(
+		"Time",
+		"Old Primary",
+		"New Primary",
+		"Reason",
+		"Timeline",
+		"LSN",
+		"Estimated Data Loss",
+	)
+
+	for i := len(history) - 1; i >= 0; i-- {
+		item := history[i]
+		timeline := "-"
+		if item.TimelineID != 0 {
+			timeline = strconv.Itoa(item.TimelineID)
+		}
+		lsn := "-"
+		if item.LSN != "" {
+			lsn = item.LSN
+		}
+		dataLoss := "unknown"
+		if item.EstimatedDataLossBytes != nil {
+			dataLoss = fmt.Sprintf("%d bytes", *item.EstimatedDataLossBytes)
+		}
+		status.AddLine(
+			item.Time.Format(time.RFC3339),
+			item.OldPrimary,
+			item.NewPrimary,
+			item.Reason,
+			timeline,
+			lsn,
+			dataLoss,
+		)
+	}
+
+	status.Print()
+	fmt.Println()
+}
+
 func (fullStatus *PostgresqlStatus) printBasebackupStatus(verbosity int) {
 	const header = "Physical backups"
 
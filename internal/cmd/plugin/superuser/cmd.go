@@ -0,0 +1,73 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package superuser
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin"
+)
+
+// NewCmd creates the new "superuser" command
+func NewCmd() *cobra.Command {
+	superuserCmd := &cobra.Command{
+		Use:     "superuser [grant|revoke]",
+		Short:   "Manages the temporary superuser access to a cluster",
+		GroupID: plugin.GroupIDCluster,
+	}
+
+	var ttl time.Duration
+	grantCmd := &cobra.Command{
+		Use:   "grant CLUSTER",
+		Short: "Grants a temporary superuser access to the cluster",
+		Long: `The operator will create a superuser role whose password is only valid
+for the requested time, storing its credentials in the <cluster>-superuser-grant
+secret. The role is dropped when the access expires.`,
+		Args: plugin.RequiresArguments(1),
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			return plugin.CompleteClusters(cmd.Context(), args, toComplete), cobra.ShellCompDirectiveNoFileComp
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if ttl <= 0 {
+				return fmt.Errorf("the ttl must be positive, got %v", ttl)
+			}
+			return grant(cmd.Context(), args[0], ttl)
+		},
+	}
+	grantCmd.Flags().DurationVar(&ttl, "ttl", time.Hour, "How long the superuser access is granted for")
+	superuserCmd.AddCommand(grantCmd)
+
+	superuserCmd.AddCommand(&cobra.Command{
+		Use:   "revoke CLUSTER",
+		Short: "Revokes the temporary superuser access to the cluster",
+		Args:  plugin.RequiresArguments(1),
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			return plugin.CompleteClusters(cmd.Context(), args, toComplete), cobra.ShellCompDirectiveNoFileComp
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return revoke(cmd.Context(), args[0])
+		},
+	})
+
+	return superuserCmd
+}
@@ -0,0 +1,90 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package superuser implements the kubectl-cnpg superuser sub-command
+package superuser
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+)
+
+// grant requests the operator a temporary superuser access to the cluster
+func grant(ctx context.Context, clusterName string, ttl time.Duration) error {
+	expiresAt := time.Now().Add(ttl).UTC().Truncate(time.Second)
+	err := patchSuperuserGrantAnnotation(ctx, clusterName, func(annotations map[string]string) {
+		annotations[utils.SuperuserGrantUntilAnnotationName] = expiresAt.Format(time.RFC3339)
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Requested superuser access to %s until %s\n", clusterName, expiresAt.Format(time.RFC3339))
+	fmt.Printf("The credentials of the %s role will be stored in the %s%s secret\n",
+		apiv1.SuperuserGrantRoleName, clusterName, apiv1.SuperuserGrantSecretSuffix)
+	return nil
+}
+
+// revoke requests the operator to drop the temporary superuser access
+func revoke(ctx context.Context, clusterName string) error {
+	err := patchSuperuserGrantAnnotation(ctx, clusterName, func(annotations map[string]string) {
+		delete(annotations, utils.SuperuserGrantUntilAnnotationName)
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Requested the revocation of the superuser access to %s\n", clusterName)
+	return nil
+}
+
+// patchSuperuserGrantAnnotation applies the passed change to the
+// annotations of the cluster
+func patchSuperuserGrantAnnotation(
+	ctx context.Context,
+	clusterName string,
+	mutate func(annotations map[string]string),
+) error {
+	var cluster apiv1.Cluster
+
+	err := plugin.Client.Get(ctx, client.ObjectKey{Namespace: plugin.Namespace, Name: clusterName}, &cluster)
+	if err != nil {
+		return fmt.Errorf("while trying to get cluster %v: %w", clusterName, err)
+	}
+
+	updatedCluster := cluster.DeepCopy()
+	if updatedCluster.Annotations == nil {
+		updatedCluster.Annotations = make(map[string]string)
+	}
+	mutate(updatedCluster.Annotations)
+	updatedCluster.ManagedFields = nil
+
+	if err := plugin.Client.Patch(ctx, updatedCluster, client.MergeFrom(&cluster)); err != nil {
+		return fmt.Errorf("while patching cluster %v: %w", clusterName, err)
+	}
+
+	return nil
+}
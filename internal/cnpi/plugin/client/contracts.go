@@ -41,6 +41,7 @@ type Client interface {
 	WalCapabilities
 	BackupCapabilities
 	RestoreJobHooksCapabilities
+	CredentialsCapabilities
 }
 
 // Connection describes a set of behaviour needed to properly handle the plugin connections
@@ -137,6 +138,23 @@ type WalCapabilities interface {
 		sourceWALName string,
 		destinationFileName string,
 	) (bool, error)
+
+	// GetWALArchiveStatus calls the loaded plugins to get the oldest and
+	// the newest WAL file in the WAL archive. This call returns a nil
+	// status if there's no plugin implementing the WAL status capability
+	GetWALArchiveStatus(
+		ctx context.Context,
+		cluster client.Object,
+	) (*WALArchiveStatus, error)
+}
+
+// WALArchiveStatus is the content of a WAL archive as reported by a plugin
+type WALArchiveStatus struct {
+	// FirstWAL is the oldest WAL file in the archive
+	FirstWAL string
+
+	// LastWAL is the newest WAL file in the archive
+	LastWAL string
 }
 
 // BackupCapabilities describes a set of behaviour needed to backup
@@ -152,6 +170,32 @@ type BackupCapabilities interface {
 	) (*BackupResponse, error)
 }
 
+// Credentials is the content of a secret supplied by a plugin
+type Credentials struct {
+	// The name of the plugin supplying the credentials
+	PluginName string
+
+	// The content of the secret
+	Data map[string][]byte
+
+	// An opaque identifier of the current version of the credentials
+	Version string
+}
+
+// CredentialsCapabilities describes a set of behavior needed to
+// read the credentials of a cluster from an external backend
+type CredentialsCapabilities interface {
+	// GetCredentials asks the loaded plugins declared as credentials
+	// providers for the content of a secret used by the cluster. This call
+	// returns nil when no plugin manages the requested secret, which should
+	// then be read from Kubernetes
+	GetCredentials(
+		ctx context.Context,
+		cluster *apiv1.Cluster,
+		secretName string,
+	) (*Credentials, error)
+}
+
 // RestoreJobHooksCapabilities describes a set of behaviour needed to run the Restore
 type RestoreJobHooksCapabilities interface {
 	Restore(ctx context.Context, cluster *apiv1.Cluster) (*restore.RestoreResponse, error)
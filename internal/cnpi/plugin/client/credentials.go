@@ -0,0 +1,139 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/internal/cnpi/plugin/credentials"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+)
+
+// credentialsResourceVersionPrefix is the prefix of the resource version
+// of the secrets built from the credentials supplied by a plugin
+const credentialsResourceVersionPrefix = "plugin:"
+
+func (data *data) GetCredentials(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+	secretName string,
+) (*Credentials, error) {
+	contextLogger := log.FromContext(ctx)
+
+	// The credentials service is not advertised by the identity service
+	// of the plugins, which are called only when the cluster declares
+	// them as credentials providers
+	providerNames := cluster.GetCredentialsProviderPluginNames()
+	if len(providerNames) == 0 {
+		return nil, nil
+	}
+
+	serializedCluster, err := json.Marshal(cluster)
+	if err != nil {
+		return nil, fmt.Errorf("while serializing cluster %s/%s to JSON: %w",
+			cluster.GetNamespace(), cluster.GetName(), err)
+	}
+
+	for idx := range data.plugins {
+		plugin := data.plugins[idx]
+
+		if !slices.Contains(providerNames, plugin.Name()) {
+			continue
+		}
+
+		pluginLogger := contextLogger.WithValues("pluginName", plugin.Name())
+		request := credentials.GetCredentialsRequest{
+			ClusterDefinition: serializedCluster,
+			SecretName:        secretName,
+		}
+
+		pluginLogger.Trace("Calling GetCredentials endpoint", "secretName", secretName)
+		result, err := plugin.CredentialsClient().GetCredentials(ctx, &request)
+		if err != nil {
+			pluginLogger.Error(err, "Error while calling GetCredentials, failing")
+			return nil, err
+		}
+
+		if result.Found {
+			return &Credentials{
+				PluginName: plugin.Name(),
+				Data:       result.Data,
+				Version:    result.Version,
+			}, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// GetSecret reads the secret with the passed key. When the cluster and the
+// plugin client are recorded in the context, and a plugin supplies the
+// credentials stored in that secret, a secret built from the content
+// supplied by the plugin is returned instead of the Kubernetes one
+func GetSecret(ctx context.Context, cli client.Reader, key client.ObjectKey) (*corev1.Secret, error) {
+	cluster, _ := ctx.Value(utils.ContextKeyCluster).(*apiv1.Cluster)
+	pluginClient, _ := ctx.Value(utils.PluginClientKey).(CredentialsCapabilities)
+	if cluster != nil && pluginClient != nil && cluster.GetNamespace() == key.Namespace {
+		pluginCredentials, err := pluginClient.GetCredentials(ctx, cluster, key.Name)
+		if err != nil {
+			return nil, fmt.Errorf("while getting credentials for secret %s from plugins: %w", key.Name, err)
+		}
+		if pluginCredentials != nil {
+			return pluginCredentials.toSecret(key), nil
+		}
+	}
+
+	var secret corev1.Secret
+	if err := cli.Get(ctx, key, &secret); err != nil {
+		return nil, err
+	}
+	return &secret, nil
+}
+
+// IsSuppliedByPlugin checks if the passed secret has been built
+// from the credentials supplied by a plugin
+func IsSuppliedByPlugin(secret *corev1.Secret) bool {
+	return strings.HasPrefix(secret.ResourceVersion, credentialsResourceVersionPrefix)
+}
+
+// toSecret builds a secret holding the credentials. The resource version
+// changes every time the credentials are rotated by the plugin, so that
+// consumers tracking it will reload the content
+func (c *Credentials) toSecret(key client.ObjectKey) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            key.Name,
+			Namespace:       key.Namespace,
+			ResourceVersion: credentialsResourceVersionPrefix + c.PluginName + ":" + c.Version,
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: c.Data,
+	}
+}
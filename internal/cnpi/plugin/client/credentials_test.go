@@ -0,0 +1,178 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package client
+
+import (
+	"context"
+	"net"
+
+	"github.com/cloudnative-pg/cnpg-i/pkg/identity"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+	"k8s.io/utils/ptr"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/internal/cnpi/plugin/connection"
+	"github.com/cloudnative-pg/cloudnative-pg/internal/cnpi/plugin/credentials"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// standInIdentity is the identity service of the stand-in
+// credentials plugin
+type standInIdentity struct {
+	identity.UnimplementedIdentityServer
+
+	name string
+}
+
+func (s standInIdentity) GetPluginMetadata(
+	context.Context,
+	*identity.GetPluginMetadataRequest,
+) (*identity.GetPluginMetadataResponse, error) {
+	return &identity.GetPluginMetadataResponse{
+		Name:    s.name,
+		Version: "0.0.1",
+	}, nil
+}
+
+func (standInIdentity) GetPluginCapabilities(
+	context.Context,
+	*identity.GetPluginCapabilitiesRequest,
+) (*identity.GetPluginCapabilitiesResponse, error) {
+	return &identity.GetPluginCapabilitiesResponse{}, nil
+}
+
+// standInCredentials is a credentials service serving the
+// content of an in-memory vault
+type standInCredentials struct {
+	vault map[string]map[string][]byte
+}
+
+func (s standInCredentials) GetCredentials(
+	_ context.Context,
+	request *credentials.GetCredentialsRequest,
+) (*credentials.GetCredentialsResult, error) {
+	content, ok := s.vault[request.SecretName]
+	if !ok {
+		return &credentials.GetCredentialsResult{}, nil
+	}
+
+	return &credentials.GetCredentialsResult{
+		Found:   true,
+		Data:    content,
+		Version: "1",
+	}, nil
+}
+
+// startStandInPlugin starts an in-process plugin and loads it,
+// registering the credentials service only when a vault is passed
+func startStandInPlugin(
+	ctx context.Context,
+	name string,
+	vault map[string]map[string][]byte,
+) connection.Interface {
+	listener := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer()
+	identity.RegisterIdentityServer(server, standInIdentity{name: name})
+	if vault != nil {
+		credentials.RegisterServer(server, standInCredentials{vault: vault})
+	}
+	go func() {
+		defer GinkgoRecover()
+		Expect(server.Serve(listener)).To(Succeed())
+	}()
+	DeferCleanup(server.Stop)
+
+	conn, err := grpc.NewClient(
+		"passthrough:///"+name,
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	Expect(err).ToNot(HaveOccurred())
+
+	plugin, err := connection.LoadPlugin(ctx, conn)
+	Expect(err).ToNot(HaveOccurred())
+	DeferCleanup(plugin.Close)
+
+	return plugin
+}
+
+var _ = Describe("GetCredentials", func() {
+	var cluster *apiv1.Cluster
+	BeforeEach(func() {
+		cluster = &apiv1.Cluster{}
+		cluster.Name = "cluster-example"
+		cluster.Namespace = "default"
+		cluster.Spec.Plugins = []apiv1.PluginConfiguration{
+			{Name: "no-credentials"},
+			{Name: "vault", IsCredentialsProvider: ptr.To(true)},
+		}
+	})
+
+	It("reads the credentials from the plugin managing the secret", func(ctx SpecContext) {
+		d := data{
+			plugins: []connection.Interface{
+				startStandInPlugin(ctx, "no-credentials", nil),
+				startStandInPlugin(ctx, "vault", map[string]map[string][]byte{
+					"cluster-example-app": {"password": []byte("secret")},
+				}),
+			},
+		}
+
+		result, err := d.GetCredentials(ctx, cluster, "cluster-example-app")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).ToNot(BeNil())
+		Expect(result.PluginName).To(Equal("vault"))
+		Expect(result.Version).To(Equal("1"))
+		Expect(result.Data).To(HaveKeyWithValue("password", []byte("secret")))
+	})
+
+	It("returns nil when no plugin manages the secret", func(ctx SpecContext) {
+		d := data{
+			plugins: []connection.Interface{
+				startStandInPlugin(ctx, "vault", map[string]map[string][]byte{}),
+			},
+		}
+
+		result, err := d.GetCredentials(ctx, cluster, "cluster-example-superuser")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(BeNil())
+	})
+
+	It("does not call the plugins not declared as credentials providers", func(ctx SpecContext) {
+		d := data{
+			plugins: []connection.Interface{
+				startStandInPlugin(ctx, "vault", map[string]map[string][]byte{
+					"cluster-example-app": {"password": []byte("secret")},
+				}),
+			},
+		}
+
+		cluster.Spec.Plugins = []apiv1.PluginConfiguration{{Name: "vault"}}
+		result, err := d.GetCredentials(ctx, cluster, "cluster-example-app")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(BeNil())
+	})
+})
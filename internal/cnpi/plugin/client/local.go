@@ -0,0 +1,139 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package client
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	"github.com/cloudnative-pg/machinery/pkg/stringset"
+	"go.uber.org/multierr"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/internal/cnpi/plugin/repository"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+)
+
+// LocalPlugins is the set of plugins running as sidecar containers of
+// the instance, which is meant to be loaded once by the instance manager
+// and shared by its reconcilers. The connections to the plugins are pooled
+// and reused across reconciliation loops.
+// A nil LocalPlugins is valid, and records no plugin client in the context
+type LocalPlugins struct {
+	pluginSocketDir string
+	repository      repository.Interface
+
+	mux       sync.Mutex
+	available *stringset.Data
+}
+
+// LoadLocalPlugins registers the plugins whose socket is available in
+// the passed directory
+func LoadLocalPlugins(pluginSocketDir string) (*LocalPlugins, error) {
+	result := &LocalPlugins{
+		pluginSocketDir: pluginSocketDir,
+		repository:      repository.New(),
+		available:       stringset.New(),
+	}
+
+	if err := result.scan(); err != nil {
+		result.repository.Close()
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// scan registers the plugins whose socket appeared in the plugin
+// directory after the last scan
+func (local *LocalPlugins) scan() error {
+	names, err := local.repository.RegisterUnixSocketPluginsInPath(local.pluginSocketDir)
+	for _, name := range names {
+		local.available.Put(name)
+	}
+
+	// The plugins registered by the previous scans are expected to
+	// be found again
+	var result error
+	for _, registrationErr := range multierr.Errors(err) {
+		var alreadyRegistered *repository.ErrPluginAlreadyRegistered
+		if !errors.As(registrationErr, &alreadyRegistered) {
+			result = multierr.Append(result, registrationErr)
+		}
+	}
+	return result
+}
+
+// availablePluginNames gets the names of the passed plugins whose socket
+// is available. The plugin directory is scanned again only when some of
+// the requested plugins have not been found yet, as the sidecar containers
+// may create their socket after the instance manager started
+func (local *LocalPlugins) availablePluginNames(ctx context.Context, names []string) []string {
+	local.mux.Lock()
+	defer local.mux.Unlock()
+
+	requested := stringset.From(names)
+	if requested.Subtract(local.available).Len() > 0 {
+		if err := local.scan(); err != nil {
+			log.FromContext(ctx).Error(err, "Error while loading local plugins")
+		}
+	}
+
+	return requested.Intersect(local.available).ToList()
+}
+
+// WithCluster records in the context the cluster and a CNPG-I client for
+// the plugins enabled in the cluster whose socket is available.
+// The returned function releases the plugin connections to the pool and
+// must be called when the context is not needed anymore
+func (local *LocalPlugins) WithCluster(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+) (context.Context, func(), error) {
+	ctx = cluster.SetInContext(ctx)
+	if local == nil {
+		return ctx, func() {}, nil
+	}
+
+	pluginClient, err := WithPlugins(
+		ctx,
+		local.repository,
+		local.availablePluginNames(ctx, apiv1.GetPluginConfigurationEnabledPluginNames(cluster.Spec.Plugins))...,
+	)
+	if err != nil {
+		return ctx, func() {}, err
+	}
+
+	ctx = context.WithValue(ctx, utils.PluginClientKey, pluginClient)
+	return ctx, func() {
+		pluginClient.Close(ctx)
+	}, nil
+}
+
+// Close closes the connections to the local plugins
+func (local *LocalPlugins) Close() {
+	if local == nil {
+		return
+	}
+
+	local.repository.Close()
+}
@@ -0,0 +1,62 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package client
+
+import (
+	"os"
+	"path/filepath"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("LocalPlugins", func() {
+	It("records only the cluster in the context when no plugin is loaded", func(ctx SpecContext) {
+		var local *LocalPlugins
+		cluster := &apiv1.Cluster{}
+
+		pluginCtx, release, err := local.WithCluster(ctx, cluster)
+		Expect(err).ToNot(HaveOccurred())
+		release()
+		local.Close()
+
+		Expect(pluginCtx.Value(utils.ContextKeyCluster)).To(BeIdenticalTo(cluster))
+		Expect(pluginCtx.Value(utils.PluginClientKey)).To(BeNil())
+	})
+
+	It("finds the plugins whose socket appeared after the first scan", func(ctx SpecContext) {
+		socketDir := GinkgoT().TempDir()
+		Expect(os.WriteFile(filepath.Join(socketDir, "wal-archiver"), nil, 0o600)).To(Succeed())
+
+		local, err := LoadLocalPlugins(socketDir)
+		Expect(err).ToNot(HaveOccurred())
+		DeferCleanup(local.Close)
+
+		Expect(local.availablePluginNames(ctx, []string{"wal-archiver", "vault"})).
+			To(ConsistOf("wal-archiver"))
+
+		Expect(os.WriteFile(filepath.Join(socketDir, "vault"), nil, 0o600)).To(Succeed())
+		Expect(local.availablePluginNames(ctx, []string{"wal-archiver", "vault"})).
+			To(ConsistOf("wal-archiver", "vault"))
+	})
+})
@@ -33,6 +33,7 @@ import (
 	"google.golang.org/grpc"
 
 	"github.com/cloudnative-pg/cloudnative-pg/internal/cnpi/plugin/connection"
+	"github.com/cloudnative-pg/cloudnative-pg/internal/cnpi/plugin/credentials"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -181,6 +182,10 @@ func (f *fakeConnection) ReconcilerCapabilities() []reconciler.ReconcilerHooksCa
 	panic("not implemented")
 }
 
+func (f *fakeConnection) CredentialsClient() credentials.Client {
+	panic("not implemented")
+}
+
 func (f *fakeConnection) Ping(_ context.Context) error {
 	panic("not implemented") // TODO: Implement
 }
@@ -123,3 +123,48 @@ func (data *data) RestoreWAL(
 
 	return false, errorCollector
 }
+
+func (data *data) GetWALArchiveStatus(
+	ctx context.Context,
+	cluster client.Object,
+) (*WALArchiveStatus, error) {
+	contextLogger := log.FromContext(ctx)
+
+	serializedCluster, err := json.Marshal(cluster)
+	if err != nil {
+		return nil, fmt.Errorf("while serializing %s %s/%s to JSON: %w",
+			cluster.GetObjectKind().GroupVersionKind().Kind,
+			cluster.GetNamespace(), cluster.GetName(),
+			err,
+		)
+	}
+
+	for idx := range data.plugins {
+		plugin := data.plugins[idx]
+
+		if !slices.Contains(plugin.WALCapabilities(), wal.WALCapability_RPC_TYPE_STATUS) {
+			continue
+		}
+
+		pluginLogger := contextLogger.WithValues("pluginName", plugin.Name())
+		request := wal.WALStatusRequest{
+			ClusterDefinition: serializedCluster,
+		}
+
+		pluginLogger.Trace(
+			"Calling WAL Status endpoint",
+			"clusterDefinition", request.ClusterDefinition)
+		result, err := plugin.WALClient().Status(ctx, &request)
+		if err != nil {
+			pluginLogger.Error(err, "Error while calling WAL Status, failing")
+			return nil, err
+		}
+
+		return &WALArchiveStatus{
+			FirstWAL: result.GetFirstWal(),
+			LastWAL:  result.GetLastWal(),
+		}, nil
+	}
+
+	return nil, nil
+}
@@ -34,6 +34,8 @@ import (
 	restore "github.com/cloudnative-pg/cnpg-i/pkg/restore/job"
 	"github.com/cloudnative-pg/cnpg-i/pkg/wal"
 	"google.golang.org/grpc"
+
+	"github.com/cloudnative-pg/cloudnative-pg/internal/cnpi/plugin/credentials"
 )
 
 // defaultTimeout is the timeout applied by default to every GRPC call
@@ -62,6 +64,7 @@ type Interface interface {
 	BackupClient() backup.BackupClient
 	ReconcilerHooksClient() reconciler.ReconcilerHooksClient
 	RestoreJobHooksClient() restore.RestoreJobHooksClient
+	CredentialsClient() credentials.Client
 
 	PluginCapabilities() []identity.PluginCapability_Service_Type
 	OperatorCapabilities() []operator.OperatorCapability_RPC_Type
@@ -84,6 +87,7 @@ type data struct {
 	backupClient          backup.BackupClient
 	reconcilerHooksClient reconciler.ReconcilerHooksClient
 	restoreJobHooksClient restore.RestoreJobHooksClient
+	credentialsClient     credentials.Client
 
 	name                        string
 	version                     string
@@ -121,6 +125,7 @@ func newPluginDataFromConnection(ctx context.Context, connection Handler) (data,
 		backupClient:          backup.NewBackupClient(connection),
 		reconcilerHooksClient: reconciler.NewReconcilerHooksClient(connection),
 		restoreJobHooksClient: restore.NewRestoreJobHooksClient(connection),
+		credentialsClient:     credentials.NewClient(connection),
 	}
 
 	return result, err
@@ -328,6 +333,10 @@ func (pluginData *data) RestoreJobHooksClient() restore.RestoreJobHooksClient {
 	return pluginData.restoreJobHooksClient
 }
 
+func (pluginData *data) CredentialsClient() credentials.Client {
+	return pluginData.credentialsClient
+}
+
 func (pluginData *data) ReconcilerHooksClient() reconciler.ReconcilerHooksClient {
 	return pluginData.reconcilerHooksClient
 }
@@ -0,0 +1,172 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package credentials
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/encoding"
+)
+
+const (
+	// ServiceName is the fully qualified name of the credentials service
+	ServiceName = "cnpgi.credentials.v1.Credentials"
+
+	// CodecName is the name of the codec used by the credentials service,
+	// whose messages are transmitted with the `application/grpc+json`
+	// content type
+	CodecName = "json"
+
+	getCredentialsMethod = "/" + ServiceName + "/GetCredentials"
+)
+
+// GetCredentialsRequest asks the plugin for the content of a secret
+// used by a cluster
+type GetCredentialsRequest struct {
+	// The JSON definition of the cluster
+	ClusterDefinition []byte `json:"clusterDefinition"`
+
+	// The name of the Kubernetes secret that would
+	// contain the credentials
+	SecretName string `json:"secretName"`
+}
+
+// GetCredentialsResult is the content of a secret as supplied by a plugin
+type GetCredentialsResult struct {
+	// Found is false when the plugin doesn't manage the requested secret,
+	// and the Kubernetes secret should be used instead
+	Found bool `json:"found"`
+
+	// The content of the secret, using the same keys of the Kubernetes one
+	Data map[string][]byte `json:"data,omitempty"`
+
+	// An opaque identifier of the current version of the credentials, which
+	// changes every time the credentials are rotated
+	Version string `json:"version,omitempty"`
+}
+
+// Client is the client API of the credentials service
+type Client interface {
+	// GetCredentials gets the content of a secret used by a cluster
+	GetCredentials(
+		ctx context.Context,
+		request *GetCredentialsRequest,
+		opts ...grpc.CallOption,
+	) (*GetCredentialsResult, error)
+}
+
+// Server is the server API of the credentials service
+type Server interface {
+	// GetCredentials gets the content of a secret used by a cluster
+	GetCredentials(ctx context.Context, request *GetCredentialsRequest) (*GetCredentialsResult, error)
+}
+
+// codec encodes the messages of the credentials service as JSON
+// documents, so that the service can be defined without generated code
+type codec struct{}
+
+func (codec) Marshal(v any) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (codec) Unmarshal(data []byte, v any) error {
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("while decoding credentials service message: %w", err)
+	}
+	return nil
+}
+
+func (codec) Name() string {
+	return CodecName
+}
+
+// The gRPC servers choose the codec from the content type of the
+// request, so it must be registered before they are started
+func init() {
+	encoding.RegisterCodec(codec{})
+}
+
+type client struct {
+	cc grpc.ClientConnInterface
+}
+
+// NewClient creates a new client for the credentials service
+func NewClient(cc grpc.ClientConnInterface) Client {
+	return &client{cc: cc}
+}
+
+func (c *client) GetCredentials(
+	ctx context.Context,
+	request *GetCredentialsRequest,
+	opts ...grpc.CallOption,
+) (*GetCredentialsResult, error) {
+	var result GetCredentialsResult
+	opts = append(opts, grpc.ForceCodec(codec{}))
+	if err := c.cc.Invoke(ctx, getCredentialsMethod, request, &result, opts...); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// RegisterServer registers the implementation of the credentials service
+// in a gRPC server
+func RegisterServer(registrar grpc.ServiceRegistrar, server Server) {
+	registrar.RegisterService(&serviceDesc, server)
+}
+
+func getCredentialsHandler(
+	srv any,
+	ctx context.Context,
+	dec func(any) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (any, error) {
+	in := &GetCredentialsRequest{}
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+
+	handler := func(ctx context.Context, req any) (any, error) {
+		return srv.(Server).GetCredentials(ctx, req.(*GetCredentialsRequest))
+	}
+
+	if interceptor == nil {
+		return handler(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: getCredentialsMethod,
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var serviceDesc = grpc.ServiceDesc{
+	ServiceName: ServiceName,
+	HandlerType: (*Server)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetCredentials",
+			Handler:    getCredentialsHandler,
+		},
+	},
+	Metadata: "credentials.proto",
+}
@@ -44,6 +44,12 @@ const (
 	// ExpiringCheckThreshold is the default threshold to consider a certificate as expiring
 	ExpiringCheckThreshold = 7
 
+	// CARotationGracePeriod is the default number of hours the trust bundle
+	// of a rotated CA is given to propagate before the new CA signs the
+	// leaf certificates, and during which the old CA is kept in the bundle
+	// after the leaf certificates have been reissued
+	CARotationGracePeriod = 24
+
 	// DefaultKubernetesClusterDomain is the default value used as
 	// Kubernetes cluster domain.
 	DefaultKubernetesClusterDomain = "cluster.local"
@@ -133,6 +139,11 @@ type Data struct {
 	// Threshold to consider a certificate as expiring
 	ExpiringCheckThreshold int `json:"expiringCheckThreshold" env:"EXPIRING_CHECK_THRESHOLD"`
 
+	// The number of hours the trust bundle of a rotated CA is given to
+	// propagate before the new CA signs the leaf certificates, and during
+	// which the old CA is kept in the bundle after they have been reissued
+	CARotationGracePeriod int `json:"caRotationGracePeriod" env:"CA_ROTATION_GRACE_PERIOD"`
+
 	// CreateAnyService is true when the user wants the operator to create
 	// the <cluster-name>-any service. Defaults to false.
 	CreateAnyService bool `json:"createAnyService" env:"CREATE_ANY_SERVICE"`
@@ -168,6 +179,26 @@ type Data struct {
 
 	// DrainTaints is a list of taints the operator will watch and treat as Unschedule
 	DrainTaints []string `json:"drainTaints" env:"DRAIN_TAINTS"`
+
+	// MaxConcurrentBackups is the maximum number of backups that can run
+	// at the same time across all the watched namespaces. The default
+	// value is 0, meaning no limit
+	MaxConcurrentBackups int `json:"maxConcurrentBackups" env:"MAX_CONCURRENT_BACKUPS"`
+
+	// MaxConcurrentBackupsPerNamespace is the maximum number of backups
+	// that can run at the same time in a namespace. The default value is 0,
+	// meaning no limit
+	MaxConcurrentBackupsPerNamespace int `json:"maxConcurrentBackupsPerNamespace" env:"MAX_CONCURRENT_BACKUPS_PER_NAMESPACE"` //nolint
+
+	// MaxConcurrentBackupsPerStorageClass is the maximum number of backups
+	// that can run at the same time for clusters sharing the same storage
+	// class. The default value is 0, meaning no limit
+	MaxConcurrentBackupsPerStorageClass int `json:"maxConcurrentBackupsPerStorageClass" env:"MAX_CONCURRENT_BACKUPS_PER_STORAGE_CLASS"` //nolint
+
+	// ScheduledBackupsMaxJitter is the maximum delay (in seconds) randomly
+	// added to the time of each scheduled backup, to spread the backups
+	// sharing the same schedule. The default value is 0, meaning no delay
+	ScheduledBackupsMaxJitter int `json:"scheduledBackupsMaxJitter" env:"SCHEDULED_BACKUPS_MAX_JITTER"`
 }
 
 // Current is the configuration used by the operator
@@ -183,6 +214,7 @@ func newDefaultConfig() *Data {
 		CreateAnyService:        false,
 		CertificateDuration:     CertificateDuration,
 		ExpiringCheckThreshold:  ExpiringCheckThreshold,
+		CARotationGracePeriod:   CARotationGracePeriod,
 		StandbyTCPUserTimeout:   0,
 		KubernetesClusterDomain: DefaultKubernetesClusterDomain,
 		DrainTaints:             DefaultDrainTaints,
@@ -225,6 +257,20 @@ func (config *Data) GetInstancesRolloutDelay() time.Duration {
 	return time.Duration(config.InstancesRolloutDelay) * time.Second
 }
 
+// IsBackupConcurrencyLimited checks if the operator limits the number
+// of backups running at the same time
+func (config *Data) IsBackupConcurrencyLimited() bool {
+	return config.MaxConcurrentBackups > 0 ||
+		config.MaxConcurrentBackupsPerNamespace > 0 ||
+		config.MaxConcurrentBackupsPerStorageClass > 0
+}
+
+// GetScheduledBackupsMaxJitter gets the maximum delay randomly added to
+// the time of each scheduled backup
+func (config *Data) GetScheduledBackupsMaxJitter() time.Duration {
+	return time.Duration(config.ScheduledBackupsMaxJitter) * time.Second
+}
+
 // WatchedNamespaces get the list of additional watched namespaces.
 // The result is a list of namespaces specified in the WATCHED_NAMESPACE where
 // each namespace is separated by comma
@@ -171,3 +171,22 @@ var _ = Describe("Annotation and label inheritance", func() {
 		Expect(config.GetInstancesRolloutDelay()).To(BeZero())
 	})
 })
+
+var _ = Describe("Backup concurrency", func() {
+	It("is not limited by default", func() {
+		config := Data{}
+		Expect(config.IsBackupConcurrencyLimited()).To(BeFalse())
+		Expect(config.GetScheduledBackupsMaxJitter()).To(BeZero())
+	})
+
+	It("is limited when any of the limits is set", func() {
+		Expect((&Data{MaxConcurrentBackups: 10}).IsBackupConcurrencyLimited()).To(BeTrue())
+		Expect((&Data{MaxConcurrentBackupsPerNamespace: 2}).IsBackupConcurrencyLimited()).To(BeTrue())
+		Expect((&Data{MaxConcurrentBackupsPerStorageClass: 5}).IsBackupConcurrencyLimited()).To(BeTrue())
+	})
+
+	It("returns the maximum jitter of the scheduled backups", func() {
+		config := Data{ScheduledBackupsMaxJitter: 300}
+		Expect(config.GetScheduledBackupsMaxJitter()).To(Equal(5 * time.Minute))
+	})
+})
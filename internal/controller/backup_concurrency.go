@@ -0,0 +1,316 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/internal/configuration"
+)
+
+// backupConcurrencyUsage counts the backups holding the concurrency slots
+// of the operator, in total, per namespace and per storage class
+type backupConcurrencyUsage struct {
+	total          int
+	namespaces     map[string]int
+	storageClasses map[string]int
+}
+
+// backupConcurrencySlot is the set of concurrency slots needed by a backup.
+// The storage class is nil when the cluster of the backup doesn't exist
+type backupConcurrencySlot struct {
+	namespace    string
+	storageClass *string
+}
+
+func newBackupConcurrencyUsage() *backupConcurrencyUsage {
+	return &backupConcurrencyUsage{
+		namespaces:     make(map[string]int),
+		storageClasses: make(map[string]int),
+	}
+}
+
+// add records the slots taken by a backup
+func (usage *backupConcurrencyUsage) add(slot backupConcurrencySlot) {
+	usage.total++
+	usage.namespaces[slot.namespace]++
+	if slot.storageClass != nil {
+		usage.storageClasses[*slot.storageClass]++
+	}
+}
+
+// getQueueReason returns why a backup needing the passed slots can't be
+// started, or an empty string when it can
+func (usage *backupConcurrencyUsage) getQueueReason(
+	config *configuration.Data,
+	slot backupConcurrencySlot,
+) string {
+	switch {
+	case config.MaxConcurrentBackups > 0 && usage.total >= config.MaxConcurrentBackups:
+		return fmt.Sprintf("maximum number of concurrent backups reached (%d)",
+			config.MaxConcurrentBackups)
+	case config.MaxConcurrentBackupsPerNamespace > 0 &&
+		usage.namespaces[slot.namespace] >= config.MaxConcurrentBackupsPerNamespace:
+		return fmt.Sprintf("maximum number of concurrent backups in the namespace reached (%d)",
+			config.MaxConcurrentBackupsPerNamespace)
+	case config.MaxConcurrentBackupsPerStorageClass > 0 && slot.storageClass != nil &&
+		usage.storageClasses[*slot.storageClass] >= config.MaxConcurrentBackupsPerStorageClass:
+		return fmt.Sprintf("maximum number of concurrent backups in the storage class reached (%d)",
+			config.MaxConcurrentBackupsPerStorageClass)
+	}
+
+	return ""
+}
+
+// isBackupHoldingConcurrencySlot checks if a backup has been admitted by
+// the concurrency limits of the operator, and is then counted against them.
+// The pending phase is only set once a backup has been admitted
+func isBackupHoldingConcurrencySlot(backup *apiv1.Backup) bool {
+	switch backup.Status.Phase {
+	case apiv1.BackupPhasePending, apiv1.BackupPhaseStarted, apiv1.BackupPhaseRunning, apiv1.BackupPhaseFinalizing:
+		return true
+	}
+	return false
+}
+
+// isBackupQueuedBefore checks if a backup has been queued before another
+// one, and will then be started first when a slot is available
+func isBackupQueuedBefore(backup, other *apiv1.Backup) bool {
+	if backup.Status.Phase != apiv1.BackupPhaseQueued {
+		return false
+	}
+	if !backup.CreationTimestamp.Equal(&other.CreationTimestamp) {
+		return backup.CreationTimestamp.Before(&other.CreationTimestamp)
+	}
+	return backup.Namespace+"/"+backup.Name < other.Namespace+"/"+other.Name
+}
+
+// getClusterStorageClass gets the storage class of the data volumes of a
+// cluster, where an empty string stands for the default storage class
+func getClusterStorageClass(cluster *apiv1.Cluster) string {
+	if cluster.Spec.StorageConfiguration.StorageClass == nil {
+		return ""
+	}
+	return *cluster.Spec.StorageConfiguration.StorageClass
+}
+
+// getBackupQueueReason checks the concurrency limits of the operator,
+// returning why the backup needs to wait before being started, or an
+// empty string when it can start right away.
+// Backups are started in the order they have been queued: the queued
+// backups preceding this one are replayed in order, and only the ones
+// which can be started take a slot, so that a backup blocked by the
+// limit of its namespace or storage class doesn't hold back the others.
+// The backups are read bypassing the cache, as the decision must take
+// into account the slots claimed by the previous reconciliation loops
+func (r *BackupReconciler) getBackupQueueReason(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+	backup *apiv1.Backup,
+) (string, error) {
+	config := configuration.Current
+	if !config.IsBackupConcurrencyLimited() {
+		return "", nil
+	}
+
+	var backups apiv1.BackupList
+	if err := r.getUncachedReader().List(ctx, &backups); err != nil {
+		return "", fmt.Errorf("while listing backups: %w", err)
+	}
+
+	storageClasses := map[client.ObjectKey]*string{
+		client.ObjectKeyFromObject(cluster): ptr.To(getClusterStorageClass(cluster)),
+	}
+	getSlot := func(item *apiv1.Backup) (backupConcurrencySlot, error) {
+		slot := backupConcurrencySlot{namespace: item.Namespace}
+		if config.MaxConcurrentBackupsPerStorageClass == 0 {
+			return slot, nil
+		}
+
+		var err error
+		slot.storageClass, err = r.getBackupStorageClass(ctx, item, storageClasses)
+		return slot, err
+	}
+
+	usage := newBackupConcurrencyUsage()
+	var queue []*apiv1.Backup
+	for idx := range backups.Items {
+		item := &backups.Items[idx]
+		if item.Namespace == backup.Namespace && item.Name == backup.Name {
+			continue
+		}
+
+		switch {
+		case isBackupHoldingConcurrencySlot(item):
+			slot, err := getSlot(item)
+			if err != nil {
+				return "", err
+			}
+			usage.add(slot)
+
+		case isBackupQueuedBefore(item, backup):
+			queue = append(queue, item)
+		}
+	}
+
+	slices.SortFunc(queue, func(a, b *apiv1.Backup) int {
+		if isBackupQueuedBefore(a, b) {
+			return -1
+		}
+		return 1
+	})
+	for _, item := range queue {
+		slot, err := getSlot(item)
+		if err != nil {
+			return "", err
+		}
+		if usage.getQueueReason(config, slot) == "" {
+			usage.add(slot)
+		}
+	}
+
+	slot, err := getSlot(backup)
+	if err != nil {
+		return "", err
+	}
+	return usage.getQueueReason(config, slot), nil
+}
+
+// getBackupStorageClass gets the storage class of the cluster of a backup,
+// caching it for the backups of the same cluster. The returned storage
+// class is nil when the cluster doesn't exist anymore
+func (r *BackupReconciler) getBackupStorageClass(
+	ctx context.Context,
+	backup *apiv1.Backup,
+	storageClasses map[client.ObjectKey]*string,
+) (*string, error) {
+	key := client.ObjectKey{Namespace: backup.Namespace, Name: backup.Spec.Cluster.Name}
+	if storageClass, ok := storageClasses[key]; ok {
+		return storageClass, nil
+	}
+
+	var cluster apiv1.Cluster
+	if err := r.Get(ctx, key, &cluster); err != nil {
+		if !apierrs.IsNotFound(err) {
+			return nil, err
+		}
+		storageClasses[key] = nil
+	} else {
+		storageClasses[key] = ptr.To(getClusterStorageClass(&cluster))
+	}
+
+	return storageClasses[key], nil
+}
+
+// isBackupWaitingToStart checks if a backup has not been started yet
+func isBackupWaitingToStart(backup *apiv1.Backup) bool {
+	switch backup.Status.Phase {
+	case "", apiv1.BackupPhasePending, apiv1.BackupPhaseQueued:
+		return true
+	}
+	return false
+}
+
+// reconcileBackupQueue moves a backup to the queued phase when starting it
+// would exceed the concurrency limits of the operator, returning a non-nil
+// result while the backup needs to wait.
+// When the backup can be started, it claims its concurrency slot moving to
+// the pending phase before anything else is done. The status is patched
+// with an optimistic lock, so that the decision is taken again when the
+// backup has been changed in the meantime
+func (r *BackupReconciler) reconcileBackupQueue(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+	backup *apiv1.Backup,
+) (*ctrl.Result, error) {
+	contextLogger := log.FromContext(ctx)
+
+	if !configuration.Current.IsBackupConcurrencyLimited() ||
+		backup.Status.Phase == apiv1.BackupPhasePending {
+		return nil, nil
+	}
+
+	var reason string
+	err := retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		if err := r.getUncachedReader().Get(ctx, client.ObjectKeyFromObject(backup), backup); err != nil {
+			return err
+		}
+		if !isBackupWaitingToStart(backup) || backup.Status.Phase == apiv1.BackupPhasePending {
+			reason = ""
+			return nil
+		}
+
+		var err error
+		if reason, err = r.getBackupQueueReason(ctx, cluster, backup); err != nil {
+			return err
+		}
+
+		origBackup := backup.DeepCopy()
+		if reason == "" {
+			backup.Status.Phase = apiv1.BackupPhasePending
+		} else {
+			backup.Status.Phase = apiv1.BackupPhaseQueued
+		}
+		if backup.Status.Phase == origBackup.Status.Phase {
+			return nil
+		}
+
+		if err := r.Status().Patch(
+			ctx,
+			backup,
+			client.MergeFromWithOptions(origBackup, client.MergeFromWithOptimisticLock{}),
+		); err != nil {
+			return err
+		}
+		if reason != "" {
+			r.Recorder.Eventf(backup, "Normal", "BackupQueued", "Backup queued: %s", reason)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if reason == "" {
+		return nil, nil
+	}
+
+	contextLogger.Info("Backup queued, will retry in 30 seconds", "reason", reason)
+	return &ctrl.Result{RequeueAfter: 30 * time.Second}, nil
+}
+
+// getUncachedReader gets the reader used to take the decisions about
+// the concurrency limits, which must not be affected by stale data
+func (r *BackupReconciler) getUncachedReader() client.Reader {
+	if r.APIReader == nil {
+		return r.Client
+	}
+	return r.APIReader
+}
@@ -0,0 +1,280 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/internal/configuration"
+	schemeBuilder "github.com/cloudnative-pg/cloudnative-pg/internal/scheme"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("backup concurrency limits", func() {
+	var (
+		reconciler *BackupReconciler
+		cli        client.Client
+		cluster    *apiv1.Cluster
+		backup     *apiv1.Backup
+		now        time.Time
+	)
+
+	newCluster := func(namespace, name, storageClass string) *apiv1.Cluster {
+		return &apiv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+			Spec: apiv1.ClusterSpec{
+				StorageConfiguration: apiv1.StorageConfiguration{StorageClass: ptr.To(storageClass)},
+			},
+		}
+	}
+
+	newBackup := func(cluster *apiv1.Cluster, name, phase string, created time.Time) *apiv1.Backup {
+		return &apiv1.Backup{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:         cluster.Namespace,
+				Name:              name,
+				CreationTimestamp: metav1.NewTime(created),
+			},
+			Spec:   apiv1.BackupSpec{Cluster: apiv1.LocalObjectReference{Name: cluster.Name}},
+			Status: apiv1.BackupStatus{Phase: apiv1.BackupPhase(phase)},
+		}
+	}
+
+	BeforeEach(func() {
+		configuration.Current = configuration.NewConfiguration()
+		DeferCleanup(func() {
+			configuration.Current = configuration.NewConfiguration()
+		})
+
+		now = time.Now().Truncate(time.Second)
+		cluster = newCluster("first", "cluster-example", "fast")
+		backup = newBackup(cluster, "backup", "", now)
+	})
+
+	build := func(objects ...client.Object) {
+		cli = fake.NewClientBuilder().
+			WithScheme(schemeBuilder.BuildWithAllKnownScheme()).
+			WithObjects(objects...).
+			WithStatusSubresource(&apiv1.Backup{}).
+			Build()
+		reconciler = &BackupReconciler{
+			Client:   cli,
+			Recorder: record.NewFakeRecorder(120),
+		}
+	}
+
+	It("doesn't queue backups without limits", func(ctx SpecContext) {
+		build(cluster, backup, newBackup(cluster, "running", apiv1.BackupPhaseRunning, now))
+
+		reason, err := reconciler.getBackupQueueReason(ctx, cluster, backup)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(reason).To(BeEmpty())
+	})
+
+	It("enforces the global limit", func(ctx SpecContext) {
+		configuration.Current.MaxConcurrentBackups = 2
+		other := newCluster("second", "cluster-other", "slow")
+		build(cluster, other, backup,
+			newBackup(cluster, "running", apiv1.BackupPhaseRunning, now),
+			newBackup(other, "completed", apiv1.BackupPhaseCompleted, now))
+
+		reason, err := reconciler.getBackupQueueReason(ctx, cluster, backup)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(reason).To(BeEmpty())
+
+		build(cluster, other, backup,
+			newBackup(cluster, "running", apiv1.BackupPhaseRunning, now),
+			newBackup(other, "started", apiv1.BackupPhaseStarted, now))
+		reason, err = reconciler.getBackupQueueReason(ctx, cluster, backup)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(reason).To(ContainSubstring("maximum number of concurrent backups reached"))
+	})
+
+	It("enforces the namespace limit", func(ctx SpecContext) {
+		configuration.Current.MaxConcurrentBackupsPerNamespace = 1
+		other := newCluster("second", "cluster-other", "fast")
+		build(cluster, other, backup, newBackup(other, "running", apiv1.BackupPhaseRunning, now))
+
+		reason, err := reconciler.getBackupQueueReason(ctx, cluster, backup)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(reason).To(BeEmpty())
+
+		build(cluster, other, backup, newBackup(cluster, "finalizing", apiv1.BackupPhaseFinalizing, now))
+		reason, err = reconciler.getBackupQueueReason(ctx, cluster, backup)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(reason).To(ContainSubstring("in the namespace"))
+	})
+
+	It("enforces the storage class limit", func(ctx SpecContext) {
+		configuration.Current.MaxConcurrentBackupsPerStorageClass = 1
+		slow := newCluster("second", "cluster-slow", "slow")
+		fast := newCluster("second", "cluster-fast", "fast")
+		build(cluster, slow, fast, backup, newBackup(slow, "running", apiv1.BackupPhaseRunning, now))
+
+		reason, err := reconciler.getBackupQueueReason(ctx, cluster, backup)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(reason).To(BeEmpty())
+
+		build(cluster, slow, fast, backup, newBackup(fast, "running", apiv1.BackupPhaseRunning, now))
+		reason, err = reconciler.getBackupQueueReason(ctx, cluster, backup)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(reason).To(ContainSubstring("in the storage class"))
+	})
+
+	It("starts the queued backups in order", func(ctx SpecContext) {
+		configuration.Current.MaxConcurrentBackups = 1
+		older := newBackup(cluster, "older", apiv1.BackupPhaseQueued, now.Add(-time.Minute))
+		newer := newBackup(cluster, "newer", apiv1.BackupPhaseQueued, now.Add(time.Minute))
+		build(cluster, backup, older, newer)
+
+		reason, err := reconciler.getBackupQueueReason(ctx, cluster, backup)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(reason).ToNot(BeEmpty())
+
+		reason, err = reconciler.getBackupQueueReason(ctx, cluster, older)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(reason).To(BeEmpty())
+	})
+
+	It("doesn't count the queued backups blocked by their namespace limit", func(ctx SpecContext) {
+		configuration.Current.MaxConcurrentBackups = 2
+		configuration.Current.MaxConcurrentBackupsPerNamespace = 1
+		other := newCluster("second", "cluster-other", "fast")
+		build(cluster, other, backup,
+			newBackup(other, "running", apiv1.BackupPhaseRunning, now),
+			newBackup(other, "blocked", apiv1.BackupPhaseQueued, now.Add(-time.Minute)))
+
+		reason, err := reconciler.getBackupQueueReason(ctx, cluster, backup)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(reason).To(BeEmpty())
+	})
+
+	It("counts the pending backups as holding a slot", func(ctx SpecContext) {
+		configuration.Current.MaxConcurrentBackups = 1
+		build(cluster, backup, newBackup(cluster, "pending", apiv1.BackupPhasePending, now))
+
+		reason, err := reconciler.getBackupQueueReason(ctx, cluster, backup)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(reason).To(ContainSubstring("maximum number of concurrent backups reached"))
+	})
+
+	It("claims the slot before starting the backup", func(ctx SpecContext) {
+		configuration.Current.MaxConcurrentBackups = 1
+		second := newBackup(cluster, "second", "", now)
+		build(cluster, backup, second)
+
+		res, err := reconciler.reconcileBackupQueue(ctx, cluster, backup)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(res).To(BeNil())
+		Expect(backup.Status.Phase).To(BeEquivalentTo(apiv1.BackupPhasePending))
+
+		res, err = reconciler.reconcileBackupQueue(ctx, cluster, second)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(res).ToNot(BeNil())
+		Expect(second.Status.Phase).To(BeEquivalentTo(apiv1.BackupPhaseQueued))
+	})
+
+	It("takes the decision again when the backup changed while claiming the slot", func(ctx SpecContext) {
+		configuration.Current.MaxConcurrentBackups = 1
+		build(cluster, backup)
+
+		conflicts := 0
+		cli = interceptor.NewClient(cli.(client.WithWatch), interceptor.Funcs{
+			SubResourcePatch: func(
+				ctx context.Context,
+				c client.Client,
+				subResourceName string,
+				obj client.Object,
+				patch client.Patch,
+				opts ...client.SubResourcePatchOption,
+			) error {
+				if conflicts == 0 {
+					conflicts++
+					return apierrs.NewConflict(apiv1.SchemeGroupVersion.WithResource("backups").GroupResource(),
+						obj.GetName(), errors.New("changed"))
+				}
+				return c.SubResource(subResourceName).Patch(ctx, obj, patch, opts...)
+			},
+		})
+		reconciler.Client = cli
+
+		res, err := reconciler.reconcileBackupQueue(ctx, cluster, backup)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(res).To(BeNil())
+		Expect(conflicts).To(Equal(1))
+
+		var updatedBackup apiv1.Backup
+		Expect(cli.Get(ctx, client.ObjectKeyFromObject(backup), &updatedBackup)).To(Succeed())
+		Expect(updatedBackup.Status.Phase).To(BeEquivalentTo(apiv1.BackupPhasePending))
+	})
+
+	It("moves the backup to the queued phase", func(ctx SpecContext) {
+		configuration.Current.MaxConcurrentBackups = 1
+		build(cluster, backup, newBackup(cluster, "running", apiv1.BackupPhaseRunning, now))
+
+		res, err := reconciler.reconcileBackupQueue(ctx, cluster, backup)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(res).ToNot(BeNil())
+		Expect(res.RequeueAfter).To(Equal(30 * time.Second))
+
+		var updatedBackup apiv1.Backup
+		Expect(cli.Get(ctx, client.ObjectKeyFromObject(backup), &updatedBackup)).To(Succeed())
+		Expect(updatedBackup.Status.Phase).To(BeEquivalentTo(apiv1.BackupPhaseQueued))
+	})
+})
+
+var _ = Describe("scheduled backup jitter", func() {
+	scheduledBackup := &apiv1.ScheduledBackup{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "nightly"},
+	}
+	scheduledTime := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+
+	BeforeEach(func() {
+		configuration.Current = configuration.NewConfiguration()
+		DeferCleanup(func() {
+			configuration.Current = configuration.NewConfiguration()
+		})
+	})
+
+	It("is disabled by default", func() {
+		Expect(getScheduleJitter(scheduledBackup, scheduledTime)).To(BeZero())
+	})
+
+	It("is stable and within the configured maximum", func() {
+		configuration.Current.ScheduledBackupsMaxJitter = 600
+		jitter := getScheduleJitter(scheduledBackup, scheduledTime)
+		Expect(jitter).To(BeNumerically(">=", 0))
+		Expect(jitter).To(BeNumerically("<", 10*time.Minute))
+		Expect(getScheduleJitter(scheduledBackup, scheduledTime)).To(Equal(jitter))
+	})
+})
@@ -70,6 +70,9 @@ type BackupReconciler struct {
 	client.Client
 	DiscoveryClient discovery.DiscoveryInterface
 
+	// APIReader reads the objects bypassing the cache
+	APIReader client.Reader
+
 	Scheme   *runtime.Scheme
 	Recorder record.EventRecorder
 	Plugins  repository.Interface
@@ -85,6 +88,7 @@ func NewBackupReconciler(
 ) *BackupReconciler {
 	return &BackupReconciler{
 		Client:               mgr.GetClient(),
+		APIReader:            mgr.GetAPIReader(),
 		DiscoveryClient:      discoveryClient,
 		Scheme:               mgr.GetScheme(),
 		Recorder:             mgr.GetEventRecorderFor("cloudnative-pg-backup"),
@@ -120,6 +124,12 @@ func (r *BackupReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 		return ctrl.Result{}, nil
 	}
 
+	// The status of an exported backup is copied from its source by the
+	// BackupExport, there is nothing to take here
+	if backup.IsExported() {
+		return ctrl.Result{}, nil
+	}
+
 	clusterName := backup.Spec.Cluster.Name
 	var cluster apiv1.Cluster
 	if err := r.Get(ctx, client.ObjectKey{
@@ -138,7 +148,7 @@ func (r *BackupReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 		return ctrl.Result{}, nil
 	}
 
-	if backup.Spec.Method == apiv1.BackupMethodPlugin && len(cluster.Spec.Plugins) == 0 {
+	if backup.IsPluginBased() && len(cluster.Spec.Plugins) == 0 {
 		message := "cannot proceed with the backup as the cluster has no plugin configured"
 		contextLogger.Warning(message)
 		r.Recorder.Event(&backup, "Warning", "ClusterHasNoBackupExecutorPlugin", message)
@@ -146,7 +156,7 @@ func (r *BackupReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 		return ctrl.Result{}, nil
 	}
 
-	if backup.Spec.Method != apiv1.BackupMethodPlugin && cluster.Spec.Backup == nil {
+	if !backup.IsPluginBased() && cluster.Spec.Backup == nil {
 		message := "cannot proceed with the backup as the cluster has no backup section"
 		contextLogger.Warning(message)
 		r.Recorder.Event(&backup, "Warning", "ClusterHasBackupConfigured", message)
@@ -196,12 +206,31 @@ func (r *BackupReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 		return ctrl.Result{}, err
 	}
 
+	// Authenticate the operator against the instance manager
+	ctx, err = certs.NewOperatorClientTLSConfigForContext(
+		ctx,
+		r.Client,
+		cluster.GetOperatorClientCASecretObjectKey(),
+	)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
 	isRunning, err := r.isValidBackupRunning(ctx, &backup, &cluster)
 	if err != nil {
 		contextLogger.Error(err, "while running isValidBackupRunning")
 		return ctrl.Result{}, err
 	}
 
+	if !isRunning && isBackupWaitingToStart(&backup) {
+		if res, err := r.reconcileBackupQueue(ctx, &cluster, &backup); res != nil || err != nil {
+			if res == nil {
+				res = &ctrl.Result{}
+			}
+			return *res, err
+		}
+	}
+
 	if backup.Spec.Method == apiv1.BackupMethodBarmanObjectStore {
 		if cluster.Spec.Backup == nil || cluster.Spec.Backup.BarmanObjectStore == nil {
 			tryFlagBackupAsFailed(ctx, r.Client, &backup,
@@ -226,13 +255,43 @@ func (r *BackupReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 			"Starting backup for cluster %v", cluster.Name)
 	}
 
+	if backup.Spec.Method == apiv1.BackupMethodLogical {
+		if !backup.IsPluginBased() && cluster.GetLogicalBackupVolumeClaimName() == "" {
+			tryFlagBackupAsFailed(ctx, r.Client, &backup,
+				errors.New("no logical section defined on the target cluster"))
+			return ctrl.Result{}, nil
+		}
+
+		if isRunning {
+			return ctrl.Result{}, nil
+		}
+
+		r.Recorder.Eventf(&backup, "Normal", "Starting",
+			"Starting logical backup for cluster %v", cluster.Name)
+	}
+
+	if backup.Spec.Method == apiv1.BackupMethodPersistentVolume {
+		if isRunning {
+			return ctrl.Result{}, nil
+		}
+
+		if err := r.validatePersistentVolumeBackup(ctx, &cluster, &backup); err != nil {
+			tryFlagBackupAsFailed(ctx, r.Client, &backup, err)
+			return ctrl.Result{}, nil
+		}
+
+		r.Recorder.Eventf(&backup, "Normal", "Starting",
+			"Starting base backup for cluster %v", cluster.Name)
+	}
+
 	origBackup := backup.DeepCopy()
 
-	// From now on, we differentiate backups managed by the instance manager (barman and plugins)
-	// from the ones managed directly by the operator (VolumeSnapshot)
+	// From now on, we differentiate backups managed by the instance manager (barman, plugins,
+	// logical and persistent volume) from the ones managed directly by the operator (VolumeSnapshot)
 
 	switch backup.Spec.Method {
-	case apiv1.BackupMethodBarmanObjectStore, apiv1.BackupMethodPlugin:
+	case apiv1.BackupMethodBarmanObjectStore, apiv1.BackupMethodPlugin, apiv1.BackupMethodLogical,
+		apiv1.BackupMethodPersistentVolume:
 		// If no good running backups are found we elect a pod for the backup
 		pod, err := r.getBackupTargetPod(ctx, &cluster, &backup)
 		if apierrs.IsNotFound(err) {
@@ -367,6 +426,53 @@ func (r *BackupReconciler) isValidBackupRunning(
 	return false, nil
 }
 
+// validatePersistentVolumeBackup checks whether a base backup can be taken
+// into the backup volume of the cluster, including the parent backup of
+// an incremental one
+func (r *BackupReconciler) validatePersistentVolumeBackup(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+	backup *apiv1.Backup,
+) error {
+	if cluster.GetPersistentVolumeBackupClaimName() == "" {
+		return errors.New("no persistentVolume section defined on the target cluster")
+	}
+
+	if len(cluster.Spec.Tablespaces) > 0 {
+		return errors.New("persistentVolume backups are not supported for clusters with tablespaces")
+	}
+
+	if backup.Spec.ParentBackup == nil {
+		return nil
+	}
+
+	pgVersion, err := cluster.GetPostgresqlVersion()
+	if err != nil {
+		return err
+	}
+	if pgVersion.Major() < 17 {
+		return errors.New("incremental backups require PostgreSQL 17 or later")
+	}
+
+	var parentBackup apiv1.Backup
+	if err := r.Get(ctx, client.ObjectKey{
+		Namespace: backup.Namespace,
+		Name:      backup.Spec.ParentBackup.Name,
+	}, &parentBackup); err != nil {
+		return fmt.Errorf("while getting the parent backup %s: %w", backup.Spec.ParentBackup.Name, err)
+	}
+
+	if parentBackup.Spec.Cluster.Name != cluster.Name {
+		return fmt.Errorf("parent backup %s belongs to a different cluster", parentBackup.Name)
+	}
+
+	if !parentBackup.IsCompletedPersistentVolumeBackup() {
+		return fmt.Errorf("parent backup %s is not a completed persistentVolume backup", parentBackup.Name)
+	}
+
+	return nil
+}
+
 func (r *BackupReconciler) reconcileSnapshotBackup(
 	ctx context.Context,
 	cluster *apiv1.Cluster,
@@ -425,7 +531,7 @@ func (r *BackupReconciler) reconcileSnapshotBackup(
 		return nil, fmt.Errorf("target pod lacks container statuses")
 	}
 
-	if len(backup.Status.Phase) == 0 || backup.Status.Phase == apiv1.BackupPhasePending {
+	if isBackupWaitingToStart(backup) {
 		backup.Status.SetAsStarted(
 			targetPod.Name,
 			targetPod.Status.ContainerStatuses[0].ContainerID,
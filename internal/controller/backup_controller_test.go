@@ -438,3 +438,99 @@ var _ = Describe("update snapshot backup metadata", func() {
 			To(Equal(oneHourAgo))
 	})
 })
+
+var _ = Describe("backup_controller persistentVolume unit tests", func() {
+	var (
+		env     *testingEnvironment
+		cluster *apiv1.Cluster
+		backup  *apiv1.Backup
+	)
+
+	BeforeEach(func() {
+		env = buildTestEnvironment()
+		namespace := newFakeNamespace(env.client)
+
+		cluster = &apiv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "cluster-example",
+				Namespace: namespace,
+			},
+			Spec: apiv1.ClusterSpec{
+				ImageName: "ghcr.io/cloudnative-pg/postgresql:17.4",
+				Backup: &apiv1.BackupConfiguration{
+					PersistentVolume: &apiv1.PersistentVolumeBackupConfiguration{
+						VolumeClaimName: "base-backups",
+					},
+				},
+			},
+		}
+
+		backup = &apiv1.Backup{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "incremental",
+				Namespace: namespace,
+			},
+			Spec: apiv1.BackupSpec{
+				Cluster:      apiv1.LocalObjectReference{Name: cluster.Name},
+				Method:       apiv1.BackupMethodPersistentVolume,
+				ParentBackup: &apiv1.LocalObjectReference{Name: "full"},
+			},
+		}
+	})
+
+	createParentBackup := func(ctx context.Context, phase apiv1.BackupPhase) {
+		parentBackup := &apiv1.Backup{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "full",
+				Namespace: cluster.Namespace,
+			},
+			Spec: apiv1.BackupSpec{
+				Cluster: apiv1.LocalObjectReference{Name: cluster.Name},
+				Method:  apiv1.BackupMethodPersistentVolume,
+			},
+		}
+		Expect(env.client.Create(ctx, parentBackup)).To(Succeed())
+
+		parentBackup.Status = apiv1.BackupStatus{
+			Phase: phase,
+			PersistentVolumeBackupStatus: &apiv1.PersistentVolumeBackupStatus{
+				VolumeClaimName: "base-backups",
+				Path:            "cluster-example/full",
+			},
+		}
+		Expect(env.client.Status().Update(ctx, parentBackup)).To(Succeed())
+	}
+
+	It("accepts a full backup", func(ctx context.Context) {
+		backup.Spec.ParentBackup = nil
+		Expect(env.backupReconciler.validatePersistentVolumeBackup(ctx, cluster, backup)).To(Succeed())
+	})
+
+	It("accepts an incremental backup based on a completed one", func(ctx context.Context) {
+		createParentBackup(ctx, apiv1.BackupPhaseCompleted)
+		Expect(env.backupReconciler.validatePersistentVolumeBackup(ctx, cluster, backup)).To(Succeed())
+	})
+
+	It("rejects backups when the backup volume is not configured", func(ctx context.Context) {
+		cluster.Spec.Backup.PersistentVolume = nil
+		err := env.backupReconciler.validatePersistentVolumeBackup(ctx, cluster, backup)
+		Expect(err).To(MatchError(ContainSubstring("no persistentVolume section")))
+	})
+
+	It("rejects incremental backups before PostgreSQL 17", func(ctx context.Context) {
+		cluster.Spec.ImageName = "ghcr.io/cloudnative-pg/postgresql:16.8"
+		createParentBackup(ctx, apiv1.BackupPhaseCompleted)
+		err := env.backupReconciler.validatePersistentVolumeBackup(ctx, cluster, backup)
+		Expect(err).To(MatchError(ContainSubstring("PostgreSQL 17")))
+	})
+
+	It("rejects incremental backups based on a missing backup", func(ctx context.Context) {
+		Expect(env.backupReconciler.validatePersistentVolumeBackup(ctx, cluster, backup)).ToNot(Succeed())
+	})
+
+	It("rejects incremental backups based on a backup not yet completed", func(ctx context.Context) {
+		createParentBackup(ctx, apiv1.BackupPhaseRunning)
+		err := env.backupReconciler.validatePersistentVolumeBackup(ctx, cluster, backup)
+		Expect(err).To(MatchError(ContainSubstring("is not a completed")))
+	})
+})
@@ -0,0 +1,746 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	storagesnapshotv1 "github.com/kubernetes-csi/external-snapshotter/client/v8/apis/volumesnapshot/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/reconciler/persistentvolumeclaim"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+)
+
+// errBackupExportFailed is the error wrapped by the failures that can't be
+// recovered by retrying the reconciliation
+var errBackupExportFailed = errors.New("backup export failed")
+
+// BackupExportReconciler reconciles a BackupExport object
+type BackupExportReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+}
+
+// exportedSnapshot is a snapshot of the source backup together with the
+// content it is bound to
+type exportedSnapshot struct {
+	snapshot *storagesnapshotv1.VolumeSnapshot
+	content  *storagesnapshotv1.VolumeSnapshotContent
+}
+
+// +kubebuilder:rbac:groups=postgresql.cnpg.io,resources=backupexports,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=postgresql.cnpg.io,resources=backupexports/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=postgresql.cnpg.io,resources=backups,verbs=get;list;watch;create;delete
+// +kubebuilder:rbac:groups=postgresql.cnpg.io,resources=backups/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=snapshot.storage.k8s.io,resources=volumesnapshots,verbs=get;create;delete
+// +kubebuilder:rbac:groups=snapshot.storage.k8s.io,resources=volumesnapshotcontents,verbs=get;create;patch;delete
+// +kubebuilder:rbac:groups="",resources=namespaces,verbs=get
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+
+// Reconcile exports a completed volume snapshot backup into the target
+// namespace
+func (r *BackupExportReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	contextLogger, ctx := log.SetupLogger(ctx)
+
+	var backupExport apiv1.BackupExport
+	if err := r.Get(ctx, req.NamespacedName, &backupExport); err != nil {
+		if apierrs.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	if !backupExport.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, r.deleteBackupExport(ctx, &backupExport)
+	}
+
+	if backupExport.Status.IsDone() {
+		return ctrl.Result{}, nil
+	}
+
+	// The exported objects are deleted together with the backup export,
+	// so the finalizer is added before creating any of them
+	if controllerutil.AddFinalizer(&backupExport, utils.BackupExportFinalizerName) {
+		if err := r.Update(ctx, &backupExport); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	origBackupExport := backupExport.DeepCopy()
+	result, err := r.reconcileBackupExport(ctx, &backupExport)
+	if errors.Is(err, errBackupExportFailed) {
+		contextLogger.Warning("Backup export failed", "err", err.Error())
+		r.Recorder.Event(&backupExport, "Warning", "BackupExportFailed", err.Error())
+		backupExport.Status.SetPhase(apiv1.BackupExportPhaseFailed, err.Error())
+	} else if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if equality.Semantic.DeepEqual(origBackupExport.Status, backupExport.Status) {
+		return result, nil
+	}
+
+	return result, r.Status().Patch(ctx, &backupExport, client.MergeFrom(origBackupExport))
+}
+
+// reconcileBackupExport moves the backup export forward, updating its
+// status. Unrecoverable failures wrap errBackupExportFailed
+func (r *BackupExportReconciler) reconcileBackupExport(
+	ctx context.Context,
+	backupExport *apiv1.BackupExport,
+) (ctrl.Result, error) {
+	if !utils.HaveVolumeSnapshot() {
+		return ctrl.Result{}, fmt.Errorf("%w: the Kubernetes cluster has no VolumeSnapshot support", errBackupExportFailed)
+	}
+
+	if backupExport.Spec.TargetNamespace == backupExport.Namespace &&
+		backupExport.GetTargetBackupName() == backupExport.Spec.Backup.Name {
+		return ctrl.Result{}, fmt.Errorf("%w: the backup can't be exported onto itself", errBackupExportFailed)
+	}
+
+	if err := r.checkTargetNamespace(ctx, backupExport); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	var backup apiv1.Backup
+	if err := r.Get(ctx, client.ObjectKey{
+		Namespace: backupExport.Namespace,
+		Name:      backupExport.Spec.Backup.Name,
+	}, &backup); err != nil {
+		if apierrs.IsNotFound(err) {
+			return ctrl.Result{}, fmt.Errorf("%w: backup %q not found", errBackupExportFailed, backupExport.Spec.Backup.Name)
+		}
+		return ctrl.Result{}, err
+	}
+
+	if backup.Spec.Method != apiv1.BackupMethodVolumeSnapshot {
+		return ctrl.Result{}, fmt.Errorf("%w: backup %q uses the %q method, only volumeSnapshot backups can be exported",
+			errBackupExportFailed, backup.Name, backup.Spec.Method)
+	}
+
+	switch backup.Status.Phase {
+	case apiv1.BackupPhaseCompleted:
+	case apiv1.BackupPhaseFailed:
+		return ctrl.Result{}, fmt.Errorf("%w: backup %q failed: %s", errBackupExportFailed, backup.Name, backup.Status.Error)
+	default:
+		// We'll be notified when the backup completes
+		backupExport.Status.SetPhase(apiv1.BackupExportPhasePending,
+			fmt.Sprintf("waiting for backup %q to be completed", backup.Name))
+		return ctrl.Result{}, nil
+	}
+
+	if len(backup.Status.BackupSnapshotStatus.Elements) == 0 {
+		return ctrl.Result{}, fmt.Errorf("%w: backup %q has no volume snapshots", errBackupExportFailed, backup.Name)
+	}
+
+	sources, err := r.getExportedSnapshots(ctx, &backup)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if err := r.retainSourceSnapshotContents(ctx, sources); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	targetBackup, err := r.ensureTargetBackup(ctx, backupExport, &backup)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	backupExport.Status.BackupName = targetBackup.Name
+
+	contentNames := make([]string, 0, len(sources))
+	ready := true
+	for _, source := range sources {
+		content, err := r.ensureTargetSnapshotContent(ctx, backupExport, source)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		contentNames = append(contentNames, content.Name)
+
+		snapshot, err := r.ensureTargetSnapshot(ctx, backupExport, &backup, source, content.Name)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if snapshot.Status == nil || !ptr.Deref(snapshot.Status.ReadyToUse, false) {
+			ready = false
+		}
+	}
+	backupExport.Status.SnapshotContents = contentNames
+
+	if !ready {
+		backupExport.Status.SetPhase(apiv1.BackupExportPhaseExporting,
+			"waiting for the exported volume snapshots to be ready")
+		return ctrl.Result{RequeueAfter: 5 * time.Second}, nil
+	}
+
+	if err := r.completeTargetBackup(ctx, &backup, targetBackup); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	backupExport.Status.SetPhase(apiv1.BackupExportPhaseCompleted, "")
+	r.Recorder.Eventf(backupExport, "Normal", "BackupExported",
+		"Exported backup %q as %s/%s", backup.Name, targetBackup.Namespace, targetBackup.Name)
+	return ctrl.Result{}, nil
+}
+
+// getExportedSnapshots gets the snapshots of the source backup together
+// with the contents they are bound to, checking they can be imported
+// elsewhere
+func (r *BackupExportReconciler) getExportedSnapshots(
+	ctx context.Context,
+	backup *apiv1.Backup,
+) ([]exportedSnapshot, error) {
+	result := make([]exportedSnapshot, 0, len(backup.Status.BackupSnapshotStatus.Elements))
+	for _, element := range backup.Status.BackupSnapshotStatus.Elements {
+		var snapshot storagesnapshotv1.VolumeSnapshot
+		if err := r.Get(ctx, client.ObjectKey{Namespace: backup.Namespace, Name: element.Name}, &snapshot); err != nil {
+			if apierrs.IsNotFound(err) {
+				return nil, fmt.Errorf("%w: volume snapshot %q not found", errBackupExportFailed, element.Name)
+			}
+			return nil, err
+		}
+
+		if snapshot.Status == nil || !ptr.Deref(snapshot.Status.ReadyToUse, false) ||
+			ptr.Deref(snapshot.Status.BoundVolumeSnapshotContentName, "") == "" {
+			return nil, fmt.Errorf("%w: volume snapshot %q is not ready to use", errBackupExportFailed, element.Name)
+		}
+
+		var content storagesnapshotv1.VolumeSnapshotContent
+		if err := r.Get(ctx, client.ObjectKey{Name: *snapshot.Status.BoundVolumeSnapshotContentName}, &content); err != nil {
+			if apierrs.IsNotFound(err) {
+				return nil, fmt.Errorf("%w: volume snapshot content %q not found",
+					errBackupExportFailed, *snapshot.Status.BoundVolumeSnapshotContentName)
+			}
+			return nil, err
+		}
+
+		if content.Status == nil || ptr.Deref(content.Status.SnapshotHandle, "") == "" {
+			return nil, fmt.Errorf("%w: volume snapshot content %q has no snapshot handle",
+				errBackupExportFailed, content.Name)
+		}
+
+		result = append(result, exportedSnapshot{snapshot: &snapshot, content: &content})
+	}
+
+	return result, nil
+}
+
+// checkTargetNamespace checks if the target namespace accepts the backups
+// exported from the namespace of the backup export
+func (r *BackupExportReconciler) checkTargetNamespace(
+	ctx context.Context,
+	backupExport *apiv1.BackupExport,
+) error {
+	if backupExport.Spec.TargetNamespace == backupExport.Namespace {
+		return nil
+	}
+
+	var namespace corev1.Namespace
+	if err := r.Get(ctx, client.ObjectKey{Name: backupExport.Spec.TargetNamespace}, &namespace); err != nil {
+		if apierrs.IsNotFound(err) {
+			return fmt.Errorf("%w: namespace %q not found", errBackupExportFailed, backupExport.Spec.TargetNamespace)
+		}
+		return err
+	}
+
+	allowedSources := namespace.Annotations[utils.BackupExportAllowedSourcesAnnotationName]
+	for _, source := range strings.Split(allowedSources, ",") {
+		source = strings.TrimSpace(source)
+		if source == "*" || source == backupExport.Namespace {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: namespace %q doesn't accept backups exported from namespace %q, "+
+		"as it is not listed in its %s annotation",
+		errBackupExportFailed, namespace.Name, backupExport.Namespace, utils.BackupExportAllowedSourcesAnnotationName)
+}
+
+// retainSourceSnapshotContents sets the Retain deletion policy on the
+// contents of the source snapshots, recording the original one, so that
+// the storage snapshots the exported contents point to are kept when
+// the source snapshots are deleted
+func (r *BackupExportReconciler) retainSourceSnapshotContents(
+	ctx context.Context,
+	sources []exportedSnapshot,
+) error {
+	for _, source := range sources {
+		if _, ok := source.content.Annotations[utils.ExportedDeletionPolicyAnnotationName]; ok {
+			continue
+		}
+
+		origContent := source.content.DeepCopy()
+		if source.content.Annotations == nil {
+			source.content.Annotations = make(map[string]string)
+		}
+		source.content.Annotations[utils.ExportedDeletionPolicyAnnotationName] = string(source.content.Spec.DeletionPolicy)
+		source.content.Spec.DeletionPolicy = storagesnapshotv1.VolumeSnapshotContentRetain
+		if err := r.Patch(ctx, source.content, client.MergeFrom(origContent)); err != nil {
+			return fmt.Errorf("while retaining volume snapshot content %q: %w", source.content.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// ensureTargetBackup creates the backup in the target namespace, without
+// status until the exported snapshots are ready
+func (r *BackupExportReconciler) ensureTargetBackup(
+	ctx context.Context,
+	backupExport *apiv1.BackupExport,
+	backup *apiv1.Backup,
+) (*apiv1.Backup, error) {
+	exportedFrom := getBackupExportSource(backup)
+
+	var targetBackup apiv1.Backup
+	err := r.Get(ctx, client.ObjectKey{
+		Namespace: backupExport.Spec.TargetNamespace,
+		Name:      backupExport.GetTargetBackupName(),
+	}, &targetBackup)
+	switch {
+	case err == nil:
+		if targetBackup.Annotations[utils.BackupExportedFromAnnotationName] != exportedFrom {
+			return nil, fmt.Errorf("%w: backup %s/%s already exists",
+				errBackupExportFailed, targetBackup.Namespace, targetBackup.Name)
+		}
+		return &targetBackup, nil
+	case !apierrs.IsNotFound(err):
+		return nil, err
+	}
+
+	targetBackup = apiv1.Backup{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      backupExport.GetTargetBackupName(),
+			Namespace: backupExport.Spec.TargetNamespace,
+			Annotations: map[string]string{
+				utils.BackupExportedFromAnnotationName: exportedFrom,
+			},
+		},
+		Spec: apiv1.BackupSpec{
+			Cluster: apiv1.LocalObjectReference{Name: backupExport.GetTargetClusterName(backup)},
+			Method:  apiv1.BackupMethodVolumeSnapshot,
+			Online:  backup.Spec.Online,
+			Target:  backup.Spec.Target,
+		},
+	}
+	utils.LabelClusterName(&targetBackup.ObjectMeta, targetBackup.Spec.Cluster.Name)
+	if err := r.Create(ctx, &targetBackup); err != nil {
+		return nil, err
+	}
+
+	return &targetBackup, nil
+}
+
+// ensureTargetSnapshotContent creates a pre-provisioned VolumeSnapshotContent
+// pointing to the same storage snapshot as the source one, bound to the
+// snapshot in the target namespace. The content is retained when the
+// exported snapshot is deleted, as the storage snapshot still belongs to
+// the source backup, and is deleted together with the backup export
+func (r *BackupExportReconciler) ensureTargetSnapshotContent(
+	ctx context.Context,
+	backupExport *apiv1.BackupExport,
+	source exportedSnapshot,
+) (*storagesnapshotv1.VolumeSnapshotContent, error) {
+	var content storagesnapshotv1.VolumeSnapshotContent
+	err := r.Get(ctx, client.ObjectKey{Name: backupExport.GetSnapshotContentName(source.snapshot.Name)}, &content)
+	switch {
+	case err == nil:
+		if content.Spec.VolumeSnapshotRef.Namespace != backupExport.Spec.TargetNamespace ||
+			content.Spec.VolumeSnapshotRef.Name != source.snapshot.Name {
+			return nil, fmt.Errorf("%w: volume snapshot content %q already exists", errBackupExportFailed, content.Name)
+		}
+		return &content, nil
+	case !apierrs.IsNotFound(err):
+		return nil, err
+	}
+
+	content = storagesnapshotv1.VolumeSnapshotContent{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: backupExport.GetSnapshotContentName(source.snapshot.Name),
+			Annotations: map[string]string{
+				utils.BackupExportedFromAnnotationName: source.content.Name,
+			},
+		},
+		Spec: storagesnapshotv1.VolumeSnapshotContentSpec{
+			VolumeSnapshotRef: corev1.ObjectReference{
+				Namespace: backupExport.Spec.TargetNamespace,
+				Name:      source.snapshot.Name,
+			},
+			DeletionPolicy:          storagesnapshotv1.VolumeSnapshotContentRetain,
+			Driver:                  source.content.Spec.Driver,
+			VolumeSnapshotClassName: source.content.Spec.VolumeSnapshotClassName,
+			Source: storagesnapshotv1.VolumeSnapshotContentSource{
+				SnapshotHandle: source.content.Status.SnapshotHandle,
+			},
+			SourceVolumeMode: source.content.Spec.SourceVolumeMode,
+		},
+	}
+	if err := r.Create(ctx, &content); err != nil {
+		return nil, err
+	}
+
+	return &content, nil
+}
+
+// ensureTargetSnapshot creates the snapshot in the target namespace bound
+// to the exported content. It carries the metadata of the source snapshot,
+// which is needed to recover from it, relabeled for the target cluster
+func (r *BackupExportReconciler) ensureTargetSnapshot(
+	ctx context.Context,
+	backupExport *apiv1.BackupExport,
+	backup *apiv1.Backup,
+	source exportedSnapshot,
+	contentName string,
+) (*storagesnapshotv1.VolumeSnapshot, error) {
+	var snapshot storagesnapshotv1.VolumeSnapshot
+	err := r.Get(ctx, client.ObjectKey{
+		Namespace: backupExport.Spec.TargetNamespace,
+		Name:      source.snapshot.Name,
+	}, &snapshot)
+	switch {
+	case err == nil:
+		if ptr.Deref(snapshot.Spec.Source.VolumeSnapshotContentName, "") != contentName {
+			return nil, fmt.Errorf("%w: volume snapshot %s/%s already exists",
+				errBackupExportFailed, snapshot.Namespace, snapshot.Name)
+		}
+		return &snapshot, nil
+	case !apierrs.IsNotFound(err):
+		return nil, err
+	}
+
+	labels := make(map[string]string, len(source.snapshot.Labels))
+	for key, value := range source.snapshot.Labels {
+		labels[key] = value
+	}
+	labels[utils.ClusterLabelName] = backupExport.GetTargetClusterName(backup)
+	labels[utils.BackupNameLabelName] = backupExport.GetTargetBackupName()
+
+	annotations := make(map[string]string, len(source.snapshot.Annotations)+1)
+	for key, value := range source.snapshot.Annotations {
+		annotations[key] = value
+	}
+	annotations[utils.BackupExportedFromAnnotationName] = getBackupExportSource(backup)
+
+	snapshot = storagesnapshotv1.VolumeSnapshot{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        source.snapshot.Name,
+			Namespace:   backupExport.Spec.TargetNamespace,
+			Labels:      labels,
+			Annotations: annotations,
+		},
+		Spec: storagesnapshotv1.VolumeSnapshotSpec{
+			Source: storagesnapshotv1.VolumeSnapshotSource{
+				VolumeSnapshotContentName: ptr.To(contentName),
+			},
+			VolumeSnapshotClassName: source.snapshot.Spec.VolumeSnapshotClassName,
+		},
+	}
+	if err := r.Create(ctx, &snapshot); err != nil {
+		return nil, err
+	}
+
+	return &snapshot, nil
+}
+
+// completeTargetBackup copies the status of the source backup, making the
+// exported one available for recovery, after checking the exported
+// snapshots can be used to bootstrap a cluster
+func (r *BackupExportReconciler) completeTargetBackup(
+	ctx context.Context,
+	backup *apiv1.Backup,
+	targetBackup *apiv1.Backup,
+) error {
+	if targetBackup.Status.Phase == apiv1.BackupPhaseCompleted {
+		return nil
+	}
+
+	origTargetBackup := targetBackup.DeepCopy()
+	targetBackup.Status = *backup.Status.DeepCopy()
+
+	storageSource := persistentvolumeclaim.GetCandidateStorageSourceForPrimary(nil, targetBackup)
+	validation, err := persistentvolumeclaim.VerifyDataSourceCoherence(
+		ctx, r.Client, targetBackup.Namespace, &apiv1.DataSource{
+			Storage:           storageSource.DataSource,
+			WalStorage:        storageSource.WALSource,
+			TablespaceStorage: storageSource.TablespaceSource,
+		})
+	if err != nil {
+		return err
+	}
+	if validation.ContainsErrors() {
+		messages := make([]string, 0, len(validation.Errors))
+		for _, message := range validation.Errors {
+			messages = append(messages, fmt.Sprintf("%s: %s", message.ObjectName, message.Message))
+		}
+		return fmt.Errorf("%w: the exported volume snapshots can't be used for recovery: %s",
+			errBackupExportFailed, strings.Join(messages, ", "))
+	}
+
+	return r.Status().Patch(ctx, targetBackup, client.MergeFrom(origTargetBackup))
+}
+
+// deleteBackupExport deletes the objects created in the target namespace
+// and, when no other export of the same backup exists, restores the
+// deletion policy of the source contents, before removing the finalizer
+func (r *BackupExportReconciler) deleteBackupExport(
+	ctx context.Context,
+	backupExport *apiv1.BackupExport,
+) error {
+	if !controllerutil.ContainsFinalizer(backupExport, utils.BackupExportFinalizerName) {
+		return nil
+	}
+
+	var contents []*storagesnapshotv1.VolumeSnapshotContent
+	for _, contentName := range backupExport.Status.SnapshotContents {
+		var content storagesnapshotv1.VolumeSnapshotContent
+		if err := r.Get(ctx, client.ObjectKey{Name: contentName}, &content); err != nil {
+			if apierrs.IsNotFound(err) {
+				continue
+			}
+			return err
+		}
+		contents = append(contents, &content)
+	}
+
+	isExported, err := r.isBackupExportedElsewhere(ctx, backupExport)
+	if err != nil {
+		return err
+	}
+	if !isExported {
+		for _, content := range contents {
+			if err := r.releaseSourceSnapshotContent(
+				ctx, content.Annotations[utils.BackupExportedFromAnnotationName],
+			); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, content := range contents {
+		if err := r.deleteTargetSnapshot(ctx, content); err != nil {
+			return err
+		}
+		if err := r.Delete(ctx, content); err != nil && !apierrs.IsNotFound(err) {
+			return fmt.Errorf("while deleting volume snapshot content %q: %w", content.Name, err)
+		}
+	}
+
+	if err := r.deleteTargetBackup(ctx, backupExport); err != nil {
+		return err
+	}
+
+	origBackupExport := backupExport.DeepCopy()
+	controllerutil.RemoveFinalizer(backupExport, utils.BackupExportFinalizerName)
+	return r.Patch(ctx, backupExport, client.MergeFrom(origBackupExport))
+}
+
+// isBackupExportedElsewhere checks if another backup export of the same
+// backup exists, keeping the source contents retained
+func (r *BackupExportReconciler) isBackupExportedElsewhere(
+	ctx context.Context,
+	backupExport *apiv1.BackupExport,
+) (bool, error) {
+	var backupExports apiv1.BackupExportList
+	if err := r.List(ctx, &backupExports, client.InNamespace(backupExport.Namespace)); err != nil {
+		return false, err
+	}
+
+	for _, item := range backupExports.Items {
+		if item.Name != backupExport.Name && item.DeletionTimestamp.IsZero() &&
+			item.Spec.Backup.Name == backupExport.Spec.Backup.Name {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// releaseSourceSnapshotContent restores the deletion policy of a source
+// content. When the source snapshot has been deleted in the meantime,
+// the content is deleted as it would have been without the export
+func (r *BackupExportReconciler) releaseSourceSnapshotContent(ctx context.Context, contentName string) error {
+	if contentName == "" {
+		return nil
+	}
+
+	var content storagesnapshotv1.VolumeSnapshotContent
+	if err := r.Get(ctx, client.ObjectKey{Name: contentName}, &content); err != nil {
+		if apierrs.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	deletionPolicy, ok := content.Annotations[utils.ExportedDeletionPolicyAnnotationName]
+	if !ok {
+		return nil
+	}
+
+	origContent := content.DeepCopy()
+	content.Spec.DeletionPolicy = storagesnapshotv1.DeletionPolicy(deletionPolicy)
+	delete(content.Annotations, utils.ExportedDeletionPolicyAnnotationName)
+	if err := r.Patch(ctx, &content, client.MergeFrom(origContent)); err != nil {
+		return fmt.Errorf("while restoring the deletion policy of volume snapshot content %q: %w", content.Name, err)
+	}
+
+	if content.Spec.DeletionPolicy != storagesnapshotv1.VolumeSnapshotContentDelete {
+		return nil
+	}
+
+	err := r.Get(ctx, client.ObjectKey{
+		Namespace: content.Spec.VolumeSnapshotRef.Namespace,
+		Name:      content.Spec.VolumeSnapshotRef.Name,
+	}, &storagesnapshotv1.VolumeSnapshot{})
+	if !apierrs.IsNotFound(err) {
+		return err
+	}
+
+	if err := r.Delete(ctx, &content); err != nil && !apierrs.IsNotFound(err) {
+		return fmt.Errorf("while deleting volume snapshot content %q: %w", content.Name, err)
+	}
+
+	return nil
+}
+
+// deleteTargetSnapshot deletes the snapshot in the target namespace bound
+// to an exported content
+func (r *BackupExportReconciler) deleteTargetSnapshot(
+	ctx context.Context,
+	content *storagesnapshotv1.VolumeSnapshotContent,
+) error {
+	var snapshot storagesnapshotv1.VolumeSnapshot
+	if err := r.Get(ctx, client.ObjectKey{
+		Namespace: content.Spec.VolumeSnapshotRef.Namespace,
+		Name:      content.Spec.VolumeSnapshotRef.Name,
+	}, &snapshot); err != nil {
+		if apierrs.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	if ptr.Deref(snapshot.Spec.Source.VolumeSnapshotContentName, "") != content.Name {
+		return nil
+	}
+
+	if err := r.Delete(ctx, &snapshot); err != nil && !apierrs.IsNotFound(err) {
+		return fmt.Errorf("while deleting volume snapshot %s/%s: %w", snapshot.Namespace, snapshot.Name, err)
+	}
+
+	return nil
+}
+
+// deleteTargetBackup deletes the backup created in the target namespace
+func (r *BackupExportReconciler) deleteTargetBackup(ctx context.Context, backupExport *apiv1.BackupExport) error {
+	if backupExport.Status.BackupName == "" {
+		return nil
+	}
+
+	var targetBackup apiv1.Backup
+	if err := r.Get(ctx, client.ObjectKey{
+		Namespace: backupExport.Spec.TargetNamespace,
+		Name:      backupExport.Status.BackupName,
+	}, &targetBackup); err != nil {
+		if apierrs.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	exportedFrom := getBackupExportSource(&apiv1.Backup{
+		ObjectMeta: metav1.ObjectMeta{Namespace: backupExport.Namespace, Name: backupExport.Spec.Backup.Name},
+	})
+	if targetBackup.Annotations[utils.BackupExportedFromAnnotationName] != exportedFrom {
+		return nil
+	}
+
+	if err := r.Delete(ctx, &targetBackup); err != nil && !apierrs.IsNotFound(err) {
+		return fmt.Errorf("while deleting backup %s/%s: %w", targetBackup.Namespace, targetBackup.Name, err)
+	}
+
+	return nil
+}
+
+// getBackupExportSource gets the value of the annotation marking the
+// objects exported from a backup
+func getBackupExportSource(backup *apiv1.Backup) string {
+	return backup.Namespace + "/" + backup.Name
+}
+
+// mapBackupsToBackupExports enqueues the exports waiting for a backup
+func (r *BackupExportReconciler) mapBackupsToBackupExports() handler.MapFunc {
+	return func(ctx context.Context, obj client.Object) []reconcile.Request {
+		backup, ok := obj.(*apiv1.Backup)
+		if !ok || !backup.IsCompletedVolumeSnapshot() {
+			return nil
+		}
+
+		var backupExports apiv1.BackupExportList
+		if err := r.List(ctx, &backupExports, client.InNamespace(backup.Namespace)); err != nil {
+			log.FromContext(ctx).Error(err, "while getting the backup exports", "backup", backup.Name)
+			return nil
+		}
+
+		var requests []reconcile.Request
+		for _, backupExport := range backupExports.Items {
+			if backupExport.Spec.Backup.Name != backup.Name || backupExport.Status.IsDone() {
+				continue
+			}
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name:      backupExport.Name,
+					Namespace: backupExport.Namespace,
+				},
+			})
+		}
+		return requests
+	}
+}
+
+// SetupWithManager sets up the controller with the Manager
+func (r *BackupExportReconciler) SetupWithManager(mgr ctrl.Manager, maxConcurrentReconciles int) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		WithOptions(controller.Options{MaxConcurrentReconciles: maxConcurrentReconciles}).
+		For(&apiv1.BackupExport{}).
+		Named("backup-export").
+		Watches(&apiv1.Backup{}, handler.EnqueueRequestsFromMapFunc(r.mapBackupsToBackupExports())).
+		Complete(r)
+}
@@ -0,0 +1,287 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package controller
+
+import (
+	"context"
+
+	storagesnapshotv1 "github.com/kubernetes-csi/external-snapshotter/client/v8/apis/volumesnapshot/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("BackupExport reconciler", func() {
+	var env *testingEnvironment
+	var reconciler *BackupExportReconciler
+	var backup *apiv1.Backup
+	var backupExport *apiv1.BackupExport
+	var targetNamespace string
+
+	BeforeEach(func(ctx context.Context) {
+		utils.SetVolumeSnapshot(true)
+		DeferCleanup(func() {
+			utils.SetVolumeSnapshot(false)
+		})
+
+		env = buildTestEnvironment()
+		reconciler = &BackupExportReconciler{
+			Client:   env.client,
+			Scheme:   env.scheme,
+			Recorder: record.NewFakeRecorder(10),
+		}
+
+		namespace := newFakeNamespace(env.client)
+		targetNamespace = rand.String(10)
+		Expect(env.client.Create(ctx, &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: targetNamespace,
+				Annotations: map[string]string{
+					utils.BackupExportAllowedSourcesAnnotationName: "other, " + namespace,
+				},
+			},
+		})).To(Succeed())
+
+		backup = &apiv1.Backup{
+			ObjectMeta: metav1.ObjectMeta{Name: "backup", Namespace: namespace},
+			Spec: apiv1.BackupSpec{
+				Cluster: apiv1.LocalObjectReference{Name: "cluster-example"},
+				Method:  apiv1.BackupMethodVolumeSnapshot,
+			},
+		}
+		Expect(env.client.Create(ctx, backup)).To(Succeed())
+		backup.Status = apiv1.BackupStatus{
+			Phase:           apiv1.BackupPhaseCompleted,
+			BackupLabelFile: []byte("START WAL LOCATION: 0/3000028"),
+			BackupSnapshotStatus: apiv1.BackupSnapshotStatus{
+				Elements: []apiv1.BackupSnapshotElementStatus{
+					{Name: "backup-1", Type: string(utils.PVCRolePgData)},
+				},
+			},
+		}
+		Expect(env.client.Status().Update(ctx, backup)).To(Succeed())
+
+		Expect(env.client.Create(ctx, &storagesnapshotv1.VolumeSnapshotContent{
+			ObjectMeta: metav1.ObjectMeta{Name: "snapcontent-1"},
+			Spec: storagesnapshotv1.VolumeSnapshotContentSpec{
+				VolumeSnapshotRef: corev1.ObjectReference{Namespace: namespace, Name: "backup-1"},
+				Driver:            "csi.example.com",
+				DeletionPolicy:    storagesnapshotv1.VolumeSnapshotContentDelete,
+			},
+			Status: &storagesnapshotv1.VolumeSnapshotContentStatus{
+				SnapshotHandle: ptr.To("snapshot-handle-1"),
+			},
+		})).To(Succeed())
+		Expect(env.client.Create(ctx, &storagesnapshotv1.VolumeSnapshot{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "backup-1",
+				Namespace: namespace,
+				Labels: map[string]string{
+					utils.ClusterLabelName:    "cluster-example",
+					utils.BackupNameLabelName: "backup",
+				},
+				Annotations: map[string]string{
+					utils.PvcRoleLabelName:              string(utils.PVCRolePgData),
+					utils.BackupLabelFileAnnotationName: "U1RBUlQgV0FM",
+				},
+			},
+			Status: &storagesnapshotv1.VolumeSnapshotStatus{
+				ReadyToUse:                     ptr.To(true),
+				BoundVolumeSnapshotContentName: ptr.To("snapcontent-1"),
+			},
+		})).To(Succeed())
+
+		backupExport = &apiv1.BackupExport{
+			ObjectMeta: metav1.ObjectMeta{Name: "export", Namespace: namespace},
+			Spec: apiv1.BackupExportSpec{
+				Backup:            apiv1.LocalObjectReference{Name: backup.Name},
+				TargetNamespace:   targetNamespace,
+				TargetClusterName: "cluster-dr",
+			},
+		}
+		Expect(env.client.Create(ctx, backupExport)).To(Succeed())
+	})
+
+	reconcile := func(ctx context.Context) ctrl.Result {
+		result, err := reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(backupExport)})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(env.client.Get(ctx, client.ObjectKeyFromObject(backupExport), backupExport)).To(Succeed())
+		return result
+	}
+
+	deleteBackupExport := func(ctx context.Context) {
+		Expect(env.client.Delete(ctx, backupExport)).To(Succeed())
+		_, err := reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(backupExport)})
+		Expect(err).ToNot(HaveOccurred())
+		err = env.client.Get(ctx, client.ObjectKeyFromObject(backupExport), backupExport)
+		Expect(apierrs.IsNotFound(err)).To(BeTrue())
+	}
+
+	isNotFound := func(ctx context.Context, key client.ObjectKey, obj client.Object) bool {
+		return apierrs.IsNotFound(env.client.Get(ctx, key, obj))
+	}
+
+	It("exports the snapshots and completes the backup once they are ready", func(ctx context.Context) {
+		result := reconcile(ctx)
+		Expect(result.RequeueAfter).ToNot(BeZero())
+		Expect(backupExport.Status.Phase).To(Equal(apiv1.BackupExportPhaseExporting))
+		Expect(backupExport.Status.BackupName).To(Equal("backup"))
+		Expect(backupExport.Status.SnapshotContents).To(ConsistOf(targetNamespace + "-backup-1"))
+
+		var content storagesnapshotv1.VolumeSnapshotContent
+		Expect(env.client.Get(ctx, client.ObjectKey{Name: targetNamespace + "-backup-1"}, &content)).To(Succeed())
+		Expect(content.Spec.Source.SnapshotHandle).To(Equal(ptr.To("snapshot-handle-1")))
+		Expect(content.Spec.Driver).To(Equal("csi.example.com"))
+		Expect(content.Spec.DeletionPolicy).To(Equal(storagesnapshotv1.VolumeSnapshotContentRetain))
+		Expect(content.Spec.VolumeSnapshotRef.Namespace).To(Equal(targetNamespace))
+		Expect(content.Spec.VolumeSnapshotRef.Name).To(Equal("backup-1"))
+		Expect(content.Annotations).To(HaveKeyWithValue(utils.BackupExportedFromAnnotationName, "snapcontent-1"))
+
+		var sourceContent storagesnapshotv1.VolumeSnapshotContent
+		Expect(env.client.Get(ctx, client.ObjectKey{Name: "snapcontent-1"}, &sourceContent)).To(Succeed())
+		Expect(sourceContent.Spec.DeletionPolicy).To(Equal(storagesnapshotv1.VolumeSnapshotContentRetain))
+		Expect(sourceContent.Annotations).To(HaveKeyWithValue(utils.ExportedDeletionPolicyAnnotationName,
+			string(storagesnapshotv1.VolumeSnapshotContentDelete)))
+		Expect(backupExport.Finalizers).To(ContainElement(utils.BackupExportFinalizerName))
+
+		var snapshot storagesnapshotv1.VolumeSnapshot
+		Expect(env.client.Get(ctx, client.ObjectKey{Namespace: targetNamespace, Name: "backup-1"}, &snapshot)).
+			To(Succeed())
+		Expect(snapshot.Spec.Source.VolumeSnapshotContentName).To(Equal(ptr.To(content.Name)))
+		Expect(snapshot.Labels).To(HaveKeyWithValue(utils.ClusterLabelName, "cluster-dr"))
+		Expect(snapshot.Annotations).To(HaveKeyWithValue(utils.BackupLabelFileAnnotationName, "U1RBUlQgV0FM"))
+
+		var targetBackup apiv1.Backup
+		Expect(env.client.Get(ctx, client.ObjectKey{Namespace: targetNamespace, Name: "backup"}, &targetBackup)).
+			To(Succeed())
+		Expect(targetBackup.IsExported()).To(BeTrue())
+		Expect(targetBackup.Spec.Cluster.Name).To(Equal("cluster-dr"))
+		Expect(targetBackup.Status.Phase).To(BeEmpty())
+
+		snapshot.Status = &storagesnapshotv1.VolumeSnapshotStatus{ReadyToUse: ptr.To(true)}
+		Expect(env.client.Update(ctx, &snapshot)).To(Succeed())
+
+		reconcile(ctx)
+		Expect(backupExport.Status.Phase).To(Equal(apiv1.BackupExportPhaseCompleted))
+
+		Expect(env.client.Get(ctx, client.ObjectKeyFromObject(&targetBackup), &targetBackup)).To(Succeed())
+		Expect(targetBackup.IsCompletedVolumeSnapshot()).To(BeTrue())
+		Expect(targetBackup.Status.BackupLabelFile).To(Equal(backup.Status.BackupLabelFile))
+		Expect(targetBackup.Status.BackupSnapshotStatus.Elements).To(Equal(backup.Status.BackupSnapshotStatus.Elements))
+	})
+
+	It("waits for the source backup to be completed", func(ctx context.Context) {
+		backup.Status.Phase = apiv1.BackupPhaseRunning
+		Expect(env.client.Status().Update(ctx, backup)).To(Succeed())
+
+		reconcile(ctx)
+		Expect(backupExport.Status.Phase).To(Equal(apiv1.BackupExportPhasePending))
+	})
+
+	It("fails when the source backup doesn't use volume snapshots", func(ctx context.Context) {
+		backup.Spec.Method = apiv1.BackupMethodBarmanObjectStore
+		Expect(env.client.Update(ctx, backup)).To(Succeed())
+
+		reconcile(ctx)
+		Expect(backupExport.Status.Phase).To(Equal(apiv1.BackupExportPhaseFailed))
+		Expect(backupExport.Status.Message).To(ContainSubstring("only volumeSnapshot backups can be exported"))
+	})
+
+	It("doesn't overwrite a backup it didn't export", func(ctx context.Context) {
+		Expect(env.client.Create(ctx, &apiv1.Backup{
+			ObjectMeta: metav1.ObjectMeta{Name: "backup", Namespace: targetNamespace},
+			Spec: apiv1.BackupSpec{
+				Cluster: apiv1.LocalObjectReference{Name: "cluster-dr"},
+			},
+		})).To(Succeed())
+
+		reconcile(ctx)
+		Expect(backupExport.Status.Phase).To(Equal(apiv1.BackupExportPhaseFailed))
+		Expect(backupExport.Status.Message).To(ContainSubstring("already exists"))
+	})
+
+	It("fails when the target namespace doesn't accept the export", func(ctx context.Context) {
+		var target corev1.Namespace
+		Expect(env.client.Get(ctx, client.ObjectKey{Name: targetNamespace}, &target)).To(Succeed())
+		target.Annotations = nil
+		Expect(env.client.Update(ctx, &target)).To(Succeed())
+
+		reconcile(ctx)
+		Expect(backupExport.Status.Phase).To(Equal(apiv1.BackupExportPhaseFailed))
+		Expect(backupExport.Status.Message).To(ContainSubstring(utils.BackupExportAllowedSourcesAnnotationName))
+		Expect(isNotFound(ctx, client.ObjectKey{Name: targetNamespace + "-backup-1"},
+			&storagesnapshotv1.VolumeSnapshotContent{})).To(BeTrue())
+	})
+
+	It("deletes the exported objects and releases the source when deleted", func(ctx context.Context) {
+		reconcile(ctx)
+		deleteBackupExport(ctx)
+
+		Expect(isNotFound(ctx, client.ObjectKey{Name: targetNamespace + "-backup-1"},
+			&storagesnapshotv1.VolumeSnapshotContent{})).To(BeTrue())
+		Expect(isNotFound(ctx, client.ObjectKey{Namespace: targetNamespace, Name: "backup-1"},
+			&storagesnapshotv1.VolumeSnapshot{})).To(BeTrue())
+		Expect(isNotFound(ctx, client.ObjectKey{Namespace: targetNamespace, Name: "backup"},
+			&apiv1.Backup{})).To(BeTrue())
+
+		var sourceContent storagesnapshotv1.VolumeSnapshotContent
+		Expect(env.client.Get(ctx, client.ObjectKey{Name: "snapcontent-1"}, &sourceContent)).To(Succeed())
+		Expect(sourceContent.Spec.DeletionPolicy).To(Equal(storagesnapshotv1.VolumeSnapshotContentDelete))
+		Expect(sourceContent.Annotations).ToNot(HaveKey(utils.ExportedDeletionPolicyAnnotationName))
+	})
+
+	It("deletes the source content whose snapshot was deleted while exported", func(ctx context.Context) {
+		reconcile(ctx)
+		Expect(env.client.Delete(ctx, &storagesnapshotv1.VolumeSnapshot{
+			ObjectMeta: metav1.ObjectMeta{Namespace: backup.Namespace, Name: "backup-1"},
+		})).To(Succeed())
+
+		deleteBackupExport(ctx)
+		Expect(isNotFound(ctx, client.ObjectKey{Name: "snapcontent-1"},
+			&storagesnapshotv1.VolumeSnapshotContent{})).To(BeTrue())
+	})
+
+	It("keeps the source retained while the backup is exported elsewhere", func(ctx context.Context) {
+		reconcile(ctx)
+		Expect(env.client.Create(ctx, &apiv1.BackupExport{
+			ObjectMeta: metav1.ObjectMeta{Name: "other-export", Namespace: backup.Namespace},
+			Spec: apiv1.BackupExportSpec{
+				Backup:          apiv1.LocalObjectReference{Name: backup.Name},
+				TargetNamespace: targetNamespace,
+			},
+		})).To(Succeed())
+
+		deleteBackupExport(ctx)
+		var sourceContent storagesnapshotv1.VolumeSnapshotContent
+		Expect(env.client.Get(ctx, client.ObjectKey{Name: "snapcontent-1"}, &sourceContent)).To(Succeed())
+		Expect(sourceContent.Spec.DeletionPolicy).To(Equal(storagesnapshotv1.VolumeSnapshotContentRetain))
+	})
+})
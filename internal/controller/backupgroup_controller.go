@@ -0,0 +1,325 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/certs"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres/webserver/client/remote"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+)
+
+// errBackupGroupFailed is the error wrapped by the failures that can't be
+// recovered by retrying the reconciliation
+var errBackupGroupFailed = errors.New("backup group failed")
+
+// BackupGroupReconciler reconciles a BackupGroup object
+type BackupGroupReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+
+	instanceClient remote.InstanceClient
+}
+
+// NewBackupGroupReconciler properly initializes the BackupGroupReconciler
+func NewBackupGroupReconciler(mgr manager.Manager) *BackupGroupReconciler {
+	return &BackupGroupReconciler{
+		Client:         mgr.GetClient(),
+		Scheme:         mgr.GetScheme(),
+		Recorder:       mgr.GetEventRecorderFor("cloudnative-pg-backupgroup"),
+		instanceClient: remote.NewClient().Instance(),
+	}
+}
+
+// +kubebuilder:rbac:groups=postgresql.cnpg.io,resources=backupgroups,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=postgresql.cnpg.io,resources=backupgroups/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=postgresql.cnpg.io,resources=backups,verbs=get;list;watch;create
+// +kubebuilder:rbac:groups=postgresql.cnpg.io,resources=clusters,verbs=get
+// +kubebuilder:rbac:groups="",resources=pods,verbs=get
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+
+// Reconcile takes the backups of the clusters of the group and, once they
+// are all completed, creates the shared restore point
+func (r *BackupGroupReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	contextLogger, ctx := log.SetupLogger(ctx)
+
+	var backupGroup apiv1.BackupGroup
+	if err := r.Get(ctx, req.NamespacedName, &backupGroup); err != nil {
+		if apierrs.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	if !backupGroup.DeletionTimestamp.IsZero() || backupGroup.Status.IsDone() {
+		return ctrl.Result{}, nil
+	}
+
+	origBackupGroup := backupGroup.DeepCopy()
+	err := r.reconcileBackupGroup(ctx, &backupGroup)
+	if errors.Is(err, errBackupGroupFailed) {
+		contextLogger.Warning("Backup group failed", "err", err.Error())
+		r.Recorder.Event(&backupGroup, "Warning", "BackupGroupFailed", err.Error())
+		backupGroup.Status.SetPhase(apiv1.BackupGroupPhaseFailed, err.Error())
+	} else if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if equality.Semantic.DeepEqual(origBackupGroup.Status, backupGroup.Status) {
+		return ctrl.Result{}, nil
+	}
+
+	return ctrl.Result{}, r.Status().Patch(ctx, &backupGroup, client.MergeFrom(origBackupGroup))
+}
+
+// reconcileBackupGroup moves the backup group forward, updating its
+// status. Unrecoverable failures wrap errBackupGroupFailed
+func (r *BackupGroupReconciler) reconcileBackupGroup(ctx context.Context, backupGroup *apiv1.BackupGroup) error {
+	if len(backupGroup.GetRestorePointName()) > 63 {
+		return fmt.Errorf("%w: the restore point name %q is longer than 63 characters, set restorePointName",
+			errBackupGroupFailed, backupGroup.GetRestorePointName())
+	}
+
+	backups, err := r.reconcileBackupGroupMembers(ctx, backupGroup)
+	if err != nil {
+		return err
+	}
+
+	completed := 0
+	for _, backup := range backups {
+		switch backup.Status.Phase {
+		case apiv1.BackupPhaseFailed:
+			return fmt.Errorf("%w: backup %q of cluster %q failed: %s",
+				errBackupGroupFailed, backup.Name, backup.Spec.Cluster.Name, backup.Status.Error)
+		case apiv1.BackupPhaseCompleted:
+			completed++
+		}
+	}
+
+	if completed < len(backups) {
+		backupGroup.Status.SetPhase(apiv1.BackupGroupPhaseRunning,
+			fmt.Sprintf("%d of %d backups completed", completed, len(backups)))
+		return nil
+	}
+
+	return r.createRestorePoints(ctx, backupGroup)
+}
+
+// reconcileBackupGroupMembers creates the missing backups of the clusters
+// of the group, returning all of them
+func (r *BackupGroupReconciler) reconcileBackupGroupMembers(
+	ctx context.Context,
+	backupGroup *apiv1.BackupGroup,
+) ([]apiv1.Backup, error) {
+	backups := make([]apiv1.Backup, 0, len(backupGroup.Spec.Clusters))
+	members := make([]apiv1.BackupGroupMemberStatus, 0, len(backupGroup.Spec.Clusters))
+	for _, clusterRef := range backupGroup.Spec.Clusters {
+		var backup apiv1.Backup
+		err := r.Get(ctx, client.ObjectKey{
+			Namespace: backupGroup.Namespace,
+			Name:      backupGroup.GetBackupName(clusterRef.Name),
+		}, &backup)
+		switch {
+		case apierrs.IsNotFound(err):
+			newBackup, err := r.createBackupGroupMember(ctx, backupGroup, clusterRef.Name)
+			if err != nil {
+				return nil, err
+			}
+			backup = *newBackup
+		case err != nil:
+			return nil, err
+		}
+
+		backups = append(backups, backup)
+		members = append(members, apiv1.BackupGroupMemberStatus{
+			Cluster:    clusterRef.Name,
+			BackupName: backup.Name,
+		})
+	}
+
+	if len(backupGroup.Status.Backups) == 0 {
+		backupGroup.Status.Backups = members
+	}
+
+	return backups, nil
+}
+
+// createBackupGroupMember creates the backup of a cluster of the group
+func (r *BackupGroupReconciler) createBackupGroupMember(
+	ctx context.Context,
+	backupGroup *apiv1.BackupGroup,
+	clusterName string,
+) (*apiv1.Backup, error) {
+	var cluster apiv1.Cluster
+	if err := r.Get(ctx, client.ObjectKey{Namespace: backupGroup.Namespace, Name: clusterName}, &cluster); err != nil {
+		if apierrs.IsNotFound(err) {
+			return nil, fmt.Errorf("%w: cluster %q not found", errBackupGroupFailed, clusterName)
+		}
+		return nil, err
+	}
+
+	backup := &apiv1.Backup{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      backupGroup.GetBackupName(clusterName),
+			Namespace: backupGroup.Namespace,
+			Labels: map[string]string{
+				utils.BackupGroupLabelName: backupGroup.Name,
+			},
+		},
+		Spec: apiv1.BackupSpec{
+			Cluster:             apiv1.LocalObjectReference{Name: clusterName},
+			Method:              backupGroup.Spec.Method,
+			PluginConfiguration: backupGroup.Spec.PluginConfiguration.DeepCopy(),
+		},
+	}
+	utils.LabelClusterName(&backup.ObjectMeta, clusterName)
+	if err := ctrl.SetControllerReference(backupGroup, backup, r.Scheme); err != nil {
+		return nil, err
+	}
+	if err := r.Create(ctx, backup); err != nil {
+		return nil, err
+	}
+
+	r.Recorder.Eventf(backupGroup, "Normal", "BackupCreated",
+		"Created backup %q of cluster %q", backup.Name, clusterName)
+	return backup, nil
+}
+
+// createRestorePoints creates the restore point on the primary of every
+// cluster of the group at the same time. As a restore point can't be
+// removed, creating it again after a partial failure would make the
+// recovery target ambiguous, and the group is failed instead
+func (r *BackupGroupReconciler) createRestorePoints(ctx context.Context, backupGroup *apiv1.BackupGroup) error {
+	primaries := make([]*corev1.Pod, len(backupGroup.Status.Backups))
+	primaryContexts := make([]context.Context, len(backupGroup.Status.Backups))
+	for idx, member := range backupGroup.Status.Backups {
+		primaryContext, primary, err := r.getClusterPrimary(ctx, backupGroup.Namespace, member.Cluster)
+		if err != nil {
+			return err
+		}
+		primaries[idx] = primary
+		primaryContexts[idx] = primaryContext
+	}
+
+	restorePointName := backupGroup.GetRestorePointName()
+	lsns := make([]string, len(primaries))
+	errs := make([]error, len(primaries))
+
+	var wg sync.WaitGroup
+	for idx := range primaries {
+		wg.Add(1)
+		go func(idx int) {
+			defer wg.Done()
+			lsns[idx], errs[idx] = r.instanceClient.CreateRestorePoint(
+				primaryContexts[idx], primaries[idx], restorePointName)
+		}(idx)
+	}
+	wg.Wait()
+	restorePointTime := metav1.Now()
+
+	for idx, err := range errs {
+		if err != nil {
+			return fmt.Errorf("%w: while creating the restore point on cluster %q: %w",
+				errBackupGroupFailed, backupGroup.Status.Backups[idx].Cluster, err)
+		}
+		backupGroup.Status.Backups[idx].RestorePointLSN = lsns[idx]
+	}
+
+	backupGroup.Status.RestorePointName = restorePointName
+	backupGroup.Status.RestorePointTime = ptr.To(restorePointTime)
+	backupGroup.Status.SetPhase(apiv1.BackupGroupPhaseCompleted, "")
+	r.Recorder.Eventf(backupGroup, "Normal", "RestorePointCreated",
+		"Created restore point %q on %d clusters", restorePointName, len(primaries))
+
+	return nil
+}
+
+// getClusterPrimary gets the Pod running the primary of a cluster, together
+// with the context storing the TLS configuration required to communicate
+// with its instance manager
+func (r *BackupGroupReconciler) getClusterPrimary(
+	ctx context.Context,
+	namespace, clusterName string,
+) (context.Context, *corev1.Pod, error) {
+	var cluster apiv1.Cluster
+	if err := r.Get(ctx, client.ObjectKey{Namespace: namespace, Name: clusterName}, &cluster); err != nil {
+		return ctx, nil, err
+	}
+	if cluster.Status.CurrentPrimary == "" {
+		return ctx, nil, fmt.Errorf("cluster %q has no primary", clusterName)
+	}
+
+	var pod corev1.Pod
+	if err := r.Get(ctx, client.ObjectKey{Namespace: namespace, Name: cluster.Status.CurrentPrimary}, &pod); err != nil {
+		return ctx, nil, err
+	}
+
+	// Store in the context the TLS configuration required communicating with the Pods
+	ctx, err := certs.NewTLSConfigForContext(
+		ctx,
+		r.Client,
+		cluster.GetServerCASecretObjectKey(),
+	)
+	if err != nil {
+		return ctx, nil, err
+	}
+
+	// Authenticate the operator against the instance manager
+	ctx, err = certs.NewOperatorClientTLSConfigForContext(
+		ctx,
+		r.Client,
+		cluster.GetOperatorClientCASecretObjectKey(),
+	)
+	if err != nil {
+		return ctx, nil, err
+	}
+
+	return ctx, &pod, nil
+}
+
+// SetupWithManager sets up the controller with the Manager
+func (r *BackupGroupReconciler) SetupWithManager(mgr ctrl.Manager, maxConcurrentReconciles int) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		WithOptions(controller.Options{MaxConcurrentReconciles: maxConcurrentReconciles}).
+		For(&apiv1.BackupGroup{}).
+		Named("backup-group").
+		Owns(&apiv1.Backup{}).
+		Complete(r)
+}
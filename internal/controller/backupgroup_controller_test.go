@@ -0,0 +1,264 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package controller
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/certs"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres/webserver"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres/webserver/client/remote"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/url"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/specs"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// fakeRestorePointClient is an instance client recording the restore
+// points created on each Pod
+type fakeRestorePointClient struct {
+	remote.InstanceClient
+
+	mu            sync.Mutex
+	restorePoints map[string]string
+	failingPod    string
+}
+
+func (f *fakeRestorePointClient) CreateRestorePoint(_ context.Context, pod *corev1.Pod, name string) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if pod.Name == f.failingPod {
+		return "", fmt.Errorf("connection refused")
+	}
+	f.restorePoints[pod.Name] = name
+	return "0/3000028", nil
+}
+
+var _ = Describe("BackupGroup reconciler", func() {
+	var env *testingEnvironment
+	var reconciler *BackupGroupReconciler
+	var instanceClient *fakeRestorePointClient
+	var clusters []*apiv1.Cluster
+	var backupGroup *apiv1.BackupGroup
+	var caPair *certs.KeyPair
+
+	BeforeEach(func(ctx context.Context) {
+		env = buildTestEnvironment()
+		instanceClient = &fakeRestorePointClient{restorePoints: make(map[string]string)}
+		reconciler = &BackupGroupReconciler{
+			Client:         env.client,
+			Scheme:         env.scheme,
+			Recorder:       record.NewFakeRecorder(10),
+			instanceClient: instanceClient,
+		}
+
+		namespace := newFakeNamespace(env.client)
+
+		var err error
+		caPair, err = certs.CreateRootCA("backup-group", namespace)
+		Expect(err).ToNot(HaveOccurred())
+		caSecret := caPair.GenerateCASecret(namespace, "backup-group-ca")
+		Expect(env.client.Create(ctx, caSecret)).To(Succeed())
+
+		clusters = nil
+		for range 2 {
+			cluster := newFakeCNPGCluster(env.client, namespace, func(cluster *apiv1.Cluster) {
+				cluster.Spec.Certificates.ServerCASecret = caSecret.Name
+				cluster.Status.CurrentPrimary = cluster.Name + "-1"
+			})
+			Expect(env.client.Create(ctx,
+				caPair.GenerateCASecret(namespace, cluster.GetOperatorClientCASecretName()))).To(Succeed())
+			Expect(env.client.Create(ctx, &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: cluster.Status.CurrentPrimary, Namespace: namespace},
+			})).To(Succeed())
+			clusters = append(clusters, cluster)
+		}
+
+		backupGroup = &apiv1.BackupGroup{
+			ObjectMeta: metav1.ObjectMeta{Name: "group", Namespace: namespace},
+			Spec: apiv1.BackupGroupSpec{
+				Clusters: []apiv1.LocalObjectReference{
+					{Name: clusters[0].Name},
+					{Name: clusters[1].Name},
+				},
+				Method: apiv1.BackupMethodBarmanObjectStore,
+			},
+		}
+		Expect(env.client.Create(ctx, backupGroup)).To(Succeed())
+	})
+
+	reconcile := func(ctx context.Context) {
+		_, err := reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(backupGroup)})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(env.client.Get(ctx, client.ObjectKeyFromObject(backupGroup), backupGroup)).To(Succeed())
+	}
+
+	setBackupPhase := func(ctx context.Context, cluster *apiv1.Cluster, phase apiv1.BackupPhase) {
+		var backup apiv1.Backup
+		Expect(env.client.Get(ctx, client.ObjectKey{
+			Namespace: backupGroup.Namespace,
+			Name:      backupGroup.GetBackupName(cluster.Name),
+		}, &backup)).To(Succeed())
+		backup.Status.Phase = phase
+		Expect(env.client.Status().Update(ctx, &backup)).To(Succeed())
+	}
+
+	It("backs up the clusters and creates the restore point once they are completed", func(ctx context.Context) {
+		reconcile(ctx)
+		Expect(backupGroup.Status.Phase).To(Equal(apiv1.BackupGroupPhaseRunning))
+		Expect(backupGroup.Status.Backups).To(HaveLen(2))
+
+		var backups apiv1.BackupList
+		Expect(env.client.List(ctx, &backups, client.InNamespace(backupGroup.Namespace),
+			client.MatchingLabels{utils.BackupGroupLabelName: backupGroup.Name})).To(Succeed())
+		Expect(backups.Items).To(HaveLen(2))
+		for _, backup := range backups.Items {
+			Expect(metav1.IsControlledBy(&backup, backupGroup)).To(BeTrue())
+			Expect(backup.Spec.Method).To(Equal(apiv1.BackupMethodBarmanObjectStore))
+		}
+
+		setBackupPhase(ctx, clusters[0], apiv1.BackupPhaseCompleted)
+		reconcile(ctx)
+		Expect(backupGroup.Status.Phase).To(Equal(apiv1.BackupGroupPhaseRunning))
+		Expect(backupGroup.Status.Message).To(Equal("1 of 2 backups completed"))
+		Expect(instanceClient.restorePoints).To(BeEmpty())
+
+		setBackupPhase(ctx, clusters[1], apiv1.BackupPhaseCompleted)
+		reconcile(ctx)
+		Expect(backupGroup.Status.Phase).To(Equal(apiv1.BackupGroupPhaseCompleted))
+		Expect(backupGroup.Status.RestorePointName).To(Equal("group"))
+		Expect(backupGroup.Status.RestorePointTime).ToNot(BeNil())
+		Expect(backupGroup.Status.Backups[0].RestorePointLSN).To(Equal("0/3000028"))
+		Expect(instanceClient.restorePoints).To(Equal(map[string]string{
+			clusters[0].Status.CurrentPrimary: "group",
+			clusters[1].Status.CurrentPrimary: "group",
+		}))
+	})
+
+	It("fails when a backup fails", func(ctx context.Context) {
+		reconcile(ctx)
+		setBackupPhase(ctx, clusters[0], apiv1.BackupPhaseFailed)
+		reconcile(ctx)
+		Expect(backupGroup.Status.Phase).To(Equal(apiv1.BackupGroupPhaseFailed))
+		Expect(backupGroup.Status.Message).To(ContainSubstring(clusters[0].Name))
+	})
+
+	It("fails when the restore point can't be created on a cluster", func(ctx context.Context) {
+		instanceClient.failingPod = clusters[1].Status.CurrentPrimary
+		reconcile(ctx)
+		setBackupPhase(ctx, clusters[0], apiv1.BackupPhaseCompleted)
+		setBackupPhase(ctx, clusters[1], apiv1.BackupPhaseCompleted)
+		reconcile(ctx)
+		Expect(backupGroup.Status.Phase).To(Equal(apiv1.BackupGroupPhaseFailed))
+		Expect(backupGroup.Status.Message).To(ContainSubstring("connection refused"))
+		Expect(backupGroup.Status.RestorePointName).To(BeEmpty())
+	})
+
+	It("creates the restore point authenticating against the instance manager", func(ctx context.Context) {
+		listener, err := net.Listen("tcp", fmt.Sprintf("127.0.0.1:%d", url.StatusPort))
+		if err != nil {
+			Skip(fmt.Sprintf("the status port is not available: %v", err))
+		}
+
+		serverPair, err := caPair.CreateAndSignPair("127.0.0.1", certs.CertTypeServer, nil)
+		Expect(err).ToNot(HaveOccurred())
+		serverCertificate, err := tls.X509KeyPair(serverPair.Certificate, serverPair.Private)
+		Expect(err).ToNot(HaveOccurred())
+		caPool := x509.NewCertPool()
+		Expect(caPool.AppendCertsFromPEM(caPair.Certificate)).To(BeTrue())
+
+		var mu sync.Mutex
+		var restorePoints []string
+		server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if err := certs.VerifyOperatorClientCertificate(r.TLS.PeerCertificates, caPool); err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			if r.URL.Path != url.PathPgRestorePoint {
+				http.NotFound(w, r)
+				return
+			}
+
+			var request webserver.RestorePointRequest
+			if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			mu.Lock()
+			restorePoints = append(restorePoints, request.Name)
+			mu.Unlock()
+
+			_ = json.NewEncoder(w).Encode(webserver.Response[webserver.RestorePointResultData]{
+				Data: &webserver.RestorePointResultData{LSN: "0/3000028"},
+			})
+		}))
+		_ = server.Listener.Close()
+		server.Listener = listener
+		server.TLS = &tls.Config{
+			MinVersion:   tls.VersionTLS13,
+			Certificates: []tls.Certificate{serverCertificate},
+			ClientAuth:   tls.RequireAnyClientCert,
+		}
+		server.StartTLS()
+		DeferCleanup(server.Close)
+
+		for _, cluster := range clusters {
+			var pod corev1.Pod
+			Expect(env.client.Get(ctx, client.ObjectKey{
+				Namespace: cluster.Namespace,
+				Name:      cluster.Status.CurrentPrimary,
+			}, &pod)).To(Succeed())
+			pod.Spec.Containers = []corev1.Container{{
+				Name:    specs.PostgresContainerName,
+				Command: []string{"/controller/manager", "instance", "run", "--status-port-tls"},
+			}}
+			Expect(env.client.Update(ctx, &pod)).To(Succeed())
+			pod.Status.PodIP = "127.0.0.1"
+			Expect(env.client.Status().Update(ctx, &pod)).To(Succeed())
+		}
+		reconciler.instanceClient = remote.NewClient().Instance()
+
+		reconcile(ctx)
+		setBackupPhase(ctx, clusters[0], apiv1.BackupPhaseCompleted)
+		setBackupPhase(ctx, clusters[1], apiv1.BackupPhaseCompleted)
+		reconcile(ctx)
+		Expect(backupGroup.Status.Phase).To(Equal(apiv1.BackupGroupPhaseCompleted), backupGroup.Status.Message)
+		Expect(backupGroup.Status.Backups[1].RestorePointLSN).To(Equal("0/3000028"))
+		Expect(restorePoints).To(Equal([]string{"group", "group"}))
+	})
+})
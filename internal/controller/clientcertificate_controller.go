@@ -0,0 +1,291 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package controller
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/certs"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
+)
+
+// clientCertificateRecheckInterval is the maximum time between two
+// reconciliations of a ClientCertificate. It bounds the time needed to
+// reissue a certificate after the client CA of the cluster has been renewed
+const clientCertificateRecheckInterval = time.Hour
+
+// errClientCertificateUnsupportedWithCertManager is raised when the client
+// CA of the cluster is issued by cert-manager, which doesn't expose its
+// private key
+var errClientCertificateUnsupportedWithCertManager = errors.New(
+	"client certificates are unsupported with cert-manager: the private key of the client CA is not available, " +
+		"issue the certificate through a cert-manager Certificate instead")
+
+// ClientCertificateReconciler reconciles a ClientCertificate object
+type ClientCertificateReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+}
+
+// +kubebuilder:rbac:groups=postgresql.cnpg.io,resources=clientcertificates,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=postgresql.cnpg.io,resources=clientcertificates/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=create;list;get;watch;delete;update;patch
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+
+// Reconcile issues the client certificate and renews it before it expires
+func (r *ClientCertificateReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	contextLogger, ctx := log.SetupLogger(ctx)
+
+	var clientCertificate apiv1.ClientCertificate
+	if err := r.Get(ctx, req.NamespacedName, &clientCertificate); err != nil {
+		// The secret is owned by the ClientCertificate and will be
+		// garbage collected by Kubernetes
+		if apierrs.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	if !clientCertificate.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	renewalTime, err := r.reconcileCertificate(ctx, &clientCertificate)
+	if err != nil {
+		contextLogger.Warning("Cannot issue client certificate", "err", err.Error())
+		return ctrl.Result{RequeueAfter: 30 * time.Second}, r.failCertificate(ctx, &clientCertificate, err)
+	}
+
+	requeueAfter := time.Until(renewalTime)
+	if requeueAfter > clientCertificateRecheckInterval {
+		requeueAfter = clientCertificateRecheckInterval
+	}
+	if requeueAfter < time.Second {
+		requeueAfter = time.Second
+	}
+
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
+}
+
+// reconcileCertificate ensures the secret contains a valid certificate
+// signed by the client CA of the cluster, returning its renewal time
+func (r *ClientCertificateReconciler) reconcileCertificate(
+	ctx context.Context,
+	clientCertificate *apiv1.ClientCertificate,
+) (time.Time, error) {
+	if postgres.IsRoleReserved(clientCertificate.Spec.Role) {
+		return time.Time{}, fmt.Errorf("the role %q is reserved for PostgreSQL or the operator",
+			clientCertificate.Spec.Role)
+	}
+
+	var cluster apiv1.Cluster
+	if err := r.Get(ctx, client.ObjectKey{
+		Namespace: clientCertificate.Namespace,
+		Name:      clientCertificate.Spec.ClusterRef.Name,
+	}, &cluster); err != nil {
+		if apierrs.IsNotFound(err) {
+			return time.Time{}, fmt.Errorf("cluster %q not found", clientCertificate.Spec.ClusterRef.Name)
+		}
+		return time.Time{}, err
+	}
+
+	if cluster.IsCertManagerEnabled() {
+		return time.Time{}, errClientCertificateUnsupportedWithCertManager
+	}
+
+	var caSecret corev1.Secret
+	if err := r.Get(ctx, client.ObjectKey{
+		Namespace: cluster.Namespace,
+		Name:      cluster.GetClientCASecretName(),
+	}, &caSecret); err != nil {
+		return time.Time{}, fmt.Errorf("while reading the client CA secret: %w", err)
+	}
+
+	caPair, err := certs.ParseCASecret(&caSecret)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("while parsing the client CA secret %q: %w", caSecret.Name, err)
+	}
+
+	caCertificate, err := caPair.ParseCertificate()
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	secretName := clientCertificate.GetSecretName()
+	var secret corev1.Secret
+	err = r.Get(ctx, client.ObjectKey{Namespace: clientCertificate.Namespace, Name: secretName}, &secret)
+	switch {
+	case apierrs.IsNotFound(err):
+		return r.issueCertificate(ctx, clientCertificate, caPair, nil)
+	case err != nil:
+		return time.Time{}, err
+	}
+
+	if !metav1.IsControlledBy(&secret, clientCertificate) {
+		return time.Time{}, fmt.Errorf("secret %q exists and is not owned by this ClientCertificate", secretName)
+	}
+
+	pair, err := certs.ParseServerSecret(&secret)
+	if err != nil {
+		return r.issueCertificate(ctx, clientCertificate, caPair, &secret)
+	}
+
+	needsRenewal, err := isClientCertificateRenewalNeeded(pair, caCertificate, clientCertificate.Spec.Role)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if needsRenewal {
+		return r.issueCertificate(ctx, clientCertificate, caPair, &secret)
+	}
+
+	return r.markCertificateReady(ctx, clientCertificate, pair)
+}
+
+// isClientCertificateRenewalNeeded checks if a certificate is expiring,
+// belongs to a different role or hasn't been signed by the current CA
+func isClientCertificateRenewalNeeded(
+	pair *certs.KeyPair,
+	caCertificate *x509.Certificate,
+	role string,
+) (bool, error) {
+	expiring, _, err := pair.IsExpiring()
+	if err != nil || expiring {
+		return true, nil
+	}
+
+	certificate, err := pair.ParseCertificate()
+	if err != nil {
+		return true, nil
+	}
+	if certificate.Subject.CommonName != role {
+		return true, nil
+	}
+
+	signedByCA, err := pair.IsSignedBy(caCertificate)
+	if err != nil {
+		return false, err
+	}
+
+	return !signedByCA, nil
+}
+
+// issueCertificate creates a new certificate for the role and stores it
+// in the secret, creating it when needed
+func (r *ClientCertificateReconciler) issueCertificate(
+	ctx context.Context,
+	clientCertificate *apiv1.ClientCertificate,
+	caPair *certs.KeyPair,
+	secret *corev1.Secret,
+) (time.Time, error) {
+	pair, err := caPair.CreateAndSignPair(clientCertificate.Spec.Role, certs.CertTypeClient, nil)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	newSecret := pair.GenerateCertificateSecret(clientCertificate.Namespace, clientCertificate.GetSecretName())
+	if secret == nil {
+		if err := ctrl.SetControllerReference(clientCertificate, newSecret, r.Scheme); err != nil {
+			return time.Time{}, err
+		}
+		if err := r.Create(ctx, newSecret); err != nil {
+			return time.Time{}, err
+		}
+		r.Recorder.Eventf(clientCertificate, "Normal", "Issued",
+			"Issued client certificate for role %q", clientCertificate.Spec.Role)
+	} else {
+		origSecret := secret.DeepCopy()
+		secret.Data = newSecret.Data
+		if err := r.Patch(ctx, secret, client.MergeFrom(origSecret)); err != nil {
+			return time.Time{}, err
+		}
+		r.Recorder.Eventf(clientCertificate, "Normal", "Renewed",
+			"Renewed client certificate for role %q", clientCertificate.Spec.Role)
+	}
+
+	return r.markCertificateReady(ctx, clientCertificate, pair)
+}
+
+// markCertificateReady reports the expiration of the certificate in the
+// status, returning its renewal time
+func (r *ClientCertificateReconciler) markCertificateReady(
+	ctx context.Context,
+	clientCertificate *apiv1.ClientCertificate,
+	pair *certs.KeyPair,
+) (time.Time, error) {
+	certificate, err := pair.ParseCertificate()
+	if err != nil {
+		return time.Time{}, err
+	}
+	renewalTime, err := pair.GetRenewalTime()
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	origClientCertificate := clientCertificate.DeepCopy()
+	clientCertificate.SetAsReady(certificate.NotAfter, renewalTime)
+	if equality.Semantic.DeepEqual(origClientCertificate.Status, clientCertificate.Status) {
+		return renewalTime, nil
+	}
+
+	return renewalTime, r.Status().Patch(ctx, clientCertificate, client.MergeFrom(origClientCertificate))
+}
+
+// failCertificate reports the error in the status of the ClientCertificate
+func (r *ClientCertificateReconciler) failCertificate(
+	ctx context.Context,
+	clientCertificate *apiv1.ClientCertificate,
+	err error,
+) error {
+	origClientCertificate := clientCertificate.DeepCopy()
+	clientCertificate.SetAsFailed(err)
+	if equality.Semantic.DeepEqual(origClientCertificate.Status, clientCertificate.Status) {
+		return nil
+	}
+
+	r.Recorder.Event(clientCertificate, "Warning", "IssueFailed", err.Error())
+	return r.Status().Patch(ctx, clientCertificate, client.MergeFrom(origClientCertificate))
+}
+
+// SetupWithManager sets up the controller with the Manager
+func (r *ClientCertificateReconciler) SetupWithManager(mgr ctrl.Manager, maxConcurrentReconciles int) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		WithOptions(controller.Options{MaxConcurrentReconciles: maxConcurrentReconciles}).
+		For(&apiv1.ClientCertificate{}).
+		Named("client-certificate").
+		Owns(&corev1.Secret{}).
+		Complete(r)
+}
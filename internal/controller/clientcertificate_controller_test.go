@@ -0,0 +1,204 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/certs"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ClientCertificate reconciler", func() {
+	var env *testingEnvironment
+	var reconciler *ClientCertificateReconciler
+	var cluster *apiv1.Cluster
+	var clientCertificate *apiv1.ClientCertificate
+	var caPair *certs.KeyPair
+
+	BeforeEach(func(ctx context.Context) {
+		env = buildTestEnvironment()
+		reconciler = &ClientCertificateReconciler{
+			Client:   env.client,
+			Scheme:   env.scheme,
+			Recorder: record.NewFakeRecorder(10),
+		}
+		namespace := newFakeNamespace(env.client)
+		cluster = newFakeCNPGCluster(env.client, namespace)
+
+		var err error
+		caPair, err = certs.CreateRootCA(cluster.Name, namespace)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(env.client.Create(ctx, caPair.GenerateCASecret(namespace, cluster.GetClientCASecretName()))).
+			To(Succeed())
+
+		clientCertificate = &apiv1.ClientCertificate{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "app-cert",
+				Namespace: namespace,
+			},
+			Spec: apiv1.ClientCertificateSpec{
+				ClusterRef: corev1.LocalObjectReference{Name: cluster.Name},
+				Role:       "app",
+			},
+		}
+		Expect(env.client.Create(ctx, clientCertificate)).To(Succeed())
+	})
+
+	reconcile := func(ctx context.Context) ctrl.Result {
+		result, err := reconciler.Reconcile(ctx, ctrl.Request{
+			NamespacedName: client.ObjectKeyFromObject(clientCertificate),
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(env.client.Get(ctx, client.ObjectKeyFromObject(clientCertificate), clientCertificate)).To(Succeed())
+		return result
+	}
+
+	getPair := func(ctx context.Context) (*corev1.Secret, *certs.KeyPair) {
+		var secret corev1.Secret
+		Expect(env.client.Get(ctx, types.NamespacedName{
+			Namespace: clientCertificate.Namespace,
+			Name:      clientCertificate.GetSecretName(),
+		}, &secret)).To(Succeed())
+		pair, err := certs.ParseServerSecret(&secret)
+		Expect(err).ToNot(HaveOccurred())
+		return &secret, pair
+	}
+
+	It("issues a certificate for the role signed by the client CA", func(ctx context.Context) {
+		result := reconcile(ctx)
+		Expect(result.RequeueAfter).To(BeNumerically("<=", clientCertificateRecheckInterval))
+
+		secret, pair := getPair(ctx)
+		Expect(metav1.IsControlledBy(secret, clientCertificate)).To(BeTrue())
+		Expect(secret.Type).To(Equal(corev1.SecretTypeTLS))
+
+		certificate, err := pair.ParseCertificate()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(certificate.Subject.CommonName).To(Equal("app"))
+
+		caCertificate, err := caPair.ParseCertificate()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(pair.IsSignedBy(caCertificate)).To(BeTrue())
+
+		Expect(clientCertificate.Status.Ready).To(BeTrue())
+		Expect(clientCertificate.Status.Expiration.Time).To(BeTemporally("~", certificate.NotAfter, time.Second))
+		Expect(clientCertificate.Status.RenewalTime).ToNot(BeNil())
+	})
+
+	It("keeps a valid certificate untouched", func(ctx context.Context) {
+		reconcile(ctx)
+		_, pair := getPair(ctx)
+
+		reconcile(ctx)
+		_, newPair := getPair(ctx)
+		Expect(newPair.Certificate).To(Equal(pair.Certificate))
+	})
+
+	It("reissues the certificate when the role changes", func(ctx context.Context) {
+		reconcile(ctx)
+
+		clientCertificate.Spec.Role = "reporting"
+		Expect(env.client.Update(ctx, clientCertificate)).To(Succeed())
+		reconcile(ctx)
+
+		_, pair := getPair(ctx)
+		certificate, err := pair.ParseCertificate()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(certificate.Subject.CommonName).To(Equal("reporting"))
+	})
+
+	It("reissues the certificate when the client CA changes", func(ctx context.Context) {
+		reconcile(ctx)
+
+		newCAPair, err := certs.CreateRootCA(cluster.Name, cluster.Namespace)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(env.client.Update(ctx,
+			newCAPair.GenerateCASecret(cluster.Namespace, cluster.GetClientCASecretName()))).To(Succeed())
+		reconcile(ctx)
+
+		_, pair := getPair(ctx)
+		newCACertificate, err := newCAPair.ParseCertificate()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(pair.IsSignedBy(newCACertificate)).To(BeTrue())
+	})
+
+	It("refuses to overwrite a secret it doesn't own", func(ctx context.Context) {
+		Expect(env.client.Create(ctx, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      clientCertificate.GetSecretName(),
+				Namespace: clientCertificate.Namespace,
+			},
+		})).To(Succeed())
+
+		reconcile(ctx)
+		Expect(clientCertificate.Status.Ready).To(BeFalse())
+		Expect(clientCertificate.Status.Message).To(ContainSubstring("not owned"))
+	})
+
+	It("reports a missing cluster", func(ctx context.Context) {
+		clientCertificate.Spec.ClusterRef.Name = "missing"
+		Expect(env.client.Update(ctx, clientCertificate)).To(Succeed())
+
+		reconcile(ctx)
+		Expect(clientCertificate.Status.Ready).To(BeFalse())
+		Expect(clientCertificate.Status.Message).To(ContainSubstring("not found"))
+	})
+
+	It("reports that client certificates are unsupported with cert-manager", func(ctx context.Context) {
+		cluster.Spec.Certificates = &apiv1.CertificatesConfiguration{
+			CertManager: &apiv1.CertManagerConfiguration{
+				IssuerRef: apiv1.CertManagerIssuerReference{Name: "my-issuer"},
+			},
+		}
+		Expect(env.client.Update(ctx, cluster)).To(Succeed())
+
+		reconcile(ctx)
+		Expect(clientCertificate.Status.Ready).To(BeFalse())
+		Expect(clientCertificate.Status.Message).To(ContainSubstring("unsupported with cert-manager"))
+	})
+
+	It("refuses to sign a certificate for a reserved role", func(ctx context.Context) {
+		for _, role := range []string{"postgres", "streaming_replica", "cnpg_pooler_pgbouncer", "pg_monitor"} {
+			clientCertificate.Spec.Role = role
+			Expect(env.client.Update(ctx, clientCertificate)).To(Succeed())
+
+			reconcile(ctx)
+			Expect(clientCertificate.Status.Ready).To(BeFalse())
+			Expect(clientCertificate.Status.Message).To(ContainSubstring("is reserved"))
+		}
+
+		Expect(env.client.Get(ctx, types.NamespacedName{
+			Namespace: clientCertificate.Namespace,
+			Name:      clientCertificate.GetSecretName(),
+		}, &corev1.Secret{})).ToNot(Succeed())
+	})
+})
@@ -0,0 +1,368 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/resources/status"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/specs"
+)
+
+// canaryCheckInterval is how often the health of the canary instance
+// is checked during the soak time
+const canaryCheckInterval = 10 * time.Second
+
+// reconcileCanaryImage applies a new image, of the same major version of the
+// running one, using the canary strategy: the image is stored in the status
+// together with the canary rollout, which limits the rollout to a single
+// instance until it passes the health checks
+func (r *ClusterReconciler) reconcileCanaryImage(ctx context.Context, cluster *apiv1.Cluster, image string) error {
+	contextLogger := log.FromContext(ctx)
+	canary := cluster.Status.CanaryRollout
+
+	switch {
+	case image == cluster.Status.Image:
+		// The user requested again the image we are running. If the
+		// latest canary rollout has been rolled back, its image can
+		// now be tried again
+		if canary != nil && canary.Phase == apiv1.CanaryRolloutPhaseRolledBack {
+			return status.PatchWithOptimisticLock(ctx, r.Client, cluster, status.SetCanaryRollout(nil))
+		}
+		return nil
+
+	case canary != nil && canary.Phase == apiv1.CanaryRolloutPhaseRolledBack && canary.TargetImage == image:
+		contextLogger.Debug("Not applying an image whose canary rollout has been rolled back",
+			"image", image,
+			"currentImage", cluster.Status.Image)
+		return nil
+
+	case cluster.IsCanaryRolloutInProgress() && image == canary.PreviousImage:
+		contextLogger.Info("Canary rollout cancelled by the user",
+			"image", canary.TargetImage,
+			"previousImage", canary.PreviousImage)
+		cancelled := canary.DeepCopy()
+		cancelled.Phase = apiv1.CanaryRolloutPhaseRolledBack
+		cancelled.Message = fmt.Sprintf("Canary rollout of %s cancelled by the user", canary.TargetImage)
+		return status.PatchWithOptimisticLock(
+			ctx,
+			r.Client,
+			cluster,
+			status.SetImage(image),
+			status.SetCanaryRollout(cancelled),
+			status.SetCanaryRolloutCondition,
+		)
+	}
+
+	newCanary := &apiv1.CanaryRolloutStatus{
+		Phase:         apiv1.CanaryRolloutPhaseInProgress,
+		PreviousImage: cluster.Status.Image,
+		TargetImage:   image,
+		Message:       fmt.Sprintf("Rolling out %s to a canary instance", image),
+	}
+
+	// If the image changes while a canary rollout is in progress, the
+	// previous image is still the one that was running before it, and
+	// we keep using the same canary instance
+	if cluster.IsCanaryRolloutInProgress() {
+		newCanary.PreviousImage = canary.PreviousImage
+		newCanary.Instance = canary.Instance
+	}
+
+	contextLogger.Info("Starting a canary rollout",
+		"image", image,
+		"previousImage", newCanary.PreviousImage)
+	r.Recorder.Eventf(cluster, "Normal", "CanaryRolloutStarted",
+		"Rolling out %s to a canary instance", image)
+
+	return status.PatchWithOptimisticLock(
+		ctx,
+		r.Client,
+		cluster,
+		status.SetImage(image),
+		status.SetMajorVersionUpgradeFromImage(nil),
+		status.SetCanaryRollout(newCanary),
+		status.SetCanaryRolloutCondition,
+	)
+}
+
+// isRolloutAllowedByCanary checks if the passed instance can be rolled out
+// while a canary rollout is in progress. Until the canary instance passes
+// every check, only the canary instance can be updated to the new image.
+// The first replica to be rolled out is selected as the canary instance
+func (r *ClusterReconciler) isRolloutAllowedByCanary(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+	pod *corev1.Pod,
+	isPrimary bool,
+) (bool, error) {
+	if !cluster.IsCanaryRolloutInProgress() {
+		return true, nil
+	}
+
+	// Instances already running the new image can be rolled out
+	// for other reasons
+	if imageName, err := specs.GetPostgresImageName(*pod); err != nil || imageName == cluster.Status.Image {
+		return true, nil
+	}
+
+	canary := cluster.Status.CanaryRollout
+	switch {
+	case canary.Instance == pod.Name:
+		return true, nil
+
+	case canary.Instance == "" && !isPrimary:
+		log.FromContext(ctx).Info("Selected the canary instance", "instance", pod.Name)
+		selected := canary.DeepCopy()
+		selected.Instance = pod.Name
+		selected.Message = fmt.Sprintf("Rolling out %s to the canary instance %s", canary.TargetImage, pod.Name)
+		if err := status.PatchWithOptimisticLock(
+			ctx,
+			r.Client,
+			cluster,
+			status.SetCanaryRollout(selected),
+			status.SetCanaryRolloutCondition,
+		); err != nil {
+			return false, err
+		}
+		return true, nil
+
+	default:
+		return false, nil
+	}
+}
+
+// reconcileCanaryRollout checks the health of the canary instance once it
+// is running the new image. When the soak time passes with the canary
+// instance healthy, the canary rollout succeeds and the new image is rolled
+// out to the rest of the cluster. Otherwise, the cluster goes back to the
+// previous image
+func (r *ClusterReconciler) reconcileCanaryRollout(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+	instancesStatus postgres.PostgresqlStatusList,
+) (ctrl.Result, error) {
+	contextLogger := log.FromContext(ctx)
+
+	if !cluster.IsCanaryRolloutInProgress() || cluster.Status.CanaryRollout.Instance == "" {
+		return ctrl.Result{}, nil
+	}
+	canary := cluster.Status.CanaryRollout
+
+	var canaryStatus *postgres.PostgresqlStatus
+	for idx := range instancesStatus.Items {
+		if instancesStatus.Items[idx].Pod != nil && instancesStatus.Items[idx].Pod.Name == canary.Instance {
+			canaryStatus = &instancesStatus.Items[idx]
+			break
+		}
+	}
+	if canaryStatus == nil {
+		return ctrl.Result{}, nil
+	}
+
+	// Wait for the canary instance to be running the new image
+	if imageName, err := specs.GetPostgresImageName(*canaryStatus.Pod); err != nil ||
+		imageName != canary.TargetImage || !canaryStatus.HasHTTPStatus() {
+		return ctrl.Result{}, nil
+	}
+
+	if canary.SoakStartedAt == nil {
+		soaking := canary.DeepCopy()
+		now := metav1.Now()
+		soaking.SoakStartedAt = &now
+		soaking.LogErrorsCounter = canaryStatus.LogErrorsCount
+		soaking.ContainerRestarts = getPostgresContainerRestarts(canaryStatus.Pod)
+		soaking.Message = fmt.Sprintf("Checking the canary instance %s running %s",
+			canary.Instance, canary.TargetImage)
+		if err := status.PatchWithOptimisticLock(
+			ctx,
+			r.Client,
+			cluster,
+			status.SetCanaryRollout(soaking),
+			status.SetCanaryRolloutCondition,
+			status.SetPhase(apiv1.PhaseCanaryRollout, soaking.Message),
+			status.SetClusterReadyCondition,
+		); err != nil {
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{RequeueAfter: canaryCheckInterval}, ErrNextLoop
+	}
+
+	// The error log records are counted by the instance manager in memory,
+	// so they are accumulated in the status to survive its restarts
+	if checked := updateCanaryLogErrors(canary, canaryStatus); !equality.Semantic.DeepEqual(checked, canary) {
+		if err := status.PatchWithOptimisticLock(
+			ctx,
+			r.Client,
+			cluster,
+			status.SetCanaryRollout(checked),
+		); err != nil {
+			return ctrl.Result{}, err
+		}
+		canary = cluster.Status.CanaryRollout
+	}
+
+	if failure := getCanaryHealthFailure(cluster, instancesStatus, canaryStatus); failure != "" {
+		contextLogger.Warning("Canary instance failed a health check, rolling back",
+			"instance", canary.Instance,
+			"image", canary.TargetImage,
+			"previousImage", canary.PreviousImage,
+			"failure", failure)
+		r.Recorder.Eventf(cluster, "Warning", "CanaryRolledBack",
+			"Rolling back to %s, the canary instance %s failed a health check: %s",
+			canary.PreviousImage, canary.Instance, failure)
+
+		rolledBack := canary.DeepCopy()
+		rolledBack.Phase = apiv1.CanaryRolloutPhaseRolledBack
+		rolledBack.Message = fmt.Sprintf("The canary instance %s failed a health check: %s",
+			canary.Instance, failure)
+		if err := status.PatchWithOptimisticLock(
+			ctx,
+			r.Client,
+			cluster,
+			status.SetImage(canary.PreviousImage),
+			status.SetCanaryRollout(rolledBack),
+			status.SetCanaryRolloutCondition,
+		); err != nil {
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{RequeueAfter: 1 * time.Second}, ErrNextLoop
+	}
+
+	remaining := cluster.GetCanarySoakTime() - time.Since(canary.SoakStartedAt.Time)
+	if remaining > 0 {
+		contextLogger.Debug("Checking the canary instance",
+			"instance", canary.Instance,
+			"remainingSoakTime", remaining)
+		if cluster.Status.Phase != apiv1.PhaseCanaryRollout {
+			if err := r.RegisterPhase(ctx, cluster, apiv1.PhaseCanaryRollout, canary.Message); err != nil {
+				return ctrl.Result{}, err
+			}
+		}
+		return ctrl.Result{RequeueAfter: min(remaining, canaryCheckInterval)}, ErrNextLoop
+	}
+
+	contextLogger.Info("Canary instance passed every health check, continuing the rollout",
+		"instance", canary.Instance,
+		"image", canary.TargetImage)
+	r.Recorder.Eventf(cluster, "Normal", "CanarySucceeded",
+		"The canary instance %s passed every health check, rolling out %s",
+		canary.Instance, canary.TargetImage)
+
+	succeeded := canary.DeepCopy()
+	succeeded.Phase = apiv1.CanaryRolloutPhaseSucceeded
+	succeeded.Message = fmt.Sprintf("The canary instance %s passed every health check", canary.Instance)
+	return ctrl.Result{}, status.PatchWithOptimisticLock(
+		ctx,
+		r.Client,
+		cluster,
+		status.SetCanaryRollout(succeeded),
+		status.SetCanaryRolloutCondition,
+	)
+}
+
+// getCanaryHealthFailure checks the health of the canary instance,
+// returning the description of the failed check, if any
+func getCanaryHealthFailure(
+	cluster *apiv1.Cluster,
+	instancesStatus postgres.PostgresqlStatusList,
+	canaryStatus *postgres.PostgresqlStatus,
+) string {
+	canary := cluster.Status.CanaryRollout
+
+	if !canaryStatus.IsWalReceiverActive {
+		return "the instance is not streaming from the primary"
+	}
+
+	for _, item := range instancesStatus.Items {
+		if !item.IsPrimary || !item.HasHTTPStatus() {
+			continue
+		}
+
+		primaryLSN, err := item.CurrentLsn.Parse()
+		if err != nil {
+			break
+		}
+		replayLSN, err := canaryStatus.ReplayLsn.Parse()
+		if err != nil {
+			return fmt.Sprintf("cannot parse the replay LSN %q", canaryStatus.ReplayLsn)
+		}
+		if primaryLSN > replayLSN && primaryLSN-replayLSN > cluster.GetCanaryMaximumLag() {
+			return fmt.Sprintf("the replay lag is %d bytes, higher than the maximum of %d bytes",
+				primaryLSN-replayLSN, cluster.GetCanaryMaximumLag())
+		}
+		break
+	}
+
+	if canary.LogErrors > cluster.GetCanaryMaximumLogErrors() {
+		return fmt.Sprintf("%d error log records have been emitted, more than the maximum of %d",
+			canary.LogErrors, cluster.GetCanaryMaximumLogErrors())
+	}
+
+	if len(canaryStatus.CanaryProbeFailures) > 0 {
+		return fmt.Sprintf("failed SQL probes: %s", strings.Join(canaryStatus.CanaryProbeFailures, ", "))
+	}
+
+	return ""
+}
+
+// updateCanaryLogErrors adds to the status of the canary rollout the error
+// log records emitted by the canary instance since the last check. When the
+// PostgreSQL container has restarted, the counter of the instance manager
+// started again from zero, and every record it counted has been emitted
+// since the last check
+func updateCanaryLogErrors(
+	canary *apiv1.CanaryRolloutStatus,
+	canaryStatus *postgres.PostgresqlStatus,
+) *apiv1.CanaryRolloutStatus {
+	restarts := getPostgresContainerRestarts(canaryStatus.Pod)
+	emitted := canaryStatus.LogErrorsCount - canary.LogErrorsCounter
+	if restarts != canary.ContainerRestarts || emitted < 0 {
+		emitted = canaryStatus.LogErrorsCount
+	}
+
+	checked := canary.DeepCopy()
+	checked.LogErrors += emitted
+	checked.LogErrorsCounter = canaryStatus.LogErrorsCount
+	checked.ContainerRestarts = restarts
+	return checked
+}
+
+// getPostgresContainerRestarts gets the restart count of the PostgreSQL
+// container of a Pod
+func getPostgresContainerRestarts(pod *corev1.Pod) int32 {
+	for _, containerStatus := range pod.Status.ContainerStatuses {
+		if containerStatus.Name == specs.PostgresContainerName {
+			return containerStatus.RestartCount
+		}
+	}
+	return 0
+}
@@ -0,0 +1,221 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package controller
+
+import (
+	"time"
+
+	cnpgTypes "github.com/cloudnative-pg/machinery/pkg/types"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/specs"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Canary rollout", func() {
+	const (
+		previousImage = "ghcr.io/cloudnative-pg/postgresql:17.1"
+		targetImage   = "ghcr.io/cloudnative-pg/postgresql:17.2"
+	)
+
+	newPod := func(name, image string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{Name: specs.PostgresContainerName, Image: image}},
+			},
+			Status: corev1.PodStatus{
+				Conditions: []corev1.PodCondition{{Type: corev1.ContainersReady, Status: corev1.ConditionTrue}},
+			},
+		}
+	}
+
+	var env *testingEnvironment
+	var cluster *apiv1.Cluster
+	var instancesStatus postgres.PostgresqlStatusList
+
+	BeforeEach(func() {
+		env = buildTestEnvironment()
+		namespace := newFakeNamespace(env.client)
+		cluster = newFakeCNPGCluster(env.client, namespace, func(cluster *apiv1.Cluster) {
+			cluster.Spec.CanaryRollout = &apiv1.CanaryRolloutConfiguration{Enabled: true}
+			cluster.Status.Image = targetImage
+			cluster.Status.CurrentPrimary = cluster.Name + "-1"
+			cluster.Status.CanaryRollout = &apiv1.CanaryRolloutStatus{
+				Phase:         apiv1.CanaryRolloutPhaseInProgress,
+				PreviousImage: previousImage,
+				TargetImage:   targetImage,
+			}
+		})
+
+		instancesStatus = postgres.PostgresqlStatusList{
+			Items: []postgres.PostgresqlStatus{
+				{
+					Pod:        newPod(cluster.Name+"-1", previousImage),
+					IsPrimary:  true,
+					CurrentLsn: cnpgTypes.LSN("0/3000000"),
+				},
+				{
+					Pod:                 newPod(cluster.Name+"-2", previousImage),
+					IsWalReceiverActive: true,
+					ReplayLsn:           cnpgTypes.LSN("0/3000000"),
+				},
+				{
+					Pod:                 newPod(cluster.Name+"-3", targetImage),
+					IsWalReceiverActive: true,
+					ReplayLsn:           cnpgTypes.LSN("0/3000000"),
+				},
+			},
+		}
+	})
+
+	startSoak := func(ctx SpecContext, since time.Time) {
+		soakStartedAt := metav1.NewTime(since)
+		cluster.Status.CanaryRollout.Instance = cluster.Name + "-3"
+		cluster.Status.CanaryRollout.SoakStartedAt = &soakStartedAt
+		Expect(env.client.Status().Update(ctx, cluster)).To(Succeed())
+	}
+
+	It("selects the first replica to be rolled out as the canary instance", func(ctx SpecContext) {
+		replica := instancesStatus.Items[1].Pod
+		otherReplica := newPod(cluster.Name+"-4", previousImage)
+		primary := instancesStatus.Items[0].Pod
+
+		Expect(env.clusterReconciler.isRolloutAllowedByCanary(ctx, cluster, primary, true)).To(BeFalse())
+		Expect(env.clusterReconciler.isRolloutAllowedByCanary(ctx, cluster, replica, false)).To(BeTrue())
+		Expect(cluster.Status.CanaryRollout.Instance).To(Equal(replica.Name))
+		Expect(env.clusterReconciler.isRolloutAllowedByCanary(ctx, cluster, otherReplica, false)).To(BeFalse())
+		Expect(env.clusterReconciler.isRolloutAllowedByCanary(ctx, cluster, replica, false)).To(BeTrue())
+
+		By("allowing the rollout of instances already running the new image")
+		Expect(env.clusterReconciler.isRolloutAllowedByCanary(
+			ctx, cluster, instancesStatus.Items[2].Pod, false)).To(BeTrue())
+	})
+
+	It("starts the soak time when the canary instance runs the new image", func(ctx SpecContext) {
+		cluster.Status.CanaryRollout.Instance = cluster.Name + "-3"
+		instancesStatus.Items[2].LogErrorsCount = 7
+		Expect(env.client.Status().Update(ctx, cluster)).To(Succeed())
+
+		_, err := env.clusterReconciler.reconcileCanaryRollout(ctx, cluster, instancesStatus)
+		Expect(err).To(MatchError(ErrNextLoop))
+		Expect(cluster.Status.CanaryRollout.SoakStartedAt).ToNot(BeNil())
+		Expect(cluster.Status.CanaryRollout.LogErrorsCounter).To(BeEquivalentTo(7))
+		Expect(cluster.Status.CanaryRollout.LogErrors).To(BeZero())
+		Expect(cluster.Status.Phase).To(Equal(apiv1.PhaseCanaryRollout))
+	})
+
+	It("rolls back to the previous image when the canary instance is unhealthy", func(ctx SpecContext) {
+		startSoak(ctx, time.Now())
+		instancesStatus.Items[2].CanaryProbeFailures = []string{"orders: the query returned false"}
+
+		_, err := env.clusterReconciler.reconcileCanaryRollout(ctx, cluster, instancesStatus)
+		Expect(err).To(MatchError(ErrNextLoop))
+
+		var updatedCluster apiv1.Cluster
+		Expect(env.client.Get(ctx, client.ObjectKeyFromObject(cluster), &updatedCluster)).To(Succeed())
+		Expect(updatedCluster.Status.Image).To(Equal(previousImage))
+		Expect(updatedCluster.Status.CanaryRollout.Phase).To(Equal(apiv1.CanaryRolloutPhaseRolledBack))
+		condition := meta.FindStatusCondition(updatedCluster.Status.Conditions, string(apiv1.ConditionCanaryRollout))
+		Expect(condition).ToNot(BeNil())
+		Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+		Expect(condition.Reason).To(Equal(string(apiv1.ConditionReasonCanaryRolledBack)))
+	})
+
+	It("continues the rollout when the soak time passed", func(ctx SpecContext) {
+		startSoak(ctx, time.Now().Add(-time.Hour))
+
+		res, err := env.clusterReconciler.reconcileCanaryRollout(ctx, cluster, instancesStatus)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(res.IsZero()).To(BeTrue())
+		Expect(cluster.Status.CanaryRollout.Phase).To(Equal(apiv1.CanaryRolloutPhaseSucceeded))
+		condition := meta.FindStatusCondition(cluster.Status.Conditions, string(apiv1.ConditionCanaryRollout))
+		Expect(condition).ToNot(BeNil())
+		Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+
+		Expect(env.clusterReconciler.isRolloutAllowedByCanary(
+			ctx, cluster, instancesStatus.Items[1].Pod, false)).To(BeTrue())
+	})
+
+	It("checks the replication lag and the error log records of the canary instance", func() {
+		canaryStatus := &instancesStatus.Items[2]
+		Expect(getCanaryHealthFailure(cluster, instancesStatus, canaryStatus)).To(BeEmpty())
+
+		cluster.Status.CanaryRollout.LogErrors = apiv1.DefaultCanaryMaximumLogErrors + 1
+		Expect(getCanaryHealthFailure(cluster, instancesStatus, canaryStatus)).To(ContainSubstring("error log records"))
+
+		cluster.Status.CanaryRollout.LogErrors = 0
+		canaryStatus.ReplayLsn = cnpgTypes.LSN("0/0")
+		Expect(getCanaryHealthFailure(cluster, instancesStatus, canaryStatus)).To(ContainSubstring("replay lag"))
+
+		canaryStatus.IsWalReceiverActive = false
+		Expect(getCanaryHealthFailure(cluster, instancesStatus, canaryStatus)).To(ContainSubstring("not streaming"))
+	})
+
+	It("accumulates the error log records across the restarts of the instance manager", func() {
+		canary := &apiv1.CanaryRolloutStatus{LogErrorsCounter: 5}
+		canaryStatus := &instancesStatus.Items[2]
+
+		canaryStatus.LogErrorsCount = 8
+		canary = updateCanaryLogErrors(canary, canaryStatus)
+		Expect(canary.LogErrors).To(BeEquivalentTo(3))
+
+		By("counting every record after a restart of the PostgreSQL container")
+		canaryStatus.Pod.Status.ContainerStatuses = []corev1.ContainerStatus{
+			{Name: specs.PostgresContainerName, RestartCount: 1},
+		}
+		canaryStatus.LogErrorsCount = 9
+		canary = updateCanaryLogErrors(canary, canaryStatus)
+		Expect(canary.LogErrors).To(BeEquivalentTo(12))
+		Expect(canary.ContainerRestarts).To(BeEquivalentTo(1))
+
+		canaryStatus.LogErrorsCount = 10
+		canary = updateCanaryLogErrors(canary, canaryStatus)
+		Expect(canary.LogErrors).To(BeEquivalentTo(13))
+	})
+
+	It("rolls back when the errors logged before a restart exceed the maximum", func(ctx SpecContext) {
+		startSoak(ctx, time.Now())
+		cluster.Status.CanaryRollout.LogErrors = apiv1.DefaultCanaryMaximumLogErrors
+		cluster.Status.CanaryRollout.LogErrorsCounter = apiv1.DefaultCanaryMaximumLogErrors
+		Expect(env.client.Status().Update(ctx, cluster)).To(Succeed())
+
+		canaryStatus := &instancesStatus.Items[2]
+		canaryStatus.Pod.Status.ContainerStatuses = []corev1.ContainerStatus{
+			{Name: specs.PostgresContainerName, RestartCount: 1},
+		}
+		canaryStatus.LogErrorsCount = 1
+
+		_, err := env.clusterReconciler.reconcileCanaryRollout(ctx, cluster, instancesStatus)
+		Expect(err).To(MatchError(ErrNextLoop))
+
+		var updatedCluster apiv1.Cluster
+		Expect(env.client.Get(ctx, client.ObjectKeyFromObject(cluster), &updatedCluster)).To(Succeed())
+		Expect(updatedCluster.Status.CanaryRollout.Phase).To(Equal(apiv1.CanaryRolloutPhaseRolledBack))
+		Expect(updatedCluster.Status.CanaryRollout.LogErrors).To(BeEquivalentTo(apiv1.DefaultCanaryMaximumLogErrors + 1))
+	})
+})
@@ -0,0 +1,332 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/certs"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/resources/status"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+)
+
+// certManagerCertificateGVK is the kind of the cert-manager certificates
+var certManagerCertificateGVK = schema.GroupVersionKind{
+	Group:   "cert-manager.io",
+	Version: "v1",
+	Kind:    "Certificate",
+}
+
+// errCertManagerNotInstalled is raised when a cluster requires cert-manager
+// but its CRDs are not installed
+var errCertManagerNotInstalled = errors.New("cert-manager is not installed in the Kubernetes cluster")
+
+// certManagerCertificateRequest describes a certificate to be issued by
+// cert-manager
+type certManagerCertificateRequest struct {
+	// The name of the secret where the certificate will be stored, used
+	// also as the name of the Certificate resource
+	secretName string
+
+	// The common name of the certificate
+	commonName string
+
+	// The intended usage of the certificate
+	usage certs.CertType
+
+	// The DNS names of the certificate, only used for server certificates
+	dnsNames []string
+
+	// Additional labels to be set on the issued secret
+	secretLabels map[string]string
+}
+
+// newCertManagerCertificate creates an empty cert-manager Certificate
+func newCertManagerCertificate() *unstructured.Unstructured {
+	certificate := &unstructured.Unstructured{}
+	certificate.SetGroupVersionKind(certManagerCertificateGVK)
+	return certificate
+}
+
+// reconcileCertManagerCertificates ensures that cert-manager issues the
+// server and the streaming replication certificates of the cluster,
+// stopping the reconciliation loop until they are ready
+func (r *ClusterReconciler) reconcileCertManagerCertificates(ctx context.Context, cluster *apiv1.Cluster) error {
+	if !utils.HaveCertManager() {
+		return errCertManagerNotInstalled
+	}
+
+	requests := []certManagerCertificateRequest{
+		{
+			secretName: cluster.GetServerTLSSecretName(),
+			commonName: cluster.GetServiceReadWriteName(),
+			usage:      certs.CertTypeServer,
+			dnsNames:   cluster.GetClusterAltDNSNames(),
+		},
+		{
+			secretName: cluster.GetReplicationSecretName(),
+			commonName: apiv1.StreamingReplicationUser,
+			usage:      certs.CertTypeClient,
+		},
+	}
+
+	if err := r.ensureCertManagerCertificatesReady(ctx, cluster, requests); err != nil {
+		return err
+	}
+
+	return r.ensureCertManagerClientCASecret(ctx, cluster)
+}
+
+// ensureCertManagerClientCASecret stores the CA certificate of the issuer
+// in the client CA secret of the cluster. cert-manager doesn't expose the
+// private key of the CA, so the operator reports that it can't sign
+// client certificates
+func (r *ClusterReconciler) ensureCertManagerClientCASecret(ctx context.Context, cluster *apiv1.Cluster) error {
+	var replicationSecret corev1.Secret
+	if err := r.Get(ctx, client.ObjectKey{Namespace: cluster.Namespace, Name: cluster.GetReplicationSecretName()},
+		&replicationSecret); err != nil {
+		return fmt.Errorf("while reading the streaming replication secret: %w", err)
+	}
+
+	caCertificate := replicationSecret.Data[certs.CACertKey]
+	if len(caCertificate) == 0 {
+		return fmt.Errorf("missing %s in the secret %s issued by cert-manager",
+			certs.CACertKey, replicationSecret.Name)
+	}
+
+	desired := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: cluster.Namespace,
+			Name:      cluster.GetClientCASecretName(),
+			Labels: map[string]string{
+				utils.ClusterLabelName: cluster.Name,
+				utils.WatchedLabelName: "true",
+			},
+		},
+		Data: map[string][]byte{
+			certs.CACertKey: caCertificate,
+		},
+	}
+	utils.SetAsOwnedBy(&desired.ObjectMeta, cluster.ObjectMeta, cluster.TypeMeta)
+
+	var current corev1.Secret
+	err := r.Get(ctx, client.ObjectKeyFromObject(desired), &current)
+	switch {
+	case apierrors.IsNotFound(err):
+		if err := r.Create(ctx, desired); err != nil {
+			return err
+		}
+	case err != nil:
+		return err
+	case !equality.Semantic.DeepEqual(current.Data, desired.Data):
+		// The secret may contain the CA created by the operator before
+		// cert-manager was enabled, whose private key must be dropped
+		updated := current.DeepCopy()
+		updated.Data = desired.Data
+		if err := r.Update(ctx, updated); err != nil {
+			return err
+		}
+	}
+
+	return status.PatchConditionsWithOptimisticLock(ctx, r.Client, cluster, metav1.Condition{
+		Type:   string(apiv1.ConditionClientCertificateSigning),
+		Status: metav1.ConditionFalse,
+		Reason: string(apiv1.ConditionReasonUnsupportedWithCertManager),
+		Message: "The client CA is issued by cert-manager and its private key is not available: " +
+			"client certificates must be issued through cert-manager",
+	})
+}
+
+// ensureCertManagerCertificatesReady creates or updates the passed
+// certificates, and stops the reconciliation loop until all of them
+// have been issued
+func (r *ClusterReconciler) ensureCertManagerCertificatesReady(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+	requests []certManagerCertificateRequest,
+) error {
+	var notReady []string
+	for _, request := range requests {
+		ready, err := r.ensureCertManagerCertificate(ctx, cluster, request)
+		if err != nil {
+			return fmt.Errorf("while reconciling certificate %s: %w", request.secretName, err)
+		}
+		if !ready {
+			notReady = append(notReady, request.secretName)
+		}
+	}
+
+	if len(notReady) == 0 {
+		return nil
+	}
+
+	log.FromContext(ctx).Info("Waiting for cert-manager to issue the certificates",
+		"certificates", notReady)
+	if err := r.RegisterPhase(ctx, cluster, apiv1.PhaseWaitingForCertificates,
+		fmt.Sprintf("Waiting for certificates: %s", strings.Join(notReady, ", "))); err != nil {
+		return err
+	}
+	return ErrNextLoop
+}
+
+// ensureCertManagerCertificate creates or updates a cert-manager Certificate,
+// returning true if it has been issued
+func (r *ClusterReconciler) ensureCertManagerCertificate(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+	request certManagerCertificateRequest,
+) (bool, error) {
+	desired := buildCertManagerCertificate(cluster, request)
+
+	current := newCertManagerCertificate()
+	err := r.Get(ctx, client.ObjectKeyFromObject(desired), current)
+	if apierrors.IsNotFound(err) {
+		return false, r.Create(ctx, desired)
+	}
+	if err != nil {
+		return false, err
+	}
+
+	if !isCertManagerCertificateUpToDate(current, desired) {
+		updated := current.DeepCopy()
+		updated.Object["spec"] = desired.Object["spec"]
+		return false, r.Patch(ctx, updated, client.MergeFrom(current))
+	}
+
+	return isCertManagerCertificateReady(current), nil
+}
+
+// buildCertManagerCertificate creates the Certificate resource corresponding
+// to the passed request
+func buildCertManagerCertificate(
+	cluster *apiv1.Cluster,
+	request certManagerCertificateRequest,
+) *unstructured.Unstructured {
+	config := cluster.Spec.Certificates.CertManager
+
+	issuerKind := config.IssuerRef.Kind
+	if issuerKind == "" {
+		issuerKind = "Issuer"
+	}
+	issuerGroup := config.IssuerRef.Group
+	if issuerGroup == "" {
+		issuerGroup = certManagerCertificateGVK.Group
+	}
+
+	usage := "client auth"
+	if request.usage == certs.CertTypeServer {
+		usage = "server auth"
+	}
+
+	secretLabels := map[string]interface{}{
+		utils.ClusterLabelName: cluster.Name,
+	}
+	for key, value := range request.secretLabels {
+		secretLabels[key] = value
+	}
+
+	spec := map[string]interface{}{
+		"secretName": request.secretName,
+		"commonName": request.commonName,
+		"usages":     []interface{}{"digital signature", "key encipherment", usage},
+		"privateKey": map[string]interface{}{
+			"algorithm":      "ECDSA",
+			"size":           int64(256),
+			"rotationPolicy": "Always",
+		},
+		"issuerRef": map[string]interface{}{
+			"name":  config.IssuerRef.Name,
+			"kind":  issuerKind,
+			"group": issuerGroup,
+		},
+		"secretTemplate": map[string]interface{}{
+			"labels": secretLabels,
+		},
+	}
+	if len(request.dnsNames) > 0 {
+		dnsNames := make([]interface{}, 0, len(request.dnsNames))
+		for _, name := range request.dnsNames {
+			dnsNames = append(dnsNames, name)
+		}
+		spec["dnsNames"] = dnsNames
+	}
+	if config.Duration != nil {
+		spec["duration"] = config.Duration.Duration.String()
+	}
+	if config.RenewBefore != nil {
+		spec["renewBefore"] = config.RenewBefore.Duration.String()
+	}
+
+	objectMeta := metav1.ObjectMeta{}
+	cluster.SetInheritedDataAndOwnership(&objectMeta)
+
+	certificate := newCertManagerCertificate()
+	certificate.SetName(request.secretName)
+	certificate.SetNamespace(cluster.Namespace)
+	certificate.SetLabels(objectMeta.Labels)
+	certificate.SetAnnotations(objectMeta.Annotations)
+	certificate.SetOwnerReferences(objectMeta.OwnerReferences)
+	certificate.Object["spec"] = spec
+	return certificate
+}
+
+// isCertManagerCertificateUpToDate checks if every field of the desired
+// specification is set in the current Certificate
+func isCertManagerCertificateUpToDate(current, desired *unstructured.Unstructured) bool {
+	currentSpec, _, _ := unstructured.NestedMap(current.Object, "spec")
+	desiredSpec, _, _ := unstructured.NestedMap(desired.Object, "spec")
+	for key, value := range desiredSpec {
+		if !equality.Semantic.DeepEqual(currentSpec[key], value) {
+			return false
+		}
+	}
+	return true
+}
+
+// isCertManagerCertificateReady checks if cert-manager reported the
+// Certificate as ready for its latest generation
+func isCertManagerCertificateReady(certificate *unstructured.Unstructured) bool {
+	conditions, _, _ := unstructured.NestedSlice(certificate.Object, "status", "conditions")
+	for _, item := range conditions {
+		condition, ok := item.(map[string]interface{})
+		if !ok || condition["type"] != "Ready" {
+			continue
+		}
+		if observedGeneration, found, _ := unstructured.NestedInt64(condition, "observedGeneration"); found &&
+			observedGeneration < certificate.GetGeneration() {
+			return false
+		}
+		return condition["status"] == string(metav1.ConditionTrue)
+	}
+	return false
+}
@@ -0,0 +1,180 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package controller
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/certs"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("cert-manager integration", func() {
+	var env *testingEnvironment
+	var cluster *apiv1.Cluster
+
+	BeforeEach(func() {
+		utils.SetCertManager(true)
+		DeferCleanup(func() {
+			utils.SetCertManager(false)
+		})
+
+		env = buildTestEnvironment()
+		namespace := newFakeNamespace(env.client)
+		cluster = newFakeCNPGCluster(env.client, namespace, func(cluster *apiv1.Cluster) {
+			cluster.Spec.Certificates = &apiv1.CertificatesConfiguration{
+				ServerAltDNSNames: []string{"my-dns-name"},
+				CertManager: &apiv1.CertManagerConfiguration{
+					IssuerRef: apiv1.CertManagerIssuerReference{
+						Name: "my-issuer",
+						Kind: "ClusterIssuer",
+					},
+				},
+			}
+		})
+	})
+
+	getCertificate := func(ctx context.Context, name string) *unstructured.Unstructured {
+		certificate := newCertManagerCertificate()
+		Expect(env.client.Get(ctx, client.ObjectKey{Namespace: cluster.Namespace, Name: name}, certificate)).
+			To(Succeed())
+		return certificate
+	}
+
+	setReady := func(ctx context.Context, certificate *unstructured.Unstructured) {
+		Expect(unstructured.SetNestedSlice(certificate.Object, []interface{}{
+			map[string]interface{}{"type": "Ready", "status": "True"},
+		}, "status", "conditions")).To(Succeed())
+		Expect(env.client.Update(ctx, certificate)).To(Succeed())
+	}
+
+	issueSecret := func(ctx context.Context, name string) {
+		Expect(env.client.Create(ctx, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: cluster.Namespace, Name: name},
+			Data: map[string][]byte{
+				certs.CACertKey:         []byte("issuer-ca"),
+				corev1.TLSCertKey:       []byte("certificate"),
+				corev1.TLSPrivateKeyKey: []byte("key"),
+			},
+		})).To(Succeed())
+	}
+
+	getString := func(certificate *unstructured.Unstructured, fields ...string) string {
+		value, _, _ := unstructured.NestedString(certificate.Object, fields...)
+		return value
+	}
+
+	It("reads the server CA from the issued secret and keeps the dedicated client CA secret", func() {
+		Expect(cluster.GetServerCASecretName()).To(Equal(cluster.GetServerTLSSecretName()))
+		Expect(cluster.GetClientCASecretName()).To(Equal(cluster.Name + apiv1.ClientCaSecretSuffix))
+	})
+
+	It("creates the certificates and waits for them to be ready", func(ctx SpecContext) {
+		err := env.clusterReconciler.setupPostgresPKI(ctx, cluster)
+		Expect(err).To(MatchError(ErrNextLoop))
+		Expect(cluster.Status.Phase).To(Equal(apiv1.PhaseWaitingForCertificates))
+
+		server := getCertificate(ctx, cluster.GetServerTLSSecretName())
+		Expect(server.GetOwnerReferences()).To(HaveLen(1))
+		Expect(getString(server, "spec", "issuerRef", "kind")).To(Equal("ClusterIssuer"))
+		Expect(getString(server, "spec", "issuerRef", "group")).To(Equal("cert-manager.io"))
+		dnsNames, _, _ := unstructured.NestedStringSlice(server.Object, "spec", "dnsNames")
+		Expect(dnsNames).To(ContainElements("my-dns-name", cluster.GetServiceReadWriteName()))
+
+		replication := getCertificate(ctx, cluster.GetReplicationSecretName())
+		Expect(getString(replication, "spec", "commonName")).To(Equal(apiv1.StreamingReplicationUser))
+
+		setReady(ctx, server)
+		Expect(env.clusterReconciler.setupPostgresPKI(ctx, cluster)).To(MatchError(ErrNextLoop))
+
+		setReady(ctx, replication)
+		issueSecret(ctx, cluster.GetReplicationSecretName())
+		Expect(env.clusterReconciler.setupPostgresPKI(ctx, cluster)).To(Succeed())
+	})
+
+	It("stores the issuer CA in the client CA secret and reports that it can't sign", func(ctx SpecContext) {
+		Expect(env.client.Create(ctx, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: cluster.Namespace, Name: cluster.GetClientCASecretName()},
+			Data: map[string][]byte{
+				certs.CACertKey:       []byte("operator-ca"),
+				certs.CAPrivateKeyKey: []byte("operator-ca-key"),
+			},
+		})).To(Succeed())
+
+		Expect(env.clusterReconciler.setupPostgresPKI(ctx, cluster)).To(MatchError(ErrNextLoop))
+		setReady(ctx, getCertificate(ctx, cluster.GetServerTLSSecretName()))
+		setReady(ctx, getCertificate(ctx, cluster.GetReplicationSecretName()))
+		issueSecret(ctx, cluster.GetReplicationSecretName())
+		Expect(env.clusterReconciler.setupPostgresPKI(ctx, cluster)).To(Succeed())
+
+		var caSecret corev1.Secret
+		Expect(env.client.Get(ctx, client.ObjectKey{Namespace: cluster.Namespace, Name: cluster.GetClientCASecretName()},
+			&caSecret)).To(Succeed())
+		Expect(caSecret.Data).To(Equal(map[string][]byte{certs.CACertKey: []byte("issuer-ca")}))
+		Expect(certs.IsCAPrivateKeyAvailable(&caSecret)).To(BeFalse())
+
+		condition := meta.FindStatusCondition(cluster.Status.Conditions,
+			string(apiv1.ConditionClientCertificateSigning))
+		Expect(condition).ToNot(BeNil())
+		Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+		Expect(condition.Reason).To(Equal(string(apiv1.ConditionReasonUnsupportedWithCertManager)))
+	})
+
+	It("generates the operator client CA, which the operator can always sign with", func(ctx SpecContext) {
+		Expect(env.clusterReconciler.setupPostgresPKI(ctx, cluster)).To(MatchError(ErrNextLoop))
+
+		var caSecret corev1.Secret
+		Expect(env.client.Get(ctx, cluster.GetOperatorClientCASecretObjectKey(), &caSecret)).To(Succeed())
+		Expect(certs.IsCAPrivateKeyAvailable(&caSecret)).To(BeTrue())
+		Expect(caSecret.OwnerReferences).To(HaveLen(1))
+		Expect(caSecret.OwnerReferences[0].Name).To(Equal(cluster.Name))
+	})
+
+	It("updates the certificates when the DNS names change", func(ctx SpecContext) {
+		Expect(env.clusterReconciler.setupPostgresPKI(ctx, cluster)).To(MatchError(ErrNextLoop))
+		setReady(ctx, getCertificate(ctx, cluster.GetServerTLSSecretName()))
+		setReady(ctx, getCertificate(ctx, cluster.GetReplicationSecretName()))
+		issueSecret(ctx, cluster.GetReplicationSecretName())
+		Expect(env.clusterReconciler.setupPostgresPKI(ctx, cluster)).To(Succeed())
+
+		cluster.Spec.Certificates.ServerAltDNSNames = []string{"another-dns-name"}
+		Expect(env.clusterReconciler.setupPostgresPKI(ctx, cluster)).To(MatchError(ErrNextLoop))
+
+		dnsNames, _, _ := unstructured.NestedStringSlice(
+			getCertificate(ctx, cluster.GetServerTLSSecretName()).Object, "spec", "dnsNames")
+		Expect(dnsNames).To(ContainElement("another-dns-name"))
+		Expect(dnsNames).ToNot(ContainElement("my-dns-name"))
+	})
+
+	It("fails when cert-manager is not installed", func(ctx SpecContext) {
+		utils.SetCertManager(false)
+		Expect(env.clusterReconciler.setupPostgresPKI(ctx, cluster)).To(MatchError(errCertManagerNotInstalled))
+	})
+})
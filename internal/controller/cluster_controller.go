@@ -30,6 +30,7 @@ import (
 	"github.com/cloudnative-pg/machinery/pkg/log"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	policyv1 "k8s.io/api/policy/v1"
 	apierrs "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -123,8 +124,10 @@ var ErrNextLoop = utils.ErrNextLoop
 // +kubebuilder:rbac:groups=admissionregistration.k8s.io,resources=mutatingwebhookconfigurations,verbs=get;patch
 // +kubebuilder:rbac:groups=admissionregistration.k8s.io,resources=validatingwebhookconfigurations,verbs=get;patch
 // +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;delete;patch;create;watch
+// +kubebuilder:rbac:groups=cert-manager.io,resources=certificates,verbs=get;list;watch;create;patch
 // +kubebuilder:rbac:groups=coordination.k8s.io,resources=leases,verbs=get;create;update
 // +kubebuilder:rbac:groups=monitoring.coreos.com,resources=podmonitors,verbs=get;create;list;watch;delete;patch
+// +kubebuilder:rbac:groups=networking.k8s.io,resources=networkpolicies,verbs=get;create;list;watch;delete;patch
 // +kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=create;delete;get;list;watch;update;patch
 // +kubebuilder:rbac:groups=postgresql.cnpg.io,resources=clusters,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=postgresql.cnpg.io,resources=clusters/finalizers,verbs=update
@@ -227,7 +230,7 @@ func (r *ClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 	if err != nil {
 		return ctrl.Result{}, err
 	}
-	return result, nil
+	return requeueForSuperuserGrant(cluster, requeueForPasswordRotation(cluster, result)), nil
 }
 
 // Inner reconcile loop. Anything inside can require the reconciliation loop to stop by returning ErrNextLoop
@@ -351,6 +354,16 @@ func (r *ClusterReconciler) reconcile(ctx context.Context, cluster *apiv1.Cluste
 		return ctrl.Result{}, err
 	}
 
+	// Authenticate the operator against the instance manager
+	ctx, err = certs.NewOperatorClientTLSConfigForContext(
+		ctx,
+		r.Client,
+		cluster.GetOperatorClientCASecretObjectKey(),
+	)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
 	// Get the replication status
 	instancesStatus := r.InstanceClient.GetStatusFromInstances(ctx, resources.instances)
 
@@ -601,6 +614,10 @@ func (r *ClusterReconciler) handleSwitchover(
 			contextLogger.Info("Waiting for all WAL receivers to be down to elect a new primary")
 			return &ctrl.Result{RequeueAfter: 1 * time.Second}, nil
 		}
+		if errors.Is(err, ErrFailoverQuorumNotReached) {
+			contextLogger.Info("Waiting for the majority of the instances to confirm the primary is lost")
+			return &ctrl.Result{RequeueAfter: 1 * time.Second}, nil
+		}
 		contextLogger.Info("Cannot update target primary: operation cannot be fulfilled. "+
 			"An immediate retry will be scheduled",
 			"error", err)
@@ -767,6 +784,14 @@ func (r *ClusterReconciler) reconcileResources(
 		return ctrl.Result{RequeueAfter: 1 * time.Second}, ErrNextLoop
 	}
 
+	// Move the primary back to a preferred node if needed. A switchback
+	// waiting for the next maintenance window doesn't stop the reconciliation
+	res, err := r.reconcilePrimarySwitchback(ctx, cluster, resources, instancesStatus)
+	switchbackPostponed := errors.Is(err, errOutsideMaintenanceWindow)
+	if !switchbackPostponed && (!res.IsZero() || err != nil) {
+		return res, err
+	}
+
 	// When everything is reconciled, update the status
 	if err := r.RegisterPhase(ctx, cluster, apiv1.PhaseHealthy, ""); err != nil {
 		return ctrl.Result{}, err
@@ -774,6 +799,11 @@ func (r *ClusterReconciler) reconcileResources(
 
 	r.cleanupCompletedJobs(ctx, resources.jobs)
 
+	// Check again later if an action is waiting for the next maintenance window
+	if cluster.Status.PendingRollout != nil || switchbackPostponed {
+		return ctrl.Result{RequeueAfter: pendingRolloutCheckInterval}, nil
+	}
+
 	return ctrl.Result{}, nil
 }
 
@@ -1006,9 +1036,20 @@ func (r *ClusterReconciler) handleRollingUpdate(
 ) (ctrl.Result, error) {
 	contextLogger := log.FromContext(ctx).WithName("handle_rolling_update")
 
+	// Check the canary instance, if a canary rollout is in progress
+	if res, err := r.reconcileCanaryRollout(ctx, cluster, instancesStatus); !res.IsZero() || err != nil {
+		return res, err
+	}
+
 	// If we need to roll out a restart of any instance, this is the right moment
 	done, err := r.rolloutRequiredInstances(ctx, cluster, &instancesStatus)
 	switch {
+	case errors.Is(err, errOutsideMaintenanceWindow):
+		contextLogger.Info(
+			"A Pod need to be rolled out, waiting for the next maintenance window",
+			"pendingRollout", cluster.Status.PendingRollout,
+		)
+		return ctrl.Result{}, nil
 	case errors.Is(err, errLogShippingReplicaElected):
 		contextLogger.Warning(
 			"The primary needs to be restarted, but the chosen new primary is still " +
@@ -1052,7 +1093,15 @@ func (r *ClusterReconciler) handleRollingUpdate(
 
 	// Execute online update, if enabled and if not already executing
 	if cluster.Status.OnlineUpdateEnabled && cluster.Status.Phase != apiv1.PhaseOnlineUpgrading {
-		if err := r.upgradeInstanceManager(ctx, cluster, &instancesStatus); err != nil {
+		err := r.upgradeInstanceManager(ctx, cluster, &instancesStatus)
+		if errors.Is(err, errOutsideMaintenanceWindow) {
+			contextLogger.Info(
+				"An instance manager need to be upgraded, waiting for the next maintenance window",
+				"pendingRollout", cluster.Status.PendingRollout,
+			)
+			return ctrl.Result{}, nil
+		}
+		if err != nil {
 			return ctrl.Result{}, err
 		}
 		// Stop the reconciliation loop if upgradeInstanceManager initiated an upgrade
@@ -1061,7 +1110,8 @@ func (r *ClusterReconciler) handleRollingUpdate(
 		}
 	}
 
-	return ctrl.Result{}, nil
+	// Every instance is up to date, nothing is waiting for a maintenance window
+	return ctrl.Result{}, r.clearPendingRollout(ctx, cluster)
 }
 
 // SetupWithManager creates a ClusterReconciler
@@ -1071,7 +1121,7 @@ func (r *ClusterReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manag
 		return err
 	}
 
-	return ctrl.NewControllerManagedBy(mgr).
+	controllerBuilder := ctrl.NewControllerManagedBy(mgr).
 		WithOptions(controller.Options{
 			MaxConcurrentReconciles: maxConcurrentReconciles,
 		}).
@@ -1082,6 +1132,7 @@ func (r *ClusterReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manag
 		Owns(&corev1.Service{}).
 		Owns(&corev1.PersistentVolumeClaim{}).
 		Owns(&policyv1.PodDisruptionBudget{}).
+		Owns(&networkingv1.NetworkPolicy{}).
 		Watches(
 			&corev1.ConfigMap{},
 			handler.EnqueueRequestsFromMapFunc(r.mapConfigMapsToClusters()),
@@ -1110,8 +1161,15 @@ func (r *ClusterReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manag
 			&apiv1.ClusterImageCatalog{},
 			handler.EnqueueRequestsFromMapFunc(r.mapClusterImageCatalogsToClusters()),
 			builder.WithPredicates(predicate.ResourceVersionChangedPredicate{}),
-		).
-		Complete(r)
+		)
+
+	// The readiness of the certificates issued by cert-manager gates
+	// the creation of the instances
+	if utils.HaveCertManager() {
+		controllerBuilder = controllerBuilder.Owns(newCertManagerCertificate())
+	}
+
+	return controllerBuilder.Complete(r)
 }
 
 // jobOwnerIndexFunc maps a job definition to its owning cluster and
@@ -20,6 +20,7 @@ SPDX-License-Identifier: Apache-2.0
 package controller
 
 import (
+	"fmt"
 	"time"
 
 	cnpgTypes "github.com/cloudnative-pg/machinery/pkg/types"
@@ -211,6 +212,74 @@ var _ = Describe("Updating target primary", func() {
 		})
 	})
 
+	It("it should wait for the failover quorum to select the new target primary", func(ctx SpecContext) {
+		namespace := newFakeNamespace(env.client)
+		cluster := newFakeCNPGCluster(env.client, namespace, func(cluster *apiv1.Cluster) {
+			cluster.Spec.FailoverQuorum = &apiv1.FailoverQuorumConfiguration{Enabled: true}
+		})
+
+		By("creating the cluster resources")
+		jobs := generateFakeInitDBJobs(env.client, cluster)
+		instances := generateFakeClusterPods(env.client, cluster, true)
+		pvc := generateClusterPVC(env.client, cluster, persistentvolumeclaim.StatusReady)
+
+		managedResources := &managedResources{
+			nodes:     nil,
+			instances: corev1.PodList{Items: instances},
+			pvcs:      corev1.PersistentVolumeClaimList{Items: pvc},
+			jobs:      batchv1.JobList{Items: jobs},
+		}
+		statusList := postgres.PostgresqlStatusList{
+			Items: []postgres.PostgresqlStatus{
+				{
+					CurrentLsn:  cnpgTypes.LSN("0/0"),
+					ReceivedLsn: cnpgTypes.LSN("0/0"),
+					ReplayLsn:   cnpgTypes.LSN("0/0"),
+					IsPodReady:  true,
+					Pod:         &instances[0],
+				},
+				{
+					Pod:   &instances[1],
+					Error: fmt.Errorf("cannot reach the instance"),
+				},
+				{
+					CurrentLsn:          cnpgTypes.LSN("0/0"),
+					ReceivedLsn:         cnpgTypes.LSN("0/0"),
+					ReplayLsn:           cnpgTypes.LSN("0/0"),
+					IsPodReady:          true,
+					IsWalReceiverActive: true,
+					Pod:                 &instances[2],
+				},
+			},
+		}
+
+		cluster.Status.TargetPrimary = instances[1].Name
+		cluster.Status.CurrentPrimary = instances[1].Name
+
+		By("refusing the failover while a replica is still streaming from the primary", func() {
+			selectedPrimary, err := env.clusterReconciler.reconcileTargetPrimaryForNonReplicaCluster(
+				ctx,
+				cluster,
+				statusList,
+				managedResources,
+			)
+			Expect(err).To(Equal(ErrFailoverQuorumNotReached))
+			Expect(selectedPrimary).To(BeEmpty())
+		})
+
+		By("failing over once the majority confirms the primary is lost", func() {
+			statusList.Items[2].IsWalReceiverActive = false
+			selectedPrimary, err := env.clusterReconciler.reconcileTargetPrimaryForNonReplicaCluster(
+				ctx,
+				cluster,
+				statusList,
+				managedResources,
+			)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(selectedPrimary).To(Equal(instances[0].Name))
+		})
+	})
+
 	It("Issue #1783: ensure that the scale-down behaviour remain consistent", func(ctx SpecContext) {
 		namespace := newFakeNamespace(env.client)
 		cluster := newFakeCNPGCluster(env.client, namespace, func(cluster *apiv1.Cluster) {
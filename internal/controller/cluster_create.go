@@ -74,6 +74,11 @@ func (r *ClusterReconciler) createPostgresClusterObjects(ctx context.Context, cl
 		return err
 	}
 
+	err = persistentvolumeclaim.ReconcileBackupVolume(ctx, r.Client, cluster)
+	if err != nil {
+		return err
+	}
+
 	err = r.createOrPatchServiceAccount(ctx, cluster)
 	if err != nil {
 		return err
@@ -101,6 +106,11 @@ func (r *ClusterReconciler) createPostgresClusterObjects(ctx context.Context, cl
 		return err
 	}
 
+	err = createOrPatchNetworkPolicy(ctx, r.Client, specs.NewClusterNetworkPolicyManager(cluster))
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -161,6 +171,16 @@ func (r *ClusterReconciler) reconcilePostgresSecrets(ctx context.Context, cluste
 		return err
 	}
 
+	err = r.reconcilePasswordRotation(ctx, cluster)
+	if err != nil {
+		return err
+	}
+
+	err = r.reconcileSuperuserGrant(ctx, cluster)
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -169,6 +189,12 @@ func (r *ClusterReconciler) reconcileSuperuserSecret(ctx context.Context, cluste
 	// access is enabled and the user haven't specified his own
 	if cluster.GetEnableSuperuserAccess() &&
 		(cluster.Spec.SuperuserSecret == nil || cluster.Spec.SuperuserSecret.Name == "") {
+		// The credentials may be supplied by a plugin instead
+		supplied, err := isSecretSuppliedByPlugins(ctx, cluster, cluster.GetSuperuserSecretName())
+		if err != nil || supplied {
+			return err
+		}
+
 		postgresPassword, err := password.Generate(64, 10, 0, false, true)
 		if err != nil {
 			return err
@@ -210,6 +236,12 @@ func (r *ClusterReconciler) reconcileSuperuserSecret(ctx context.Context, cluste
 
 func (r *ClusterReconciler) reconcileAppUserSecret(ctx context.Context, cluster *apiv1.Cluster) error {
 	if cluster.ShouldCreateApplicationSecret() {
+		// The credentials may be supplied by a plugin instead
+		supplied, err := isSecretSuppliedByPlugins(ctx, cluster, cluster.GetApplicationSecretName())
+		if err != nil || supplied {
+			return err
+		}
+
 		appPassword, err := password.Generate(64, 10, 0, false, true)
 		if err != nil {
 			return err
@@ -266,6 +298,20 @@ func (r *ClusterReconciler) reconcilePoolerSecrets(ctx context.Context, cluster
 		return nil
 	}
 
+	if len(cluster.Status.PoolerIntegrations.PgBouncerIntegration.Secrets) > 0 && cluster.IsCertManagerEnabled() {
+		requests := make(
+			[]certManagerCertificateRequest, 0, len(cluster.Status.PoolerIntegrations.PgBouncerIntegration.Secrets))
+		for _, secretName := range cluster.Status.PoolerIntegrations.PgBouncerIntegration.Secrets {
+			requests = append(requests, certManagerCertificateRequest{
+				secretName:   secretName,
+				commonName:   apiv1.PGBouncerPoolerUserName,
+				usage:        certs.CertTypeClient,
+				secretLabels: map[string]string{utils.WatchedLabelName: "true"},
+			})
+		}
+		return r.ensureCertManagerCertificatesReady(ctx, cluster, requests)
+	}
+
 	if len(cluster.Status.PoolerIntegrations.PgBouncerIntegration.Secrets) > 0 {
 		var clientCaSecret corev1.Secret
 
@@ -21,6 +21,7 @@ package controller
 
 import (
 	"context"
+	"slices"
 
 	volumesnapshot "github.com/kubernetes-csi/external-snapshotter/client/v8/apis/volumesnapshot/v1"
 	v1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
@@ -40,6 +41,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	pluginClient "github.com/cloudnative-pg/cloudnative-pg/internal/cnpi/plugin/client"
 	"github.com/cloudnative-pg/cloudnative-pg/internal/configuration"
 	schemeBuilder "github.com/cloudnative-pg/cloudnative-pg/internal/scheme"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/specs"
@@ -139,6 +141,39 @@ var _ = Describe("cluster_create unit tests", func() {
 		})
 	})
 
+	It("should not create the secrets whose credentials are supplied by plugins", func(ctx SpecContext) {
+		namespace := newFakeNamespace(env.client)
+		cluster := newFakeCNPGCluster(env.client, namespace)
+		cluster.Spec.EnableSuperuserAccess = ptr.To(true)
+
+		pluginCtx := context.WithValue(ctx, utils.PluginClientKey, fakeCredentialsPluginClient{
+			secretNames: []string{cluster.GetApplicationSecretName()},
+		})
+
+		By("executing reconcilePostgresSecrets", func() {
+			err := env.clusterReconciler.reconcilePostgresSecrets(pluginCtx, cluster)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		By("making sure that the appUserSecret has not been created", func() {
+			err := env.client.Get(
+				ctx,
+				types.NamespacedName{Name: cluster.GetApplicationSecretName(), Namespace: namespace},
+				&corev1.Secret{},
+			)
+			Expect(apierrs.IsNotFound(err)).To(BeTrue())
+		})
+
+		By("making sure that the superUser secret has been created", func() {
+			err := env.client.Get(
+				ctx,
+				types.NamespacedName{Name: cluster.GetSuperuserSecretName(), Namespace: namespace},
+				&corev1.Secret{},
+			)
+			Expect(err).ToNot(HaveOccurred())
+		})
+	})
+
 	It("should make sure that reconcilePostgresServices works correctly", func(ctx SpecContext) {
 		namespace := newFakeNamespace(env.client)
 		cluster := newFakeCNPGCluster(env.client, namespace)
@@ -1344,3 +1379,24 @@ var _ = Describe("Service Reconciling", func() {
 		})
 	})
 })
+
+type fakeCredentialsPluginClient struct {
+	pluginClient.Client
+	secretNames []string
+}
+
+func (f fakeCredentialsPluginClient) GetCredentials(
+	_ context.Context,
+	_ *apiv1.Cluster,
+	secretName string,
+) (*pluginClient.Credentials, error) {
+	if !slices.Contains(f.secretNames, secretName) {
+		return nil, nil
+	}
+
+	return &pluginClient.Credentials{
+		PluginName: "fake-vault",
+		Data:       map[string][]byte{"username": []byte("app"), "password": []byte("secret")},
+		Version:    "1",
+	}, nil
+}
@@ -100,6 +100,13 @@ func (r *ClusterReconciler) reconcileImage(ctx context.Context, cluster *apiv1.C
 		image = currentDataImage
 	}
 
+	// Case 3: the image changes within the same major version, and the
+	// user requested the canary strategy to roll it out
+	if majorVersionUpgradeFromImage == nil && cluster.Status.MajorVersionUpgradeFromImage == nil &&
+		cluster.IsCanaryRolloutEnabled() && cluster.Spec.Instances > 1 {
+		return nil, r.reconcileCanaryImage(ctx, cluster, image)
+	}
+
 	return nil, status.PatchWithOptimisticLock(
 		ctx,
 		r.Client,
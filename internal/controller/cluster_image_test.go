@@ -211,4 +211,68 @@ var _ = Describe("Cluster image detection", func() {
 		Expect(cluster.Status.MajorVersionUpgradeFromImage).ToNot(BeNil())
 		Expect(*cluster.Status.MajorVersionUpgradeFromImage).To(Equal("postgres:16.2"))
 	})
+
+	It("starts a canary rollout for minor version updates", func(ctx SpecContext) {
+		cluster := &apiv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "cluster-example",
+				Namespace: "default",
+			},
+			Spec: apiv1.ClusterSpec{
+				Instances:     3,
+				ImageName:     "postgres:17.2",
+				CanaryRollout: &apiv1.CanaryRolloutConfiguration{Enabled: true},
+			},
+			Status: apiv1.ClusterStatus{
+				Image: "postgres:17.1",
+			},
+		}
+
+		r := newFakeReconcilerFor(cluster, nil)
+
+		result, err := r.reconcileImage(ctx, cluster)
+		Expect(err).Error().ShouldNot(HaveOccurred())
+		Expect(result).To(BeNil())
+
+		Expect(cluster.Status.Image).To(Equal("postgres:17.2"))
+		Expect(cluster.Status.CanaryRollout).ToNot(BeNil())
+		Expect(cluster.Status.CanaryRollout.Phase).To(Equal(apiv1.CanaryRolloutPhaseInProgress))
+		Expect(cluster.Status.CanaryRollout.PreviousImage).To(Equal("postgres:17.1"))
+	})
+
+	It("doesn't apply again an image whose canary rollout has been rolled back", func(ctx SpecContext) {
+		cluster := &apiv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "cluster-example",
+				Namespace: "default",
+			},
+			Spec: apiv1.ClusterSpec{
+				Instances:     3,
+				ImageName:     "postgres:17.2",
+				CanaryRollout: &apiv1.CanaryRolloutConfiguration{Enabled: true},
+			},
+			Status: apiv1.ClusterStatus{
+				Image: "postgres:17.1",
+				CanaryRollout: &apiv1.CanaryRolloutStatus{
+					Phase:         apiv1.CanaryRolloutPhaseRolledBack,
+					PreviousImage: "postgres:17.1",
+					TargetImage:   "postgres:17.2",
+				},
+			},
+		}
+
+		r := newFakeReconcilerFor(cluster, nil)
+
+		result, err := r.reconcileImage(ctx, cluster)
+		Expect(err).Error().ShouldNot(HaveOccurred())
+		Expect(result).To(BeNil())
+		Expect(cluster.Status.Image).To(Equal("postgres:17.1"))
+
+		By("allowing it again once the user requested the running image")
+		cluster.Spec.ImageName = "postgres:17.1"
+		result, err = r.reconcileImage(ctx, cluster)
+		Expect(err).Error().ShouldNot(HaveOccurred())
+		Expect(result).To(BeNil())
+		Expect(cluster.Status.CanaryRollout).To(BeNil())
+	})
 })
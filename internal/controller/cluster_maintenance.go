@@ -0,0 +1,120 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+)
+
+// pendingRolloutCheckInterval is how often we check if a maintenance
+// window opened while a rollout is pending
+const pendingRolloutCheckInterval = 1 * time.Minute
+
+// errOutsideMaintenanceWindow is raised when a disruptive action has been
+// postponed because the cluster is outside its maintenance windows
+var errOutsideMaintenanceWindow = errors.New("disruptive action postponed to the next maintenance window")
+
+// isDisruptionAllowed checks if the operator can perform a disruptive
+// action on the cluster at the passed time. This happens when no maintenance
+// window is defined, when one of them is open, or when the user requested
+// the pending rollout to be applied. The request contains the time when
+// the pending rollout has been postponed, as recorded by the operator, and
+// doesn't cover the rollouts postponed afterwards
+func isDisruptionAllowed(cluster *apiv1.Cluster, now time.Time) (bool, error) {
+	if len(cluster.Spec.MaintenanceWindows) == 0 {
+		return true, nil
+	}
+
+	if pending := cluster.Status.PendingRollout; pending != nil {
+		if value, ok := cluster.Annotations[utils.RunPendingRolloutAnnotationName]; ok {
+			requestedAt, err := time.Parse(time.RFC3339, value)
+			if err == nil && !requestedAt.Before(pending.Since.Time) {
+				return true, nil
+			}
+		}
+	}
+
+	return apiv1.IsInMaintenanceWindow(cluster.Spec.MaintenanceWindows, now)
+}
+
+// checkMaintenanceWindow returns errOutsideMaintenanceWindow when the
+// disruptive action on the target instance can't be performed now,
+// recording it as the pending rollout in the cluster status
+func (r *ClusterReconciler) checkMaintenanceWindow(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+	target string,
+	reason string,
+) error {
+	allowed, err := isDisruptionAllowed(cluster, time.Now())
+	if err != nil {
+		return err
+	}
+	if allowed {
+		return nil
+	}
+
+	pending := cluster.Status.PendingRollout
+	if pending != nil && pending.Target == target && pending.Reason == reason {
+		return errOutsideMaintenanceWindow
+	}
+
+	// We keep the time when the first action has been postponed, to not
+	// invalidate a request to apply the pending rollout done in the meantime
+	since := metav1.Now()
+	if pending != nil {
+		since = pending.Since
+	}
+
+	r.Recorder.Eventf(cluster, "Normal", "RolloutPostponed",
+		"Rollout of instance %s postponed to the next maintenance window: %s", target, reason)
+
+	origCluster := cluster.DeepCopy()
+	cluster.Status.PendingRollout = &apiv1.PendingRollout{
+		Target: target,
+		Reason: reason,
+		Since:  since,
+	}
+	if err := r.Status().Patch(ctx, cluster, client.MergeFrom(origCluster)); err != nil {
+		return err
+	}
+
+	return errOutsideMaintenanceWindow
+}
+
+// clearPendingRollout removes the pending rollout from the cluster status,
+// once every disruptive action has been performed
+func (r *ClusterReconciler) clearPendingRollout(ctx context.Context, cluster *apiv1.Cluster) error {
+	if cluster.Status.PendingRollout == nil {
+		return nil
+	}
+
+	origCluster := cluster.DeepCopy()
+	cluster.Status.PendingRollout = nil
+	return r.Status().Patch(ctx, cluster, client.MergeFrom(origCluster))
+}
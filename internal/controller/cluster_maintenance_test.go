@@ -0,0 +1,115 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package controller
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Maintenance windows", func() {
+	// Saturday, 10:00 UTC
+	now := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+	saturdayMorning := apiv1.MaintenanceWindow{
+		Schedule: "0 0 8 * * 6",
+		Duration: metav1.Duration{Duration: 4 * time.Hour},
+	}
+	sundayMorning := apiv1.MaintenanceWindow{
+		Schedule: "0 0 8 * * 0",
+		Duration: metav1.Duration{Duration: 4 * time.Hour},
+	}
+
+	It("allows disruptive actions when no window is defined", func() {
+		allowed, err := isDisruptionAllowed(&apiv1.Cluster{}, now)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(allowed).To(BeTrue())
+	})
+
+	It("allows disruptive actions only inside a window", func() {
+		cluster := &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{MaintenanceWindows: []apiv1.MaintenanceWindow{sundayMorning}},
+		}
+		allowed, err := isDisruptionAllowed(cluster, now)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(allowed).To(BeFalse())
+
+		cluster.Spec.MaintenanceWindows = append(cluster.Spec.MaintenanceWindows, saturdayMorning)
+		allowed, err = isDisruptionAllowed(cluster, now)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(allowed).To(BeTrue())
+	})
+
+	It("allows the pending rollout when the user requested it", func() {
+		cluster := &apiv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{
+					utils.RunPendingRolloutAnnotationName: now.Add(-time.Hour).Format(time.RFC3339),
+				},
+			},
+			Spec: apiv1.ClusterSpec{MaintenanceWindows: []apiv1.MaintenanceWindow{sundayMorning}},
+			Status: apiv1.ClusterStatus{
+				PendingRollout: &apiv1.PendingRollout{
+					Target: "cluster-example-2",
+					Since:  metav1.NewTime(now.Add(-30 * time.Minute)),
+				},
+			},
+		}
+		allowed, err := isDisruptionAllowed(cluster, now)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(allowed).To(BeFalse())
+
+		cluster.Annotations[utils.RunPendingRolloutAnnotationName] =
+			cluster.Status.PendingRollout.Since.Format(time.RFC3339)
+		allowed, err = isDisruptionAllowed(cluster, now)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(allowed).To(BeTrue())
+	})
+
+	It("records the postponed action in the cluster status", func(ctx SpecContext) {
+		env := buildTestEnvironment()
+		namespace := newFakeNamespace(env.client)
+		cluster := newFakeCNPGCluster(env.client, namespace, func(cluster *apiv1.Cluster) {
+			cluster.Spec.MaintenanceWindows = []apiv1.MaintenanceWindow{
+				{Schedule: "0 0 0 1 1 *", Duration: metav1.Duration{Duration: time.Second}},
+			}
+		})
+
+		err := env.clusterReconciler.checkMaintenanceWindow(ctx, cluster, cluster.Name+"-2", "new image")
+		Expect(err).To(MatchError(errOutsideMaintenanceWindow))
+
+		var updatedCluster apiv1.Cluster
+		Expect(env.client.Get(ctx, client.ObjectKeyFromObject(cluster), &updatedCluster)).To(Succeed())
+		Expect(updatedCluster.Status.PendingRollout).ToNot(BeNil())
+		Expect(updatedCluster.Status.PendingRollout.Target).To(Equal(cluster.Name + "-2"))
+		Expect(updatedCluster.Status.PendingRollout.Reason).To(Equal("new image"))
+
+		Expect(env.clusterReconciler.clearPendingRollout(ctx, &updatedCluster)).To(Succeed())
+		Expect(env.client.Get(ctx, client.ObjectKeyFromObject(cluster), &updatedCluster)).To(Succeed())
+		Expect(updatedCluster.Status.PendingRollout).To(BeNil())
+	})
+})
@@ -0,0 +1,216 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	"github.com/sethvargo/go-password/password"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/specs"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+)
+
+// reconcilePasswordRotation generates a new password for the managed roles
+// whose rotation is due, storing it in their password secret. The instance
+// manager applies it to PostgreSQL when it detects the change of the secret
+func (r *ClusterReconciler) reconcilePasswordRotation(ctx context.Context, cluster *apiv1.Cluster) error {
+	if cluster.Spec.Managed == nil {
+		return nil
+	}
+
+	origCluster := cluster.DeepCopy()
+	now := time.Now()
+	for _, role := range cluster.Spec.Managed.Roles {
+		if !isPasswordRotationEnabled(role) {
+			continue
+		}
+
+		state := cluster.Status.ManagedRolesStatus.PasswordStatus[role.Name]
+		if err := r.rotateRolePassword(ctx, cluster, role, &state, now); err != nil {
+			return err
+		}
+
+		if cluster.Status.ManagedRolesStatus.PasswordStatus == nil {
+			cluster.Status.ManagedRolesStatus.PasswordStatus = make(map[string]apiv1.PasswordState)
+		}
+		cluster.Status.ManagedRolesStatus.PasswordStatus[role.Name] = state
+	}
+
+	if equality.Semantic.DeepEqual(origCluster.Status, cluster.Status) {
+		return nil
+	}
+
+	return r.Status().Patch(ctx, cluster, client.MergeFrom(origCluster))
+}
+
+// rotateRolePassword rotates the password of a role when needed, updating
+// the passed password state
+func (r *ClusterReconciler) rotateRolePassword(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+	role apiv1.RoleConfiguration,
+	state *apiv1.PasswordState,
+	now time.Time,
+) error {
+	contextLogger := log.FromContext(ctx).WithValues("role", role.Name)
+
+	// The credentials supplied by a plugin are rotated by the plugin itself
+	if supplied, err := isSecretSuppliedByPlugins(ctx, cluster, role.GetRoleSecretsName()); err != nil || supplied {
+		return err
+	}
+
+	var secret corev1.Secret
+	err := r.Get(ctx, client.ObjectKey{Namespace: cluster.Namespace, Name: role.GetRoleSecretsName()}, &secret)
+	secretFound := err == nil
+	switch {
+	case apierrs.IsNotFound(err):
+		// The secret will be created with a new password
+	case err != nil:
+		return err
+	case state.LastRotation == nil:
+		// The secret has been provided by the user: it's the starting
+		// point of the rotation schedule
+		state.LastRotation = &metav1.Time{Time: now}
+		if role.IsPasswordRotationAlternating() {
+			state.ActiveRole = string(secret.Data["username"])
+		}
+		return nil
+	case now.Before(state.LastRotation.Add(role.PasswordRotation.Interval.Duration)):
+		return nil
+	}
+
+	username := role.Name
+	if role.IsPasswordRotationAlternating() && secretFound &&
+		string(secret.Data["username"]) == role.Name {
+		username = role.GetAlternateRoleName()
+	}
+
+	newPassword, err := password.Generate(64, 10, 0, false, true)
+	if err != nil {
+		return err
+	}
+
+	proposed := specs.CreateSecret(
+		role.GetRoleSecretsName(),
+		cluster.Namespace,
+		cluster.GetServiceReadWriteName(),
+		"*",
+		username,
+		newPassword,
+		utils.UserTypeApp)
+	proposed.Data = make(map[string][]byte, len(proposed.StringData))
+	for key, value := range proposed.StringData {
+		proposed.Data[key] = []byte(value)
+	}
+	proposed.StringData = nil
+
+	if !secretFound {
+		cluster.SetInheritedDataAndOwnership(&proposed.ObjectMeta)
+		if err := r.Create(ctx, proposed); err != nil {
+			return err
+		}
+	} else {
+		origSecret := secret.DeepCopy()
+		if secret.Data == nil {
+			secret.Data = make(map[string][]byte, len(proposed.Data))
+		}
+		for key, value := range proposed.Data {
+			secret.Data[key] = value
+		}
+		if err := r.Patch(ctx, &secret, client.MergeFrom(origSecret)); err != nil {
+			return err
+		}
+	}
+
+	contextLogger.Info("Rotated the password of a managed role", "activeRole", username)
+	r.Recorder.Eventf(cluster, "Normal", "RotatedPassword",
+		"Rotated the password of the managed role %s", role.Name)
+
+	state.LastRotation = &metav1.Time{Time: now}
+	if role.IsPasswordRotationAlternating() {
+		state.ActiveRole = username
+	}
+	return nil
+}
+
+// isPasswordRotationEnabled checks if the operator should rotate the
+// password of a managed role
+func isPasswordRotationEnabled(role apiv1.RoleConfiguration) bool {
+	return role.PasswordRotation != nil &&
+		role.PasswordSecret != nil &&
+		!role.DisablePassword &&
+		role.Ensure != apiv1.EnsureAbsent
+}
+
+// getNextPasswordRotation gets the time of the next password rotation
+// of the managed roles of the cluster, if any
+func getNextPasswordRotation(cluster *apiv1.Cluster) *time.Time {
+	if cluster.Spec.Managed == nil {
+		return nil
+	}
+
+	var result *time.Time
+	for _, role := range cluster.Spec.Managed.Roles {
+		if !isPasswordRotationEnabled(role) {
+			continue
+		}
+
+		lastRotation := cluster.Status.ManagedRolesStatus.PasswordStatus[role.Name].LastRotation
+		if lastRotation == nil {
+			continue
+		}
+
+		nextRotation := lastRotation.Add(role.PasswordRotation.Interval.Duration)
+		if result == nil || nextRotation.Before(*result) {
+			result = &nextRotation
+		}
+	}
+
+	return result
+}
+
+// requeueForPasswordRotation makes sure the cluster is reconciled again
+// when the next password rotation is due
+func requeueForPasswordRotation(cluster *apiv1.Cluster, result ctrl.Result) ctrl.Result {
+	nextRotation := getNextPasswordRotation(cluster)
+	if nextRotation == nil {
+		return result
+	}
+
+	requeueAfter := time.Until(*nextRotation)
+	if requeueAfter < time.Second {
+		requeueAfter = time.Second
+	}
+	if result.RequeueAfter == 0 || requeueAfter < result.RequeueAfter {
+		result.RequeueAfter = requeueAfter
+	}
+
+	return result
+}
@@ -0,0 +1,134 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("managed roles password rotation", func() {
+	const secretName = "app-password"
+
+	var env *testingEnvironment
+	var cluster *apiv1.Cluster
+
+	BeforeEach(func() {
+		env = buildTestEnvironment()
+		namespace := newFakeNamespace(env.client)
+		cluster = newFakeCNPGCluster(env.client, namespace, func(cluster *apiv1.Cluster) {
+			cluster.Spec.Managed = &apiv1.ManagedConfiguration{
+				Roles: []apiv1.RoleConfiguration{
+					{
+						Name:           "app_user",
+						Ensure:         apiv1.EnsurePresent,
+						Login:          true,
+						PasswordSecret: &apiv1.LocalObjectReference{Name: secretName},
+						PasswordRotation: &apiv1.PasswordRotationConfiguration{
+							Interval: metav1.Duration{Duration: 24 * time.Hour},
+							Strategy: apiv1.PasswordRotationStrategyAlternating,
+						},
+					},
+				},
+			}
+		})
+	})
+
+	getSecret := func(ctx context.Context) *corev1.Secret {
+		var secret corev1.Secret
+		Expect(env.client.Get(ctx, client.ObjectKey{Namespace: cluster.Namespace, Name: secretName}, &secret)).
+			To(Succeed())
+		return &secret
+	}
+
+	setLastRotation := func(lastRotation time.Time) {
+		state := cluster.Status.ManagedRolesStatus.PasswordStatus["app_user"]
+		state.LastRotation = &metav1.Time{Time: lastRotation}
+		cluster.Status.ManagedRolesStatus.PasswordStatus["app_user"] = state
+	}
+
+	It("creates the password secret when it doesn't exist", func(ctx context.Context) {
+		Expect(env.clusterReconciler.reconcilePasswordRotation(ctx, cluster)).To(Succeed())
+
+		secret := getSecret(ctx)
+		Expect(string(secret.Data["username"])).To(Equal("app_user"))
+		Expect(secret.Data["password"]).ToNot(BeEmpty())
+		_, owned := IsOwnedByCluster(secret)
+		Expect(owned).To(BeTrue())
+
+		state := cluster.Status.ManagedRolesStatus.PasswordStatus["app_user"]
+		Expect(state.LastRotation).ToNot(BeNil())
+		Expect(state.ActiveRole).To(Equal("app_user"))
+	})
+
+	It("starts the schedule from an existing secret without rotating it", func(ctx context.Context) {
+		Expect(env.client.Create(ctx, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: secretName, Namespace: cluster.Namespace},
+			Data: map[string][]byte{
+				"username": []byte("app_user"),
+				"password": []byte("initial"),
+			},
+		})).To(Succeed())
+
+		Expect(env.clusterReconciler.reconcilePasswordRotation(ctx, cluster)).To(Succeed())
+		Expect(string(getSecret(ctx).Data["password"])).To(Equal("initial"))
+		Expect(cluster.Status.ManagedRolesStatus.PasswordStatus["app_user"].LastRotation).ToNot(BeNil())
+	})
+
+	It("alternates between the role and its twin at each rotation", func(ctx context.Context) {
+		Expect(env.clusterReconciler.reconcilePasswordRotation(ctx, cluster)).To(Succeed())
+		firstPassword := string(getSecret(ctx).Data["password"])
+
+		// Not yet due
+		Expect(env.clusterReconciler.reconcilePasswordRotation(ctx, cluster)).To(Succeed())
+		Expect(string(getSecret(ctx).Data["password"])).To(Equal(firstPassword))
+
+		setLastRotation(time.Now().Add(-25 * time.Hour))
+		Expect(env.clusterReconciler.reconcilePasswordRotation(ctx, cluster)).To(Succeed())
+		secret := getSecret(ctx)
+		Expect(string(secret.Data["username"])).To(Equal("app_user_alt"))
+		Expect(string(secret.Data["password"])).ToNot(Equal(firstPassword))
+		Expect(cluster.Status.ManagedRolesStatus.PasswordStatus["app_user"].ActiveRole).To(Equal("app_user_alt"))
+
+		setLastRotation(time.Now().Add(-25 * time.Hour))
+		Expect(env.clusterReconciler.reconcilePasswordRotation(ctx, cluster)).To(Succeed())
+		Expect(string(getSecret(ctx).Data["username"])).To(Equal("app_user"))
+	})
+
+	It("requeues the reconciliation when the next rotation is due", func(ctx context.Context) {
+		Expect(env.clusterReconciler.reconcilePasswordRotation(ctx, cluster)).To(Succeed())
+
+		result := requeueForPasswordRotation(cluster, ctrl.Result{})
+		Expect(result.RequeueAfter).To(BeNumerically("~", 24*time.Hour, time.Minute))
+
+		result = requeueForPasswordRotation(cluster, ctrl.Result{RequeueAfter: time.Second})
+		Expect(result.RequeueAfter).To(Equal(time.Second))
+	})
+})
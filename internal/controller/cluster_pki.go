@@ -27,16 +27,26 @@ import (
 	"github.com/cloudnative-pg/machinery/pkg/log"
 	v1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/certs"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/resources/status"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
 )
 
 // setupPostgresPKI create all the PKI infrastructure that PostgreSQL need to work
 // if using ssl=on
 func (r *ClusterReconciler) setupPostgresPKI(ctx context.Context, cluster *apiv1.Cluster) error {
+	if err := r.ensureOperatorClientCASecret(ctx, cluster); err != nil {
+		return fmt.Errorf("generating operator client CA certificate: %w", err)
+	}
+
+	if cluster.IsCertManagerEnabled() {
+		return r.reconcileCertManagerCertificates(ctx, cluster)
+	}
+
 	// This is the CA of cluster
 	serverCaSecret, err := r.ensureServerCASecret(ctx, cluster)
 	if err != nil {
@@ -50,6 +60,10 @@ func (r *ClusterReconciler) setupPostgresPKI(ctx context.Context, cluster *apiv1
 		return fmt.Errorf("generating server TLS certificate: %w", err)
 	}
 
+	if err = r.markCALeavesReissued(ctx, serverCaSecret); err != nil {
+		return fmt.Errorf("updating server CA rotation phase: %w", err)
+	}
+
 	clientCaSecret, err := r.ensureClientCASecret(ctx, cluster)
 	if err != nil {
 		if apierrors.IsNotFound(err) {
@@ -67,7 +81,48 @@ func (r *ClusterReconciler) setupPostgresPKI(ctx context.Context, cluster *apiv1
 		return fmt.Errorf("generating streaming replication client certificate: %w", err)
 	}
 
-	return nil
+	if err = r.markCALeavesReissued(ctx, clientCaSecret); err != nil {
+		return fmt.Errorf("updating client CA rotation phase: %w", err)
+	}
+
+	return status.PatchConditionsWithOptimisticLock(ctx, r.Client, cluster,
+		getClientCertificateSigningCondition(clientCaSecret))
+}
+
+// getClientCertificateSigningCondition reports whether the operator can
+// sign client certificates with the passed client CA
+func getClientCertificateSigningCondition(clientCaSecret *v1.Secret) metav1.Condition {
+	if certs.IsCAPrivateKeyAvailable(clientCaSecret) {
+		return metav1.Condition{
+			Type:    string(apiv1.ConditionClientCertificateSigning),
+			Status:  metav1.ConditionTrue,
+			Reason:  string(apiv1.ConditionReasonClientCAKeyAvailable),
+			Message: "Client certificates can be signed with the client CA",
+		}
+	}
+
+	return metav1.Condition{
+		Type:   string(apiv1.ConditionClientCertificateSigning),
+		Status: metav1.ConditionFalse,
+		Reason: string(apiv1.ConditionReasonClientCAKeyMissing),
+		Message: fmt.Sprintf("The client CA secret %s doesn't contain the %s key",
+			clientCaSecret.Name, certs.CAPrivateKeyKey),
+	}
+}
+
+// ensureOperatorClientCASecret ensures that the CA issuing the client
+// certificate of the operator exists and is valid. This CA is always
+// generated, whatever the source of the other certificates of the cluster,
+// as the operator needs its private key
+func (r *ClusterReconciler) ensureOperatorClientCASecret(ctx context.Context, cluster *apiv1.Cluster) error {
+	caSecret, err := r.ensureCASecret(ctx, cluster, cluster.GetOperatorClientCASecretName())
+	if err != nil {
+		return err
+	}
+
+	// The client certificate of the operator is kept in memory and
+	// reissued as soon as the CA changes
+	return r.markCALeavesReissued(ctx, caSecret)
 }
 
 // ensureClientCASecret ensure that the cluster CA really exist and is valid
@@ -196,32 +251,26 @@ func (r *ClusterReconciler) ensureCASecret(ctx context.Context, cluster *apiv1.C
 	return derivedCaSecret, err
 }
 
-// renewCASecret check if this CA secret is valid and renew it if needed
+// renewCASecret advances the staged rotation of this CA secret if needed
 func (r *ClusterReconciler) renewCASecret(ctx context.Context, secret *v1.Secret) error {
-	pair, err := certs.ParseCASecret(secret)
-	if err != nil {
+	changed, err := certs.ReconcileCARotation(secret)
+	if err != nil || !changed {
 		return err
 	}
 
-	expiring, _, err := pair.IsExpiring()
-	if err != nil {
-		return err
-	}
-	if !expiring {
-		return nil
-	}
-
-	privateKey, err := pair.ParseECPrivateKey()
-	if err != nil {
-		return err
-	}
+	phase, _ := certs.GetCARotationPhase(secret)
+	log.FromContext(ctx).Info("Updating CA secret", "secret", secret.Name, "rotationPhase", phase)
+	return r.Update(ctx, secret)
+}
 
-	err = pair.RenewCertificate(privateKey, nil, nil)
-	if err != nil {
-		return err
+// markCALeavesReissued records that the leaf certificates signed by this CA
+// secret have been reissued, if a CA rotation is in progress
+func (r *ClusterReconciler) markCALeavesReissued(ctx context.Context, secret *v1.Secret) error {
+	if !certs.MarkCALeavesReissued(secret) {
+		return nil
 	}
 
-	secret.Data[certs.CACertKey] = pair.Certificate
+	log.FromContext(ctx).Info("Leaf certificates reissued by the rotated CA", "secret", secret.Name)
 	return r.Update(ctx, secret)
 }
 
@@ -0,0 +1,170 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package controller
+
+import (
+	"context"
+	"slices"
+
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	pgTime "github.com/cloudnative-pg/machinery/pkg/postgres/time"
+	"github.com/cloudnative-pg/machinery/pkg/types"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
+)
+
+// switchPrimaryInstance elects a new target primary, recording the change
+// in the primary history of the cluster
+func (r *ClusterReconciler) switchPrimaryInstance(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+	instancesStatus postgres.PostgresqlStatusList,
+	podName string,
+	reason apiv1.PrimaryChangeReason,
+) error {
+	change := newPrimaryChange(cluster, instancesStatus, podName, reason)
+	log.FromContext(ctx).Info("Recording primary change",
+		"oldPrimary", change.OldPrimary,
+		"newPrimary", change.NewPrimary,
+		"reason", change.Reason,
+		"lsn", change.LSN)
+
+	origCluster := cluster.DeepCopy()
+	cluster.Status.TargetPrimary = podName
+	cluster.Status.TargetPrimaryTimestamp = pgTime.GetCurrentTimestamp()
+	cluster.Status.PrimaryHistory = appendPrimaryChange(cluster.Status.PrimaryHistory, change)
+	return r.Status().Patch(ctx, cluster, client.MergeFrom(origCluster))
+}
+
+// newPrimaryChange creates the history entry describing the election of
+// the passed instance as the new primary
+func newPrimaryChange(
+	cluster *apiv1.Cluster,
+	instancesStatus postgres.PostgresqlStatusList,
+	podName string,
+	reason apiv1.PrimaryChangeReason,
+) apiv1.PrimaryChange {
+	change := apiv1.PrimaryChange{
+		Time:       metav1.Now(),
+		OldPrimary: cluster.Status.CurrentPrimary,
+		NewPrimary: podName,
+		Reason:     reason,
+	}
+
+	var candidateLSN, primaryLSN types.LSN
+	for _, item := range instancesStatus.Items {
+		if item.Pod == nil || item.Error != nil {
+			continue
+		}
+		switch item.Pod.Name {
+		case podName:
+			candidateLSN = getReceivedLSN(item)
+		case change.OldPrimary:
+			if item.IsPrimary {
+				primaryLSN = item.CurrentLsn
+			}
+		}
+	}
+	change.LSN = string(candidateLSN)
+
+	// The amount of data loss can be estimated only when the old
+	// primary is still able to report its position
+	if candidateLSN == "" || primaryLSN == "" {
+		return change
+	}
+	candidate, err := candidateLSN.Parse()
+	if err != nil {
+		return change
+	}
+	primary, err := primaryLSN.Parse()
+	if err != nil {
+		return change
+	}
+	var dataLoss int64
+	if primary > candidate {
+		dataLoss = int64(primary - candidate) //nolint:gosec
+	}
+	change.EstimatedDataLossBytes = &dataLoss
+	return change
+}
+
+// getReceivedLSN gets the most advanced position known to an instance
+func getReceivedLSN(item postgres.PostgresqlStatus) types.LSN {
+	switch {
+	case item.IsPrimary:
+		return item.CurrentLsn
+	case item.ReceivedLsn != "":
+		return item.ReceivedLsn
+	default:
+		return item.ReplayLsn
+	}
+}
+
+// appendPrimaryChange adds a change to the primary history, discarding
+// the oldest entries when needed. The passed slice is never modified.
+func appendPrimaryChange(history []apiv1.PrimaryChange, change apiv1.PrimaryChange) []apiv1.PrimaryChange {
+	result := append(slices.Clone(history), change)
+	if len(result) > apiv1.MaxPrimaryHistoryEntries {
+		result = result[len(result)-apiv1.MaxPrimaryHistoryEntries:]
+	}
+	return result
+}
+
+// updatePrimaryHistory records the primary changes that have not been
+// requested by the operator, such as a manual promotion, and completes the
+// latest entry with the timeline of the new primary once it has been
+// promoted
+func updatePrimaryHistory(cluster *apiv1.Cluster, instancesStatus postgres.PostgresqlStatusList) {
+	targetPrimary := cluster.Status.TargetPrimary
+	currentPrimary := cluster.Status.CurrentPrimary
+	history := cluster.Status.PrimaryHistory
+
+	if targetPrimary != "" && currentPrimary != "" &&
+		targetPrimary != currentPrimary &&
+		targetPrimary != apiv1.PendingFailoverMarker &&
+		(len(history) == 0 || history[len(history)-1].NewPrimary != targetPrimary) {
+		cluster.Status.PrimaryHistory = appendPrimaryChange(
+			history,
+			newPrimaryChange(cluster, instancesStatus, targetPrimary, apiv1.PrimaryChangeReasonPromote),
+		)
+		return
+	}
+
+	if len(history) == 0 {
+		return
+	}
+	last := history[len(history)-1]
+	if last.TimelineID != 0 || last.NewPrimary != currentPrimary || currentPrimary != targetPrimary {
+		return
+	}
+	for _, item := range instancesStatus.Items {
+		if item.Pod == nil || item.Pod.Name != currentPrimary || !item.IsPrimary || item.TimeLineID == 0 {
+			continue
+		}
+		// The history is cloned to avoid altering the status the
+		// caller is comparing against
+		cluster.Status.PrimaryHistory = slices.Clone(history)
+		cluster.Status.PrimaryHistory[len(history)-1].TimelineID = item.TimeLineID
+		return
+	}
+}
@@ -0,0 +1,139 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package controller
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Primary history", func() {
+	newPod := func(name string) *corev1.Pod {
+		return &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	}
+
+	var cluster *apiv1.Cluster
+	var status postgres.PostgresqlStatusList
+
+	BeforeEach(func() {
+		cluster = &apiv1.Cluster{
+			Status: apiv1.ClusterStatus{
+				CurrentPrimary: "cluster-1",
+				TargetPrimary:  "cluster-1",
+			},
+		}
+		status = postgres.PostgresqlStatusList{
+			Items: []postgres.PostgresqlStatus{
+				{Pod: newPod("cluster-1"), IsPrimary: true, CurrentLsn: "0/3000100", TimeLineID: 1},
+				{Pod: newPod("cluster-2"), ReceivedLsn: "0/3000000", ReplayLsn: "0/2000000"},
+			},
+		}
+	})
+
+	It("estimates the data loss when the old primary is reachable", func() {
+		change := newPrimaryChange(cluster, status, "cluster-2", apiv1.PrimaryChangeReasonSwitchover)
+		Expect(change.OldPrimary).To(Equal("cluster-1"))
+		Expect(change.NewPrimary).To(Equal("cluster-2"))
+		Expect(change.Reason).To(Equal(apiv1.PrimaryChangeReasonSwitchover))
+		Expect(change.LSN).To(Equal("0/3000000"))
+		Expect(change.EstimatedDataLossBytes).To(Equal(ptr.To(int64(0x100))))
+	})
+
+	It("doesn't estimate the data loss when the old primary is unreachable", func() {
+		status.Items = status.Items[1:]
+		change := newPrimaryChange(cluster, status, "cluster-2", apiv1.PrimaryChangeReasonFailover)
+		Expect(change.LSN).To(Equal("0/3000000"))
+		Expect(change.EstimatedDataLossBytes).To(BeNil())
+	})
+
+	It("keeps only the latest entries", func() {
+		var history []apiv1.PrimaryChange
+		for i := 0; i < apiv1.MaxPrimaryHistoryEntries+3; i++ {
+			history = appendPrimaryChange(history, apiv1.PrimaryChange{TimelineID: i + 1})
+		}
+		Expect(history).To(HaveLen(apiv1.MaxPrimaryHistoryEntries))
+		Expect(history[0].TimelineID).To(Equal(4))
+		Expect(history[apiv1.MaxPrimaryHistoryEntries-1].TimelineID).To(Equal(apiv1.MaxPrimaryHistoryEntries + 3))
+	})
+
+	It("records a manual promotion only once", func() {
+		cluster.Status.TargetPrimary = "cluster-2"
+		updatePrimaryHistory(cluster, status)
+		Expect(cluster.Status.PrimaryHistory).To(HaveLen(1))
+		Expect(cluster.Status.PrimaryHistory[0].Reason).To(Equal(apiv1.PrimaryChangeReasonPromote))
+		Expect(cluster.Status.PrimaryHistory[0].NewPrimary).To(Equal("cluster-2"))
+
+		updatePrimaryHistory(cluster, status)
+		Expect(cluster.Status.PrimaryHistory).To(HaveLen(1))
+	})
+
+	It("doesn't record a pending failover", func() {
+		cluster.Status.TargetPrimary = apiv1.PendingFailoverMarker
+		updatePrimaryHistory(cluster, status)
+		Expect(cluster.Status.PrimaryHistory).To(BeEmpty())
+	})
+
+	It("records the timeline of the new primary once promoted", func() {
+		history := []apiv1.PrimaryChange{
+			{OldPrimary: "cluster-1", NewPrimary: "cluster-2", Reason: apiv1.PrimaryChangeReasonFailover},
+		}
+		cluster.Status.PrimaryHistory = history
+		cluster.Status.CurrentPrimary = "cluster-2"
+		cluster.Status.TargetPrimary = "cluster-2"
+		status.Items = []postgres.PostgresqlStatus{
+			{Pod: newPod("cluster-2"), IsPrimary: true, TimeLineID: 2},
+		}
+
+		updatePrimaryHistory(cluster, status)
+		Expect(cluster.Status.PrimaryHistory[0].TimelineID).To(Equal(2))
+		Expect(history[0].TimelineID).To(BeZero())
+	})
+
+	It("records the primary change when electing a new primary", func(ctx context.Context) {
+		env := buildTestEnvironment()
+		namespace := newFakeNamespace(env.client)
+		cluster := newFakeCNPGCluster(env.client, namespace, func(cluster *apiv1.Cluster) {
+			cluster.Status.CurrentPrimary = "cluster-1"
+			cluster.Status.TargetPrimary = "cluster-1"
+		})
+
+		err := env.clusterReconciler.switchPrimaryInstance(
+			ctx, cluster, status, "cluster-2", apiv1.PrimaryChangeReasonSwitchover)
+		Expect(err).ToNot(HaveOccurred())
+
+		remoteCluster := &apiv1.Cluster{}
+		Expect(env.client.Get(ctx, types.NamespacedName{Name: cluster.Name, Namespace: cluster.Namespace},
+			remoteCluster)).To(Succeed())
+		Expect(remoteCluster.Status.TargetPrimary).To(Equal("cluster-2"))
+		Expect(remoteCluster.Status.PrimaryHistory).To(HaveLen(1))
+		Expect(remoteCluster.Status.PrimaryHistory[0].OldPrimary).To(Equal("cluster-1"))
+		Expect(remoteCluster.Status.PrimaryHistory[0].Reason).To(Equal(apiv1.PrimaryChangeReasonSwitchover))
+	})
+})
@@ -32,6 +32,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	apierrs "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 	"k8s.io/utils/strings/slices"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -190,6 +191,16 @@ func (r *ClusterReconciler) getManagedPVCs(
 		return corev1.PersistentVolumeClaimList{}, err
 	}
 
+	// The backup volume is not bound to any instance and is reconciled
+	// separately, we don't want it to be considered here
+	instancePVCs := make([]corev1.PersistentVolumeClaim, 0, len(childPVCs.Items))
+	for _, pvc := range childPVCs.Items {
+		if pvc.Labels[utils.PvcRoleLabelName] != string(utils.PVCRolePgBackup) {
+			instancePVCs = append(instancePVCs, pvc)
+		}
+	}
+	childPVCs.Items = instancePVCs
+
 	sort.Slice(childPVCs.Items, func(i, j int) bool {
 		return childPVCs.Items[i].Name < childPVCs.Items[j].Name
 	})
@@ -510,6 +521,7 @@ func (r *ClusterReconciler) refreshCertsExpirations(ctx context.Context, cluster
 	namespace := cluster.GetNamespace()
 
 	cluster.Status.Certificates.Expirations = make(map[string]string, 4)
+	cluster.Status.Certificates.CARotations = nil
 	certificates := cluster.Status.Certificates
 
 	err := r.setCertExpiration(ctx, cluster, certificates.ServerCASecret, namespace, certs.CACertKey)
@@ -564,6 +576,17 @@ func (r *ClusterReconciler) setCertExpiration(ctx context.Context, cluster *apiv
 
 	cluster.Status.Certificates.Expirations[secretName] = expDate.String()
 
+	if phase, since := certs.GetCARotationPhase(&secret); certKey == certs.CACertKey && phase != "" {
+		if cluster.Status.Certificates.CARotations == nil {
+			cluster.Status.Certificates.CARotations = make(map[string]apiv1.CARotationStatus)
+		}
+		rotation := apiv1.CARotationStatus{Phase: string(phase)}
+		if !since.IsZero() {
+			rotation.Since = ptr.To(metav1.NewTime(since))
+		}
+		cluster.Status.Certificates.CARotations[secretName] = rotation
+	}
+
 	return nil
 }
 
@@ -770,6 +793,8 @@ func (r *ClusterReconciler) updateClusterStatusThatRequiresInstancesState(
 		}
 	}
 
+	updatePrimaryHistory(cluster, statuses)
+
 	if !reflect.DeepEqual(existingClusterStatus, cluster.Status) {
 		return r.Status().Update(ctx, cluster)
 	}
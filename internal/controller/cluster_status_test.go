@@ -173,4 +173,31 @@ var _ = Describe("cluster_status unit tests", func() {
 			}))
 		})
 	})
+
+	It("makes sure that the backup volume isn't considered an instance PVC", func(ctx SpecContext) {
+		crReconciler := &ClusterReconciler{
+			Client: fakeClientWithIndexAdapter{
+				Client: env.clusterReconciler.Client,
+			},
+			Scheme:   env.clusterReconciler.Scheme,
+			Recorder: env.clusterReconciler.Recorder,
+		}
+
+		namespace := newFakeNamespace(env.client)
+		cluster := newFakeCNPGCluster(env.client, namespace)
+		cluster.Spec.Backup = &v1.BackupConfiguration{
+			PersistentVolume: &v1.PersistentVolumeBackupConfiguration{
+				Storage: &v1.StorageConfiguration{Size: "1Gi"},
+			},
+		}
+		pvcs := generateClusterPVC(crReconciler.Client, cluster, persistentvolumeclaim.StatusReady)
+		Expect(persistentvolumeclaim.ReconcileBackupVolume(ctx, crReconciler.Client, cluster)).To(Succeed())
+
+		managedPVCs, err := crReconciler.getManagedPVCs(ctx, cluster)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(managedPVCs.Items).To(HaveLen(len(pvcs)))
+		for _, pvc := range managedPVCs.Items {
+			Expect(pvc.Name).ToNot(Equal(cluster.GetPersistentVolumeBackupClaimName()))
+		}
+	})
 })
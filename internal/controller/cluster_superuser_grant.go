@@ -0,0 +1,195 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	"github.com/sethvargo/go-password/password"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/specs"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+)
+
+// reconcileSuperuserGrant grants or revokes the temporary superuser access
+// as requested by the `cnpg.io/superuserGrantUntil` annotation. The credentials
+// of the temporary role are stored in a secret owned by the cluster, and the
+// instance manager creates and drops the role following the cluster status
+func (r *ClusterReconciler) reconcileSuperuserGrant(ctx context.Context, cluster *apiv1.Cluster) error {
+	contextLogger := log.FromContext(ctx)
+	now := time.Now()
+
+	expiresAt, err := getRequestedSuperuserGrantExpiration(cluster)
+	if err != nil {
+		contextLogger.Warning("Ignoring invalid superuser grant annotation",
+			"annotation", utils.SuperuserGrantUntilAnnotationName, "error", err)
+		r.Recorder.Eventf(cluster, "Warning", "InvalidSuperuserGrant",
+			"Ignoring invalid %s annotation: %v", utils.SuperuserGrantUntilAnnotationName, err)
+		return nil
+	}
+
+	origCluster := cluster.DeepCopy()
+	switch {
+	case expiresAt != nil && now.Before(*expiresAt):
+		if err := r.grantSuperuserAccess(ctx, cluster, now, *expiresAt); err != nil {
+			return err
+		}
+
+	case cluster.Status.SuperuserGrant != nil:
+		if err := r.deleteSuperuserGrantSecret(ctx, cluster); err != nil {
+			return err
+		}
+
+		contextLogger.Info("Revoked temporary superuser access",
+			"role", cluster.Status.SuperuserGrant.RoleName)
+		r.Recorder.Eventf(cluster, "Normal", "SuperuserAccessRevoked",
+			"Revoked the temporary superuser access of role %s", cluster.Status.SuperuserGrant.RoleName)
+		cluster.Status.SuperuserGrant = nil
+	}
+
+	if equality.Semantic.DeepEqual(origCluster.Status, cluster.Status) {
+		return nil
+	}
+
+	return r.Status().Patch(ctx, cluster, client.MergeFrom(origCluster))
+}
+
+// grantSuperuserAccess creates the secret containing the credentials of the
+// temporary superuser role, or extends the expiration of an existing grant
+func (r *ClusterReconciler) grantSuperuserAccess(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+	now time.Time,
+	expiresAt time.Time,
+) error {
+	contextLogger := log.FromContext(ctx)
+
+	grant := cluster.Status.SuperuserGrant
+	if grant != nil && grant.ExpiresAt.Equal(&metav1.Time{Time: expiresAt}) {
+		return nil
+	}
+
+	if grant == nil {
+		// A new grant always comes with a brand-new password: we remove
+		// any leftover of a previous one
+		if err := r.deleteSuperuserGrantSecret(ctx, cluster); err != nil {
+			return err
+		}
+
+		grantPassword, err := password.Generate(64, 10, 0, false, true)
+		if err != nil {
+			return err
+		}
+		grantSecret := specs.CreateSecret(
+			cluster.GetSuperuserGrantSecretName(),
+			cluster.Namespace,
+			cluster.GetServiceReadWriteName(),
+			"*",
+			apiv1.SuperuserGrantRoleName,
+			grantPassword,
+			utils.UserTypeSuperuser)
+		cluster.SetInheritedDataAndOwnership(&grantSecret.ObjectMeta)
+		if err := r.Create(ctx, grantSecret); err != nil {
+			return err
+		}
+
+		grant = &apiv1.SuperuserGrant{
+			RoleName:   apiv1.SuperuserGrantRoleName,
+			SecretName: cluster.GetSuperuserGrantSecretName(),
+			GrantedAt:  metav1.Time{Time: now},
+		}
+	} else {
+		grant = grant.DeepCopy()
+	}
+	grant.ExpiresAt = metav1.Time{Time: expiresAt}
+	cluster.Status.SuperuserGrant = grant
+
+	contextLogger.Info("Granted temporary superuser access",
+		"role", grant.RoleName, "expiresAt", expiresAt)
+	r.Recorder.Eventf(cluster, "Normal", "SuperuserAccessGranted",
+		"Granted temporary superuser access to role %s until %s, credentials stored in secret %s",
+		grant.RoleName, expiresAt.Format(time.RFC3339), grant.SecretName)
+	return nil
+}
+
+// deleteSuperuserGrantSecret deletes the secret containing the credentials
+// of the temporary superuser role, if it exists and is owned by the cluster
+func (r *ClusterReconciler) deleteSuperuserGrantSecret(ctx context.Context, cluster *apiv1.Cluster) error {
+	var secret corev1.Secret
+	err := r.Get(
+		ctx,
+		client.ObjectKey{Namespace: cluster.Namespace, Name: cluster.GetSuperuserGrantSecretName()},
+		&secret)
+	if apierrs.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if _, owned := IsOwnedByCluster(&secret); !owned {
+		return nil
+	}
+
+	return client.IgnoreNotFound(r.Delete(ctx, &secret))
+}
+
+// getRequestedSuperuserGrantExpiration gets the expiration time of the
+// temporary superuser access requested via annotation, if any
+func getRequestedSuperuserGrantExpiration(cluster *apiv1.Cluster) (*time.Time, error) {
+	value, ok := cluster.Annotations[utils.SuperuserGrantUntilAnnotationName]
+	if !ok || value == "" {
+		return nil, nil
+	}
+
+	expiresAt, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, err
+	}
+
+	return &expiresAt, nil
+}
+
+// requeueForSuperuserGrant makes sure the cluster is reconciled again
+// when the temporary superuser access expires
+func requeueForSuperuserGrant(cluster *apiv1.Cluster, result ctrl.Result) ctrl.Result {
+	if cluster.Status.SuperuserGrant == nil {
+		return result
+	}
+
+	requeueAfter := time.Until(cluster.Status.SuperuserGrant.ExpiresAt.Time)
+	if requeueAfter < time.Second {
+		requeueAfter = time.Second
+	}
+	if result.RequeueAfter == 0 || requeueAfter < result.RequeueAfter {
+		result.RequeueAfter = requeueAfter
+	}
+
+	return result
+}
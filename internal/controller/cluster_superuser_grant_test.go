@@ -0,0 +1,140 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("temporary superuser access", func() {
+	var env *testingEnvironment
+	var cluster *apiv1.Cluster
+
+	BeforeEach(func() {
+		env = buildTestEnvironment()
+		namespace := newFakeNamespace(env.client)
+		cluster = newFakeCNPGCluster(env.client, namespace)
+	})
+
+	grantUntil := func(expiresAt time.Time) {
+		if cluster.Annotations == nil {
+			cluster.Annotations = make(map[string]string)
+		}
+		cluster.Annotations[utils.SuperuserGrantUntilAnnotationName] = expiresAt.Format(time.RFC3339)
+	}
+
+	getSecret := func(ctx context.Context) (*corev1.Secret, error) {
+		var secret corev1.Secret
+		err := env.client.Get(
+			ctx,
+			client.ObjectKey{Namespace: cluster.Namespace, Name: cluster.GetSuperuserGrantSecretName()},
+			&secret)
+		return &secret, err
+	}
+
+	It("creates the credentials secret when the access is granted", func(ctx context.Context) {
+		expiresAt := time.Now().Add(time.Hour).Truncate(time.Second)
+		grantUntil(expiresAt)
+		Expect(env.clusterReconciler.reconcileSuperuserGrant(ctx, cluster)).To(Succeed())
+
+		secret, err := getSecret(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(secret.StringData["username"]).To(Equal(apiv1.SuperuserGrantRoleName))
+		Expect(secret.StringData["password"]).ToNot(BeEmpty())
+		_, owned := IsOwnedByCluster(secret)
+		Expect(owned).To(BeTrue())
+
+		Expect(cluster.Status.SuperuserGrant).ToNot(BeNil())
+		Expect(cluster.Status.SuperuserGrant.RoleName).To(Equal(apiv1.SuperuserGrantRoleName))
+		Expect(cluster.Status.SuperuserGrant.ExpiresAt.Time).To(BeTemporally("==", expiresAt))
+		Expect(cluster.IsSuperuserGrantActive(time.Now())).To(BeTrue())
+	})
+
+	It("extends an existing grant keeping its credentials", func(ctx context.Context) {
+		grantUntil(time.Now().Add(time.Hour))
+		Expect(env.clusterReconciler.reconcileSuperuserGrant(ctx, cluster)).To(Succeed())
+		secret, err := getSecret(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		grantedAt := cluster.Status.SuperuserGrant.GrantedAt
+
+		expiresAt := time.Now().Add(2 * time.Hour).Truncate(time.Second)
+		grantUntil(expiresAt)
+		Expect(env.clusterReconciler.reconcileSuperuserGrant(ctx, cluster)).To(Succeed())
+
+		extendedSecret, err := getSecret(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(extendedSecret.StringData["password"]).To(Equal(secret.StringData["password"]))
+		Expect(cluster.Status.SuperuserGrant.GrantedAt).To(Equal(grantedAt))
+		Expect(cluster.Status.SuperuserGrant.ExpiresAt.Time).To(BeTemporally("==", expiresAt))
+	})
+
+	It("revokes the access when the grant expires", func(ctx context.Context) {
+		grantUntil(time.Now().Add(time.Hour))
+		Expect(env.clusterReconciler.reconcileSuperuserGrant(ctx, cluster)).To(Succeed())
+
+		grantUntil(time.Now().Add(-time.Minute))
+		Expect(env.clusterReconciler.reconcileSuperuserGrant(ctx, cluster)).To(Succeed())
+
+		_, err := getSecret(ctx)
+		Expect(apierrs.IsNotFound(err)).To(BeTrue())
+		Expect(cluster.Status.SuperuserGrant).To(BeNil())
+	})
+
+	It("revokes the access when the annotation is removed", func(ctx context.Context) {
+		grantUntil(time.Now().Add(time.Hour))
+		Expect(env.clusterReconciler.reconcileSuperuserGrant(ctx, cluster)).To(Succeed())
+
+		delete(cluster.Annotations, utils.SuperuserGrantUntilAnnotationName)
+		Expect(env.clusterReconciler.reconcileSuperuserGrant(ctx, cluster)).To(Succeed())
+
+		_, err := getSecret(ctx)
+		Expect(apierrs.IsNotFound(err)).To(BeTrue())
+		Expect(cluster.Status.SuperuserGrant).To(BeNil())
+	})
+
+	It("ignores an invalid annotation", func(ctx context.Context) {
+		cluster.Annotations = map[string]string{utils.SuperuserGrantUntilAnnotationName: "tomorrow"}
+		Expect(env.clusterReconciler.reconcileSuperuserGrant(ctx, cluster)).To(Succeed())
+		Expect(cluster.Status.SuperuserGrant).To(BeNil())
+	})
+
+	It("requeues the reconciliation when the grant expires", func(ctx context.Context) {
+		grantUntil(time.Now().Add(time.Hour))
+		Expect(env.clusterReconciler.reconcileSuperuserGrant(ctx, cluster)).To(Succeed())
+
+		result := requeueForSuperuserGrant(cluster, ctrl.Result{})
+		Expect(result.RequeueAfter).To(BeNumerically("~", time.Hour, time.Minute))
+
+		result = requeueForSuperuserGrant(cluster, ctrl.Result{RequeueAfter: time.Second})
+		Expect(result.RequeueAfter).To(Equal(time.Second))
+	})
+})
@@ -0,0 +1,169 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	corev1 "k8s.io/api/core/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+)
+
+// reconcilePrimarySwitchback performs a planned switchover to an instance
+// running on a node which is preferred to the one of the current primary,
+// as described by the primary placement preferences. It returns
+// errOutsideMaintenanceWindow when the switchback has been postponed
+func (r *ClusterReconciler) reconcilePrimarySwitchback(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+	resources *managedResources,
+	instancesStatus postgres.PostgresqlStatusList,
+) (ctrl.Result, error) {
+	contextLogger := log.FromContext(ctx)
+
+	if !cluster.IsPrimarySwitchbackEnabled() ||
+		cluster.IsReplica() ||
+		cluster.Status.Phase != apiv1.PhaseHealthy ||
+		cluster.Status.CurrentPrimary != cluster.Status.TargetPrimary ||
+		cluster.Status.ReadyInstances != cluster.Spec.Instances {
+		return ctrl.Result{}, nil
+	}
+
+	candidate := getSwitchbackCandidate(cluster, instancesStatus, resources.nodes)
+	if candidate == nil {
+		return ctrl.Result{}, nil
+	}
+
+	allowed, err := isSwitchbackAllowed(cluster, time.Now())
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if !allowed {
+		contextLogger.Debug("Primary switchback postponed to the next maintenance window",
+			"currentPrimary", cluster.Status.CurrentPrimary,
+			"candidate", candidate.Pod.Name)
+		return ctrl.Result{}, errOutsideMaintenanceWindow
+	}
+
+	contextLogger.Info("Switching back the primary to a preferred node",
+		"currentPrimary", cluster.Status.CurrentPrimary,
+		"targetPrimary", candidate.Pod.Name,
+		"targetPrimaryNode", candidate.Node)
+	r.Recorder.Eventf(cluster, "Normal", "SwitchingBack",
+		"Switching back from %v to %v, running on the preferred node %v",
+		cluster.Status.CurrentPrimary, candidate.Pod.Name, candidate.Node)
+	if err := r.RegisterPhase(ctx, cluster, apiv1.PhaseSwitchover,
+		fmt.Sprintf("Switching back to %v, running on the preferred node %v",
+			candidate.Pod.Name, candidate.Node)); err != nil {
+		return ctrl.Result{}, err
+	}
+	if err := r.switchPrimaryInstance(
+		ctx, cluster, instancesStatus, candidate.Pod.Name, apiv1.PrimaryChangeReasonSwitchover,
+	); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{RequeueAfter: 1 * time.Second}, nil
+}
+
+// isSwitchbackAllowed checks if the switchback can happen at the passed
+// time, that is in a cluster-wide maintenance window and in one of the
+// switchback windows, if any
+func isSwitchbackAllowed(cluster *apiv1.Cluster, now time.Time) (bool, error) {
+	allowed, err := isDisruptionAllowed(cluster, now)
+	if err != nil || !allowed {
+		return false, err
+	}
+
+	if !cluster.IsPrimarySwitchbackEnabled() ||
+		len(cluster.Spec.PrimaryPlacement.Switchback.MaintenanceWindows) == 0 {
+		return true, nil
+	}
+	return apiv1.IsInMaintenanceWindow(cluster.Spec.PrimaryPlacement.Switchback.MaintenanceWindows, now)
+}
+
+// getSwitchbackCandidate gets the healthy and caught up instance running on
+// the node with the highest primary placement score, if that score is
+// higher than the one of the node running the current primary
+func getSwitchbackCandidate(
+	cluster *apiv1.Cluster,
+	instancesStatus postgres.PostgresqlStatusList,
+	nodes map[string]corev1.Node,
+) *postgres.PostgresqlStatus {
+	getScore := func(item *postgres.PostgresqlStatus) int32 {
+		node, ok := nodes[item.Node]
+		if !ok {
+			return 0
+		}
+		return cluster.GetPrimaryPlacementScore(node.Labels)
+	}
+
+	var primary *postgres.PostgresqlStatus
+	for idx := range instancesStatus.Items {
+		item := &instancesStatus.Items[idx]
+		if item.Pod != nil && item.Pod.Name == cluster.Status.CurrentPrimary && item.IsPrimary {
+			primary = item
+			break
+		}
+	}
+	if primary == nil || !primary.HasHTTPStatus() {
+		return nil
+	}
+
+	primaryLSN, err := primary.CurrentLsn.Parse()
+	if err != nil {
+		return nil
+	}
+
+	maximumLag := cluster.GetPrimarySwitchbackMaximumLag()
+	bestScore := getScore(primary)
+
+	var result *postgres.PostgresqlStatus
+	for idx := range instancesStatus.Items {
+		candidate := &instancesStatus.Items[idx]
+		if candidate == primary ||
+			candidate.IsPrimary ||
+			!candidate.HasHTTPStatus() ||
+			!candidate.IsWalReceiverActive ||
+			!utils.IsPodReady(*candidate.Pod) ||
+			cluster.IsInstanceFenced(candidate.Pod.Name) {
+			continue
+		}
+
+		replayLSN, err := candidate.ReplayLsn.Parse()
+		if err != nil || (primaryLSN > replayLSN && primaryLSN-replayLSN > maximumLag) {
+			continue
+		}
+
+		if score := getScore(candidate); score > bestScore {
+			result = candidate
+			bestScore = score
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,150 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package controller
+
+import (
+	"time"
+
+	cnpgTypes "github.com/cloudnative-pg/machinery/pkg/types"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Primary placement", func() {
+	const zoneLabel = "topology.kubernetes.io/zone"
+
+	newReadyPod := func(name string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status: corev1.PodStatus{
+				Conditions: []corev1.PodCondition{
+					{
+						Type:   corev1.ContainersReady,
+						Status: corev1.ConditionTrue,
+					},
+				},
+			},
+		}
+	}
+
+	nodes := map[string]corev1.Node{
+		"node-a": {ObjectMeta: metav1.ObjectMeta{Name: "node-a", Labels: map[string]string{zoneLabel: "zone-a"}}},
+		"node-b": {ObjectMeta: metav1.ObjectMeta{Name: "node-b", Labels: map[string]string{zoneLabel: "zone-b"}}},
+		"node-c": {ObjectMeta: metav1.ObjectMeta{Name: "node-c", Labels: map[string]string{zoneLabel: "zone-c"}}},
+	}
+
+	var cluster *apiv1.Cluster
+	var status postgres.PostgresqlStatusList
+
+	BeforeEach(func() {
+		cluster = &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				Instances: 3,
+				PrimaryPlacement: &apiv1.PrimaryPlacementConfiguration{
+					Preferences: []apiv1.PrimaryPlacementPreference{
+						{Weight: 100, NodeLabels: map[string]string{zoneLabel: "zone-a"}},
+					},
+					Switchback: &apiv1.PrimarySwitchbackConfiguration{Enabled: true},
+				},
+			},
+			Status: apiv1.ClusterStatus{
+				CurrentPrimary: "cluster-2",
+				TargetPrimary:  "cluster-2",
+			},
+		}
+
+		status = postgres.PostgresqlStatusList{
+			Items: []postgres.PostgresqlStatus{
+				{
+					Pod:        newReadyPod("cluster-2"),
+					Node:       "node-b",
+					IsPrimary:  true,
+					CurrentLsn: cnpgTypes.LSN("0/3000000"),
+				},
+				{
+					Pod:                 newReadyPod("cluster-3"),
+					Node:                "node-c",
+					IsWalReceiverActive: true,
+					ReceivedLsn:         cnpgTypes.LSN("0/3000000"),
+					ReplayLsn:           cnpgTypes.LSN("0/3000000"),
+				},
+				{
+					Pod:                 newReadyPod("cluster-1"),
+					Node:                "node-a",
+					IsWalReceiverActive: true,
+					ReceivedLsn:         cnpgTypes.LSN("0/3000000"),
+					ReplayLsn:           cnpgTypes.LSN("0/3000000"),
+				},
+			},
+		}
+	})
+
+	It("selects the caught up instance on the preferred node for the switchback", func() {
+		candidate := getSwitchbackCandidate(cluster, status, nodes)
+		Expect(candidate).ToNot(BeNil())
+		Expect(candidate.Pod.Name).To(Equal("cluster-1"))
+	})
+
+	It("doesn't switch back to an instance which is lagging", func() {
+		status.Items[0].CurrentLsn = cnpgTypes.LSN("1/0")
+		Expect(getSwitchbackCandidate(cluster, status, nodes)).To(BeNil())
+	})
+
+	It("doesn't switch back when the primary is already on the preferred node", func() {
+		status.Items[0].Node = "node-a"
+		status.Items[2].Node = "node-b"
+		Expect(getSwitchbackCandidate(cluster, status, nodes)).To(BeNil())
+	})
+
+	It("prefers equally advanced instances on preferred nodes during a failover", func() {
+		failoverStatus := postgres.PostgresqlStatusList{Items: status.Items[1:]}
+		candidate := getPreferredFailoverCandidate(cluster, failoverStatus, nodes)
+		Expect(candidate.Pod.Name).To(Equal("cluster-1"))
+
+		failoverStatus.Items[0].ReceivedLsn = cnpgTypes.LSN("0/4000000")
+		candidate = getPreferredFailoverCandidate(cluster, failoverStatus, nodes)
+		Expect(candidate.Pod.Name).To(Equal("cluster-3"))
+	})
+
+	It("allows the switchback only when both the cluster and the switchback windows are open", func() {
+		now := time.Date(2026, 1, 5, 10, 30, 0, 0, time.UTC)
+		window := func(schedule string) apiv1.MaintenanceWindow {
+			return apiv1.MaintenanceWindow{Schedule: schedule, Duration: metav1.Duration{Duration: time.Hour}}
+		}
+
+		Expect(isSwitchbackAllowed(cluster, now)).To(BeTrue())
+
+		cluster.Spec.PrimaryPlacement.Switchback.MaintenanceWindows = []apiv1.MaintenanceWindow{window("0 0 10 * * *")}
+		Expect(isSwitchbackAllowed(cluster, now)).To(BeTrue())
+
+		cluster.Spec.MaintenanceWindows = []apiv1.MaintenanceWindow{window("0 0 2 * * *")}
+		Expect(isSwitchbackAllowed(cluster, now)).To(BeFalse())
+
+		cluster.Spec.MaintenanceWindows = nil
+		cluster.Spec.PrimaryPlacement.Switchback.MaintenanceWindows = []apiv1.MaintenanceWindow{window("0 0 2 * * *")}
+		Expect(isSwitchbackAllowed(cluster, now)).To(BeFalse())
+	})
+})
@@ -79,6 +79,16 @@ func (r *ClusterReconciler) rolloutRequiredInstances(
 			continue
 		}
 
+		if allowed, err := r.isRolloutAllowedByCanary(ctx, cluster, postgresqlStatus.Pod, false); err != nil {
+			return false, err
+		} else if !allowed {
+			continue
+		}
+
+		if err := r.checkMaintenanceWindow(ctx, cluster, postgresqlStatus.Pod.Name, podRollout.reason); err != nil {
+			return false, err
+		}
+
 		managerResult := r.rolloutManager.CoordinateRollout(client.ObjectKeyFromObject(cluster), postgresqlStatus.Pod.Name)
 		if !managerResult.RolloutAllowed {
 			r.Recorder.Eventf(
@@ -125,6 +135,17 @@ func (r *ClusterReconciler) rolloutRequiredInstances(
 		return false, nil
 	}
 
+	// the primary is updated to the new image only after the canary instance
+	// passed every check
+	if allowed, err := r.isRolloutAllowedByCanary(ctx, cluster, primaryPostgresqlStatus.Pod, true); err != nil ||
+		!allowed {
+		return false, err
+	}
+
+	if err := r.checkMaintenanceWindow(ctx, cluster, primaryPostgresqlStatus.Pod.Name, podRollout.reason); err != nil {
+		return false, err
+	}
+
 	managerResult := r.rolloutManager.CoordinateRollout(
 		client.ObjectKeyFromObject(cluster),
 		primaryPostgresqlStatus.Pod.Name)
@@ -228,7 +249,9 @@ func (r *ClusterReconciler) updatePrimaryPod(
 		podList.LogStatus(ctx)
 		r.Recorder.Eventf(cluster, "Normal", "Switchover",
 			"Initiating switchover to %s to upgrade %s", targetInstance.Pod.Name, primaryPod.Name)
-		return true, r.setPrimaryInstance(ctx, cluster, targetInstance.Pod.Name)
+		return true, r.switchPrimaryInstance(
+			ctx, cluster, *podList, targetInstance.Pod.Name, apiv1.PrimaryChangeReasonSwitchover,
+		)
 	}
 
 	// if there is only one instance in the cluster, we should upgrade it even if it's a primary
@@ -766,6 +789,12 @@ func (r *ClusterReconciler) upgradeInstanceManager(
 			continue
 		}
 
+		if err := r.checkMaintenanceWindow(
+			ctx, cluster, postgresqlStatus.Pod.Name, "instance manager upgrade",
+		); err != nil {
+			return err
+		}
+
 		// We need to upgrade this Pod
 		contextLogger.Info("Upgrading instance manager",
 			"pod", postgresqlStatus.Pod.Name,
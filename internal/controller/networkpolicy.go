@@ -0,0 +1,113 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+)
+
+type networkPolicyManager interface {
+	// IsNetworkPolicyEnabled returns a boolean indicating if the NetworkPolicy should exist or not
+	IsNetworkPolicyEnabled() bool
+	// BuildNetworkPolicy builds a new NetworkPolicy object
+	BuildNetworkPolicy() *networkingv1.NetworkPolicy
+}
+
+// createOrPatchNetworkPolicy reconciles the NetworkPolicy generated for
+// a resource, creating, patching or deleting it as needed. NetworkPolicies
+// which are not controlled by the resource are never touched
+func createOrPatchNetworkPolicy(
+	ctx context.Context,
+	cli client.Client,
+	manager networkPolicyManager,
+) error {
+	contextLogger := log.FromContext(ctx)
+
+	expectedNetworkPolicy := manager.BuildNetworkPolicy()
+	networkPolicy := &networkingv1.NetworkPolicy{}
+	if err := cli.Get(
+		ctx,
+		client.ObjectKeyFromObject(expectedNetworkPolicy),
+		networkPolicy,
+	); err != nil {
+		if !apierrs.IsNotFound(err) {
+			return fmt.Errorf("while getting the networkpolicy: %w", err)
+		}
+		networkPolicy = nil
+	}
+
+	if networkPolicy != nil && !isControlledBySameOwner(networkPolicy, expectedNetworkPolicy) {
+		if manager.IsNetworkPolicyEnabled() {
+			contextLogger.Warning(
+				"A NetworkPolicy with the same name and not managed by the operator already exists, skipping",
+				"networkPolicyName", networkPolicy.Name)
+		}
+		return nil
+	}
+
+	switch {
+	// NetworkPolicy disabled and no NetworkPolicy - nothing to do
+	case !manager.IsNetworkPolicyEnabled() && networkPolicy == nil:
+		return nil
+	// NetworkPolicy disabled and NetworkPolicy present - delete it
+	case !manager.IsNetworkPolicyEnabled() && networkPolicy != nil:
+		contextLogger.Info("Deleting NetworkPolicy")
+		if err := cli.Delete(ctx, networkPolicy); err != nil {
+			if !apierrs.IsNotFound(err) {
+				return err
+			}
+		}
+		return nil
+	// NetworkPolicy enabled and no NetworkPolicy - create it
+	case manager.IsNetworkPolicyEnabled() && networkPolicy == nil:
+		contextLogger.Info("Creating NetworkPolicy")
+		return cli.Create(ctx, expectedNetworkPolicy)
+	// NetworkPolicy enabled and NetworkPolicy present - update it
+	default:
+		origNetworkPolicy := networkPolicy.DeepCopy()
+		networkPolicy.Spec = expectedNetworkPolicy.Spec
+		// We don't override the current labels/annotations given that there could be data that isn't managed by us
+		utils.MergeObjectsMetadata(networkPolicy, expectedNetworkPolicy)
+
+		if reflect.DeepEqual(origNetworkPolicy, networkPolicy) {
+			return nil
+		}
+
+		contextLogger.Info("Patching NetworkPolicy")
+		return cli.Patch(ctx, networkPolicy, client.MergeFrom(origNetworkPolicy))
+	}
+}
+
+// isControlledBySameOwner checks if the two objects have the same controller
+func isControlledBySameOwner(current, expected metav1.Object) bool {
+	expectedOwner := metav1.GetControllerOf(expected)
+	currentOwner := metav1.GetControllerOf(current)
+	return expectedOwner != nil && currentOwner != nil && expectedOwner.UID == currentOwner.UID
+}
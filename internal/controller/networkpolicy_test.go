@@ -0,0 +1,102 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package controller
+
+import (
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	schemeBuilder "github.com/cloudnative-pg/cloudnative-pg/internal/scheme"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/specs"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("createOrPatchNetworkPolicy", func() {
+	var (
+		fakeCli client.Client
+		cluster *apiv1.Cluster
+	)
+
+	BeforeEach(func() {
+		fakeCli = fake.NewClientBuilder().WithScheme(schemeBuilder.BuildWithAllKnownScheme()).Build()
+		cluster = &apiv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "cluster-example",
+				Namespace: "default",
+				UID:       "cluster-uid",
+			},
+			Spec: apiv1.ClusterSpec{
+				NetworkPolicy: &apiv1.NetworkPolicyConfiguration{Enabled: true},
+			},
+		}
+	})
+
+	getNetworkPolicy := func(ctx SpecContext) (*networkingv1.NetworkPolicy, error) {
+		var networkPolicy networkingv1.NetworkPolicy
+		err := fakeCli.Get(ctx, client.ObjectKeyFromObject(cluster), &networkPolicy)
+		return &networkPolicy, err
+	}
+
+	It("creates, updates and deletes the NetworkPolicy following the cluster", func(ctx SpecContext) {
+		Expect(createOrPatchNetworkPolicy(ctx, fakeCli, specs.NewClusterNetworkPolicyManager(cluster))).To(Succeed())
+		networkPolicy, err := getNetworkPolicy(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(networkPolicy.Spec.Ingress).To(HaveLen(2))
+
+		cluster.Spec.NetworkPolicy.Clients = []networkingv1.NetworkPolicyPeer{{
+			PodSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "client"}},
+		}}
+		Expect(createOrPatchNetworkPolicy(ctx, fakeCli, specs.NewClusterNetworkPolicyManager(cluster))).To(Succeed())
+		networkPolicy, err = getNetworkPolicy(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(networkPolicy.Spec.Ingress).To(HaveLen(3))
+
+		cluster.Spec.NetworkPolicy.Enabled = false
+		Expect(createOrPatchNetworkPolicy(ctx, fakeCli, specs.NewClusterNetworkPolicyManager(cluster))).To(Succeed())
+		_, err = getNetworkPolicy(ctx)
+		Expect(apierrs.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("never touches a NetworkPolicy not controlled by the cluster", func(ctx SpecContext) {
+		userPolicy := &networkingv1.NetworkPolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      cluster.Name,
+				Namespace: cluster.Namespace,
+			},
+		}
+		Expect(fakeCli.Create(ctx, userPolicy)).To(Succeed())
+
+		Expect(createOrPatchNetworkPolicy(ctx, fakeCli, specs.NewClusterNetworkPolicyManager(cluster))).To(Succeed())
+		networkPolicy, err := getNetworkPolicy(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(networkPolicy.Spec.Ingress).To(BeEmpty())
+
+		cluster.Spec.NetworkPolicy.Enabled = false
+		Expect(createOrPatchNetworkPolicy(ctx, fakeCli, specs.NewClusterNetworkPolicyManager(cluster))).To(Succeed())
+		_, err = getNetworkPolicy(ctx)
+		Expect(err).ToNot(HaveOccurred())
+	})
+})
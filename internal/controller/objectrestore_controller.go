@@ -0,0 +1,385 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"time"
+
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	"github.com/cloudnative-pg/machinery/pkg/types"
+	batchv1 "k8s.io/api/batch/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/specs"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+)
+
+// errObjectRestoreFailed is the error wrapped by the failures that can't
+// be recovered by retrying the reconciliation
+var errObjectRestoreFailed = errors.New("object restore failed")
+
+// ObjectRestoreReconciler reconciles an ObjectRestore object
+type ObjectRestoreReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+}
+
+// +kubebuilder:rbac:groups=postgresql.cnpg.io,resources=objectrestores,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=postgresql.cnpg.io,resources=objectrestores/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=postgresql.cnpg.io,resources=clusters,verbs=get;list;watch;create;delete
+// +kubebuilder:rbac:groups=postgresql.cnpg.io,resources=backups,verbs=get;list;watch
+// +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+
+// Reconcile recovers a temporary cluster from a backup, and restores the
+// requested objects from it into the cluster
+func (r *ObjectRestoreReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	contextLogger, ctx := log.SetupLogger(ctx)
+
+	var objectRestore apiv1.ObjectRestore
+	if err := r.Get(ctx, req.NamespacedName, &objectRestore); err != nil {
+		// The recovery cluster and the job are owned by the ObjectRestore
+		// and will be garbage collected by Kubernetes
+		if apierrs.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	if !objectRestore.DeletionTimestamp.IsZero() || objectRestore.Status.IsDone() {
+		return ctrl.Result{}, nil
+	}
+
+	origObjectRestore := objectRestore.DeepCopy()
+	result, err := r.reconcileObjectRestore(ctx, &objectRestore)
+	if errors.Is(err, errObjectRestoreFailed) {
+		contextLogger.Warning("Object restore failed", "err", err.Error())
+		r.Recorder.Event(&objectRestore, "Warning", "RestoreFailed", err.Error())
+		objectRestore.Status.SetPhase(apiv1.ObjectRestorePhaseFailed, err.Error())
+		if err := r.deleteRecoveryCluster(ctx, &objectRestore); err != nil {
+			return ctrl.Result{}, err
+		}
+	} else if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if equality.Semantic.DeepEqual(origObjectRestore.Status, objectRestore.Status) {
+		return result, nil
+	}
+
+	return result, r.Status().Patch(ctx, &objectRestore, client.MergeFrom(origObjectRestore))
+}
+
+// reconcileObjectRestore moves the restore forward, updating its status.
+// Unrecoverable failures wrap errObjectRestoreFailed
+func (r *ObjectRestoreReconciler) reconcileObjectRestore(
+	ctx context.Context,
+	objectRestore *apiv1.ObjectRestore,
+) (ctrl.Result, error) {
+	var cluster apiv1.Cluster
+	if err := r.Get(ctx, client.ObjectKey{
+		Namespace: objectRestore.Namespace,
+		Name:      objectRestore.Spec.ClusterRef.Name,
+	}, &cluster); err != nil {
+		if apierrs.IsNotFound(err) {
+			return ctrl.Result{}, fmt.Errorf("%w: cluster %q not found",
+				errObjectRestoreFailed, objectRestore.Spec.ClusterRef.Name)
+		}
+		return ctrl.Result{}, err
+	}
+
+	credentialsSecretName, err := getObjectRestoreCredentialsSecretName(&cluster)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if objectRestore.Status.BackupName == "" {
+		backupName, err := r.getObjectRestoreBackupName(ctx, objectRestore)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		objectRestore.Status.BackupName = backupName
+		objectRestore.Status.RecoveryClusterName = objectRestore.GetRecoveryClusterName()
+		objectRestore.Status.SetPhase(apiv1.ObjectRestorePhasePending, "")
+	}
+
+	recoveryCluster, err := r.reconcileRecoveryCluster(ctx, objectRestore, &cluster)
+	if err != nil || recoveryCluster == nil {
+		return ctrl.Result{}, err
+	}
+
+	var job batchv1.Job
+	err = r.Get(ctx, client.ObjectKey{Namespace: objectRestore.Namespace, Name: objectRestore.GetJobName()}, &job)
+	switch {
+	case apierrs.IsNotFound(err):
+		newJob := specs.CreateObjectRestoreJob(objectRestore, &cluster, recoveryCluster, credentialsSecretName)
+		if err := ctrl.SetControllerReference(objectRestore, newJob, r.Scheme); err != nil {
+			return ctrl.Result{}, err
+		}
+		if err := r.Create(ctx, newJob); err != nil {
+			return ctrl.Result{}, err
+		}
+		objectRestore.Status.JobName = newJob.Name
+		objectRestore.Status.SetPhase(apiv1.ObjectRestorePhaseRestoring,
+			"Dumping the objects from the recovery cluster and restoring them")
+		return ctrl.Result{}, nil
+	case err != nil:
+		return ctrl.Result{}, err
+	}
+
+	switch {
+	case utils.JobHasOneCompletion(job):
+		if err := r.deleteRecoveryCluster(ctx, objectRestore); err != nil {
+			return ctrl.Result{}, err
+		}
+		r.Recorder.Eventf(objectRestore, "Normal", "Restored",
+			"Restored the objects into cluster %q", cluster.Name)
+		objectRestore.Status.SetPhase(apiv1.ObjectRestorePhaseCompleted, "")
+	case utils.JobHasFailed(job):
+		return ctrl.Result{}, fmt.Errorf("%w: job %q failed, check its logs for details",
+			errObjectRestoreFailed, job.Name)
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// getObjectRestoreCredentialsSecretName gets the name of the secret
+// containing the superuser credentials used to restore the objects
+func getObjectRestoreCredentialsSecretName(cluster *apiv1.Cluster) (string, error) {
+	switch {
+	case cluster.GetEnableSuperuserAccess():
+		return cluster.GetSuperuserSecretName(), nil
+	case cluster.Status.SuperuserGrant != nil:
+		return cluster.GetSuperuserGrantSecretName(), nil
+	default:
+		return "", fmt.Errorf("%w: superuser access to cluster %q is required, "+
+			"enable it or grant a temporary superuser access", errObjectRestoreFailed, cluster.Name)
+	}
+}
+
+// getObjectRestoreBackupName gets the name of the backup the objects are
+// recovered from, checking it is completed
+func (r *ObjectRestoreReconciler) getObjectRestoreBackupName(
+	ctx context.Context,
+	objectRestore *apiv1.ObjectRestore,
+) (string, error) {
+	if objectRestore.Spec.Backup != nil {
+		var backup apiv1.Backup
+		if err := r.Get(ctx, client.ObjectKey{
+			Namespace: objectRestore.Namespace,
+			Name:      objectRestore.Spec.Backup.Name,
+		}, &backup); err != nil {
+			if apierrs.IsNotFound(err) {
+				return "", fmt.Errorf("%w: backup %q not found", errObjectRestoreFailed, objectRestore.Spec.Backup.Name)
+			}
+			return "", err
+		}
+		if backup.Status.Phase != apiv1.BackupPhaseCompleted {
+			return "", fmt.Errorf("%w: backup %q is not completed", errObjectRestoreFailed, backup.Name)
+		}
+		if !backup.IsRecoverableFromBackupSource() {
+			return "", fmt.Errorf("%w: backup %q uses the %q method, only the %q and %q ones can be recovered",
+				errObjectRestoreFailed, backup.Name, backup.Spec.Method,
+				apiv1.BackupMethodBarmanObjectStore, apiv1.BackupMethodPersistentVolume)
+		}
+		return backup.Name, nil
+	}
+
+	var before *time.Time
+	if target := objectRestore.Spec.RecoveryTarget; target != nil && target.TargetTime != "" {
+		parsedTime, err := types.ParseTargetTime(nil, target.TargetTime)
+		if err != nil {
+			return "", fmt.Errorf("%w: invalid target time %q: %w", errObjectRestoreFailed, target.TargetTime, err)
+		}
+		before = &parsedTime
+	}
+
+	var backups apiv1.BackupList
+	if err := r.List(ctx, &backups, client.InNamespace(objectRestore.Namespace)); err != nil {
+		return "", err
+	}
+
+	backup := backups.GetLatestRecoverableBackup(objectRestore.Spec.ClusterRef.Name, before)
+	if backup == nil {
+		return "", fmt.Errorf("%w: no completed barmanObjectStore or persistentVolume backup "+
+			"of cluster %q to recover from",
+			errObjectRestoreFailed, objectRestore.Spec.ClusterRef.Name)
+	}
+
+	return backup.Name, nil
+}
+
+// reconcileRecoveryCluster creates the recovery cluster, returning it
+// only when it is ready to be dumped. An existing cluster not controlled
+// by the object restore is never used
+func (r *ObjectRestoreReconciler) reconcileRecoveryCluster(
+	ctx context.Context,
+	objectRestore *apiv1.ObjectRestore,
+	cluster *apiv1.Cluster,
+) (*apiv1.Cluster, error) {
+	var recoveryCluster apiv1.Cluster
+	err := r.Get(ctx, client.ObjectKey{
+		Namespace: objectRestore.Namespace,
+		Name:      objectRestore.Status.RecoveryClusterName,
+	}, &recoveryCluster)
+	switch {
+	case apierrs.IsNotFound(err):
+		newCluster := buildObjectRestoreRecoveryCluster(objectRestore, cluster)
+		if err := ctrl.SetControllerReference(objectRestore, newCluster, r.Scheme); err != nil {
+			return nil, err
+		}
+		if err := r.Create(ctx, newCluster); err != nil {
+			return nil, err
+		}
+		r.Recorder.Eventf(objectRestore, "Normal", "CreatingRecoveryCluster",
+			"Recovering cluster %q from backup %q", newCluster.Name, objectRestore.Status.BackupName)
+		objectRestore.Status.SetPhase(apiv1.ObjectRestorePhaseRecovering,
+			fmt.Sprintf("Recovering cluster %q", newCluster.Name))
+		return nil, nil
+	case err != nil:
+		return nil, err
+	}
+
+	if !metav1.IsControlledBy(&recoveryCluster, objectRestore) {
+		return nil, fmt.Errorf("%w: cluster %q already exists and is not managed by this object restore",
+			errObjectRestoreFailed, recoveryCluster.Name)
+	}
+
+	if objectRestore.Status.Phase != apiv1.ObjectRestorePhaseRecovering {
+		return &recoveryCluster, nil
+	}
+
+	if recoveryCluster.Status.Phase != apiv1.PhaseHealthy || recoveryCluster.Status.ReadyInstances < 1 {
+		objectRestore.Status.SetPhase(apiv1.ObjectRestorePhaseRecovering,
+			fmt.Sprintf("Recovering cluster %q: %s", recoveryCluster.Name, recoveryCluster.Status.Phase))
+		return nil, nil
+	}
+
+	return &recoveryCluster, nil
+}
+
+// buildObjectRestoreRecoveryCluster builds the temporary cluster recovered
+// from the backup, sharing the image, the configuration and the storage
+// settings of the cluster
+func buildObjectRestoreRecoveryCluster(objectRestore *apiv1.ObjectRestore, cluster *apiv1.Cluster) *apiv1.Cluster {
+	imageName := cluster.Status.Image
+	if imageName == "" {
+		imageName = cluster.Spec.ImageName
+	}
+
+	recoveryCluster := &apiv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      objectRestore.Status.RecoveryClusterName,
+			Namespace: objectRestore.Namespace,
+			Labels: map[string]string{
+				utils.ObjectRestoreLabelName: objectRestore.Name,
+			},
+		},
+		Spec: apiv1.ClusterSpec{
+			Instances:        1,
+			ImageName:        imageName,
+			ImagePullPolicy:  cluster.Spec.ImagePullPolicy,
+			ImagePullSecrets: cluster.Spec.ImagePullSecrets,
+			PostgresUID:      cluster.Spec.PostgresUID,
+			PostgresGID:      cluster.Spec.PostgresGID,
+			PostgresConfiguration: apiv1.PostgresConfiguration{
+				Parameters: maps.Clone(cluster.Spec.PostgresConfiguration.Parameters),
+			},
+			StorageConfiguration:  *cluster.Spec.StorageConfiguration.DeepCopy(),
+			WalStorage:            cluster.Spec.WalStorage.DeepCopy(),
+			Tablespaces:           cluster.Spec.Tablespaces,
+			Affinity:              *cluster.Spec.Affinity.DeepCopy(),
+			Resources:             *cluster.Spec.Resources.DeepCopy(),
+			EnableSuperuserAccess: ptr.To(true),
+			Bootstrap: &apiv1.BootstrapConfiguration{
+				Recovery: &apiv1.BootstrapRecovery{
+					Backup: &apiv1.BackupSource{
+						LocalObjectReference: apiv1.LocalObjectReference{Name: objectRestore.Status.BackupName},
+					},
+					RecoveryTarget: objectRestore.Spec.RecoveryTarget.DeepCopy(),
+				},
+			},
+		},
+	}
+
+	if cluster.Spec.Backup.IsBarmanEndpointCASet() {
+		recoveryCluster.Spec.Bootstrap.Recovery.Backup.EndpointCA = cluster.Spec.Backup.BarmanObjectStore.EndpointCA
+	}
+
+	return recoveryCluster
+}
+
+// deleteRecoveryCluster deletes the temporary cluster of an object restore,
+// leaving alone a cluster with the same name not controlled by it
+func (r *ObjectRestoreReconciler) deleteRecoveryCluster(
+	ctx context.Context,
+	objectRestore *apiv1.ObjectRestore,
+) error {
+	if objectRestore.Status.RecoveryClusterName == "" {
+		return nil
+	}
+
+	var recoveryCluster apiv1.Cluster
+	if err := r.Get(ctx, client.ObjectKey{
+		Namespace: objectRestore.Namespace,
+		Name:      objectRestore.Status.RecoveryClusterName,
+	}, &recoveryCluster); err != nil {
+		if apierrs.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	if !metav1.IsControlledBy(&recoveryCluster, objectRestore) {
+		return nil
+	}
+
+	if err := r.Delete(ctx, &recoveryCluster, client.Preconditions{UID: &recoveryCluster.UID}); err != nil &&
+		!apierrs.IsNotFound(err) {
+		return fmt.Errorf("while deleting the recovery cluster: %w", err)
+	}
+
+	return nil
+}
+
+// SetupWithManager sets up the controller with the Manager
+func (r *ObjectRestoreReconciler) SetupWithManager(mgr ctrl.Manager, maxConcurrentReconciles int) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		WithOptions(controller.Options{MaxConcurrentReconciles: maxConcurrentReconciles}).
+		For(&apiv1.ObjectRestore{}).
+		Named("object-restore").
+		Owns(&apiv1.Cluster{}).
+		Owns(&batchv1.Job{}).
+		Complete(r)
+}
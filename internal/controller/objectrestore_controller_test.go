@@ -0,0 +1,226 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ObjectRestore reconciler", func() {
+	var env *testingEnvironment
+	var reconciler *ObjectRestoreReconciler
+	var cluster *apiv1.Cluster
+	var objectRestore *apiv1.ObjectRestore
+
+	createBackupWithMethod := func(ctx context.Context, name string, method apiv1.BackupMethod, stoppedAt time.Time) {
+		backup := &apiv1.Backup{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: cluster.Namespace},
+			Spec: apiv1.BackupSpec{
+				Cluster: apiv1.LocalObjectReference{Name: cluster.Name},
+				Method:  method,
+			},
+		}
+		Expect(env.client.Create(ctx, backup)).To(Succeed())
+		backup.Status.Phase = apiv1.BackupPhaseCompleted
+		backup.Status.StoppedAt = ptr.To(metav1.NewTime(stoppedAt))
+		Expect(env.client.Status().Update(ctx, backup)).To(Succeed())
+	}
+
+	createBackup := func(ctx context.Context, name string, stoppedAt time.Time) {
+		createBackupWithMethod(ctx, name, apiv1.BackupMethodBarmanObjectStore, stoppedAt)
+	}
+
+	BeforeEach(func(ctx context.Context) {
+		env = buildTestEnvironment()
+		reconciler = &ObjectRestoreReconciler{
+			Client:   env.client,
+			Scheme:   env.scheme,
+			Recorder: record.NewFakeRecorder(10),
+		}
+		namespace := newFakeNamespace(env.client)
+		cluster = newFakeCNPGCluster(env.client, namespace, func(cluster *apiv1.Cluster) {
+			cluster.Spec.EnableSuperuserAccess = ptr.To(true)
+		})
+
+		objectRestore = &apiv1.ObjectRestore{
+			ObjectMeta: metav1.ObjectMeta{Name: "restore", Namespace: namespace},
+			Spec: apiv1.ObjectRestoreSpec{
+				ClusterRef:   apiv1.LocalObjectReference{Name: cluster.Name},
+				Database:     "app",
+				Tables:       []string{"public.orders"},
+				TargetSchema: "restored",
+			},
+		}
+		Expect(env.client.Create(ctx, objectRestore)).To(Succeed())
+	})
+
+	reconcile := func(ctx context.Context) {
+		_, err := reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(objectRestore)})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(env.client.Get(ctx, client.ObjectKeyFromObject(objectRestore), objectRestore)).To(Succeed())
+	}
+
+	getRecoveryCluster := func(ctx context.Context) (*apiv1.Cluster, error) {
+		var recoveryCluster apiv1.Cluster
+		err := env.client.Get(ctx, client.ObjectKey{
+			Namespace: objectRestore.Namespace,
+			Name:      objectRestore.GetRecoveryClusterName(),
+		}, &recoveryCluster)
+		return &recoveryCluster, err
+	}
+
+	It("recovers a cluster, restores the objects and deletes it", func(ctx context.Context) {
+		createBackup(ctx, "old", time.Now().Add(-2*time.Hour))
+		createBackup(ctx, "latest", time.Now().Add(-time.Hour))
+		createBackupWithMethod(ctx, "snapshot", apiv1.BackupMethodVolumeSnapshot, time.Now())
+
+		reconcile(ctx)
+		Expect(objectRestore.Status.Phase).To(Equal(apiv1.ObjectRestorePhaseRecovering))
+		Expect(objectRestore.Status.BackupName).To(Equal("latest"))
+
+		recoveryCluster, err := getRecoveryCluster(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(recoveryCluster.Spec.Bootstrap.Recovery.Backup.Name).To(Equal("latest"))
+		Expect(recoveryCluster.GetEnableSuperuserAccess()).To(BeTrue())
+		Expect(metav1.IsControlledBy(recoveryCluster, objectRestore)).To(BeTrue())
+
+		// The job is not created until the recovery cluster is ready
+		reconcile(ctx)
+		var job batchv1.Job
+		jobKey := client.ObjectKey{Namespace: objectRestore.Namespace, Name: objectRestore.GetJobName()}
+		Expect(apierrs.IsNotFound(env.client.Get(ctx, jobKey, &job))).To(BeTrue())
+
+		recoveryCluster.Status.Phase = apiv1.PhaseHealthy
+		recoveryCluster.Status.ReadyInstances = 1
+		Expect(env.client.Status().Update(ctx, recoveryCluster)).To(Succeed())
+
+		reconcile(ctx)
+		Expect(objectRestore.Status.Phase).To(Equal(apiv1.ObjectRestorePhaseRestoring))
+		Expect(env.client.Get(ctx, jobKey, &job)).To(Succeed())
+		Expect(job.Spec.Template.Spec.Containers[0].Env[3].ValueFrom.SecretKeyRef.Name).
+			To(Equal(cluster.GetSuperuserSecretName()))
+
+		job.Status.Succeeded = 1
+		Expect(env.client.Status().Update(ctx, &job)).To(Succeed())
+
+		reconcile(ctx)
+		Expect(objectRestore.Status.Phase).To(Equal(apiv1.ObjectRestorePhaseCompleted))
+		Expect(objectRestore.Status.StoppedAt).ToNot(BeNil())
+		_, err = getRecoveryCluster(ctx)
+		Expect(apierrs.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("fails when the restore job fails", func(ctx context.Context) {
+		createBackup(ctx, "latest", time.Now().Add(-time.Hour))
+		reconcile(ctx)
+
+		recoveryCluster, err := getRecoveryCluster(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		recoveryCluster.Status.Phase = apiv1.PhaseHealthy
+		recoveryCluster.Status.ReadyInstances = 1
+		Expect(env.client.Status().Update(ctx, recoveryCluster)).To(Succeed())
+		reconcile(ctx)
+
+		var job batchv1.Job
+		Expect(env.client.Get(ctx, client.ObjectKey{
+			Namespace: objectRestore.Namespace,
+			Name:      objectRestore.GetJobName(),
+		}, &job)).To(Succeed())
+		job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobFailed, Status: corev1.ConditionTrue}}
+		Expect(env.client.Status().Update(ctx, &job)).To(Succeed())
+
+		reconcile(ctx)
+		Expect(objectRestore.Status.Phase).To(Equal(apiv1.ObjectRestorePhaseFailed))
+		Expect(objectRestore.Status.Message).To(ContainSubstring(job.Name))
+		_, err = getRecoveryCluster(ctx)
+		Expect(apierrs.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("fails when there is no backup to recover from", func(ctx context.Context) {
+		reconcile(ctx)
+		Expect(objectRestore.Status.Phase).To(Equal(apiv1.ObjectRestorePhaseFailed))
+		Expect(objectRestore.Status.Message).To(ContainSubstring("no completed barmanObjectStore or persistentVolume"))
+	})
+
+	It("fails when the requested backup can't be recovered from", func(ctx context.Context) {
+		createBackupWithMethod(ctx, "snapshot", apiv1.BackupMethodVolumeSnapshot, time.Now().Add(-time.Hour))
+		objectRestore.Spec.Backup = &apiv1.LocalObjectReference{Name: "snapshot"}
+		Expect(env.client.Update(ctx, objectRestore)).To(Succeed())
+
+		reconcile(ctx)
+		Expect(objectRestore.Status.Phase).To(Equal(apiv1.ObjectRestorePhaseFailed))
+		Expect(objectRestore.Status.Message).To(ContainSubstring(`uses the "volumeSnapshot" method`))
+		_, err := getRecoveryCluster(ctx)
+		Expect(apierrs.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("fails without touching an existing cluster with the recovery cluster name", func(ctx context.Context) {
+		createBackup(ctx, "latest", time.Now().Add(-time.Hour))
+		existingCluster := &apiv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      objectRestore.GetRecoveryClusterName(),
+				Namespace: objectRestore.Namespace,
+			},
+		}
+		Expect(env.client.Create(ctx, existingCluster)).To(Succeed())
+
+		reconcile(ctx)
+		Expect(objectRestore.Status.Phase).To(Equal(apiv1.ObjectRestorePhaseFailed))
+		Expect(objectRestore.Status.Message).To(ContainSubstring("not managed by this object restore"))
+
+		recoveryCluster, err := getRecoveryCluster(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(recoveryCluster.UID).To(Equal(existingCluster.UID))
+		Expect(recoveryCluster.Spec.Bootstrap).To(BeNil())
+
+		var job batchv1.Job
+		Expect(apierrs.IsNotFound(env.client.Get(ctx, client.ObjectKey{
+			Namespace: objectRestore.Namespace,
+			Name:      objectRestore.GetJobName(),
+		}, &job))).To(BeTrue())
+	})
+
+	It("fails without superuser access to the cluster", func(ctx context.Context) {
+		createBackup(ctx, "latest", time.Now().Add(-time.Hour))
+		cluster.Spec.EnableSuperuserAccess = ptr.To(false)
+		Expect(env.client.Update(ctx, cluster)).To(Succeed())
+
+		reconcile(ctx)
+		Expect(objectRestore.Status.Phase).To(Equal(apiv1.ObjectRestorePhaseFailed))
+		Expect(objectRestore.Status.Message).To(ContainSubstring("superuser access"))
+		_, err := getRecoveryCluster(ctx)
+		Expect(apierrs.IsNotFound(err)).To(BeTrue())
+	})
+})
@@ -96,3 +96,20 @@ func setPluginClientInContext(ctx context.Context, client cnpgiClient.Client) co
 func getPluginClientFromContext(ctx context.Context) cnpgiClient.Client {
 	return ctx.Value(utils.PluginClientKey).(cnpgiClient.Client)
 }
+
+// isSecretSuppliedByPlugins checks if the credentials stored in the passed
+// secret are supplied by a plugin. In that case the operator must neither
+// generate nor rotate them
+func isSecretSuppliedByPlugins(ctx context.Context, cluster *apiv1.Cluster, secretName string) (bool, error) {
+	pluginClient, ok := ctx.Value(utils.PluginClientKey).(cnpgiClient.Client)
+	if !ok || pluginClient == nil {
+		return false, nil
+	}
+
+	credentials, err := pluginClient.GetCredentials(ctx, cluster, secretName)
+	if err != nil {
+		return false, fmt.Errorf("while getting credentials for secret %s from plugins: %w", secretName, err)
+	}
+
+	return credentials != nil, nil
+}
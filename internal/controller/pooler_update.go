@@ -59,7 +59,13 @@ func (r *PoolerReconciler) updateOwnedObjects(
 		return err
 	}
 
-	return createOrPatchPodMonitor(ctx, r.Client, r.DiscoveryClient, pgbouncer.NewPoolerPodMonitorManager(pooler))
+	if err := createOrPatchPodMonitor(
+		ctx, r.Client, r.DiscoveryClient, pgbouncer.NewPoolerPodMonitorManager(pooler),
+	); err != nil {
+		return err
+	}
+
+	return createOrPatchNetworkPolicy(ctx, r.Client, pgbouncer.NewPoolerNetworkPolicyManager(pooler))
 }
 
 // updateDeployment update the deployment or create it when needed
@@ -44,6 +44,10 @@ var ErrWalReceiversRunning = fmt.Errorf("wal receivers are still running")
 // elapsed yet
 var ErrWaitingOnFailOverDelay = fmt.Errorf("current primary isn't healthy, waiting for the delay before triggering a failover") //nolint: lll
 
+// ErrFailoverQuorumNotReached is raised when the primary server can't be elected
+// because the majority of the instances didn't confirm that the current primary is lost
+var ErrFailoverQuorumNotReached = fmt.Errorf("current primary isn't healthy, waiting for the failover quorum")
+
 // reconcileTargetPrimaryFromPods sets the name of the target primary from the Pods status if needed
 // this function will return the name of the new primary selected for promotion.
 // Returns the name of the primary if any changes was made and any error encountered.
@@ -110,6 +114,19 @@ func (r *ClusterReconciler) reconcileTargetPrimaryForNonReplicaCluster(
 		return "", err
 	}
 
+	// When the quorum-based failover is enabled, the operator's own view of the
+	// primary is not enough: we also need the majority of the instances to
+	// confirm that they lost it, otherwise we may be on the wrong side of a
+	// network partition.
+	if cluster.IsFailoverQuorumEnabled() &&
+		cluster.Status.TargetPrimary == cluster.Status.CurrentPrimary &&
+		!status.HasFailoverQuorum(cluster.Status.CurrentPrimary, cluster.Spec.Instances) {
+		contextLogger.Info("Current primary isn't healthy, but the failover quorum has not been reached",
+			"currentPrimary", cluster.Status.CurrentPrimary,
+			"instances", cluster.Spec.Instances)
+		return "", ErrFailoverQuorumNotReached
+	}
+
 	// The current primary is not correctly working, and we need to elect a new one
 	// but before doing that we need to wait for all the WAL receivers to be
 	// terminated. To make sure they eventually terminate we signal the old primary
@@ -137,36 +154,42 @@ func (r *ClusterReconciler) reconcileTargetPrimaryForNonReplicaCluster(
 		return "", ErrWalReceiversRunning
 	}
 
+	// Among the most advanced instances, prefer the ones running on the
+	// nodes chosen by the primary placement preferences
+	newPrimary := getPreferredFailoverCandidate(cluster, status, resources.nodes)
+
 	// This may be tha last step of a failover if target primary is set to apiv1.PendingFailoverMarker
 	// or change the target primary if the current one is not valid anymore.
 	if cluster.Status.TargetPrimary == apiv1.PendingFailoverMarker {
-		contextLogger.Info("Failing over", "newPrimary", mostAdvancedInstance.Pod.Name)
+		contextLogger.Info("Failing over", "newPrimary", newPrimary.Pod.Name)
 		status.LogStatus(ctx)
 		contextLogger.Debug("Cluster status before failover", "instances", resources.instances)
 		r.Recorder.Eventf(cluster, "Normal", "FailoverTarget",
 			"Failing over from %v to %v",
-			cluster.Status.CurrentPrimary, mostAdvancedInstance.Pod.Name)
+			cluster.Status.CurrentPrimary, newPrimary.Pod.Name)
 		if err := r.RegisterPhase(ctx, cluster, apiv1.PhaseFailOver,
-			fmt.Sprintf("Failing over from %v to %v", cluster.Status.CurrentPrimary, mostAdvancedInstance.Pod.Name),
+			fmt.Sprintf("Failing over from %v to %v", cluster.Status.CurrentPrimary, newPrimary.Pod.Name),
 		); err != nil {
 			return "", err
 		}
 	} else {
 		contextLogger.Info("Target primary isn't healthy, switching target",
-			"newPrimary", mostAdvancedInstance.Pod.Name)
+			"newPrimary", newPrimary.Pod.Name)
 		status.LogStatus(ctx)
 		contextLogger.Debug("Cluster status before switching target", "instances", resources.instances)
 		r.Recorder.Eventf(cluster, "Normal", "FailingOver",
 			"Target primary isn't healthy, switching target from %v to %v",
-			cluster.Status.TargetPrimary, mostAdvancedInstance.Pod.Name)
+			cluster.Status.TargetPrimary, newPrimary.Pod.Name)
 		if err := r.RegisterPhase(ctx, cluster, apiv1.PhaseSwitchover,
-			fmt.Sprintf("Switching over to %v", mostAdvancedInstance.Pod.Name)); err != nil {
+			fmt.Sprintf("Switching over to %v", newPrimary.Pod.Name)); err != nil {
 			return "", err
 		}
 	}
 
-	// Set the first pod in the sorted list as the new targetPrimary
-	return mostAdvancedInstance.Pod.Name, r.setPrimaryInstance(ctx, cluster, mostAdvancedInstance.Pod.Name)
+	// Set the selected pod as the new targetPrimary
+	return newPrimary.Pod.Name, r.switchPrimaryInstance(
+		ctx, cluster, status, newPrimary.Pod.Name, apiv1.PrimaryChangeReasonFailover,
+	)
 }
 
 // isNodeUnschedulableOrBeingDrained checks if a node is currently being drained.
@@ -265,7 +288,9 @@ func (r *ClusterReconciler) setPrimaryOnSchedulableNode(
 				primaryPod.Node)); err != nil {
 			return "", err
 		}
-		return candidate.Pod.Name, r.setPrimaryInstance(ctx, cluster, candidate.Pod.Name)
+		return candidate.Pod.Name, r.switchPrimaryInstance(
+			ctx, cluster, status, candidate.Pod.Name, apiv1.PrimaryChangeReasonSwitchover,
+		)
 	}
 
 	// if we are here this means no new primary has been chosen
@@ -322,7 +347,49 @@ func (r *ClusterReconciler) reconcileTargetPrimaryForReplicaCluster(
 		return "", err
 	}
 
-	return status.Items[0].Pod.Name, r.setPrimaryInstance(ctx, cluster, status.Items[0].Pod.Name)
+	return status.Items[0].Pod.Name, r.switchPrimaryInstance(
+		ctx, cluster, status, status.Items[0].Pod.Name, apiv1.PrimaryChangeReasonFailover,
+	)
+}
+
+// getPreferredFailoverCandidate gets the instance to be promoted during a failover.
+// This is the most advanced instance unless there are other instances that are
+// equally advanced and run on a node with a higher primary placement score
+func getPreferredFailoverCandidate(
+	cluster *apiv1.Cluster,
+	status postgres.PostgresqlStatusList,
+	nodes map[string]corev1.Node,
+) postgres.PostgresqlStatus {
+	mostAdvancedInstance := status.Items[0]
+	if cluster.Spec.PrimaryPlacement == nil || len(cluster.Spec.PrimaryPlacement.Preferences) == 0 {
+		return mostAdvancedInstance
+	}
+
+	getScore := func(item postgres.PostgresqlStatus) int32 {
+		node, ok := nodes[item.Node]
+		if !ok {
+			return 0
+		}
+		return cluster.GetPrimaryPlacementScore(node.Labels)
+	}
+
+	result := mostAdvancedInstance
+	resultScore := getScore(result)
+	for _, candidate := range status.Items[1:] {
+		if !candidate.HasHTTPStatus() ||
+			candidate.Pod.Name == cluster.Status.TargetPrimary ||
+			candidate.ReceivedLsn != mostAdvancedInstance.ReceivedLsn ||
+			candidate.ReplayLsn != mostAdvancedInstance.ReplayLsn {
+			continue
+		}
+
+		if score := getScore(candidate); score > resultScore {
+			result = candidate
+			resultScore = score
+		}
+	}
+
+	return result
 }
 
 // GetPodsNotOnPrimaryNode filters out only pods that are not on the same node as the primary one
@@ -23,6 +23,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"hash/fnv"
 	"strconv"
 	"time"
 
@@ -39,6 +40,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 
 	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/internal/configuration"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
 )
 
@@ -59,11 +61,25 @@ type ScheduledBackupReconciler struct {
 	client.Client
 	Scheme   *runtime.Scheme
 	Recorder record.EventRecorder
+
+	// removeBackupDirectory removes the data of the expired backups
+	// from the instances, defaulting to executing a command in them
+	removeBackupDirectory backupDirectoryRemover
+}
+
+func (r *ScheduledBackupReconciler) getBackupDirectoryRemover() backupDirectoryRemover {
+	if r.removeBackupDirectory != nil {
+		return r.removeBackupDirectory
+	}
+	return removeBackupDirectoryInPod
 }
 
 // +kubebuilder:rbac:groups=postgresql.cnpg.io,resources=scheduledbackups,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=postgresql.cnpg.io,resources=scheduledbackups/status,verbs=get;update;patch
-// +kubebuilder:rbac:groups=postgresql.cnpg.io,resources=backups,verbs=get;list;create
+// +kubebuilder:rbac:groups=postgresql.cnpg.io,resources=backups,verbs=get;list;create;delete
+// +kubebuilder:rbac:groups=snapshot.storage.k8s.io,resources=volumesnapshots,verbs=delete
+// +kubebuilder:rbac:groups="",resources=pods,verbs=get;list
+// +kubebuilder:rbac:groups="",resources=pods/exec,verbs=create
 // +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 
 // Reconcile is the main reconciler logic
@@ -123,6 +139,19 @@ func (r *ScheduledBackupReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 		}
 	}
 
+	// The expired backups are deleted only when no backup is running,
+	// and a failure in deleting them must not prevent new backups from
+	// being taken
+	if scheduledBackup.Spec.Retention != nil {
+		if err := pruneBackupTiers(
+			ctx, r.Client, r.Recorder, &scheduledBackup, r.getBackupDirectoryRemover(),
+		); err != nil {
+			contextLogger.Error(err, "while deleting the expired backups")
+			r.Recorder.Eventf(&scheduledBackup, "Warning", "BackupPruning",
+				"Error while deleting the expired backups: %v", err)
+		}
+	}
+
 	return ReconcileScheduledBackup(ctx, r.Recorder, r.Client, &scheduledBackup)
 }
 
@@ -215,16 +244,32 @@ func ReconcileScheduledBackup(
 
 	// Let's check if we are supposed to start a new backup.
 	nextTime := schedule.Next(scheduledBackup.GetStatus().LastCheckTime.Time)
-	contextLogger.Info("Next backup schedule", "next", nextTime)
+	startTime := nextTime.Add(getScheduleJitter(scheduledBackup, nextTime))
+	contextLogger.Info("Next backup schedule", "next", nextTime, "start", startTime)
 
-	if now.Before(nextTime) {
+	if now.Before(startTime) {
 		// No need to schedule a new backup, let's wait a bit
-		return ctrl.Result{RequeueAfter: nextTime.Sub(now)}, nil
+		return ctrl.Result{RequeueAfter: startTime.Sub(now)}, nil
 	}
 
 	return createBackup(ctx, event, cli, scheduledBackup, nextTime, now, schedule, false)
 }
 
+// getScheduleJitter gets the delay added to a scheduled time of a scheduled
+// backup, to spread the backups sharing the same schedule. The delay is
+// pseudo-random, and stable for each scheduled time, so that it doesn't
+// change between reconciliation loops
+func getScheduleJitter(scheduledBackup *apiv1.ScheduledBackup, scheduledTime time.Time) time.Duration {
+	maxJitter := configuration.Current.GetScheduledBackupsMaxJitter()
+	if maxJitter <= 0 {
+		return 0
+	}
+
+	hash := fnv.New64a()
+	_, _ = fmt.Fprintf(hash, "%s/%s/%d", scheduledBackup.Namespace, scheduledBackup.Name, scheduledTime.Unix())
+	return time.Duration(hash.Sum64()%uint64(maxJitter.Seconds())) * time.Second
+}
+
 // createBackup creates a scheduled backup for a backuptime, updating the ScheduledBackup accordingly
 func createBackup(
 	ctx context.Context,
@@ -253,6 +298,24 @@ func createBackup(
 	metadata.Labels[utils.ImmediateBackupLabelName] = strconv.FormatBool(immediate)
 	metadata.Labels[utils.ParentScheduledBackupLabelName] = scheduledBackup.GetName()
 
+	if scheduledBackup.Spec.Retention != nil {
+		tier, err := getScheduledBackupTier(ctx, cli, scheduledBackup, backupTime)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		metadata.Labels[utils.BackupTierLabelName] = string(tier)
+	}
+
+	if scheduledBackup.Spec.Incremental && scheduledBackup.Spec.Method == apiv1.BackupMethodPersistentVolume {
+		parentBackup, err := getIncrementalParentBackup(ctx, cli, scheduledBackup)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if parentBackup != nil {
+			backup.Spec.ParentBackup = &apiv1.LocalObjectReference{Name: parentBackup.Name}
+		}
+	}
+
 	switch scheduledBackup.Spec.BackupOwnerReference {
 	case "cluster":
 		var cluster apiv1.Cluster
@@ -312,6 +375,21 @@ func createBackup(
 	return ctrl.Result{RequeueAfter: nextBackupTime.Sub(now)}, nil
 }
 
+// getIncrementalParentBackup gets the backup the next incremental backup
+// of a scheduled backup is based on, or nil if a full backup is needed
+func getIncrementalParentBackup(
+	ctx context.Context,
+	cli client.Client,
+	scheduledBackup *apiv1.ScheduledBackup,
+) (*apiv1.Backup, error) {
+	var backups apiv1.BackupList
+	if err := cli.List(ctx, &backups, client.InNamespace(scheduledBackup.Namespace)); err != nil {
+		return nil, fmt.Errorf("while listing the backups: %w", err)
+	}
+
+	return backups.GetLatestPersistentVolumeBackup(scheduledBackup.Spec.Cluster.Name), nil
+}
+
 // GetChildBackups gets all the backups scheduled by a certain scheduler
 func (r *ScheduledBackupReconciler) GetChildBackups(
 	ctx context.Context,
@@ -0,0 +1,326 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	storagesnapshotv1 "github.com/kubernetes-csi/external-snapshotter/client/v8/apis/volumesnapshot/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/specs"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+)
+
+// listScheduledBackupChildren lists the backups created by a scheduled backup
+func listScheduledBackupChildren(
+	ctx context.Context,
+	cli client.Client,
+	scheduledBackup *apiv1.ScheduledBackup,
+) ([]apiv1.Backup, error) {
+	var backups apiv1.BackupList
+	if err := cli.List(ctx, &backups,
+		client.InNamespace(scheduledBackup.Namespace),
+		client.MatchingLabels{utils.ParentScheduledBackupLabelName: scheduledBackup.Name},
+	); err != nil {
+		return nil, fmt.Errorf("while listing the backups of the scheduled backup: %w", err)
+	}
+
+	return backups.Items, nil
+}
+
+// getScheduledBackupTier gets the retention tier of the backup a scheduled
+// backup is about to create
+func getScheduledBackupTier(
+	ctx context.Context,
+	cli client.Client,
+	scheduledBackup *apiv1.ScheduledBackup,
+	backupTime time.Time,
+) (apiv1.BackupTier, error) {
+	backups, err := listScheduledBackupChildren(ctx, cli, scheduledBackup)
+	if err != nil {
+		return "", err
+	}
+
+	previousBackupTimes := make([]time.Time, 0, len(backups))
+	for _, backup := range backups {
+		// A failed backup doesn't take the place of the next one in its tier
+		if backup.Status.Phase == apiv1.BackupPhaseFailed {
+			continue
+		}
+		previousBackupTimes = append(previousBackupTimes, backup.CreationTimestamp.UTC())
+	}
+
+	return scheduledBackup.Spec.Retention.GetTier(backupTime.UTC(), previousBackupTimes), nil
+}
+
+// backupDirectoryRemover removes a directory from the filesystem of
+// the PostgreSQL container of an instance Pod
+type backupDirectoryRemover func(ctx context.Context, pod *corev1.Pod, directory string) error
+
+// removeBackupDirectoryInPod removes a directory executing a command
+// in the PostgreSQL container of an instance Pod
+func removeBackupDirectoryInPod(ctx context.Context, pod *corev1.Pod, directory string) error {
+	config := ctrl.GetConfigOrDie()
+	clientInterface := kubernetes.NewForConfigOrDie(config)
+
+	timeout := time.Minute
+	if _, stderr, err := utils.ExecCommand(
+		ctx,
+		clientInterface,
+		config,
+		*pod,
+		specs.PostgresContainerName,
+		&timeout,
+		"rm", "-rf", "--", directory,
+	); err != nil {
+		return fmt.Errorf("while removing %s from pod %s: %w (%s)", directory, pod.Name, err, stderr)
+	}
+
+	return nil
+}
+
+// pruneBackupTiers deletes the completed backups of a scheduled backup that
+// exceed the count of their retention tier, together with their data.
+// Backups are pruned only once the newest backup has completed, which is
+// never deleted, so that a backup is never replaced by one that may fail.
+// Backups on which other backups are based are never deleted
+func pruneBackupTiers(
+	ctx context.Context,
+	cli client.Client,
+	recorder record.EventRecorder,
+	scheduledBackup *apiv1.ScheduledBackup,
+	removeDirectory backupDirectoryRemover,
+) error {
+	contextLogger := log.FromContext(ctx)
+
+	backups, err := listScheduledBackupChildren(ctx, cli, scheduledBackup)
+	if err != nil {
+		return err
+	}
+	if len(backups) == 0 {
+		return nil
+	}
+
+	// The newest backups come first
+	sort.Slice(backups, func(i, j int) bool {
+		if !backups[i].CreationTimestamp.Equal(&backups[j].CreationTimestamp) {
+			return backups[j].CreationTimestamp.Before(&backups[i].CreationTimestamp)
+		}
+		return backups[i].Name > backups[j].Name
+	})
+	if backups[0].Status.Phase != apiv1.BackupPhaseCompleted {
+		contextLogger.Debug("Waiting for the newest backup to complete before pruning",
+			"backupName", backups[0].Name, "backupPhase", backups[0].Status.Phase)
+		return nil
+	}
+
+	parentBackups := make(map[string]bool)
+	tiers := make(map[apiv1.BackupTier][]*apiv1.Backup)
+	for idx := range backups {
+		backup := &backups[idx]
+		if backup.Spec.ParentBackup != nil {
+			parentBackups[backup.Spec.ParentBackup.Name] = true
+		}
+		if backup.Status.PersistentVolumeBackupStatus != nil &&
+			backup.Status.PersistentVolumeBackupStatus.ParentBackupName != "" {
+			parentBackups[backup.Status.PersistentVolumeBackupStatus.ParentBackupName] = true
+		}
+
+		tier := apiv1.BackupTier(backup.Labels[utils.BackupTierLabelName])
+		if idx == 0 || tier == "" || backup.Status.Phase != apiv1.BackupPhaseCompleted ||
+			!backup.DeletionTimestamp.IsZero() {
+			continue
+		}
+		tiers[tier] = append(tiers[tier], backup)
+	}
+
+	for tier, tierBackups := range tiers {
+		// The newest backup has been left out of its tier, and still counts
+		count := scheduledBackup.Spec.Retention.GetCount(tier)
+		if apiv1.BackupTier(backups[0].Labels[utils.BackupTierLabelName]) == tier && count > 0 {
+			count--
+		}
+
+		for idx := count; idx < len(tierBackups); idx++ {
+			backup := tierBackups[idx]
+			if parentBackups[backup.Name] {
+				contextLogger.Info("Keeping expired backup, as other backups are based on it",
+					"backupName", backup.Name, "tier", tier)
+				continue
+			}
+
+			contextLogger.Info("Deleting expired backup", "backupName", backup.Name, "tier", tier)
+			if err := deleteBackupWithData(ctx, cli, backup, removeDirectory); err != nil {
+				return err
+			}
+			recorder.Eventf(scheduledBackup, "Normal", "BackupPruned",
+				"Deleted backup %v, exceeding the %d %s backups to keep",
+				backup.Name, scheduledBackup.Spec.Retention.GetCount(tier), tier)
+		}
+	}
+
+	return nil
+}
+
+// deleteBackupWithData deletes a backup together with its data. The backup
+// is kept when its data can't be deleted, so that this can be retried
+func deleteBackupWithData(
+	ctx context.Context,
+	cli client.Client,
+	backup *apiv1.Backup,
+	removeDirectory backupDirectoryRemover,
+) error {
+	switch {
+	case backup.Spec.Method == apiv1.BackupMethodVolumeSnapshot:
+		if err := deleteBackupSnapshots(ctx, cli, backup); err != nil {
+			return err
+		}
+
+	case backup.Status.PersistentVolumeBackupStatus != nil:
+		status := backup.Status.PersistentVolumeBackupStatus
+		if err := deleteBackupDirectory(
+			ctx, cli, backup, status.VolumeClaimName, status.Path, removeDirectory,
+		); err != nil {
+			return err
+		}
+
+	case backup.Status.LogicalBackupStatus != nil && backup.Status.LogicalBackupStatus.VolumeClaimName != "":
+		status := backup.Status.LogicalBackupStatus
+		if err := deleteBackupDirectory(
+			ctx, cli, backup, status.VolumeClaimName, status.Path, removeDirectory,
+		); err != nil {
+			return err
+		}
+
+	default:
+		return fmt.Errorf("cannot delete the data of backup %s, taken with the %s method",
+			backup.Name, backup.Spec.Method)
+	}
+
+	if err := cli.Delete(ctx, backup); err != nil && !apierrs.IsNotFound(err) {
+		return fmt.Errorf("while deleting the backup %s: %w", backup.Name, err)
+	}
+
+	return nil
+}
+
+// deleteBackupSnapshots deletes the volume snapshots a backup is made of
+func deleteBackupSnapshots(ctx context.Context, cli client.Client, backup *apiv1.Backup) error {
+	for _, element := range backup.Status.BackupSnapshotStatus.Elements {
+		snapshot := storagesnapshotv1.VolumeSnapshot{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: backup.Namespace,
+				Name:      element.Name,
+			},
+		}
+		if err := cli.Delete(ctx, &snapshot); err != nil && !apierrs.IsNotFound(err) {
+			return fmt.Errorf("while deleting the volume snapshot %s: %w", element.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// deleteBackupDirectory removes the directory containing a backup from the
+// volume where it is stored, through a ready instance of the cluster of the
+// backup mounting that volume
+func deleteBackupDirectory(
+	ctx context.Context,
+	cli client.Client,
+	backup *apiv1.Backup,
+	claimName string,
+	relativePath string,
+	removeDirectory backupDirectoryRemover,
+) error {
+	relativePath = path.Clean(relativePath)
+	if relativePath == "." || path.IsAbs(relativePath) || strings.HasPrefix(relativePath, "..") {
+		return fmt.Errorf("invalid path %q for the data of backup %s", relativePath, backup.Name)
+	}
+
+	var pods corev1.PodList
+	if err := cli.List(ctx, &pods,
+		client.InNamespace(backup.Namespace),
+		client.MatchingLabels{
+			utils.ClusterLabelName: backup.Spec.Cluster.Name,
+			utils.PodRoleLabelName: string(utils.PodRoleInstance),
+		},
+	); err != nil {
+		return fmt.Errorf("while listing the instances of cluster %s: %w", backup.Spec.Cluster.Name, err)
+	}
+
+	for idx := range pods.Items {
+		pod := &pods.Items[idx]
+		if !utils.IsPodReady(*pod) {
+			continue
+		}
+
+		mountPath := getClaimMountPath(pod, claimName)
+		if mountPath == "" {
+			continue
+		}
+
+		return removeDirectory(ctx, pod, path.Join(mountPath, relativePath))
+	}
+
+	return fmt.Errorf("no ready instance of cluster %s is mounting the volume %s of backup %s",
+		backup.Spec.Cluster.Name, claimName, backup.Name)
+}
+
+// getClaimMountPath gets the path where a PersistentVolumeClaim is mounted
+// in the PostgreSQL container of a Pod, or an empty string if it isn't
+func getClaimMountPath(pod *corev1.Pod, claimName string) string {
+	volumeName := ""
+	for _, volume := range pod.Spec.Volumes {
+		if volume.PersistentVolumeClaim != nil && volume.PersistentVolumeClaim.ClaimName == claimName {
+			volumeName = volume.Name
+			break
+		}
+	}
+	if volumeName == "" {
+		return ""
+	}
+
+	for _, container := range pod.Spec.Containers {
+		if container.Name != specs.PostgresContainerName {
+			continue
+		}
+		for _, mount := range container.VolumeMounts {
+			if mount.Name == volumeName {
+				return mount.MountPath
+			}
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,310 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	storagesnapshotv1 "github.com/kubernetes-csi/external-snapshotter/client/v8/apis/volumesnapshot/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	schemeBuilder "github.com/cloudnative-pg/cloudnative-pg/internal/scheme"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/specs"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("scheduled backup retention tiers", func() {
+	const namespace = "default"
+
+	var (
+		scheduledBackup *apiv1.ScheduledBackup
+		now             time.Time
+	)
+
+	newBackup := func(
+		name string,
+		tier apiv1.BackupTier,
+		phase apiv1.BackupPhase,
+		created time.Time,
+	) *apiv1.Backup {
+		backup := &apiv1.Backup{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:         namespace,
+				Name:              name,
+				CreationTimestamp: metav1.NewTime(created),
+				Labels: map[string]string{
+					utils.ParentScheduledBackupLabelName: scheduledBackup.Name,
+				},
+			},
+			Spec:   apiv1.BackupSpec{Method: apiv1.BackupMethodVolumeSnapshot},
+			Status: apiv1.BackupStatus{Phase: phase},
+		}
+		if tier != "" {
+			backup.Labels[utils.BackupTierLabelName] = string(tier)
+		}
+		return backup
+	}
+
+	newClient := func(objects ...client.Object) client.Client {
+		return fake.NewClientBuilder().
+			WithScheme(schemeBuilder.BuildWithAllKnownScheme()).
+			WithObjects(objects...).
+			WithStatusSubresource(&apiv1.Backup{}).
+			Build()
+	}
+
+	backupExists := func(ctx context.Context, cli client.Client, name string) bool {
+		var backup apiv1.Backup
+		err := cli.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, &backup)
+		if apierrs.IsNotFound(err) {
+			return false
+		}
+		Expect(err).ToNot(HaveOccurred())
+		return true
+	}
+
+	BeforeEach(func() {
+		now = time.Date(2025, time.January, 15, 0, 0, 0, 0, time.UTC)
+		scheduledBackup = &apiv1.ScheduledBackup{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "scheduled"},
+			Spec: apiv1.ScheduledBackupSpec{
+				Retention: &apiv1.BackupTierRetention{Daily: 2, Weekly: 1},
+			},
+		}
+	})
+
+	It("gets the tier ignoring the failed backups", func(ctx SpecContext) {
+		cli := newClient(
+			newBackup("failed", apiv1.BackupTierWeekly, apiv1.BackupPhaseFailed, now.Add(-time.Hour)),
+		)
+		tier, err := getScheduledBackupTier(ctx, cli, scheduledBackup, now)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(tier).To(Equal(apiv1.BackupTierWeekly))
+
+		cli = newClient(
+			newBackup("completed", apiv1.BackupTierWeekly, apiv1.BackupPhaseCompleted, now.Add(-time.Hour)),
+		)
+		tier, err = getScheduledBackupTier(ctx, cli, scheduledBackup, now)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(tier).To(Equal(apiv1.BackupTierDaily))
+	})
+
+	It("deletes the oldest completed backups exceeding each tier", func(ctx SpecContext) {
+		cli := newClient(
+			newBackup("daily-1", apiv1.BackupTierDaily, apiv1.BackupPhaseCompleted, now.Add(-1*time.Hour)),
+			newBackup("daily-2", apiv1.BackupTierDaily, apiv1.BackupPhaseCompleted, now.Add(-2*time.Hour)),
+			newBackup("daily-3", apiv1.BackupTierDaily, apiv1.BackupPhaseCompleted, now.Add(-3*time.Hour)),
+			newBackup("daily-failed", apiv1.BackupTierDaily, apiv1.BackupPhaseFailed, now.Add(-4*time.Hour)),
+			newBackup("weekly-1", apiv1.BackupTierWeekly, apiv1.BackupPhaseCompleted, now.Add(-5*time.Hour)),
+			newBackup("weekly-2", apiv1.BackupTierWeekly, apiv1.BackupPhaseCompleted, now.Add(-6*time.Hour)),
+			newBackup("untiered", "", apiv1.BackupPhaseCompleted, now.Add(-7*time.Hour)),
+		)
+		recorder := record.NewFakeRecorder(10)
+
+		Expect(pruneBackupTiers(ctx, cli, recorder, scheduledBackup, nil)).To(Succeed())
+
+		Expect(backupExists(ctx, cli, "daily-1")).To(BeTrue())
+		Expect(backupExists(ctx, cli, "daily-2")).To(BeTrue())
+		Expect(backupExists(ctx, cli, "daily-3")).To(BeFalse())
+		Expect(backupExists(ctx, cli, "daily-failed")).To(BeTrue())
+		Expect(backupExists(ctx, cli, "weekly-1")).To(BeTrue())
+		Expect(backupExists(ctx, cli, "weekly-2")).To(BeFalse())
+		Expect(backupExists(ctx, cli, "untiered")).To(BeTrue())
+		Expect(recorder.Events).To(HaveLen(2))
+	})
+
+	It("keeps the expired backups other backups are based on", func(ctx SpecContext) {
+		parent := newBackup("parent", apiv1.BackupTierDaily, apiv1.BackupPhaseCompleted, now.Add(-3*time.Hour))
+		child := newBackup("child", apiv1.BackupTierDaily, apiv1.BackupPhaseCompleted, now.Add(-1*time.Hour))
+		child.Spec.ParentBackup = &apiv1.LocalObjectReference{Name: parent.Name}
+		cli := newClient(
+			parent,
+			child,
+			newBackup("daily", apiv1.BackupTierDaily, apiv1.BackupPhaseCompleted, now.Add(-2*time.Hour)),
+		)
+
+		Expect(pruneBackupTiers(ctx, cli, record.NewFakeRecorder(10), scheduledBackup, nil)).To(Succeed())
+		Expect(backupExists(ctx, cli, "parent")).To(BeTrue())
+	})
+
+	It("deletes the volume snapshots of the expired backups", func(ctx SpecContext) {
+		scheduledBackup.Spec.Retention = &apiv1.BackupTierRetention{Daily: 1}
+		expired := newBackup("expired", apiv1.BackupTierDaily, apiv1.BackupPhaseCompleted, now.Add(-2*time.Hour))
+		expired.Status.BackupSnapshotStatus.Elements = []apiv1.BackupSnapshotElementStatus{
+			{Name: "expired-pgdata", Type: string(utils.PVCRolePgData)},
+			{Name: "expired-missing", Type: string(utils.PVCRolePgWal)},
+		}
+		snapshot := &storagesnapshotv1.VolumeSnapshot{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "expired-pgdata"},
+		}
+		cli := newClient(
+			expired,
+			snapshot,
+			newBackup("kept", apiv1.BackupTierDaily, apiv1.BackupPhaseCompleted, now.Add(-1*time.Hour)),
+		)
+
+		Expect(pruneBackupTiers(ctx, cli, record.NewFakeRecorder(10), scheduledBackup, nil)).To(Succeed())
+		Expect(backupExists(ctx, cli, "expired")).To(BeFalse())
+		err := cli.Get(ctx, client.ObjectKeyFromObject(snapshot), &storagesnapshotv1.VolumeSnapshot{})
+		Expect(apierrs.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("doesn't delete any backup until the newest one is completed", func(ctx SpecContext) {
+		cli := newClient(
+			newBackup("running", apiv1.BackupTierDaily, apiv1.BackupPhaseRunning, now),
+			newBackup("daily-1", apiv1.BackupTierDaily, apiv1.BackupPhaseCompleted, now.Add(-1*time.Hour)),
+			newBackup("daily-2", apiv1.BackupTierDaily, apiv1.BackupPhaseCompleted, now.Add(-2*time.Hour)),
+			newBackup("daily-3", apiv1.BackupTierDaily, apiv1.BackupPhaseCompleted, now.Add(-3*time.Hour)),
+		)
+
+		Expect(pruneBackupTiers(ctx, cli, record.NewFakeRecorder(10), scheduledBackup, nil)).To(Succeed())
+		Expect(backupExists(ctx, cli, "daily-3")).To(BeTrue())
+	})
+
+	It("never deletes the newest backup", func(ctx SpecContext) {
+		scheduledBackup.Spec.Retention = &apiv1.BackupTierRetention{Weekly: 1}
+		cli := newClient(
+			newBackup("daily-1", apiv1.BackupTierDaily, apiv1.BackupPhaseCompleted, now.Add(-1*time.Hour)),
+			newBackup("daily-2", apiv1.BackupTierDaily, apiv1.BackupPhaseCompleted, now.Add(-2*time.Hour)),
+		)
+
+		Expect(pruneBackupTiers(ctx, cli, record.NewFakeRecorder(10), scheduledBackup, nil)).To(Succeed())
+		Expect(backupExists(ctx, cli, "daily-1")).To(BeTrue())
+		Expect(backupExists(ctx, cli, "daily-2")).To(BeFalse())
+	})
+
+	Context("with backups stored in volumes", func() {
+		var pod *corev1.Pod
+
+		newVolumeBackup := func(name string, created time.Time) *apiv1.Backup {
+			backup := newBackup(name, apiv1.BackupTierDaily, apiv1.BackupPhaseCompleted, created)
+			backup.Spec.Cluster.Name = "cluster-example"
+			backup.Spec.Method = apiv1.BackupMethodPersistentVolume
+			backup.Status.PersistentVolumeBackupStatus = &apiv1.PersistentVolumeBackupStatus{
+				VolumeClaimName: "backups",
+				Path:            name,
+			}
+			return backup
+		}
+
+		BeforeEach(func() {
+			scheduledBackup.Spec.Retention = &apiv1.BackupTierRetention{Daily: 1}
+			pod = &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: namespace,
+					Name:      "cluster-example-1",
+					Labels: map[string]string{
+						utils.ClusterLabelName: "cluster-example",
+						utils.PodRoleLabelName: string(utils.PodRoleInstance),
+					},
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{
+						Name: specs.PostgresContainerName,
+						VolumeMounts: []corev1.VolumeMount{
+							{Name: "backups", MountPath: specs.PersistentVolumeBackupPath},
+						},
+					}},
+					Volumes: []corev1.Volume{{
+						Name: "backups",
+						VolumeSource: corev1.VolumeSource{
+							PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "backups"},
+						},
+					}},
+				},
+				Status: corev1.PodStatus{
+					Conditions: []corev1.PodCondition{
+						{Type: corev1.ContainersReady, Status: corev1.ConditionTrue},
+					},
+				},
+			}
+		})
+
+		It("removes the directory of the expired backups from a ready instance", func(ctx SpecContext) {
+			cli := newClient(
+				pod,
+				newVolumeBackup("expired", now.Add(-2*time.Hour)),
+				newVolumeBackup("kept", now.Add(-1*time.Hour)),
+			)
+			var removed []string
+			remover := func(_ context.Context, pod *corev1.Pod, directory string) error {
+				removed = append(removed, pod.Name+":"+directory)
+				return nil
+			}
+
+			Expect(pruneBackupTiers(ctx, cli, record.NewFakeRecorder(10), scheduledBackup, remover)).To(Succeed())
+			Expect(removed).To(ConsistOf("cluster-example-1:" + specs.PersistentVolumeBackupPath + "/expired"))
+			Expect(backupExists(ctx, cli, "expired")).To(BeFalse())
+			Expect(backupExists(ctx, cli, "kept")).To(BeTrue())
+		})
+
+		It("keeps the expired backups when no ready instance mounts their volume", func(ctx SpecContext) {
+			pod.Status.Conditions = nil
+			cli := newClient(
+				pod,
+				newVolumeBackup("expired", now.Add(-2*time.Hour)),
+				newVolumeBackup("kept", now.Add(-1*time.Hour)),
+			)
+			remover := func(context.Context, *corev1.Pod, string) error {
+				Fail("no directory should be removed")
+				return nil
+			}
+
+			Expect(pruneBackupTiers(ctx, cli, record.NewFakeRecorder(10), scheduledBackup, remover)).ToNot(Succeed())
+			Expect(backupExists(ctx, cli, "expired")).To(BeTrue())
+		})
+
+		It("refuses to remove a directory outside of the backup volume", func(ctx SpecContext) {
+			expired := newVolumeBackup("expired", now.Add(-2*time.Hour))
+			expired.Status.PersistentVolumeBackupStatus.Path = "../pgdata"
+			cli := newClient(pod, expired, newVolumeBackup("kept", now.Add(-1*time.Hour)))
+			remover := func(context.Context, *corev1.Pod, string) error {
+				Fail("no directory should be removed")
+				return nil
+			}
+
+			Expect(pruneBackupTiers(ctx, cli, record.NewFakeRecorder(10), scheduledBackup, remover)).ToNot(Succeed())
+			Expect(backupExists(ctx, cli, "expired")).To(BeTrue())
+		})
+	})
+
+	It("keeps the expired backups whose data can't be deleted", func(ctx SpecContext) {
+		scheduledBackup.Spec.Retention = &apiv1.BackupTierRetention{Daily: 1}
+		expired := newBackup("expired", apiv1.BackupTierDaily, apiv1.BackupPhaseCompleted, now.Add(-2*time.Hour))
+		expired.Spec.Method = apiv1.BackupMethodBarmanObjectStore
+		cli := newClient(
+			expired,
+			newBackup("kept", apiv1.BackupTierDaily, apiv1.BackupPhaseCompleted, now.Add(-1*time.Hour)),
+		)
+
+		Expect(pruneBackupTiers(ctx, cli, record.NewFakeRecorder(10), scheduledBackup, nil)).ToNot(Succeed())
+		Expect(backupExists(ctx, cli, "expired")).To(BeTrue())
+	})
+})
@@ -75,8 +75,9 @@ func buildTestEnvironment() *testingEnvironment {
 
 	scheme := schemeBuilder.BuildWithAllKnownScheme()
 	k8sClient := fake.NewClientBuilder().WithScheme(scheme).
-		WithStatusSubresource(&apiv1.Cluster{}, &apiv1.Backup{}, &apiv1.Pooler{}, &corev1.Service{},
-			&corev1.ConfigMap{}, &corev1.Secret{}).
+		WithStatusSubresource(&apiv1.Cluster{}, &apiv1.Backup{}, &apiv1.Pooler{}, &apiv1.ClientCertificate{},
+			&apiv1.ObjectRestore{}, &apiv1.BackupGroup{}, &apiv1.BackupExport{},
+			&corev1.Service{}, &corev1.ConfigMap{}, &corev1.Secret{}).
 		WithIndex(&batchv1.Job{}, jobOwnerKey, jobOwnerIndexFunc).
 		Build()
 	Expect(err).ToNot(HaveOccurred())
@@ -27,20 +27,27 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	pluginClient "github.com/cloudnative-pg/cloudnative-pg/internal/cnpi/plugin/client"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres"
 )
 
 // Reconciler is a Kubernetes controller that ensures pgpass file for external servers is synchronized
 type Reconciler struct {
-	instance *postgres.Instance
-	client   client.Client
+	instance     *postgres.Instance
+	client       client.Client
+	localPlugins *pluginClient.LocalPlugins
 }
 
 // NewReconciler creates a new ExternalServerReconciler
-func NewReconciler(instance *postgres.Instance, client client.Client) *Reconciler {
+func NewReconciler(
+	instance *postgres.Instance,
+	client client.Client,
+	localPlugins *pluginClient.LocalPlugins,
+) *Reconciler {
 	controller := &Reconciler{
-		instance: instance,
-		client:   client,
+		instance:     instance,
+		client:       client,
+		localPlugins: localPlugins,
 	}
 	return controller
 }
@@ -58,6 +58,14 @@ func (r *Reconciler) Reconcile(
 
 	contextLogger.Debug("starting up the external servers reconciler")
 
+	// The credentials may be supplied by the plugins running
+	// as sidecars of this instance
+	ctx, closePlugins, err := r.localPlugins.WithCluster(ctx, cluster)
+	if err != nil {
+		return reconcile.Result{}, fmt.Errorf("while loading local plugins: %w", err)
+	}
+	defer closePlugins()
+
 	// For each external server, we ensure we download the credentials
 	for i := range cluster.Spec.ExternalClusters {
 		r.synchronize(
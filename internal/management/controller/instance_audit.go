@@ -0,0 +1,194 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package controller
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	"github.com/jackc/pgx/v5"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres/logpipe"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
+)
+
+// auditScope is the kind of object on which pgaudit settings are applied
+type auditScope string
+
+const (
+	auditScopeRole     auditScope = "ROLE"
+	auditScopeDatabase auditScope = "DATABASE"
+)
+
+// pgAuditSettingsQuery lists the `pgaudit.log` settings applied to roles
+// and databases, ignoring the ones applied to a role in a database
+const pgAuditSettingsQuery = `SELECT
+  CASE WHEN s.setdatabase = 0 THEN 'ROLE' ELSE 'DATABASE' END,
+  COALESCE(r.rolname, d.datname),
+  substr(c.setting, length('pgaudit.log=') + 1)
+FROM pg_catalog.pg_db_role_setting s
+CROSS JOIN LATERAL unnest(s.setconfig) AS c(setting)
+LEFT JOIN pg_catalog.pg_roles r ON r.oid = s.setrole
+LEFT JOIN pg_catalog.pg_database d ON d.oid = s.setdatabase
+WHERE c.setting LIKE 'pgaudit.log=%'
+  AND (s.setrole = 0) <> (s.setdatabase = 0)`
+
+// auditScopeKey identifies a role or a database
+type auditScopeKey struct {
+	scope auditScope
+	name  string
+}
+
+// reconcileAudit applies the per-role and per-database pgaudit settings
+// and configures the stream where the pgaudit records are written.
+// The settings are only managed when the audit section is present: removing
+// it leaves the existing role and database settings untouched.
+func (r *InstanceReconciler) reconcileAudit(ctx context.Context, cluster *apiv1.Cluster) error {
+	logpipe.SetPgAuditOutputSeparate(cluster.IsPgAuditOutputSeparate())
+
+	audit := cluster.Spec.PostgresConfiguration.Audit
+	if audit == nil {
+		return nil
+	}
+
+	isPrimary, err := r.instance.IsPrimary()
+	if err != nil {
+		return fmt.Errorf("unable to check if instance is primary: %w", err)
+	}
+	if !isPrimary {
+		return nil
+	}
+
+	db, err := r.instance.GetSuperUserDB()
+	if err != nil {
+		return fmt.Errorf("getting the superuserdb: %w", err)
+	}
+
+	return reconcileAuditSettings(ctx, db, audit)
+}
+
+// reconcileAuditSettings aligns the `pgaudit.log` settings of the roles and
+// databases with the ones requested in the audit configuration
+func reconcileAuditSettings(ctx context.Context, db *sql.DB, audit *apiv1.AuditConfiguration) error {
+	contextLogger := log.FromContext(ctx)
+
+	current, err := getAuditSettings(ctx, db)
+	if err != nil {
+		return fmt.Errorf("while reading the pgaudit settings: %w", err)
+	}
+
+	expected := make(map[auditScopeKey]string, len(audit.Roles)+len(audit.Databases))
+	for _, role := range audit.Roles {
+		expected[auditScopeKey{scope: auditScopeRole, name: role.Name}] = apiv1.RenderPgAuditClasses(role.Classes)
+	}
+	for _, database := range audit.Databases {
+		expected[auditScopeKey{scope: auditScopeDatabase, name: database.Name}] =
+			apiv1.RenderPgAuditClasses(database.Classes)
+	}
+
+	for key, value := range expected {
+		if currentValue, ok := current[key]; ok && currentValue == value {
+			continue
+		}
+
+		exists, err := auditScopeExists(ctx, db, key)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			contextLogger.Debug("Skipping pgaudit settings for a missing object",
+				"scope", key.scope, "name", key.name)
+			continue
+		}
+
+		contextLogger.Info("Applying pgaudit settings", "scope", key.scope, "name", key.name, "classes", value)
+		if _, err := db.ExecContext(ctx, fmt.Sprintf(
+			"ALTER %s %s SET %s TO '%s'",
+			key.scope,
+			pgx.Identifier{key.name}.Sanitize(),
+			postgres.ParameterPgAuditLog,
+			strings.ReplaceAll(value, "'", "''"),
+		)); err != nil {
+			return fmt.Errorf("while applying pgaudit settings to %s %s: %w", key.scope, key.name, err)
+		}
+	}
+
+	for key := range current {
+		if _, ok := expected[key]; ok {
+			continue
+		}
+
+		contextLogger.Info("Resetting pgaudit settings", "scope", key.scope, "name", key.name)
+		if _, err := db.ExecContext(ctx, fmt.Sprintf(
+			"ALTER %s %s RESET %s",
+			key.scope,
+			pgx.Identifier{key.name}.Sanitize(),
+			postgres.ParameterPgAuditLog,
+		)); err != nil {
+			return fmt.Errorf("while resetting pgaudit settings of %s %s: %w", key.scope, key.name, err)
+		}
+	}
+
+	return nil
+}
+
+// getAuditSettings returns the `pgaudit.log` settings currently applied
+// to roles and databases
+func getAuditSettings(ctx context.Context, db *sql.DB) (map[auditScopeKey]string, error) {
+	rows, err := db.QueryContext(ctx, pgAuditSettingsQuery)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	result := make(map[auditScopeKey]string)
+	for rows.Next() {
+		var (
+			key   auditScopeKey
+			value string
+		)
+		if err := rows.Scan(&key.scope, &key.name, &value); err != nil {
+			return nil, err
+		}
+		result[key] = value
+	}
+
+	return result, rows.Err()
+}
+
+// auditScopeExists checks if the role or database exists
+func auditScopeExists(ctx context.Context, db *sql.DB, key auditScopeKey) (bool, error) {
+	query := "SELECT EXISTS(SELECT 1 FROM pg_catalog.pg_roles WHERE rolname = $1)"
+	if key.scope == auditScopeDatabase {
+		query = "SELECT EXISTS(SELECT 1 FROM pg_catalog.pg_database WHERE datname = $1)"
+	}
+
+	var exists bool
+	if err := db.QueryRowContext(ctx, query, key.name).Scan(&exists); err != nil {
+		return false, fmt.Errorf("while checking if %s %s exists: %w", key.scope, key.name, err)
+	}
+	return exists, nil
+}
@@ -0,0 +1,99 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package controller
+
+import (
+	"database/sql"
+
+	"github.com/DATA-DOG/go-sqlmock"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("pgaudit settings reconciliation", func() {
+	const (
+		roleExistsQuery     = "SELECT EXISTS(SELECT 1 FROM pg_catalog.pg_roles WHERE rolname = $1)"
+		databaseExistsQuery = "SELECT EXISTS(SELECT 1 FROM pg_catalog.pg_database WHERE datname = $1)"
+	)
+
+	var (
+		db     *sql.DB
+		dbMock sqlmock.Sqlmock
+	)
+
+	BeforeEach(func() {
+		var err error
+		db, dbMock, err = sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		Expect(dbMock.ExpectationsWereMet()).To(Succeed())
+	})
+
+	It("applies the settings of the declared roles and databases", func(ctx SpecContext) {
+		audit := &apiv1.AuditConfiguration{
+			Roles: []apiv1.AuditScopeConfiguration{
+				{Name: "app", Classes: []apiv1.PgAuditClass{"write", "ddl"}},
+			},
+			Databases: []apiv1.AuditScopeConfiguration{
+				{Name: "reporting"},
+			},
+		}
+
+		dbMock.ExpectQuery(pgAuditSettingsQuery).
+			WillReturnRows(sqlmock.NewRows([]string{"scope", "name", "value"}).
+				AddRow("DATABASE", "reporting", "none"))
+		dbMock.ExpectQuery(roleExistsQuery).WithArgs("app").
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+		dbMock.ExpectExec(`ALTER ROLE "app" SET pgaudit.log TO 'write,ddl'`).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		Expect(reconcileAuditSettings(ctx, db, audit)).To(Succeed())
+	})
+
+	It("resets the settings which are not declared anymore", func(ctx SpecContext) {
+		dbMock.ExpectQuery(pgAuditSettingsQuery).
+			WillReturnRows(sqlmock.NewRows([]string{"scope", "name", "value"}).
+				AddRow("ROLE", "legacy", "all"))
+		dbMock.ExpectExec(`ALTER ROLE "legacy" RESET pgaudit.log`).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		Expect(reconcileAuditSettings(ctx, db, &apiv1.AuditConfiguration{})).To(Succeed())
+	})
+
+	It("skips the objects which do not exist yet", func(ctx SpecContext) {
+		audit := &apiv1.AuditConfiguration{
+			Databases: []apiv1.AuditScopeConfiguration{
+				{Name: "future", Classes: []apiv1.PgAuditClass{"all"}},
+			},
+		}
+
+		dbMock.ExpectQuery(pgAuditSettingsQuery).
+			WillReturnRows(sqlmock.NewRows([]string{"scope", "name", "value"}))
+		dbMock.ExpectQuery(databaseExistsQuery).WithArgs("future").
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+
+		Expect(reconcileAuditSettings(ctx, db, audit)).To(Succeed())
+	})
+})
@@ -45,6 +45,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	pluginClient "github.com/cloudnative-pg/cloudnative-pg/internal/cnpi/plugin/client"
 	"github.com/cloudnative-pg/cloudnative-pg/internal/controller"
 	"github.com/cloudnative-pg/cloudnative-pg/internal/management/controller/roles"
 	"github.com/cloudnative-pg/cloudnative-pg/internal/management/controller/slots/reconciler"
@@ -121,6 +122,14 @@ func (r *InstanceReconciler) Reconcile(
 	// Print the Cluster
 	contextLogger.Debug("Reconciling Cluster")
 
+	// The credentials of the cluster may be supplied by the plugins
+	// running as sidecars of this instance
+	ctx, closePlugins, err := r.localPlugins.WithCluster(ctx, cluster)
+	if err != nil {
+		return reconcile.Result{}, fmt.Errorf("while loading local plugins: %w", err)
+	}
+	defer closePlugins()
+
 	// Reconcile PostgreSQL instance parameters
 	r.reconcileInstance(cluster)
 
@@ -258,6 +267,10 @@ func (r *InstanceReconciler) Reconcile(
 		return result, err
 	}
 
+	if err := r.reconcilePgHBAStatus(ctx, cluster, postgresDB); err != nil {
+		return reconcile.Result{}, fmt.Errorf("while reporting the pg_hba.conf status: %w", err)
+	}
+
 	if r.instance.GetPodName() == cluster.Status.CurrentPrimary {
 		result, err := roles.Reconcile(ctx, r.instance, cluster, r.client)
 		if err != nil || !result.IsZero() {
@@ -280,6 +293,10 @@ func (r *InstanceReconciler) Reconcile(
 		return reconcile.Result{}, fmt.Errorf("cannot reconcile database configurations: %w", err)
 	}
 
+	if err := r.reconcileAudit(ctx, cluster); err != nil {
+		return reconcile.Result{}, fmt.Errorf("cannot reconcile the audit configuration: %w", err)
+	}
+
 	// Reconcile postgresql.auto.conf file permissions (< PG 17)
 	// IMPORTANT: this needs a database connection to determine
 	// the PostgreSQL major version
@@ -571,7 +588,7 @@ func (r *InstanceReconciler) reconcileDatabases(ctx context.Context, cluster *ap
 
 	extensionStatusChanged := false
 	for _, extension := range postgres.ManagedExtensions {
-		extensionIsUsed := extension.IsUsed(cluster.Spec.PostgresConfiguration.Parameters)
+		extensionIsUsed := extension.IsUsed(cluster.GetPostgresParameters())
 		if lastStatus, ok := r.extensionStatus[extension.Name]; !ok || lastStatus != extensionIsUsed {
 			extensionStatusChanged = true
 			break
@@ -587,7 +604,7 @@ func (r *InstanceReconciler) reconcileDatabases(ctx context.Context, cluster *ap
 			continue
 		}
 		if extensionStatusChanged {
-			if err = r.reconcileExtensions(ctx, db, cluster.Spec.PostgresConfiguration.Parameters); err != nil {
+			if err = r.reconcileExtensions(ctx, db, cluster.GetPostgresParameters()); err != nil {
 				errors = append(errors,
 					fmt.Errorf("could not reconcile extensions for database %s: %w", databaseName, err))
 			}
@@ -602,7 +619,7 @@ func (r *InstanceReconciler) reconcileDatabases(ctx context.Context, cluster *ap
 	}
 
 	for _, extension := range postgres.ManagedExtensions {
-		extensionIsUsed := extension.IsUsed(cluster.Spec.PostgresConfiguration.Parameters)
+		extensionIsUsed := extension.IsUsed(cluster.GetPostgresParameters())
 		r.extensionStatus[extension.Name] = extensionIsUsed
 	}
 
@@ -943,6 +960,10 @@ func (r *InstanceReconciler) RefreshSecrets(
 		contextLogger.Error(err, "Error while getting cluster CA Client secret")
 	}
 
+	if err := r.refreshOperatorClientCA(ctx, cluster); err != nil && !apierrors.IsNotFound(err) {
+		contextLogger.Error(err, "Error while getting the operator client CA secret")
+	}
+
 	serverCaSecretChanged, err := r.refreshServerCA(ctx, cluster)
 	if err == nil {
 		changed = changed || serverCaSecretChanged
@@ -984,6 +1005,15 @@ func (r *InstanceReconciler) reconcileInstance(cluster *apiv1.Cluster) {
 	r.instance.MaxStopDelay = cluster.GetMaxStopDelay()
 	r.instance.SmartStopDelay = cluster.GetSmartShutdownTimeout()
 	r.instance.RequiresDesignatedPrimaryTransition = detectRequiresDesignatedPrimaryTransition()
+	r.instance.SetCanaryProbes(cluster.GetCanaryProbes(r.instance.GetPodName()))
+
+	// A primary without replicas has nobody to fail over to, and must
+	// never fence itself
+	primaryIsolationTimeout := cluster.GetPrimaryIsolationTimeout()
+	if cluster.Spec.Instances < 2 {
+		primaryIsolationTimeout = 0
+	}
+	r.instance.SetPrimaryIsolationTimeout(primaryIsolationTimeout)
 }
 
 // PostgreSQLAutoConfWritable reconciles the permissions bit of `postgresql.auto.conf`
@@ -1409,15 +1439,14 @@ func (r *InstanceReconciler) refreshCredentialsFromSecret(
 		}
 	}
 
-	return nil
+	return r.reconcileSuperuserGrant(ctx, cluster, db)
 }
 
 func (r *InstanceReconciler) reconcileUser(ctx context.Context, username string, secretName string, db *sql.DB) error {
-	var secret corev1.Secret
-	err := r.GetClient().Get(
+	secret, err := pluginClient.GetSecret(
 		ctx,
-		client.ObjectKey{Namespace: r.instance.GetNamespaceName(), Name: secretName},
-		&secret)
+		r.GetClient(),
+		client.ObjectKey{Namespace: r.instance.GetNamespaceName(), Name: secretName})
 	if err != nil {
 		if apierrors.IsNotFound(err) {
 			return nil
@@ -1430,7 +1459,7 @@ func (r *InstanceReconciler) reconcileUser(ctx context.Context, username string,
 		return nil
 	}
 
-	usernameFromSecret, password, err := utils.GetUserPasswordFromSecret(&secret)
+	usernameFromSecret, password, err := utils.GetUserPasswordFromSecret(secret)
 	if err != nil {
 		return err
 	}
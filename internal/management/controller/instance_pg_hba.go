@@ -0,0 +1,124 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package controller
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	clusterstatus "github.com/cloudnative-pg/cloudnative-pg/pkg/resources/status"
+)
+
+// pgHBAFileErrorsQuery lists the lines of the pg_hba.conf file
+// PostgreSQL is not able to parse
+const pgHBAFileErrorsQuery = `SELECT line_number, error
+FROM pg_catalog.pg_hba_file_rules
+WHERE error IS NOT NULL
+ORDER BY line_number`
+
+// getPgHBAFileErrors returns the description of every line of the
+// pg_hba.conf file which has been rejected by PostgreSQL
+func getPgHBAFileErrors(ctx context.Context, db *sql.DB) ([]string, error) {
+	rows, err := db.QueryContext(ctx, pgHBAFileErrorsQuery)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var result []string
+	for rows.Next() {
+		var (
+			lineNumber sql.NullInt64
+			message    string
+		)
+		if err := rows.Scan(&lineNumber, &message); err != nil {
+			return nil, err
+		}
+		if lineNumber.Valid {
+			result = append(result, fmt.Sprintf("line %d: %s", lineNumber.Int64, message))
+		} else {
+			result = append(result, message)
+		}
+	}
+
+	return result, rows.Err()
+}
+
+// newPgHBACondition builds the condition reporting whether the
+// pg_hba.conf file has been accepted by PostgreSQL
+func newPgHBACondition(hbaErrors []string) metav1.Condition {
+	if len(hbaErrors) == 0 {
+		return metav1.Condition{
+			Type:    string(apiv1.ConditionPgHBARules),
+			Status:  metav1.ConditionTrue,
+			Reason:  string(apiv1.ConditionReasonPgHBARulesApplied),
+			Message: "The pg_hba.conf file has been accepted by PostgreSQL",
+		}
+	}
+
+	return metav1.Condition{
+		Type:    string(apiv1.ConditionPgHBARules),
+		Status:  metav1.ConditionFalse,
+		Reason:  string(apiv1.ConditionReasonPgHBARulesRejected),
+		Message: "PostgreSQL rejected the pg_hba.conf file: " + strings.Join(hbaErrors, "; "),
+	}
+}
+
+// reconcilePgHBAStatus reports in the cluster status whether PostgreSQL
+// has been able to load the pg_hba.conf file generated by the instance
+// manager. Only the primary instance reports it, as the file is the same
+// across the whole cluster.
+func (r *InstanceReconciler) reconcilePgHBAStatus(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+	db *sql.DB,
+) error {
+	if cluster.Status.CurrentPrimary != r.instance.GetPodName() {
+		return nil
+	}
+
+	hbaErrors, err := getPgHBAFileErrors(ctx, db)
+	if err != nil {
+		return fmt.Errorf("while reading the pg_hba.conf rules: %w", err)
+	}
+
+	condition := newPgHBACondition(hbaErrors)
+	if current := meta.FindStatusCondition(cluster.Status.Conditions, condition.Type); current != nil &&
+		current.Status == condition.Status &&
+		current.Reason == condition.Reason &&
+		current.Message == condition.Message {
+		return nil
+	}
+
+	if condition.Status == metav1.ConditionFalse {
+		log.FromContext(ctx).Warning("PostgreSQL rejected the pg_hba.conf file", "errors", hbaErrors)
+	}
+
+	return clusterstatus.PatchConditionsWithOptimisticLock(ctx, r.client, cluster, condition)
+}
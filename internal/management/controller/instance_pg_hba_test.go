@@ -0,0 +1,82 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package controller
+
+import (
+	"database/sql"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("pg_hba.conf status reporting", func() {
+	var (
+		db     *sql.DB
+		dbMock sqlmock.Sqlmock
+	)
+
+	BeforeEach(func() {
+		var err error
+		db, dbMock, err = sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		Expect(dbMock.ExpectationsWereMet()).To(Succeed())
+	})
+
+	It("reports no errors when every line has been accepted", func(ctx SpecContext) {
+		dbMock.ExpectQuery(pgHBAFileErrorsQuery).
+			WillReturnRows(sqlmock.NewRows([]string{"line_number", "error"}))
+
+		hbaErrors, err := getPgHBAFileErrors(ctx, db)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(hbaErrors).To(BeEmpty())
+
+		condition := newPgHBACondition(hbaErrors)
+		Expect(condition.Type).To(Equal(string(apiv1.ConditionPgHBARules)))
+		Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+		Expect(condition.Reason).To(Equal(string(apiv1.ConditionReasonPgHBARulesApplied)))
+	})
+
+	It("reports the lines rejected by PostgreSQL", func(ctx SpecContext) {
+		dbMock.ExpectQuery(pgHBAFileErrorsQuery).
+			WillReturnRows(sqlmock.NewRows([]string{"line_number", "error"}).
+				AddRow(12, `invalid authentication method "md6"`).
+				AddRow(nil, "could not open file"))
+
+		hbaErrors, err := getPgHBAFileErrors(ctx, db)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(hbaErrors).To(Equal([]string{
+			`line 12: invalid authentication method "md6"`,
+			"could not open file",
+		}))
+
+		condition := newPgHBACondition(hbaErrors)
+		Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+		Expect(condition.Reason).To(Equal(string(apiv1.ConditionReasonPgHBARulesRejected)))
+		Expect(condition.Message).To(ContainSubstring(`line 12: invalid authentication method "md6"`))
+	})
+})
@@ -21,6 +21,7 @@ package controller
 
 import (
 	"context"
+	"crypto/x509"
 	"fmt"
 	"io/fs"
 	"os"
@@ -35,6 +36,7 @@ import (
 
 	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
 	"github.com/cloudnative-pg/cloudnative-pg/internal/controller"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/certs"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres/archiver"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres/utils"
 	postgresSpec "github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
@@ -119,6 +121,27 @@ func (r *InstanceReconciler) refreshClientCA(ctx context.Context, cluster *apiv1
 	return r.refreshCAFromSecret(ctx, &secret, postgresSpec.ClientCACertificateLocation)
 }
 
+// refreshOperatorClientCA sets the CA used to authenticate the operator on
+// the status port. Until it is loaded, the mutating endpoints of the status
+// port reject every request
+func (r *InstanceReconciler) refreshOperatorClientCA(ctx context.Context, cluster *apiv1.Cluster) error {
+	var secret corev1.Secret
+	err := r.GetClient().Get(
+		ctx,
+		client.ObjectKey{Namespace: r.instance.GetNamespaceName(), Name: cluster.GetOperatorClientCASecretName()},
+		&secret)
+	if err != nil {
+		return err
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(secret.Data[certs.CACertKey]) {
+		return fmt.Errorf("no valid certificate in the %s key of secret %s", certs.CACertKey, secret.Name)
+	}
+	r.instance.SetOperatorClientCA(pool)
+	return nil
+}
+
 // refreshServerCA gets the latest server CA certificates from the secrets.
 // It returns true if configuration has been changed
 func (r *InstanceReconciler) refreshServerCA(ctx context.Context, cluster *apiv1.Cluster) (bool, error) {
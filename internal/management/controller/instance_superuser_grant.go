@@ -0,0 +1,165 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package controller
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	"github.com/jackc/pgx/v5"
+	"github.com/lib/pq"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	pluginClient "github.com/cloudnative-pg/cloudnative-pg/internal/cnpi/plugin/client"
+	"github.com/cloudnative-pg/cloudnative-pg/internal/management/utils"
+)
+
+// reconcileSuperuserGrant creates the temporary superuser role while the
+// grant in the cluster status is active, and drops it afterward
+func (r *InstanceReconciler) reconcileSuperuserGrant(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+	db *sql.DB,
+) error {
+	secretName := cluster.GetSuperuserGrantSecretName()
+	if !cluster.IsSuperuserGrantActive(time.Now()) {
+		delete(r.secretVersions, secretName)
+		return revokeTemporarySuperuser(ctx, db, apiv1.SuperuserGrantRoleName)
+	}
+
+	grant := cluster.Status.SuperuserGrant
+	secret, err := pluginClient.GetSecret(
+		ctx,
+		r.GetClient(),
+		client.ObjectKey{Namespace: r.instance.GetNamespaceName(), Name: grant.SecretName})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	// The expiration can be extended without changing the password
+	appliedVersion := fmt.Sprintf("%s/%s", secret.ResourceVersion, grant.ExpiresAt.UTC().Format(time.RFC3339))
+	if r.secretVersions[secretName] == appliedVersion {
+		return nil
+	}
+
+	username, password, err := utils.GetUserPasswordFromSecret(secret)
+	if err != nil {
+		return err
+	}
+	if username != grant.RoleName {
+		return fmt.Errorf("wrong username '%v' in secret, expected '%v'", username, grant.RoleName)
+	}
+
+	if err := grantTemporarySuperuser(ctx, db, grant.RoleName, password, grant.ExpiresAt.Time); err != nil {
+		return err
+	}
+
+	r.secretVersions[secretName] = appliedVersion
+	return nil
+}
+
+// grantTemporarySuperuser creates or updates a superuser role whose
+// password is only valid until the passed time
+func grantTemporarySuperuser(
+	ctx context.Context,
+	db *sql.DB,
+	roleName string,
+	password string,
+	validUntil time.Time,
+) error {
+	contextLogger := log.FromContext(ctx)
+
+	var exists bool
+	if err := db.QueryRowContext(
+		ctx,
+		"SELECT EXISTS(SELECT 1 FROM pg_catalog.pg_roles WHERE rolname = $1)",
+		roleName).Scan(&exists); err != nil {
+		return fmt.Errorf("while checking if role %s exists: %w", roleName, err)
+	}
+
+	command := "ALTER"
+	if !exists {
+		command = "CREATE"
+	}
+
+	if _, err := db.ExecContext(ctx, fmt.Sprintf("%s ROLE %s WITH SUPERUSER LOGIN PASSWORD %s VALID UNTIL %s",
+		command,
+		pgx.Identifier{roleName}.Sanitize(),
+		pq.QuoteLiteral(password),
+		pq.QuoteLiteral(validUntil.UTC().Format(time.RFC3339)))); err != nil {
+		return fmt.Errorf("while granting temporary superuser access to role %s: %w", roleName, err)
+	}
+
+	contextLogger.Info("Granted temporary superuser access", "role", roleName, "validUntil", validUntil)
+	return nil
+}
+
+// revokeTemporarySuperuser disables the temporary superuser role, terminates
+// its sessions and drops it. A role owning database objects can't be
+// dropped and is kept disabled instead
+func revokeTemporarySuperuser(ctx context.Context, db *sql.DB, roleName string) error {
+	contextLogger := log.FromContext(ctx)
+
+	var enabled bool
+	err := db.QueryRowContext(
+		ctx,
+		"SELECT rolcanlogin OR rolsuper FROM pg_catalog.pg_roles WHERE rolname = $1",
+		roleName).Scan(&enabled)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("while checking the temporary superuser role %s: %w", roleName, err)
+	}
+	if !enabled {
+		return nil
+	}
+
+	identifier := pgx.Identifier{roleName}.Sanitize()
+	if _, err := db.ExecContext(ctx, fmt.Sprintf(
+		"ALTER ROLE %s WITH NOSUPERUSER NOLOGIN PASSWORD NULL", identifier)); err != nil {
+		return fmt.Errorf("while disabling the temporary superuser role %s: %w", roleName, err)
+	}
+
+	if _, err := db.ExecContext(
+		ctx,
+		"SELECT pg_catalog.pg_terminate_backend(pid) FROM pg_catalog.pg_stat_activity WHERE usename = $1",
+		roleName); err != nil {
+		return fmt.Errorf("while terminating the sessions of role %s: %w", roleName, err)
+	}
+
+	if _, err := db.ExecContext(ctx, fmt.Sprintf("DROP ROLE %s", identifier)); err != nil {
+		contextLogger.Warning("Temporary superuser role can't be dropped, keeping it disabled",
+			"role", roleName, "error", err)
+		return nil
+	}
+
+	contextLogger.Info("Dropped temporary superuser role", "role", roleName)
+	return nil
+}
@@ -0,0 +1,110 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package controller
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("temporary superuser role", func() {
+	const (
+		roleExistsQuery  = "SELECT EXISTS(SELECT 1 FROM pg_catalog.pg_roles WHERE rolname = $1)"
+		roleEnabledQuery = "SELECT rolcanlogin OR rolsuper FROM pg_catalog.pg_roles WHERE rolname = $1"
+		terminateQuery   = "SELECT pg_catalog.pg_terminate_backend(pid) " +
+			"FROM pg_catalog.pg_stat_activity WHERE usename = $1"
+	)
+
+	var (
+		db     *sql.DB
+		dbMock sqlmock.Sqlmock
+	)
+
+	BeforeEach(func() {
+		var err error
+		db, dbMock, err = sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		Expect(dbMock.ExpectationsWereMet()).To(Succeed())
+	})
+
+	validUntil := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	It("creates the role when it doesn't exist", func(ctx SpecContext) {
+		dbMock.ExpectQuery(roleExistsQuery).WithArgs("cnpg_break_glass").
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+		dbMock.ExpectExec(`CREATE ROLE "cnpg_break_glass" WITH SUPERUSER LOGIN PASSWORD 'secret' ` +
+			`VALID UNTIL '2026-01-02T03:04:05Z'`).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		Expect(grantTemporarySuperuser(ctx, db, "cnpg_break_glass", "secret", validUntil)).To(Succeed())
+	})
+
+	It("updates the password and the expiration of an existing role", func(ctx SpecContext) {
+		dbMock.ExpectQuery(roleExistsQuery).WithArgs("cnpg_break_glass").
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+		dbMock.ExpectExec(`ALTER ROLE "cnpg_break_glass" WITH SUPERUSER LOGIN PASSWORD 'secret' ` +
+			`VALID UNTIL '2026-01-02T03:04:05Z'`).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		Expect(grantTemporarySuperuser(ctx, db, "cnpg_break_glass", "secret", validUntil)).To(Succeed())
+	})
+
+	It("does nothing when revoking a role which doesn't exist", func(ctx SpecContext) {
+		dbMock.ExpectQuery(roleEnabledQuery).WithArgs("cnpg_break_glass").
+			WillReturnRows(sqlmock.NewRows([]string{"enabled"}))
+
+		Expect(revokeTemporarySuperuser(ctx, db, "cnpg_break_glass")).To(Succeed())
+	})
+
+	It("disables the role, terminates its sessions and drops it", func(ctx SpecContext) {
+		dbMock.ExpectQuery(roleEnabledQuery).WithArgs("cnpg_break_glass").
+			WillReturnRows(sqlmock.NewRows([]string{"enabled"}).AddRow(true))
+		dbMock.ExpectExec(`ALTER ROLE "cnpg_break_glass" WITH NOSUPERUSER NOLOGIN PASSWORD NULL`).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		dbMock.ExpectExec(terminateQuery).WithArgs("cnpg_break_glass").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		dbMock.ExpectExec(`DROP ROLE "cnpg_break_glass"`).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		Expect(revokeTemporarySuperuser(ctx, db, "cnpg_break_glass")).To(Succeed())
+	})
+
+	It("keeps the role disabled when it can't be dropped", func(ctx SpecContext) {
+		dbMock.ExpectQuery(roleEnabledQuery).WithArgs("cnpg_break_glass").
+			WillReturnRows(sqlmock.NewRows([]string{"enabled"}).AddRow(true))
+		dbMock.ExpectExec(`ALTER ROLE "cnpg_break_glass" WITH NOSUPERUSER NOLOGIN PASSWORD NULL`).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		dbMock.ExpectExec(terminateQuery).WithArgs("cnpg_break_glass").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		dbMock.ExpectExec(`DROP ROLE "cnpg_break_glass"`).
+			WillReturnError(errors.New("role owns objects"))
+
+		Expect(revokeTemporarySuperuser(ctx, db, "cnpg_break_glass")).To(Succeed())
+	})
+})
@@ -46,6 +46,8 @@ func Reconcile(
 	contextLogger := log.FromContext(ctx)
 	contextLogger.Debug("Updating managed roles information")
 
+	managedConfig := expandAlternateRoles(cluster.Spec.Managed)
+
 	db, err := instance.GetSuperUserDB()
 	if err != nil {
 		return reconcile.Result{}, err
@@ -53,7 +55,7 @@ func Reconcile(
 
 	// get current passwords from spec/secrets
 	latestPasswordResourceVersion, err := getPasswordSecretResourceVersion(
-		ctx, c, cluster.Spec.Managed.Roles, cluster.Namespace)
+		ctx, c, managedConfig.Roles, cluster.Namespace)
 	if err != nil {
 		return reconcile.Result{}, err
 	}
@@ -66,7 +68,7 @@ func Reconcile(
 
 	rolesByStatus := evaluateNextRoleActions(
 		ctx,
-		cluster.Spec.Managed,
+		managedConfig,
 		rolesInDB,
 		cluster.Status.ManagedRolesStatus.PasswordStatus,
 		latestPasswordResourceVersion,
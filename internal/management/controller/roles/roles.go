@@ -91,6 +91,32 @@ func (role roleConfigurationAdapter) toDatabaseRole() DatabaseRole {
 	return dbRole
 }
 
+// expandAlternateRoles returns a copy of the managed configuration where
+// every role using the alternating password rotation strategy is followed
+// by its twin role. The twin shares the attributes and the password secret
+// of the role, and is a member of it to inherit its privileges
+func expandAlternateRoles(config *apiv1.ManagedConfiguration) *apiv1.ManagedConfiguration {
+	if config == nil {
+		return nil
+	}
+
+	expandedConfig := config.DeepCopy()
+	expandedConfig.Roles = make([]apiv1.RoleConfiguration, 0, len(config.Roles))
+	for _, role := range config.Roles {
+		expandedConfig.Roles = append(expandedConfig.Roles, *role.DeepCopy())
+		if !role.IsPasswordRotationAlternating() {
+			continue
+		}
+
+		twin := *role.DeepCopy()
+		twin.Name = role.GetAlternateRoleName()
+		twin.InRoles = append(twin.InRoles, role.Name)
+		expandedConfig.Roles = append(expandedConfig.Roles, twin)
+	}
+
+	return expandedConfig
+}
+
 // convertToRolesByStatus gets the status of every role in the Spec and/or in the DB
 func (r rolesByAction) convertToRolesByStatus() rolesByStatus {
 	statusByAction := map[roleAction]apiv1.RoleStatus{
@@ -26,13 +26,13 @@ import (
 	"strings"
 
 	"github.com/cloudnative-pg/machinery/pkg/log"
-	corev1 "k8s.io/api/core/v1"
 	apierrs "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/utils/strings/slices"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	pluginClient "github.com/cloudnative-pg/cloudnative-pg/internal/cnpi/plugin/client"
 	"github.com/cloudnative-pg/cloudnative-pg/internal/management/utils"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres"
 )
@@ -66,15 +66,21 @@ type instanceInterface interface {
 //
 // c.f. https://pkg.go.dev/sigs.k8s.io/controller-runtime/pkg/manager#Runnable
 type RoleSynchronizer struct {
-	instance instanceInterface
-	client   client.Client
+	instance     instanceInterface
+	client       client.Client
+	localPlugins *pluginClient.LocalPlugins
 }
 
 // NewRoleSynchronizer creates a new RoleSynchronizer
-func NewRoleSynchronizer(instance *postgres.Instance, client client.Client) *RoleSynchronizer {
+func NewRoleSynchronizer(
+	instance *postgres.Instance,
+	client client.Client,
+	localPlugins *pluginClient.LocalPlugins,
+) *RoleSynchronizer {
 	runner := &RoleSynchronizer{
-		instance: instance,
-		client:   client,
+		instance:     instance,
+		client:       client,
+		localPlugins: localPlugins,
 	}
 	return runner
 }
@@ -150,6 +156,14 @@ func (sr *RoleSynchronizer) reconcile(ctx context.Context, config *apiv1.Managed
 		return err
 	}
 
+	// The passwords may be supplied by the plugins running
+	// as sidecars of this instance
+	ctx, closePlugins, err := sr.localPlugins.WithCluster(ctx, &remoteCluster)
+	if err != nil {
+		return fmt.Errorf("while loading local plugins: %w", err)
+	}
+	defer closePlugins()
+
 	rolePasswords := remoteCluster.Status.ManagedRolesStatus.PasswordStatus
 	if rolePasswords == nil {
 		rolePasswords = map[string]apiv1.PasswordState{}
@@ -170,11 +184,34 @@ func (sr *RoleSynchronizer) reconcile(ctx context.Context, config *apiv1.Managed
 		return err
 	}
 	updatedCluster := remoteCluster.DeepCopy()
-	updatedCluster.Status.ManagedRolesStatus.PasswordStatus = appliedState
+	updatedCluster.Status.ManagedRolesStatus.PasswordStatus = mergeRotationState(
+		appliedState, remoteCluster.Status.ManagedRolesStatus.PasswordStatus)
 	updatedCluster.Status.ManagedRolesStatus.CannotReconcile = irreconcilableRoles
 	return sr.client.Status().Patch(ctx, updatedCluster, client.MergeFrom(&remoteCluster))
 }
 
+// mergeRotationState merges the password state applied to the database
+// with the rotation information recorded by the operator in the cluster
+// status, that may have changed while the roles were being synchronized
+func mergeRotationState(
+	appliedState map[string]apiv1.PasswordState,
+	currentState map[string]apiv1.PasswordState,
+) map[string]apiv1.PasswordState {
+	result := make(map[string]apiv1.PasswordState, len(appliedState))
+	for role, state := range appliedState {
+		result[role] = state
+	}
+
+	for role, state := range currentState {
+		mergedState := result[role]
+		mergedState.LastRotation = state.LastRotation
+		mergedState.ActiveRole = state.ActiveRole
+		result[role] = mergedState
+	}
+
+	return result
+}
+
 func getRoleNames(roles []roleConfigurationAdapter) []string {
 	names := make([]string, len(roles))
 	for i, role := range roles {
@@ -194,6 +231,7 @@ func (sr *RoleSynchronizer) synchronizeRoles(
 	config *apiv1.ManagedConfiguration,
 	storedPasswordState map[string]apiv1.PasswordState,
 ) (map[string]apiv1.PasswordState, map[string][]string, error) {
+	config = expandAlternateRoles(config)
 	latestSecretResourceVersion, err := getPasswordSecretResourceVersion(
 		ctx, sr.client, config.Roles, sr.instance.GetNamespaceName())
 	if err != nil {
@@ -214,6 +252,8 @@ func (sr *RoleSynchronizer) synchronizeRoles(
 	// Merge the status from database into spec. We should keep all the status
 	// otherwise in the next loop the user without status will be marked as need update
 	for role, stateInDatabase := range passwordStates {
+		stateInDatabase.LastRotation = storedPasswordState[role].LastRotation
+		stateInDatabase.ActiveRole = storedPasswordState[role].ActiveRole
 		storedPasswordState[role] = stateInDatabase
 	}
 	return storedPasswordState, irreconcilableRoles, nil
@@ -340,8 +380,15 @@ func (sr *RoleSynchronizer) applyRoleCreateUpdate(
 			return apiv1.PasswordState{}, err
 		}
 
-		databaseRole.password = sql.NullString{Valid: true, String: passwordSecret.password}
 		passVersion = passwordSecret.version
+		if role.IsPasswordRotationAlternating() && passwordSecret.username != role.Name {
+			// With the alternating rotation strategy, the secret stores the
+			// credentials of the other role of the pair, whose password must
+			// be left untouched
+			databaseRole.ignorePassword = true
+			break
+		}
+		databaseRole.password = sql.NullString{Valid: true, String: passwordSecret.password}
 	}
 
 	var err error
@@ -387,21 +434,19 @@ func getPassword(
 		return passwordSecret{}, nil
 	}
 
-	var secret corev1.Secret
-	err := cl.Get(ctx,
-		client.ObjectKey{Namespace: namespace, Name: secretName},
-		&secret)
+	secret, err := pluginClient.GetSecret(ctx, cl, client.ObjectKey{Namespace: namespace, Name: secretName})
 	if err != nil {
 		if apierrs.IsNotFound(err) {
 			return passwordSecret{}, nil
 		}
 		return passwordSecret{}, err
 	}
-	usernameFromSecret, passwordFromSecret, err := utils.GetUserPasswordFromSecret(&secret)
+	usernameFromSecret, passwordFromSecret, err := utils.GetUserPasswordFromSecret(secret)
 	if err != nil {
 		return passwordSecret{}, err
 	}
-	if strings.TrimSpace(roleInSpec.Name) != strings.TrimSpace(usernameFromSecret) {
+	if strings.TrimSpace(roleInSpec.Name) != strings.TrimSpace(usernameFromSecret) &&
+		!roleInSpec.IsPasswordRotationAlternating() {
 		err := fmt.Errorf("wrong username '%v' in secret, expected '%v'", usernameFromSecret, roleInSpec.Name)
 		return passwordSecret{}, err
 	}
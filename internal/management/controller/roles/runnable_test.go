@@ -672,6 +672,23 @@ var _ = DescribeTable("role secrets test",
 		passwordSecret{},
 		true,
 	),
+	Entry("Can extract the credentials of the other role of an alternating pair",
+		&apiv1.RoleConfiguration{
+			Name: userName + apiv1.AlternateRoleSuffix,
+			PasswordSecret: &apiv1.LocalObjectReference{
+				Name: secretName,
+			},
+			PasswordRotation: &apiv1.PasswordRotationConfiguration{
+				Interval: v1.Duration{Duration: time.Hour},
+				Strategy: apiv1.PasswordRotationStrategyAlternating,
+			},
+		},
+		passwordSecret{
+			username: userName,
+			password: password,
+		},
+		false,
+	),
 	Entry("Throws error if configured secret does not contain a username",
 		&apiv1.RoleConfiguration{
 			Name: userName,
@@ -693,3 +710,51 @@ var _ = DescribeTable("role secrets test",
 		true,
 	),
 )
+
+var _ = Describe("password rotation support", func() {
+	It("adds the twin of the roles using the alternating strategy", func() {
+		config := &apiv1.ManagedConfiguration{
+			Roles: []apiv1.RoleConfiguration{
+				{
+					Name:    "app",
+					Login:   true,
+					InRoles: []string{"pg_monitor"},
+					PasswordRotation: &apiv1.PasswordRotationConfiguration{
+						Interval: v1.Duration{Duration: time.Hour},
+						Strategy: apiv1.PasswordRotationStrategyAlternating,
+					},
+				},
+				{
+					Name: "other",
+					PasswordRotation: &apiv1.PasswordRotationConfiguration{
+						Interval: v1.Duration{Duration: time.Hour},
+					},
+				},
+			},
+		}
+
+		expandedConfig := expandAlternateRoles(config)
+		Expect(expandedConfig.Roles).To(HaveLen(3))
+		Expect(expandedConfig.Roles[1].Name).To(Equal("app_alt"))
+		Expect(expandedConfig.Roles[1].Login).To(BeTrue())
+		Expect(expandedConfig.Roles[1].InRoles).To(ConsistOf("pg_monitor", "app"))
+		Expect(config.Roles[0].InRoles).To(ConsistOf("pg_monitor"))
+		Expect(expandedConfig.Roles[2].Name).To(Equal("other"))
+	})
+
+	It("keeps the rotation information recorded by the operator", func() {
+		lastRotation := v1.Now()
+		appliedState := map[string]apiv1.PasswordState{
+			"app": {TransactionID: 42, SecretResourceVersion: "2"},
+		}
+		currentState := map[string]apiv1.PasswordState{
+			"app":   {TransactionID: 40, SecretResourceVersion: "1", LastRotation: &lastRotation, ActiveRole: "app"},
+			"other": {LastRotation: &lastRotation},
+		}
+
+		Expect(mergeRotationState(appliedState, currentState)).To(Equal(map[string]apiv1.PasswordState{
+			"app":   {TransactionID: 42, SecretResourceVersion: "2", LastRotation: &lastRotation, ActiveRole: "app"},
+			"other": {LastRotation: &lastRotation},
+		}))
+	})
+})
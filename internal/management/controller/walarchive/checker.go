@@ -0,0 +1,366 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package walarchive
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	pluginClient "github.com/cloudnative-pg/cloudnative-pg/internal/cnpi/plugin/client"
+	postgresManagement "github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres/archiver"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/resources/status"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+)
+
+type instanceInterface interface {
+	GetClusterName() string
+	GetNamespaceName() string
+	GetPodName() string
+	GetWALSegmentSize() (int, error)
+	SetWALArchiveContinuity(report *postgresManagement.WALArchiveContinuityReport)
+}
+
+// A ContinuityChecker is a Kubernetes manager.Runnable that periodically
+// looks for gaps in the WAL archive, reporting them in the Cluster status
+// and marking the backups which can't be restored because of them.
+// The check runs only in the current primary instance
+//
+// c.f. https://pkg.go.dev/sigs.k8s.io/controller-runtime/pkg/manager#Runnable
+type ContinuityChecker struct {
+	instance     instanceInterface
+	client       client.Client
+	localPlugins *pluginClient.LocalPlugins
+}
+
+// NewContinuityChecker creates a new ContinuityChecker
+func NewContinuityChecker(
+	instance *postgresManagement.Instance,
+	client client.Client,
+	localPlugins *pluginClient.LocalPlugins,
+) *ContinuityChecker {
+	return &ContinuityChecker{
+		instance:     instance,
+		client:       client,
+		localPlugins: localPlugins,
+	}
+}
+
+// Start starts running the ContinuityChecker
+func (checker *ContinuityChecker) Start(ctx context.Context) error {
+	contextLogger := log.FromContext(ctx).WithName("wal_archive_continuity")
+	ctx = log.IntoContext(ctx, contextLogger)
+
+	timer := time.NewTimer(apiv1.DefaultWALContinuityCheckInterval)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			contextLogger.Info("Terminated WAL archive continuity check loop")
+			return nil
+		case <-timer.C:
+		}
+
+		interval, err := checker.check(ctx)
+		if err != nil {
+			contextLogger.Error(err, "while checking the continuity of the WAL archive")
+		}
+		timer.Reset(interval)
+	}
+}
+
+// check checks the continuity of the WAL archive once, and returns the
+// time to wait before the next check
+func (checker *ContinuityChecker) check(ctx context.Context) (time.Duration, error) {
+	var cluster apiv1.Cluster
+	if err := checker.client.Get(ctx, types.NamespacedName{
+		Namespace: checker.instance.GetNamespaceName(),
+		Name:      checker.instance.GetClusterName(),
+	}, &cluster); err != nil {
+		return apiv1.DefaultWALContinuityCheckInterval, fmt.Errorf("while getting the cluster: %w", err)
+	}
+
+	// When the check is disabled, or it is another instance's job, we keep
+	// looking at the configuration with the default interval
+	interval := cluster.GetWALContinuityCheckInterval()
+	if interval == 0 || cluster.Status.CurrentPrimary != checker.instance.GetPodName() {
+		checker.instance.SetWALArchiveContinuity(nil)
+		return apiv1.DefaultWALContinuityCheckInterval, nil
+	}
+
+	archive, err := checker.getWALArchive(ctx, &cluster)
+	if err != nil {
+		return interval, err
+	}
+	if archive == nil {
+		checker.instance.SetWALArchiveContinuity(nil)
+		return interval, nil
+	}
+
+	report := &postgresManagement.WALArchiveContinuityReport{
+		Continuity: archive.continuity,
+		CheckedAt:  time.Now(),
+	}
+	if archive.continuity != nil {
+		firstRecoverabilityPoint, err := markBackups(ctx, checker.client, &cluster, archive)
+		if err != nil {
+			return interval, err
+		}
+		report.FirstRecoverabilityPoint = firstRecoverabilityPoint
+
+		if err := status.PatchConditionsWithOptimisticLock(
+			ctx,
+			checker.client,
+			&cluster,
+			getContinuityCondition(archive.continuity),
+		); err != nil {
+			return interval, err
+		}
+	}
+	checker.instance.SetWALArchiveContinuity(report)
+
+	return interval, nil
+}
+
+// walArchive is the WAL archive of a cluster, and the methods of the
+// backups whose recovery relies on it
+type walArchive struct {
+	// continuity is nil when the WAL archive contains no WAL segment
+	continuity *postgres.WALArchiveContinuity
+
+	backupMethods []apiv1.BackupMethod
+}
+
+// getWALArchive checks the continuity of the WAL archive of the cluster,
+// returning nil if the WAL archive in use can't be checked.
+// The WAL archive in the backup volume is listed, while a WAL archiver
+// plugin can only report its oldest and newest WAL segment
+func (checker *ContinuityChecker) getWALArchive(ctx context.Context, cluster *apiv1.Cluster) (*walArchive, error) {
+	switch {
+	case cluster.GetPersistentVolumeBackupClaimName() != "":
+		walSegmentSize, err := checker.instance.GetWALSegmentSize()
+		if err != nil {
+			return nil, err
+		}
+
+		fileNames, err := archiver.ListVolumeWALArchive(cluster)
+		if err != nil {
+			return nil, err
+		}
+
+		return &walArchive{
+			continuity: postgres.CheckWALArchiveContinuity(fileNames, int64(walSegmentSize)),
+			backupMethods: []apiv1.BackupMethod{
+				apiv1.BackupMethodPersistentVolume,
+				apiv1.BackupMethodVolumeSnapshot,
+			},
+		}, nil
+
+	case cluster.GetEnabledWALArchivePluginName() != "":
+		walSegmentSize, err := checker.instance.GetWALSegmentSize()
+		if err != nil {
+			return nil, err
+		}
+
+		continuity, supported, err := checker.getPluginWALArchiveRange(ctx, cluster, int64(walSegmentSize))
+		if err != nil || !supported {
+			return nil, err
+		}
+
+		return &walArchive{
+			continuity: continuity,
+			backupMethods: []apiv1.BackupMethod{
+				apiv1.BackupMethodPlugin,
+				apiv1.BackupMethodVolumeSnapshot,
+			},
+		}, nil
+
+	default:
+		return nil, nil
+	}
+}
+
+// getPluginWALArchiveRange gets the oldest and the newest WAL segment in
+// the WAL archive managed by the WAL archiver plugin, if the plugin
+// implements the WAL status capability
+func (checker *ContinuityChecker) getPluginWALArchiveRange(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+	walSegmentSize int64,
+) (*postgres.WALArchiveContinuity, bool, error) {
+	ctx, closePlugins, err := checker.localPlugins.WithCluster(ctx, cluster)
+	if err != nil {
+		return nil, false, fmt.Errorf("while loading local plugins: %w", err)
+	}
+	defer closePlugins()
+
+	cli, ok := ctx.Value(utils.PluginClientKey).(pluginClient.Client)
+	if !ok {
+		return nil, false, fmt.Errorf("missing plugin client")
+	}
+
+	archiveStatus, err := cli.GetWALArchiveStatus(ctx, cluster)
+	if err != nil {
+		return nil, false, fmt.Errorf("while getting the status of the WAL archive: %w", err)
+	}
+	if archiveStatus == nil {
+		return nil, false, nil
+	}
+	if archiveStatus.FirstWAL == "" || archiveStatus.LastWAL == "" {
+		return nil, true, nil
+	}
+
+	firstSegment, err := postgres.SegmentFromName(archiveStatus.FirstWAL)
+	if err != nil {
+		return nil, false, fmt.Errorf("while parsing the first WAL file in the archive: %w", err)
+	}
+	lastSegment, err := postgres.SegmentFromName(archiveStatus.LastWAL)
+	if err != nil {
+		return nil, false, fmt.Errorf("while parsing the last WAL file in the archive: %w", err)
+	}
+
+	return postgres.NewWALArchiveRange(firstSegment, lastSegment, walSegmentSize), true, nil
+}
+
+// markBackups records in the status of the completed backups of the
+// cluster the first WAL segment they need which is missing from the WAL
+// archive, and returns the end time of the oldest backup which can be
+// recovered up to the newest archived WAL segment
+func markBackups(
+	ctx context.Context,
+	cli client.Client,
+	cluster *apiv1.Cluster,
+	archive *walArchive,
+) (*time.Time, error) {
+	contextLogger := log.FromContext(ctx)
+
+	var backupList apiv1.BackupList
+	if err := cli.List(ctx, &backupList, client.InNamespace(cluster.Namespace)); err != nil {
+		return nil, fmt.Errorf("while listing backups: %w", err)
+	}
+
+	var firstRecoverabilityPoint *time.Time
+	for idx := range backupList.Items {
+		backup := &backupList.Items[idx]
+		if backup.Spec.Cluster.Name != cluster.Name ||
+			backup.Status.Phase != apiv1.BackupPhaseCompleted ||
+			backup.IsExported() ||
+			!slices.Contains(archive.backupMethods, backup.Status.Method) {
+			continue
+		}
+
+		beginSegment, err := postgres.SegmentFromName(backup.Status.BeginWal)
+		if err != nil {
+			continue
+		}
+		endSegment, err := postgres.SegmentFromName(backup.Status.EndWal)
+		if err != nil {
+			continue
+		}
+
+		if archive.continuity.CanRecoverFrom(beginSegment, endSegment) && backup.Status.StoppedAt != nil &&
+			(firstRecoverabilityPoint == nil || backup.Status.StoppedAt.Time.Before(*firstRecoverabilityPoint)) {
+			firstRecoverabilityPoint = &backup.Status.StoppedAt.Time
+		}
+
+		var missingWAL string
+		if segment, missing := archive.continuity.FirstMissingSegment(beginSegment, endSegment); missing {
+			missingWAL = segment.Name()
+		}
+		if backup.Status.MissingWAL == missingWAL {
+			continue
+		}
+
+		if missingWAL != "" {
+			contextLogger.Warning("Backup is missing WAL files from the WAL archive",
+				"backupName", backup.Name,
+				"missingWAL", missingWAL)
+		}
+
+		origBackup := backup.DeepCopy()
+		backup.Status.MissingWAL = missingWAL
+		if err := cli.Status().Patch(ctx, backup, client.MergeFrom(origBackup)); err != nil {
+			return nil, fmt.Errorf("while marking backup %s: %w", backup.Name, err)
+		}
+	}
+
+	return firstRecoverabilityPoint, nil
+}
+
+// getContinuityCondition gets the Cluster condition describing the
+// continuity of the WAL archive
+func getContinuityCondition(continuity *postgres.WALArchiveContinuity) metav1.Condition {
+	if !continuity.GapDetection {
+		return metav1.Condition{
+			Type:   string(apiv1.ConditionWALArchiveContinuity),
+			Status: metav1.ConditionUnknown,
+			Reason: string(apiv1.ConditionReasonWALArchiveRangeOnly),
+			Message: fmt.Sprintf(
+				"The WAL archive starts from WAL segment %s, but it can't be checked for gaps",
+				continuity.FirstSegment.Name()),
+		}
+	}
+
+	if continuity.IsContinuous() {
+		return metav1.Condition{
+			Type:   string(apiv1.ConditionWALArchiveContinuity),
+			Status: metav1.ConditionTrue,
+			Reason: string(apiv1.ConditionReasonWALArchiveContinuous),
+			Message: fmt.Sprintf(
+				"The WAL archive is continuous since WAL segment %s",
+				continuity.ContinuousSince.Name()),
+		}
+	}
+
+	var problems []string
+	if len(continuity.Gaps) > 0 {
+		problems = append(problems, fmt.Sprintf(
+			"%d missing WAL segments, the first gap going from %s to %s",
+			continuity.MissingSegments(),
+			continuity.Gaps[0].First.Name(),
+			continuity.Gaps[0].Last.Name()))
+	}
+	if len(continuity.MissingHistoryFiles) > 0 {
+		problems = append(problems, fmt.Sprintf(
+			"missing timeline history files: %s",
+			strings.Join(continuity.MissingHistoryFiles, ", ")))
+	}
+
+	return metav1.Condition{
+		Type:   string(apiv1.ConditionWALArchiveContinuity),
+		Status: metav1.ConditionFalse,
+		Reason: string(apiv1.ConditionReasonWALArchiveGapDetected),
+		Message: fmt.Sprintf(
+			"%s; the WAL archive is continuous since WAL segment %s",
+			strings.Join(problems, "; "),
+			continuity.ContinuousSince.Name()),
+	}
+}
@@ -0,0 +1,218 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package walarchive
+
+import (
+	"context"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/internal/scheme"
+	postgresManagement "github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type fakeInstance struct {
+	podName string
+	report  *postgresManagement.WALArchiveContinuityReport
+	set     bool
+}
+
+func (f *fakeInstance) GetClusterName() string {
+	return "cluster-example"
+}
+
+func (f *fakeInstance) GetNamespaceName() string {
+	return "default"
+}
+
+func (f *fakeInstance) GetPodName() string {
+	return f.podName
+}
+
+func (f *fakeInstance) GetWALSegmentSize() (int, error) {
+	return int(postgres.DefaultWALSegmentSize), nil
+}
+
+func (f *fakeInstance) SetWALArchiveContinuity(report *postgresManagement.WALArchiveContinuityReport) {
+	f.report = report
+	f.set = true
+}
+
+func newCompletedBackup(name, beginWal, endWal string, stoppedAt time.Time) *apiv1.Backup {
+	return &apiv1.Backup{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec: apiv1.BackupSpec{
+			Cluster: apiv1.LocalObjectReference{Name: "cluster-example"},
+			Method:  apiv1.BackupMethodPersistentVolume,
+		},
+		Status: apiv1.BackupStatus{
+			Phase:     apiv1.BackupPhaseCompleted,
+			Method:    apiv1.BackupMethodPersistentVolume,
+			BeginWal:  beginWal,
+			EndWal:    endWal,
+			StoppedAt: &metav1.Time{Time: stoppedAt},
+		},
+	}
+}
+
+var _ = Describe("WAL archive continuity checker", func() {
+	var (
+		ctx     context.Context
+		cluster *apiv1.Cluster
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		cluster = &apiv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster-example", Namespace: "default"},
+			Status:     apiv1.ClusterStatus{CurrentPrimary: "cluster-example-1"},
+		}
+	})
+
+	newClient := func(objects ...client.Object) client.Client {
+		return fake.NewClientBuilder().
+			WithScheme(scheme.BuildWithAllKnownScheme()).
+			WithObjects(objects...).
+			WithStatusSubresource(&apiv1.Cluster{}, &apiv1.Backup{}).
+			Build()
+	}
+
+	It("doesn't run on the replicas", func() {
+		instance := &fakeInstance{podName: "cluster-example-2"}
+		checker := &ContinuityChecker{instance: instance, client: newClient(cluster)}
+
+		interval, err := checker.check(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(interval).To(Equal(apiv1.DefaultWALContinuityCheckInterval))
+		Expect(instance.set).To(BeTrue())
+		Expect(instance.report).To(BeNil())
+	})
+
+	It("follows the configured interval, and keeps watching when disabled", func() {
+		cluster.Spec.Backup = &apiv1.BackupConfiguration{
+			WALContinuityCheckInterval: &metav1.Duration{Duration: time.Hour},
+		}
+		instance := &fakeInstance{podName: "cluster-example-1"}
+		checker := &ContinuityChecker{instance: instance, client: newClient(cluster)}
+
+		interval, err := checker.check(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(interval).To(Equal(time.Hour))
+
+		cluster.Spec.Backup.WALContinuityCheckInterval = &metav1.Duration{}
+		checker.client = newClient(cluster)
+		interval, err = checker.check(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(interval).To(Equal(apiv1.DefaultWALContinuityCheckInterval))
+	})
+
+	It("doesn't report anything for a WAL archive which can't be checked", func() {
+		cluster.Spec.Backup = &apiv1.BackupConfiguration{
+			BarmanObjectStore: &apiv1.BarmanObjectStoreConfiguration{},
+		}
+		instance := &fakeInstance{podName: "cluster-example-1"}
+		checker := &ContinuityChecker{instance: instance, client: newClient(cluster)}
+
+		_, err := checker.check(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(instance.report).To(BeNil())
+	})
+
+	It("marks the backups missing WAL segments", func() {
+		now := time.Now().Truncate(time.Second)
+		oldBackup := newCompletedBackup("old", "000000010000000000000001", "000000010000000000000001",
+			now.Add(-3*time.Hour))
+		gapBackup := newCompletedBackup("gap", "000000010000000000000003", "000000010000000000000005",
+			now.Add(-2*time.Hour))
+		goodBackup := newCompletedBackup("good", "000000010000000000000006", "000000010000000000000006",
+			now.Add(-time.Hour))
+		newBackup := newCompletedBackup("new", "000000010000000000000007", "000000010000000000000007", now)
+		fixedBackup := newCompletedBackup("fixed", "000000010000000000000006", "000000010000000000000007", now)
+		fixedBackup.Status.MissingWAL = "000000010000000000000006"
+		otherMethodBackup := newCompletedBackup("barman", "000000010000000000000001",
+			"000000010000000000000001", now)
+		otherMethodBackup.Status.Method = apiv1.BackupMethodBarmanObjectStore
+		cli := newClient(cluster, oldBackup, gapBackup, goodBackup, newBackup, fixedBackup, otherMethodBackup)
+
+		firstRecoverabilityPoint, err := markBackups(ctx, cli, cluster, &walArchive{
+			continuity: postgres.CheckWALArchiveContinuity([]string{
+				"000000010000000000000002",
+				"000000010000000000000003",
+				"000000010000000000000006",
+				"000000010000000000000007",
+			}, postgres.DefaultWALSegmentSize),
+			backupMethods: []apiv1.BackupMethod{apiv1.BackupMethodPersistentVolume},
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(firstRecoverabilityPoint).ToNot(BeNil())
+		Expect(firstRecoverabilityPoint.Equal(now.Add(-time.Hour))).To(BeTrue())
+
+		expectedMissingWAL := map[string]string{
+			"old":    "000000010000000000000001",
+			"gap":    "000000010000000000000004",
+			"good":   "",
+			"new":    "",
+			"fixed":  "",
+			"barman": "",
+		}
+		for name, missingWAL := range expectedMissingWAL {
+			var backup apiv1.Backup
+			Expect(cli.Get(ctx, types.NamespacedName{Namespace: "default", Name: name}, &backup)).To(Succeed())
+			Expect(backup.Status.MissingWAL).To(Equal(missingWAL), name)
+		}
+	})
+
+	It("describes the continuity of the WAL archive in a condition", func() {
+		condition := getContinuityCondition(postgres.CheckWALArchiveContinuity([]string{
+			"000000010000000000000002",
+			"000000010000000000000003",
+		}, postgres.DefaultWALSegmentSize))
+		Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+		Expect(condition.Reason).To(Equal(string(apiv1.ConditionReasonWALArchiveContinuous)))
+
+		condition = getContinuityCondition(postgres.CheckWALArchiveContinuity([]string{
+			"000000010000000000000002",
+			"000000010000000000000005",
+			"000000020000000000000006",
+		}, postgres.DefaultWALSegmentSize))
+		Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+		Expect(condition.Reason).To(Equal(string(apiv1.ConditionReasonWALArchiveGapDetected)))
+		Expect(condition.Message).To(Equal(
+			"2 missing WAL segments, the first gap going from 000000010000000000000003 " +
+				"to 000000010000000000000004; missing timeline history files: 00000002.history; " +
+				"the WAL archive is continuous since WAL segment 000000010000000000000005"))
+
+		condition = getContinuityCondition(postgres.NewWALArchiveRange(
+			postgres.MustSegmentFromName("000000010000000000000002"),
+			postgres.MustSegmentFromName("000000010000000000000005"),
+			postgres.DefaultWALSegmentSize))
+		Expect(condition.Status).To(Equal(metav1.ConditionUnknown))
+		Expect(condition.Reason).To(Equal(string(apiv1.ConditionReasonWALArchiveRangeOnly)))
+	})
+})
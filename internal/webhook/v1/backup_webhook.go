@@ -164,6 +164,22 @@ func (v *BackupCustomValidator) validate(r *apiv1.Backup) field.ErrorList {
 		))
 	}
 
+	if r.Spec.Method != apiv1.BackupMethodLogical && r.Spec.Logical != nil {
+		result = append(result, field.Invalid(
+			field.NewPath("spec", "logical"),
+			r.Spec.Logical,
+			"Logical parameter can be specified only if the backup method is logical",
+		))
+	}
+
+	if r.Spec.Method != apiv1.BackupMethodPersistentVolume && r.Spec.ParentBackup != nil {
+		result = append(result, field.Invalid(
+			field.NewPath("spec", "parentBackup"),
+			r.Spec.ParentBackup,
+			"ParentBackup parameter can be specified only if the backup method is persistentVolume",
+		))
+	}
+
 	if r.Spec.Method == apiv1.BackupMethodPlugin && r.Spec.PluginConfiguration.IsEmpty() {
 		result = append(result, field.Invalid(
 			field.NewPath("spec", "pluginConfiguration"),
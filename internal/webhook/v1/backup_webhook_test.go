@@ -59,6 +59,43 @@ var _ = Describe("Backup webhook validate", func() {
 		Expect(result[0].Field).To(Equal("spec.method"))
 	})
 
+	It("complains if logical is set on a non-logical backup", func() {
+		backup := &apiv1.Backup{
+			Spec: apiv1.BackupSpec{
+				Method: apiv1.BackupMethodBarmanObjectStore,
+				Logical: &apiv1.LogicalBackupOptions{
+					Databases: []string{"app"},
+				},
+			},
+		}
+		result := v.validate(backup)
+		Expect(result).To(HaveLen(1))
+		Expect(result[0].Field).To(Equal("spec.logical"))
+	})
+
+	It("complains if parentBackup is set on a non-persistentVolume backup", func() {
+		backup := &apiv1.Backup{
+			Spec: apiv1.BackupSpec{
+				Method:       apiv1.BackupMethodBarmanObjectStore,
+				ParentBackup: &apiv1.LocalObjectReference{Name: "full"},
+			},
+		}
+		result := v.validate(backup)
+		Expect(result).To(HaveLen(1))
+		Expect(result[0].Field).To(Equal("spec.parentBackup"))
+	})
+
+	It("doesn't complain if parentBackup is set on a persistentVolume backup", func() {
+		backup := &apiv1.Backup{
+			Spec: apiv1.BackupSpec{
+				Method:       apiv1.BackupMethodPersistentVolume,
+				ParentBackup: &apiv1.LocalObjectReference{Name: "full"},
+			},
+		}
+		result := v.validate(backup)
+		Expect(result).To(BeEmpty())
+	})
+
 	It("complains if online is set on a barman backup", func() {
 		backup := &apiv1.Backup{
 			Spec: apiv1.BackupSpec{
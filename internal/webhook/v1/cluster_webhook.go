@@ -26,6 +26,7 @@ import (
 	"slices"
 	"strconv"
 	"strings"
+	"time"
 
 	barmanWebhooks "github.com/cloudnative-pg/barman-cloud/pkg/api/webhooks"
 	"github.com/cloudnative-pg/machinery/pkg/image/reference"
@@ -35,6 +36,7 @@ import (
 	"github.com/cloudnative-pg/machinery/pkg/types"
 	jsonpatch "github.com/evanphx/json-patch/v5"
 	storagesnapshotv1 "github.com/kubernetes-csi/external-snapshotter/client/v8/apis/volumesnapshot/v1"
+	"github.com/robfig/cron"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -184,12 +186,17 @@ func (v *ClusterCustomValidator) validate(r *apiv1.Cluster) (allErrs field.Error
 		v.validateImagePullPolicy,
 		v.validateRecoveryTarget,
 		v.validatePrimaryUpdateStrategy,
+		v.validateFailoverQuorum,
+		v.validatePrimaryPlacement,
+		v.validateClusterMaintenanceWindows,
+		v.validateCanaryRollout,
 		v.validateMinSyncReplicas,
 		v.validateMaxSyncReplicas,
 		v.validateStorageSize,
 		v.validateWalStorageSize,
 		v.validateEphemeralVolumeSource,
 		v.validateTablespaceStorageSize,
+		v.validateBackupVolumeStorageSize,
 		v.validateName,
 		v.validateTablespaceNames,
 		v.validateBootstrapPgBaseBackupSource,
@@ -205,6 +212,8 @@ func (v *ClusterCustomValidator) validate(r *apiv1.Cluster) (allErrs field.Error
 		v.validateConfiguration,
 		v.validateSynchronousReplicaConfiguration,
 		v.validateLDAP,
+		v.validatePgHBARules,
+		v.validateAudit,
 		v.validateReplicationSlots,
 		v.validateEnv,
 		v.validateManagedServices,
@@ -278,6 +287,44 @@ func (v *ClusterCustomValidator) validateLDAP(r *apiv1.Cluster) field.ErrorList
 	return result
 }
 
+// validatePgHBARules validates the structured pg_hba rules
+func (v *ClusterCustomValidator) validatePgHBARules(r *apiv1.Cluster) field.ErrorList {
+	return validateHBARules(
+		field.NewPath("spec", "postgresql", "pg_hba_rules"),
+		r.Spec.PostgresConfiguration.PgHBARules)
+}
+
+// validateAudit validates the declarative pgaudit configuration
+func (v *ClusterCustomValidator) validateAudit(r *apiv1.Cluster) field.ErrorList {
+	audit := r.Spec.PostgresConfiguration.Audit
+	if audit == nil {
+		return nil
+	}
+
+	var result field.ErrorList
+	path := field.NewPath("spec", "postgresql", "audit")
+
+	if _, ok := r.Spec.PostgresConfiguration.Parameters[postgres.ParameterPgAuditLog]; ok {
+		result = append(result, field.Forbidden(
+			field.NewPath("spec", "postgresql", "parameters", postgres.ParameterPgAuditLog),
+			"cannot be set together with the audit configuration, use the audit classes instead"))
+	}
+
+	validateScopes := func(scopePath *field.Path, scopes []apiv1.AuditScopeConfiguration) {
+		names := stringset.New()
+		for i, scope := range scopes {
+			if names.Has(scope.Name) {
+				result = append(result, field.Duplicate(scopePath.Index(i).Child("name"), scope.Name))
+			}
+			names.Put(scope.Name)
+		}
+	}
+	validateScopes(path.Child("roles"), audit.Roles)
+	validateScopes(path.Child("databases"), audit.Databases)
+
+	return result
+}
+
 // validateEnv validate the environment variables settings proposed by the user
 func (v *ClusterCustomValidator) validateEnv(r *apiv1.Cluster) field.ErrorList {
 	var result field.ErrorList
@@ -392,21 +439,56 @@ func (v *ClusterCustomValidator) validateImport(r *apiv1.Cluster) field.ErrorLis
 		return nil
 	}
 
+	result := v.validateImportFromBackup(r)
 	switch importSpec.Type {
 	case apiv1.MicroserviceSnapshotType:
-		return v.validateMicroservice(importSpec)
+		return append(result, v.validateMicroservice(importSpec)...)
 	case apiv1.MonolithSnapshotType:
-		return v.validateMonolith(importSpec)
+		return append(result, v.validateMonolith(importSpec)...)
 	default:
-		return field.ErrorList{
+		return append(result,
 			field.Invalid(
 				field.NewPath("spec", "bootstrap", "initdb", "import", "type"),
 				importSpec.Type,
 				"Unrecognized import type"),
-		}
+		)
 	}
 }
 
+// validateImportFromBackup validates the import of the databases
+// from a logical backup
+func (v *ClusterCustomValidator) validateImportFromBackup(r *apiv1.Cluster) field.ErrorList {
+	importSpec := r.Spec.Bootstrap.InitDB.Import
+	if importSpec.Source.Backup == nil {
+		return nil
+	}
+
+	var result field.ErrorList
+
+	if r.GetLogicalBackupVolumeClaimName() == "" {
+		result = append(
+			result,
+			field.Invalid(
+				field.NewPath("spec", "bootstrap", "initdb", "import", "source", "backup"),
+				importSpec.Source.Backup.Name,
+				"Importing from a logical backup requires the logical backup volume "+
+					"to be configured in `.spec.backup.logical`"),
+		)
+	}
+
+	if len(importSpec.Roles) != 0 {
+		result = append(
+			result,
+			field.Invalid(
+				field.NewPath("spec", "bootstrap", "initdb", "import", "roles"),
+				importSpec.Roles,
+				"Roles cannot be imported from a logical backup"),
+		)
+	}
+
+	return result
+}
+
 func (v *ClusterCustomValidator) validateMicroservice(s *apiv1.Import) field.ErrorList {
 	var result field.ErrorList
 
@@ -595,6 +677,18 @@ func (v *ClusterCustomValidator) validateCerts(r *apiv1.Cluster) field.ErrorList
 				"Client CA secret can't be empty when client replication secret is provided"))
 	}
 
+	if certificates.CertManager != nil && (certificates.ServerCASecret != "" ||
+		certificates.ServerTLSSecret != "" ||
+		certificates.ClientCASecret != "" ||
+		certificates.ReplicationTLSSecret != "") {
+		result = append(
+			result,
+			field.Invalid(
+				field.NewPath("spec", "certificates", "certManager"),
+				"",
+				"cert-manager can't be used when the certificates secrets are provided"))
+	}
+
 	return result
 }
 
@@ -1408,6 +1502,123 @@ func (v *ClusterCustomValidator) validatePrimaryUpdateStrategy(r *apiv1.Cluster)
 	return nil
 }
 
+// validateFailoverQuorum checks that the quorum-based failover is only
+// enabled when a majority of the instances can be formed without the primary
+func (v *ClusterCustomValidator) validateFailoverQuorum(r *apiv1.Cluster) field.ErrorList {
+	if !r.IsFailoverQuorumEnabled() {
+		return nil
+	}
+
+	var result field.ErrorList
+
+	if r.Spec.Instances < 3 {
+		result = append(result, field.Invalid(
+			field.NewPath("spec", "failoverQuorum", "enabled"),
+			r.Spec.FailoverQuorum.Enabled,
+			"quorum-based failover requires at least three instances"))
+	}
+
+	if r.Spec.FailoverQuorum.PrimaryIsolationTimeout < 0 {
+		result = append(result, field.Invalid(
+			field.NewPath("spec", "failoverQuorum", "primaryIsolationTimeout"),
+			r.Spec.FailoverQuorum.PrimaryIsolationTimeout,
+			"primaryIsolationTimeout must be a non negative integer"))
+	}
+
+	return result
+}
+
+// validatePrimaryPlacement checks the primary placement preferences and
+// the schedules of the switchback maintenance windows
+func (v *ClusterCustomValidator) validatePrimaryPlacement(r *apiv1.Cluster) field.ErrorList {
+	if r.Spec.PrimaryPlacement == nil {
+		return nil
+	}
+
+	var result field.ErrorList
+	basePath := field.NewPath("spec", "primaryPlacement")
+
+	for idx, preference := range r.Spec.PrimaryPlacement.Preferences {
+		if preference.Weight < 1 || preference.Weight > 100 {
+			result = append(result, field.Invalid(
+				basePath.Child("preferences").Index(idx).Child("weight"),
+				preference.Weight,
+				"weight must be in the range 1-100"))
+		}
+		if len(preference.NodeLabels) == 0 {
+			result = append(result, field.Required(
+				basePath.Child("preferences").Index(idx).Child("nodeLabels"),
+				"at least a node label is required"))
+		}
+	}
+
+	if r.Spec.PrimaryPlacement.Switchback != nil {
+		result = append(result, validateMaintenanceWindows(
+			basePath.Child("switchback", "maintenanceWindows"),
+			r.Spec.PrimaryPlacement.Switchback.MaintenanceWindows)...)
+	}
+
+	return result
+}
+
+// validateClusterMaintenanceWindows validates the windows gating the
+// disruptive actions of the operator
+func (v *ClusterCustomValidator) validateClusterMaintenanceWindows(r *apiv1.Cluster) field.ErrorList {
+	return validateMaintenanceWindows(field.NewPath("spec", "maintenanceWindows"), r.Spec.MaintenanceWindows)
+}
+
+// validateCanaryRollout validates the configuration of the canary strategy
+func (v *ClusterCustomValidator) validateCanaryRollout(r *apiv1.Cluster) field.ErrorList {
+	var result field.ErrorList
+
+	if r.Spec.CanaryRollout == nil {
+		return result
+	}
+
+	basePath := field.NewPath("spec", "canaryRollout")
+	if soakTime := r.Spec.CanaryRollout.SoakTime; soakTime != nil && soakTime.Duration <= 0 {
+		result = append(result, field.Invalid(
+			basePath.Child("soakTime"),
+			soakTime.String(),
+			"soak time must be positive"))
+	}
+
+	names := stringset.New()
+	for idx, probe := range r.Spec.CanaryRollout.Probes {
+		if names.Has(probe.Name) {
+			result = append(result, field.Duplicate(
+				basePath.Child("probes").Index(idx).Child("name"),
+				probe.Name))
+		}
+		names.Put(probe.Name)
+	}
+
+	return result
+}
+
+// validateMaintenanceWindows checks that the maintenance windows have a
+// valid schedule and a positive duration
+func validateMaintenanceWindows(path *field.Path, windows []apiv1.MaintenanceWindow) field.ErrorList {
+	var result field.ErrorList
+
+	for idx, window := range windows {
+		if _, err := cron.Parse(window.Schedule); err != nil {
+			result = append(result, field.Invalid(
+				path.Index(idx).Child("schedule"),
+				window.Schedule,
+				fmt.Sprintf("invalid cron schedule: %v", err)))
+		}
+		if window.Duration.Duration <= 0 {
+			result = append(result, field.Invalid(
+				path.Index(idx).Child("duration"),
+				window.Duration.String(),
+				"duration must be positive"))
+		}
+	}
+
+	return result
+}
+
 // Validate the maximum number of synchronous instances
 // that should be kept in sync with the primary server
 func (v *ClusterCustomValidator) validateMaxSyncReplicas(r *apiv1.Cluster) field.ErrorList {
@@ -1498,6 +1709,17 @@ func (v *ClusterCustomValidator) validateTablespaceStorageSize(r *apiv1.Cluster)
 	return result
 }
 
+func (v *ClusterCustomValidator) validateBackupVolumeStorageSize(r *apiv1.Cluster) field.ErrorList {
+	if !r.IsPersistentVolumeBackupOwned() {
+		return nil
+	}
+
+	return validateStorageConfigurationSize(
+		*field.NewPath("spec", "backup", "persistentVolume", "storage"),
+		*r.Spec.Backup.PersistentVolume.Storage,
+	)
+}
+
 func validateStorageConfigurationSize(
 	structPath field.Path,
 	storageConfiguration apiv1.StorageConfiguration,
@@ -2266,6 +2488,58 @@ func (v *ClusterCustomValidator) validateManagedRoles(r *apiv1.Cluster) field.Er
 					role.Name,
 					"This role both sets and disables a password"))
 		}
+		result = append(result, validatePasswordRotation(role)...)
+	}
+
+	for _, role := range r.Spec.Managed.Roles {
+		if !role.IsPasswordRotationAlternating() {
+			continue
+		}
+		if _, found := managedRoles[role.GetAlternateRoleName()]; found {
+			result = append(
+				result,
+				field.Invalid(
+					field.NewPath("spec", "managed", "roles"),
+					role.Name,
+					fmt.Sprintf("The alternate role %q is already a managed role", role.GetAlternateRoleName())))
+		}
+	}
+
+	return result
+}
+
+// validatePasswordRotation validates the password rotation policy of a
+// managed role
+func validatePasswordRotation(role apiv1.RoleConfiguration) field.ErrorList {
+	if role.PasswordRotation == nil {
+		return nil
+	}
+
+	var result field.ErrorList
+	path := field.NewPath("spec", "managed", "roles").Key(role.Name).Child("passwordRotation")
+	if role.PasswordSecret == nil || role.DisablePassword {
+		result = append(
+			result,
+			field.Invalid(
+				path,
+				role.Name,
+				"Password rotation requires a password secret and an enabled password"))
+	}
+	if role.PasswordRotation.Interval.Duration < time.Hour {
+		result = append(
+			result,
+			field.Invalid(
+				path.Child("interval"),
+				role.PasswordRotation.Interval.Duration.String(),
+				"The password rotation interval must be at least one hour"))
+	}
+	if role.IsPasswordRotationAlternating() && role.Ensure == apiv1.EnsureAbsent {
+		result = append(
+			result,
+			field.Invalid(
+				path.Child("strategy"),
+				role.PasswordRotation.Strategy,
+				"The alternating strategy cannot be used on absent roles"))
 	}
 
 	return result
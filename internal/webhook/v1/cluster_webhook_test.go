@@ -144,6 +144,36 @@ var _ = Describe("certificates options validation", func() {
 		result := v.validateCerts(cluster)
 		Expect(result).To(HaveLen(1))
 	})
+
+	It("doesn't complain if you specify a cert-manager issuer and alternative DNS names", func() {
+		cluster := &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				Certificates: &apiv1.CertificatesConfiguration{
+					ServerAltDNSNames: []string{"dns-name"},
+					CertManager: &apiv1.CertManagerConfiguration{
+						IssuerRef: apiv1.CertManagerIssuerReference{Name: "issuer"},
+					},
+				},
+			},
+		}
+		result := v.validateCerts(cluster)
+		Expect(result).To(BeEmpty())
+	})
+
+	It("does complain if you specify a cert-manager issuer and a secret name", func() {
+		cluster := &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				Certificates: &apiv1.CertificatesConfiguration{
+					ClientCASecret: "test-client-ca",
+					CertManager: &apiv1.CertManagerConfiguration{
+						IssuerRef: apiv1.CertManagerIssuerReference{Name: "issuer"},
+					},
+				},
+			},
+		}
+		result := v.validateCerts(cluster)
+		Expect(result).To(HaveLen(1))
+	})
 })
 
 var _ = Describe("initdb options validation", func() {
@@ -1764,6 +1794,155 @@ var _ = Describe("primary update strategy", func() {
 	})
 })
 
+var _ = Describe("failover quorum", func() {
+	var v *ClusterCustomValidator
+	BeforeEach(func() {
+		v = &ClusterCustomValidator{}
+	})
+
+	It("allows a disabled quorum on any cluster", func() {
+		cluster := &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				Instances:      1,
+				FailoverQuorum: &apiv1.FailoverQuorumConfiguration{Enabled: false},
+			},
+		}
+		Expect(v.validateFailoverQuorum(cluster)).To(BeEmpty())
+	})
+
+	It("allows the quorum on clusters with three instances", func() {
+		cluster := &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				Instances:      3,
+				FailoverQuorum: &apiv1.FailoverQuorumConfiguration{Enabled: true, PrimaryIsolationTimeout: 30},
+			},
+		}
+		Expect(v.validateFailoverQuorum(cluster)).To(BeEmpty())
+	})
+
+	It("prevents the quorum on clusters with less than three instances", func() {
+		cluster := &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				Instances:      2,
+				FailoverQuorum: &apiv1.FailoverQuorumConfiguration{Enabled: true},
+			},
+		}
+		Expect(v.validateFailoverQuorum(cluster)).To(HaveLen(1))
+	})
+})
+
+var _ = Describe("primary placement", func() {
+	var v *ClusterCustomValidator
+	BeforeEach(func() {
+		v = &ClusterCustomValidator{}
+	})
+
+	It("accepts valid preferences and maintenance windows", func() {
+		cluster := &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				PrimaryPlacement: &apiv1.PrimaryPlacementConfiguration{
+					Preferences: []apiv1.PrimaryPlacementPreference{
+						{Weight: 10, NodeLabels: map[string]string{"topology.kubernetes.io/zone": "zone-a"}},
+					},
+					Switchback: &apiv1.PrimarySwitchbackConfiguration{
+						Enabled: true,
+						MaintenanceWindows: []apiv1.MaintenanceWindow{
+							{Schedule: "0 0 2 * * *", Duration: metav1.Duration{Duration: time.Hour}},
+						},
+					},
+				},
+			},
+		}
+		Expect(v.validatePrimaryPlacement(cluster)).To(BeEmpty())
+	})
+
+	It("rejects invalid preferences and maintenance windows", func() {
+		cluster := &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				PrimaryPlacement: &apiv1.PrimaryPlacementConfiguration{
+					Preferences: []apiv1.PrimaryPlacementPreference{
+						{Weight: 0},
+					},
+					Switchback: &apiv1.PrimarySwitchbackConfiguration{
+						Enabled: true,
+						MaintenanceWindows: []apiv1.MaintenanceWindow{
+							{Schedule: "not a schedule"},
+						},
+					},
+				},
+			},
+		}
+		Expect(v.validatePrimaryPlacement(cluster)).To(HaveLen(4))
+	})
+})
+
+var _ = Describe("Canary rollout validation", func() {
+	v := &ClusterCustomValidator{}
+
+	It("accepts a valid configuration", func() {
+		cluster := &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				CanaryRollout: &apiv1.CanaryRolloutConfiguration{
+					Enabled:  true,
+					SoakTime: &metav1.Duration{Duration: 5 * time.Minute},
+					Probes: []apiv1.CanaryProbe{
+						{Name: "orders", Query: "SELECT count(*) > 0 FROM orders"},
+						{Name: "extensions", Query: "SELECT true", Database: "postgres"},
+					},
+				},
+			},
+		}
+		Expect(v.validateCanaryRollout(cluster)).To(BeEmpty())
+	})
+
+	It("rejects a non positive soak time and duplicated probes", func() {
+		cluster := &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				CanaryRollout: &apiv1.CanaryRolloutConfiguration{
+					Enabled:  true,
+					SoakTime: &metav1.Duration{},
+					Probes: []apiv1.CanaryProbe{
+						{Name: "orders", Query: "SELECT true"},
+						{Name: "orders", Query: "SELECT true"},
+					},
+				},
+			},
+		}
+		result := v.validateCanaryRollout(cluster)
+		Expect(result).To(HaveLen(2))
+		Expect(result[0].Field).To(Equal("spec.canaryRollout.soakTime"))
+		Expect(result[1].Field).To(Equal("spec.canaryRollout.probes[1].name"))
+	})
+})
+
+var _ = Describe("Cluster maintenance windows validation", func() {
+	v := &ClusterCustomValidator{}
+
+	It("accepts valid maintenance windows", func() {
+		cluster := &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				MaintenanceWindows: []apiv1.MaintenanceWindow{
+					{Schedule: "0 0 22 * * 6", Duration: metav1.Duration{Duration: 4 * time.Hour}},
+				},
+			},
+		}
+		Expect(v.validateClusterMaintenanceWindows(cluster)).To(BeEmpty())
+	})
+
+	It("rejects maintenance windows with an invalid schedule or duration", func() {
+		cluster := &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				MaintenanceWindows: []apiv1.MaintenanceWindow{
+					{Schedule: "every saturday"},
+				},
+			},
+		}
+		result := v.validateClusterMaintenanceWindows(cluster)
+		Expect(result).To(HaveLen(2))
+		Expect(result[0].Field).To(Equal("spec.maintenanceWindows[0].schedule"))
+	})
+})
+
 var _ = Describe("Number of synchronous replicas", func() {
 	var v *ClusterCustomValidator
 	BeforeEach(func() {
@@ -3286,6 +3465,84 @@ var _ = Describe("validation of imports", func() {
 		Expect(result).To(BeEmpty())
 	})
 
+	It("rejects import from a logical backup without the logical backup volume", func() {
+		cluster := &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				Bootstrap: &apiv1.BootstrapConfiguration{
+					InitDB: &apiv1.BootstrapInitDB{
+						Database: "app",
+						Owner:    "app",
+						Import: &apiv1.Import{
+							Type:      apiv1.MicroserviceSnapshotType,
+							Databases: []string{"foo"},
+							Source: apiv1.ImportSource{
+								Backup: &apiv1.LocalObjectReference{Name: "backup"},
+							},
+						},
+					},
+				},
+			},
+		}
+
+		result := v.validateImport(cluster)
+		Expect(result).To(HaveLen(1))
+		Expect(result[0].Field).To(Equal("spec.bootstrap.initdb.import.source.backup"))
+	})
+
+	It("rejects import of roles from a logical backup", func() {
+		cluster := &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				Backup: &apiv1.BackupConfiguration{
+					Logical: &apiv1.LogicalBackupConfiguration{VolumeClaimName: "dumps"},
+				},
+				Bootstrap: &apiv1.BootstrapConfiguration{
+					InitDB: &apiv1.BootstrapInitDB{
+						Database: "app",
+						Owner:    "app",
+						Import: &apiv1.Import{
+							Type:      apiv1.MonolithSnapshotType,
+							Databases: []string{"*"},
+							Roles:     []string{"foo"},
+							Source: apiv1.ImportSource{
+								Backup: &apiv1.LocalObjectReference{Name: "backup"},
+							},
+						},
+					},
+				},
+			},
+		}
+
+		result := v.validateImport(cluster)
+		Expect(result).To(HaveLen(1))
+		Expect(result[0].Field).To(Equal("spec.bootstrap.initdb.import.roles"))
+	})
+
+	It("accepts import from a logical backup when well specified", func() {
+		cluster := &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				Backup: &apiv1.BackupConfiguration{
+					Logical: &apiv1.LogicalBackupConfiguration{VolumeClaimName: "dumps"},
+				},
+				Bootstrap: &apiv1.BootstrapConfiguration{
+					InitDB: &apiv1.BootstrapInitDB{
+						Database: "app",
+						Owner:    "app",
+						Import: &apiv1.Import{
+							Type:      apiv1.MonolithSnapshotType,
+							Databases: []string{"*"},
+							Source: apiv1.ImportSource{
+								Backup: &apiv1.LocalObjectReference{Name: "backup"},
+							},
+						},
+					},
+				},
+			},
+		}
+
+		result := v.validateImport(cluster)
+		Expect(result).To(BeEmpty())
+	})
+
 	It("rejects monolith import with no databases", func() {
 		cluster := &apiv1.Cluster{
 			Spec: apiv1.ClusterSpec{
@@ -3684,6 +3941,22 @@ var _ = Describe("Storage configuration validation", func() {
 			}
 			Expect(v.validateStorageSize(cluster)).To(BeEmpty())
 		})
+
+		It("validates the size of the backup volume owned by the cluster", func() {
+			cluster := &apiv1.Cluster{
+				Spec: apiv1.ClusterSpec{
+					Backup: &apiv1.BackupConfiguration{
+						PersistentVolume: &apiv1.PersistentVolumeBackupConfiguration{
+							Storage: &apiv1.StorageConfiguration{},
+						},
+					},
+				},
+			}
+			Expect(v.validateBackupVolumeStorageSize(cluster)).To(HaveLen(1))
+
+			cluster.Spec.Backup.PersistentVolume.Storage.Size = "10Gi"
+			Expect(v.validateBackupVolumeStorageSize(cluster)).To(BeEmpty())
+		})
 	})
 })
 
@@ -3865,6 +4138,72 @@ var _ = Describe("Role management validation", func() {
 		}
 		Expect(v.validateManagedRoles(cluster)).To(HaveLen(1))
 	})
+	It("should accept a password rotation policy on a role with a password secret", func() {
+		cluster := &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				Managed: &apiv1.ManagedConfiguration{
+					Roles: []apiv1.RoleConfiguration{
+						{
+							Name:            "my_test",
+							ConnectionLimit: -1,
+							PasswordSecret: &apiv1.LocalObjectReference{
+								Name: "myPassword",
+							},
+							PasswordRotation: &apiv1.PasswordRotationConfiguration{
+								Interval: metav1.Duration{Duration: 720 * time.Hour},
+								Strategy: apiv1.PasswordRotationStrategyAlternating,
+							},
+						},
+					},
+				},
+			},
+		}
+		Expect(v.validateManagedRoles(cluster)).To(BeEmpty())
+	})
+	It("should produce an error if a rotated role has no password secret or a short interval", func() {
+		cluster := &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				Managed: &apiv1.ManagedConfiguration{
+					Roles: []apiv1.RoleConfiguration{
+						{
+							Name:            "my_test",
+							ConnectionLimit: -1,
+							PasswordRotation: &apiv1.PasswordRotationConfiguration{
+								Interval: metav1.Duration{Duration: time.Minute},
+							},
+						},
+					},
+				},
+			},
+		}
+		Expect(v.validateManagedRoles(cluster)).To(HaveLen(2))
+	})
+	It("should produce an error if the alternate role clashes with a managed role", func() {
+		cluster := &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				Managed: &apiv1.ManagedConfiguration{
+					Roles: []apiv1.RoleConfiguration{
+						{
+							Name:            "my_test",
+							ConnectionLimit: -1,
+							PasswordSecret: &apiv1.LocalObjectReference{
+								Name: "myPassword",
+							},
+							PasswordRotation: &apiv1.PasswordRotationConfiguration{
+								Interval: metav1.Duration{Duration: 720 * time.Hour},
+								Strategy: apiv1.PasswordRotationStrategyAlternating,
+							},
+						},
+						{
+							Name:            "my_test_alt",
+							ConnectionLimit: -1,
+						},
+					},
+				},
+			},
+		}
+		Expect(v.validateManagedRoles(cluster)).To(HaveLen(1))
+	})
 })
 
 var _ = Describe("Managed Extensions validation", func() {
@@ -4991,3 +5330,143 @@ var _ = Describe("validatePluginConfiguration", func() {
 		Expect(v.validatePluginConfiguration(cluster)).To(BeNil())
 	})
 })
+
+var _ = Describe("pg_hba rules validation", func() {
+	var v *ClusterCustomValidator
+	BeforeEach(func() {
+		v = &ClusterCustomValidator{}
+	})
+
+	newCluster := func(rules ...apiv1.HBARule) *apiv1.Cluster {
+		return &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				PostgresConfiguration: apiv1.PostgresConfiguration{
+					PgHBARules: rules,
+				},
+			},
+		}
+	}
+
+	It("accepts valid rules", func() {
+		cluster := newCluster(
+			apiv1.HBARule{
+				Type:      "hostssl",
+				Databases: []string{"app"},
+				Users:     []string{"app"},
+				Address:   "10.0.0.0/8",
+				Method:    "scram-sha-256",
+			},
+			apiv1.HBARule{
+				Type:      "host",
+				Databases: []string{"all"},
+				Users:     []string{"app", "+reporting"},
+				Address:   "192.168.1.0/24",
+				Method:    "reject",
+				Position:  apiv1.HBARulePositionBeforeOperatorRules,
+			},
+		)
+		Expect(v.validatePgHBARules(cluster)).To(BeEmpty())
+	})
+
+	It("rejects invalid rules", func() {
+		cluster := newCluster(apiv1.HBARule{
+			Type:      "host",
+			Databases: []string{"app"},
+			Users:     []string{"app"},
+			Address:   "10.0.0.1",
+			Method:    "cert",
+		})
+		errs := v.validatePgHBARules(cluster)
+		Expect(errs).To(HaveLen(1))
+		Expect(errs[0].Field).To(Equal("spec.postgresql.pg_hba_rules[0]"))
+		Expect(errs[0].Detail).To(ContainSubstring("requires a CIDR mask"))
+		Expect(errs[0].Detail).To(ContainSubstring("requires the hostssl"))
+	})
+
+	It("rejects local rules preceding the operator rules", func() {
+		cluster := newCluster(apiv1.HBARule{
+			Type:      "local",
+			Databases: []string{"all"},
+			Users:     []string{"app"},
+			Method:    "reject",
+			Position:  apiv1.HBARulePositionBeforeOperatorRules,
+		})
+		Expect(v.validatePgHBARules(cluster)).To(HaveLen(1))
+	})
+
+	It("rejects rules preceding the operator rules that could shadow the reserved users", func() {
+		cluster := newCluster(apiv1.HBARule{
+			Type:      "host",
+			Databases: []string{"all", "replication"},
+			Users:     []string{"streaming_replica", "all", "+cnpg_pooler_pgbouncer", "@users", "app"},
+			Address:   "all",
+			Method:    "reject",
+			Position:  apiv1.HBARulePositionBeforeOperatorRules,
+		})
+		errs := v.validatePgHBARules(cluster)
+		Expect(errs).To(HaveLen(4))
+		for _, err := range errs {
+			Expect(err.Field).To(Equal("spec.postgresql.pg_hba_rules[0].users"))
+		}
+	})
+
+	It("accepts reserved users in rules following the operator rules", func() {
+		cluster := newCluster(apiv1.HBARule{
+			Type:      "host",
+			Databases: []string{"all"},
+			Users:     []string{"streaming_replica"},
+			Address:   "all",
+			Method:    "reject",
+		})
+		Expect(v.validatePgHBARules(cluster)).To(BeEmpty())
+	})
+})
+
+var _ = Describe("audit configuration validation", func() {
+	var v *ClusterCustomValidator
+	BeforeEach(func() {
+		v = &ClusterCustomValidator{}
+	})
+
+	newCluster := func(audit *apiv1.AuditConfiguration, parameters map[string]string) *apiv1.Cluster {
+		return &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				PostgresConfiguration: apiv1.PostgresConfiguration{
+					Parameters: parameters,
+					Audit:      audit,
+				},
+			},
+		}
+	}
+
+	It("accepts a cluster without audit configuration", func() {
+		Expect(v.validateAudit(newCluster(nil, map[string]string{"pgaudit.log": "all"}))).To(BeEmpty())
+	})
+
+	It("accepts a valid audit configuration", func() {
+		cluster := newCluster(&apiv1.AuditConfiguration{
+			Classes:   []apiv1.PgAuditClass{"ddl"},
+			Roles:     []apiv1.AuditScopeConfiguration{{Name: "app", Classes: []apiv1.PgAuditClass{"all"}}},
+			Databases: []apiv1.AuditScopeConfiguration{{Name: "app"}},
+		}, map[string]string{"pgaudit.log_parameter": "on"})
+		Expect(v.validateAudit(cluster)).To(BeEmpty())
+	})
+
+	It("rejects pgaudit.log in the parameters", func() {
+		cluster := newCluster(&apiv1.AuditConfiguration{}, map[string]string{"pgaudit.log": "all"})
+		errs := v.validateAudit(cluster)
+		Expect(errs).To(HaveLen(1))
+		Expect(errs[0].Field).To(Equal("spec.postgresql.parameters.pgaudit.log"))
+	})
+
+	It("rejects duplicated roles and databases", func() {
+		cluster := newCluster(&apiv1.AuditConfiguration{
+			Roles:     []apiv1.AuditScopeConfiguration{{Name: "app"}, {Name: "app"}},
+			Databases: []apiv1.AuditScopeConfiguration{{Name: "db"}, {Name: "db"}},
+		}, nil)
+		errs := v.validateAudit(cluster)
+		Expect(errs).To(HaveLen(2))
+		Expect(errs[0].Field).To(Equal("spec.postgresql.audit.roles[1].name"))
+		Expect(errs[1].Field).To(Equal("spec.postgresql.audit.databases[1].name"))
+	})
+})
@@ -22,11 +22,16 @@ package v1
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/postgres/hba"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
 )
 
@@ -131,3 +136,52 @@ func validate(obj runtime.Object, validator func() (admission.Warnings, error))
 	warnings = append(warnings, validationWarnings...)
 	return warnings, err
 }
+
+// validateHBARules validates a list of structured Host Based Authentication
+// rules, so that errors are detected before the configuration is reloaded
+func validateHBARules(path *field.Path, rules []apiv1.HBARule) field.ErrorList {
+	var result field.ErrorList
+	for i, rule := range rules {
+		if err := hba.FromAPI(rule).Validate(); err != nil {
+			result = append(result, field.Invalid(path.Index(i), rule, err.Error()))
+		}
+
+		if rule.GetPosition() == apiv1.HBARulePositionBeforeOperatorRules {
+			result = append(result, validateHBARuleBeforeOperatorRules(path.Index(i), rule)...)
+		}
+	}
+
+	return result
+}
+
+// validateHBARuleBeforeOperatorRules checks that a rule preceding the
+// operator rules cannot shadow them, preventing the operator from
+// connecting to the instances with its reserved users
+func validateHBARuleBeforeOperatorRules(path *field.Path, rule apiv1.HBARule) field.ErrorList {
+	var result field.ErrorList
+
+	// The operator relies on local connections to manage the instances
+	if rule.Type == "local" {
+		result = append(result, field.Invalid(
+			path.Child("position"),
+			rule.Position,
+			"local rules cannot precede the operator rules"))
+	}
+
+	for _, user := range rule.Users {
+		switch {
+		case user == "all", strings.HasPrefix(user, "@"):
+			result = append(result, field.Invalid(
+				path.Child("users"),
+				user,
+				"rules preceding the operator rules must list their users explicitly"))
+		case postgres.IsRoleReserved(strings.TrimPrefix(user, "+")):
+			result = append(result, field.Invalid(
+				path.Child("users"),
+				user,
+				"rules preceding the operator rules cannot apply to the users reserved for the operator"))
+		}
+	}
+
+	return result
+}
@@ -34,6 +34,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/postgres/hba"
 )
 
 // AllowedPgbouncerGenericConfigurationParameters is the list of allowed parameters for PgBouncer
@@ -213,6 +214,24 @@ func (v *PoolerCustomValidator) validatePgBouncer(r *apiv1.Pooler) field.ErrorLi
 		result = append(result, v.validatePgbouncerGenericParameters(r)...)
 	}
 
+	if r.Spec.PgBouncer != nil && len(r.Spec.PgBouncer.PgHBARules) > 0 {
+		result = append(result, v.validatePgBouncerHBARules(r)...)
+	}
+
+	return result
+}
+
+// validatePgBouncerHBARules validates the structured pg_hba rules,
+// checking they only use the features supported by PgBouncer
+func (v *PoolerCustomValidator) validatePgBouncerHBARules(r *apiv1.Pooler) field.ErrorList {
+	path := field.NewPath("spec", "pgbouncer", "pg_hba_rules")
+	result := validateHBARules(path, r.Spec.PgBouncer.PgHBARules)
+	for i, rule := range r.Spec.PgBouncer.PgHBARules {
+		if err := hba.FromAPI(rule).ValidateForPgBouncer(); err != nil {
+			result = append(result, field.Invalid(path.Index(i), rule, err.Error()))
+		}
+	}
+
 	return result
 }
 
@@ -138,4 +138,34 @@ var _ = Describe("Pooler validation", func() {
 		}
 		Expect(v.validatePgbouncerGenericParameters(pooler)).To(BeEmpty())
 	})
+
+	It("validates the structured pg_hba rules against the features of PgBouncer", func() {
+		pooler := &apiv1.Pooler{
+			Spec: apiv1.PoolerSpec{
+				PgBouncer: &apiv1.PgBouncerSpec{
+					PgHBARules: []apiv1.HBARule{
+						{
+							Type:      "hostssl",
+							Databases: []string{"app"},
+							Users:     []string{"app"},
+							Address:   "10.0.0.0/8",
+							Method:    "scram-sha-256",
+						},
+						{
+							Type:      "hostgssenc",
+							Databases: []string{"all"},
+							Users:     []string{"all"},
+							Address:   "all",
+							Method:    "ldap",
+						},
+					},
+				},
+			},
+		}
+		errs := v.validatePgBouncer(pooler)
+		Expect(errs).To(HaveLen(1))
+		Expect(errs[0].Field).To(Equal("spec.pgbouncer.pg_hba_rules[1]"))
+		Expect(errs[0].Detail).To(ContainSubstring(`connection type "hostgssenc" not supported by PgBouncer`))
+		Expect(errs[0].Detail).To(ContainSubstring(`authentication method "ldap" not supported by PgBouncer`))
+	})
 })
@@ -189,5 +189,44 @@ func (v *ScheduledBackupCustomValidator) validate(r *apiv1.ScheduledBackup) (adm
 		))
 	}
 
+	if r.Spec.Method != apiv1.BackupMethodLogical && r.Spec.Logical != nil {
+		result = append(result, field.Invalid(
+			field.NewPath("spec", "logical"),
+			r.Spec.Logical,
+			"Logical parameter can be specified only if the method is logical",
+		))
+	}
+
+	if r.Spec.Method != apiv1.BackupMethodPersistentVolume && r.Spec.Incremental {
+		result = append(result, field.Invalid(
+			field.NewPath("spec", "incremental"),
+			r.Spec.Incremental,
+			"Incremental parameter can be specified only if the method is persistentVolume",
+		))
+	}
+
+	if r.Spec.Retention != nil && !isTierRetentionSupported(r) {
+		result = append(result, field.Invalid(
+			field.NewPath("spec", "retention"),
+			r.Spec.Retention,
+			"Retention parameter can be specified only if the method is volumeSnapshot, "+
+				"persistentVolume, or logical without a plugin, as the operator must be able "+
+				"to delete the data of the expired backups",
+		))
+	}
+
 	return warnings, result
 }
+
+// isTierRetentionSupported checks if the operator is able to delete the data
+// of the backups taken by a scheduled backup, which is needed to prune them
+func isTierRetentionSupported(r *apiv1.ScheduledBackup) bool {
+	switch r.Spec.Method {
+	case apiv1.BackupMethodVolumeSnapshot, apiv1.BackupMethodPersistentVolume:
+		return true
+	case apiv1.BackupMethodLogical:
+		return r.Spec.PluginConfiguration == nil
+	default:
+		return false
+	}
+}
@@ -126,4 +126,37 @@ var _ = Describe("Validate schedule", func() {
 		Expect(result).To(HaveLen(1))
 		Expect(result[0].Field).To(Equal("spec.onlineConfiguration"))
 	})
+
+	It("complains if incremental is set on a barman backup", func() {
+		scheduledBackup := &apiv1.ScheduledBackup{
+			Spec: apiv1.ScheduledBackupSpec{
+				Method:      apiv1.BackupMethodBarmanObjectStore,
+				Incremental: true,
+				Schedule:    "* * * * * *",
+			},
+		}
+		warnings, result := v.validate(scheduledBackup)
+		Expect(warnings).To(BeEmpty())
+		Expect(result).To(HaveLen(1))
+		Expect(result[0].Field).To(Equal("spec.incremental"))
+	})
+
+	It("complains if retention is set on a backup whose data can't be deleted", func() {
+		scheduledBackup := &apiv1.ScheduledBackup{
+			Spec: apiv1.ScheduledBackupSpec{
+				Method:              apiv1.BackupMethodLogical,
+				PluginConfiguration: &apiv1.BackupPluginConfiguration{Name: "plugin"},
+				Retention:           &apiv1.BackupTierRetention{Daily: 7},
+				Schedule:            "* * * * * *",
+			},
+		}
+		warnings, result := v.validate(scheduledBackup)
+		Expect(warnings).To(BeEmpty())
+		Expect(result).To(HaveLen(1))
+		Expect(result[0].Field).To(Equal("spec.retention"))
+
+		scheduledBackup.Spec.PluginConfiguration = nil
+		_, result = v.validate(scheduledBackup)
+		Expect(result).To(BeEmpty())
+	})
 })
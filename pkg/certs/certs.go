@@ -56,6 +56,10 @@ const (
 	// CAPrivateKeyKey is the key for the private key field in a CA secret
 	CAPrivateKeyKey = "ca.key"
 
+	// CANextPrivateKeyKey is the key for the private key of the new CA in a
+	// CA secret, while its rotation waits for the trust bundle to propagate
+	CANextPrivateKeyKey = "ca-next.key"
+
 	// TLSCertKey is the key for certificates in a CA secret
 	TLSCertKey = "tls.crt"
 
@@ -333,6 +337,28 @@ func (pair *KeyPair) IsExpiring() (bool, *time.Time, error) {
 	return false, &cert.NotAfter, nil
 }
 
+// GetRenewalTime gets the time when the certificate will be considered
+// expiring and will need to be renewed
+func (pair *KeyPair) GetRenewalTime() (time.Time, error) {
+	cert, err := pair.ParseCertificate()
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return cert.NotAfter.Add(-getCheckThreshold()), nil
+}
+
+// IsSignedBy checks if the certificate has been signed by the passed CA
+// certificate
+func (pair *KeyPair) IsSignedBy(caCertificate *x509.Certificate) (bool, error) {
+	cert, err := pair.ParseCertificate()
+	if err != nil {
+		return false, err
+	}
+
+	return cert.CheckSignatureFrom(caCertificate) == nil, nil
+}
+
 // DoAltDNSNamesMatch checks if the certificate has all of the specified altDNSNames
 func (pair *KeyPair) DoAltDNSNamesMatch(altDNSNames []string) (bool, error) {
 	cert, err := pair.ParseCertificate()
@@ -509,3 +535,11 @@ func getCheckThreshold() time.Duration {
 	}
 	return time.Duration(threshold) * 24 * time.Hour
 }
+
+func getCARotationGracePeriod() time.Duration {
+	gracePeriod := configuration.Current.CARotationGracePeriod
+	if gracePeriod <= 0 {
+		return configuration.CARotationGracePeriod * time.Hour
+	}
+	return time.Duration(gracePeriod) * time.Hour
+}
@@ -111,6 +111,16 @@ var _ = Describe("Keypair generation", func() {
 		Expect(isExpiring, err).To(BeFalse())
 	})
 
+	It("computes the renewal time using the expiration threshold", func() {
+		ca, err := CreateRootCA("test", "namespace")
+		Expect(err).ToNot(HaveOccurred())
+		cert, err := ca.ParseCertificate()
+		Expect(err).ToNot(HaveOccurred())
+		renewalTime, err := ca.GetRenewalTime()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(renewalTime).To(Equal(cert.NotAfter.Add(-getCheckThreshold())))
+	})
+
 	It("marks matching alt DNS names as matching", func() {
 		ca, err := CreateRootCA("test", "namespace")
 		Expect(err).ToNot(HaveOccurred())
@@ -105,22 +105,28 @@ func RenewLeafCertificate(caSecret *v1.Secret, secret *v1.Secret, altDNSNames []
 		return false, err
 	}
 
-	if !expiring && altDNSNamesMatch {
-		return false, nil
-	}
-
 	// Parse the CA secret to get the private key
 	caPair, err := ParseCASecret(caSecret)
 	if err != nil {
 		return false, err
 	}
 
-	caPrivateKey, err := caPair.ParseECPrivateKey()
+	caCertificate, err := caPair.ParseCertificate()
 	if err != nil {
 		return false, err
 	}
 
-	caCertificate, err := caPair.ParseCertificate()
+	// The leaf certificate needs to be reissued when the CA has been rotated
+	signedByCA, err := pair.IsSignedBy(caCertificate)
+	if err != nil {
+		return false, err
+	}
+
+	if !expiring && altDNSNamesMatch && signedByCA {
+		return false, nil
+	}
+
+	caPrivateKey, err := caPair.ParseECPrivateKey()
 	if err != nil {
 		return false, err
 	}
@@ -196,36 +202,20 @@ func (pki *PublicKeyInfrastructure) ensureRootCACertificate(
 	return secret, nil
 }
 
-// renewCACertificate renews a CA certificate if needed, returning the updated
-// secret if the secret has been renewed
+// renewCACertificate advances the staged rotation of a CA certificate,
+// returning the updated secret
 func renewCACertificate(ctx context.Context, kubeClient client.Client, secret *v1.Secret) (*v1.Secret, error) {
-	// Verify the temporal validity of this CA
-	pair, err := ParseCASecret(secret)
+	changed, err := ReconcileCARotation(secret)
 	if err != nil {
 		return nil, err
 	}
-
-	expiring, _, err := pair.IsExpiring()
-	if err != nil {
-		return nil, err
-	}
-	if !expiring {
+	if !changed {
 		return secret, nil
 	}
 
-	privateKey, err := pair.ParseECPrivateKey()
-	if err != nil {
-		return nil, err
-	}
-
-	err = pair.RenewCertificate(privateKey, nil, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	secret.Data[CACertKey] = pair.Certificate
-	err = kubeClient.Update(ctx, secret)
-	if err != nil {
+	phase, _ := GetCARotationPhase(secret)
+	pkiLog.Info("Updating CA certificate", "secret", secret.Name, "rotationPhase", phase)
+	if err := kubeClient.Update(ctx, secret); err != nil {
 		return nil, err
 	}
 
@@ -275,6 +265,12 @@ func (pki PublicKeyInfrastructure) setupWebhooksCertificate(
 		return nil, err
 	}
 
+	if MarkCALeavesReissued(caSecret) {
+		if err := kubeClient.Update(ctx, caSecret); err != nil {
+			return nil, err
+		}
+	}
+
 	if err := pki.injectPublicKeyIntoMutatingWebhook(
 		ctx,
 		kubeClient,
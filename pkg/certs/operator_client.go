@@ -0,0 +1,160 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package certs
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"slices"
+	"sync"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// OperatorClientCommonName is the common name of the client certificate
+// the operator uses to authenticate against the instance manager
+const OperatorClientCommonName = "cnpg-operator"
+
+// operatorClientCertificate is a client certificate issued to
+// the operator, together with the hash of the CA that issued it
+type operatorClientCertificate struct {
+	caHash      [sha256.Size]byte
+	certificate *tls.Certificate
+}
+
+// operatorClientCertificates caches the client certificates issued
+// to the operator, indexed by the CA secret
+var operatorClientCertificates = struct {
+	sync.Mutex
+	entries map[types.NamespacedName]operatorClientCertificate
+}{
+	entries: make(map[types.NamespacedName]operatorClientCertificate),
+}
+
+// NewOperatorClientTLSConfigForContext adds to the TLS configuration stored
+// in the context the client certificate the operator uses to authenticate
+// against the instance manager. The certificate is issued from the passed
+// CA secret, whose private key is required
+func NewOperatorClientTLSConfigForContext(
+	ctx context.Context,
+	cli client.Client,
+	caSecret types.NamespacedName,
+) (context.Context, error) {
+	conf, err := GetTLSConfigFromContext(ctx)
+	if err != nil {
+		return ctx, err
+	}
+
+	secret := &v1.Secret{}
+	if err := cli.Get(ctx, caSecret, secret); err != nil {
+		return ctx, fmt.Errorf("while getting caSecret %s: %w", caSecret.Name, err)
+	}
+
+	if !IsCAPrivateKeyAvailable(secret) {
+		return ctx, fmt.Errorf("missing %s in caSecret %s", CAPrivateKeyKey, caSecret.Name)
+	}
+
+	certificate, err := getOperatorClientCertificate(secret)
+	if err != nil {
+		return ctx, fmt.Errorf("while issuing the operator client certificate: %w", err)
+	}
+
+	conf = conf.Clone()
+	conf.Certificates = []tls.Certificate{*certificate}
+	return context.WithValue(ctx, contextKeyTLSConfig, conf), nil
+}
+
+// IsCAPrivateKeyAvailable checks if the passed CA secret contains the private
+// key of the CA, which is needed to issue certificates
+func IsCAPrivateKeyAvailable(caSecret *v1.Secret) bool {
+	_, ok := caSecret.Data[CAPrivateKeyKey]
+	return ok
+}
+
+// VerifyOperatorClientCertificate checks if the passed certificates chain
+// contains the client certificate issued to the operator by one of
+// the CAs in the passed pool
+func VerifyOperatorClientCertificate(chain []*x509.Certificate, roots *x509.CertPool) error {
+	if len(chain) == 0 {
+		return fmt.Errorf("no client certificate provided")
+	}
+
+	opts := x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: x509.NewCertPool(),
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	for _, cert := range chain[1:] {
+		opts.Intermediates.AddCert(cert)
+	}
+	if _, err := chain[0].Verify(opts); err != nil {
+		return fmt.Errorf("invalid client certificate: %w", err)
+	}
+
+	if chain[0].Subject.CommonName != OperatorClientCommonName {
+		return fmt.Errorf("client certificate not issued to the operator: %s", chain[0].Subject.CommonName)
+	}
+
+	return nil
+}
+
+// getOperatorClientCertificate gets the client certificate issued to the
+// operator from the passed CA secret, creating a new one when the cached
+// one is missing, expiring, or has been issued by a different CA
+func getOperatorClientCertificate(caSecret *v1.Secret) (*tls.Certificate, error) {
+	secretName := types.NamespacedName{Namespace: caSecret.Namespace, Name: caSecret.Name}
+	caHash := sha256.Sum256(append(slices.Clone(caSecret.Data[CACertKey]), caSecret.Data[CAPrivateKeyKey]...))
+
+	operatorClientCertificates.Lock()
+	defer operatorClientCertificates.Unlock()
+
+	if entry, ok := operatorClientCertificates.entries[secretName]; ok && entry.caHash == caHash {
+		pair := KeyPair{Certificate: encodeCertificate(entry.certificate.Certificate[0])}
+		if expiring, _, err := pair.IsExpiring(); err == nil && !expiring {
+			return entry.certificate, nil
+		}
+	}
+
+	caPair, err := ParseCASecret(caSecret)
+	if err != nil {
+		return nil, err
+	}
+
+	pair, err := caPair.CreateAndSignPair(OperatorClientCommonName, CertTypeClient, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	certificate, err := tls.X509KeyPair(pair.Certificate, pair.Private)
+	if err != nil {
+		return nil, err
+	}
+
+	operatorClientCertificates.entries[secretName] = operatorClientCertificate{
+		caHash:      caHash,
+		certificate: &certificate,
+	}
+	return &certificate, nil
+}
@@ -0,0 +1,133 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package certs
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("operator client certificate", func() {
+	var (
+		caPair   *KeyPair
+		caSecret *v1.Secret
+		caPool   *x509.CertPool
+	)
+
+	BeforeEach(func() {
+		var err error
+		caPair, err = CreateRootCA("cluster-example", "default")
+		Expect(err).ToNot(HaveOccurred())
+
+		caSecret = caPair.GenerateCASecret("default", "cluster-example-ca")
+		caPool = x509.NewCertPool()
+		Expect(caPool.AppendCertsFromPEM(caPair.Certificate)).To(BeTrue())
+	})
+
+	It("adds the operator client certificate to the TLS configuration", func(ctx context.Context) {
+		cli := fake.NewClientBuilder().WithObjects(caSecret).Build()
+		secretName := types.NamespacedName{Namespace: caSecret.Namespace, Name: caSecret.Name}
+
+		ctx, err := NewTLSConfigForContext(ctx, cli, secretName)
+		Expect(err).ToNot(HaveOccurred())
+		ctx, err = NewOperatorClientTLSConfigForContext(ctx, cli, secretName)
+		Expect(err).ToNot(HaveOccurred())
+
+		conf, err := GetTLSConfigFromContext(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(conf.Certificates).To(HaveLen(1))
+
+		chain, err := x509.ParseCertificate(conf.Certificates[0].Certificate[0])
+		Expect(err).ToNot(HaveOccurred())
+		Expect(VerifyOperatorClientCertificate([]*x509.Certificate{chain}, caPool)).To(Succeed())
+	})
+
+	It("fails when the CA private key is missing", func(ctx context.Context) {
+		delete(caSecret.Data, CAPrivateKeyKey)
+		Expect(IsCAPrivateKeyAvailable(caSecret)).To(BeFalse())
+
+		cli := fake.NewClientBuilder().WithObjects(caSecret).Build()
+		secretName := types.NamespacedName{Namespace: caSecret.Namespace, Name: caSecret.Name}
+
+		ctx, err := NewTLSConfigForContext(ctx, cli, secretName)
+		Expect(err).ToNot(HaveOccurred())
+		_, err = NewOperatorClientTLSConfigForContext(ctx, cli, secretName)
+		Expect(err).To(MatchError(ContainSubstring(CAPrivateKeyKey)))
+	})
+
+	It("reuses the issued certificate until the CA changes", func() {
+		first, err := getOperatorClientCertificate(caSecret)
+		Expect(err).ToNot(HaveOccurred())
+		second, err := getOperatorClientCertificate(caSecret)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(second).To(BeIdenticalTo(first))
+
+		newCAPair, err := CreateRootCA("cluster-example", "default")
+		Expect(err).ToNot(HaveOccurred())
+		caSecret.Data = newCAPair.GenerateCASecret("default", "cluster-example-ca").Data
+
+		third, err := getOperatorClientCertificate(caSecret)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(third).ToNot(BeIdenticalTo(first))
+	})
+
+	It("rejects missing certificates", func() {
+		Expect(VerifyOperatorClientCertificate(nil, caPool)).ToNot(Succeed())
+	})
+
+	It("rejects client certificates not issued to the operator", func() {
+		pair, err := caPair.CreateAndSignPair("app", CertTypeClient, nil)
+		Expect(err).ToNot(HaveOccurred())
+		certificate, err := pair.ParseCertificate()
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(VerifyOperatorClientCertificate([]*x509.Certificate{certificate}, caPool)).ToNot(Succeed())
+	})
+
+	It("rejects certificates issued by a different CA", func() {
+		otherCAPair, err := CreateRootCA("other", "default")
+		Expect(err).ToNot(HaveOccurred())
+		pair, err := otherCAPair.CreateAndSignPair(OperatorClientCommonName, CertTypeClient, nil)
+		Expect(err).ToNot(HaveOccurred())
+		certificate, err := tls.X509KeyPair(pair.Certificate, pair.Private)
+		Expect(err).ToNot(HaveOccurred())
+		leaf, err := x509.ParseCertificate(certificate.Certificate[0])
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(VerifyOperatorClientCertificate([]*x509.Certificate{leaf}, caPool)).ToNot(Succeed())
+	})
+
+	It("rejects server certificates", func() {
+		pair, err := caPair.CreateAndSignPair(OperatorClientCommonName, CertTypeServer, nil)
+		Expect(err).ToNot(HaveOccurred())
+		certificate, err := pair.ParseCertificate()
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(VerifyOperatorClientCertificate([]*x509.Certificate{certificate}, caPool)).ToNot(Succeed())
+	})
+})
@@ -0,0 +1,257 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package certs
+
+import (
+	"crypto/tls"
+	"encoding/pem"
+	"fmt"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+)
+
+// CARotationPhase is the phase of the staged rotation of a CA
+type CARotationPhase string
+
+const (
+	// CARotationPhaseTrustBundlePublished means that a new CA has been
+	// generated, and the CA secret contains a bundle made of the old and of
+	// the new CA certificates. The leaf certificates are still signed by the
+	// old CA, until the grace period for the bundle to propagate expires.
+	CARotationPhaseTrustBundlePublished CARotationPhase = "TrustBundlePublished"
+
+	// CARotationPhaseNewCAActive means that the new CA has replaced the old
+	// one in signing the certificates. The leaf certificates still need to
+	// be reissued.
+	CARotationPhaseNewCAActive CARotationPhase = "NewCAActive"
+
+	// CARotationPhaseLeavesReissued means that the leaf certificates have
+	// been signed by the new CA. The old CA certificate will be removed
+	// from the bundle when the grace period expires.
+	CARotationPhaseLeavesReissued CARotationPhase = "LeavesReissued"
+)
+
+// GetCARotationPhase gets the phase of the rotation of the CA contained in
+// the passed secret, and the time when that phase has been entered. The
+// phase is empty when no rotation is in progress
+func GetCARotationPhase(secret *v1.Secret) (CARotationPhase, time.Time) {
+	phase := CARotationPhase(secret.Annotations[utils.CARotationPhaseAnnotationName])
+	if phase == "" {
+		return "", time.Time{}
+	}
+
+	// A missing or invalid timestamp is considered as already expired
+	since, _ := time.Parse(time.RFC3339, secret.Annotations[utils.CARotationTimestampAnnotationName])
+	return phase, since
+}
+
+// ReconcileCARotation advances the staged rotation of the CA contained in
+// the passed secret, which needs to include the CA private key:
+//
+//  1. when the CA certificate is expiring, a new CA is generated and
+//     published together with the old one, which keeps signing the
+//     certificates
+//  2. when the grace period expires, the bundle is considered propagated
+//     and the new CA starts signing the certificates. This happens
+//     immediately if the old CA has already expired
+//  3. the leaf certificates are reissued by the new CA, and the caller
+//     records that via MarkCALeavesReissued
+//  4. when the grace period expires, the old CA certificate is removed
+//
+// The secret is changed in place, and true is returned when it needs to be
+// stored
+func ReconcileCARotation(secret *v1.Secret) (bool, error) {
+	phase, since := GetCARotationPhase(secret)
+	switch phase {
+	case "":
+		started, err := startCARotation(secret)
+		if err != nil || !started {
+			return started, err
+		}
+
+		propagated, err := isTrustBundlePropagated(secret, time.Now())
+		if err != nil || !propagated {
+			return true, err
+		}
+		return activateNewCA(secret)
+
+	case CARotationPhaseTrustBundlePublished:
+		propagated, err := isTrustBundlePropagated(secret, since)
+		if err != nil || !propagated {
+			return false, err
+		}
+		return activateNewCA(secret)
+
+	case CARotationPhaseLeavesReissued:
+		if time.Now().Before(since.Add(getCARotationGracePeriod())) {
+			return false, nil
+		}
+
+		newCACertificate, _, err := splitFirstCertificate(secret.Data[CACertKey])
+		if err != nil {
+			return false, err
+		}
+		secret.Data[CACertKey] = newCACertificate
+		delete(secret.Annotations, utils.CARotationPhaseAnnotationName)
+		delete(secret.Annotations, utils.CARotationTimestampAnnotationName)
+		return true, nil
+
+	default:
+		return false, nil
+	}
+}
+
+// MarkCALeavesReissued records in the passed CA secret that every leaf
+// certificate has been signed by the new CA, returning true when the
+// secret needs to be stored
+func MarkCALeavesReissued(secret *v1.Secret) bool {
+	if phase, _ := GetCARotationPhase(secret); phase != CARotationPhaseNewCAActive {
+		return false
+	}
+
+	setCARotationPhase(secret, CARotationPhaseLeavesReissued)
+	return true
+}
+
+// startCARotation publishes a new CA in the bundle of the passed secret when
+// its CA is expiring. The old CA keeps signing the certificates, and the
+// private key of the new one is stored aside
+func startCARotation(secret *v1.Secret) (bool, error) {
+	pair, err := ParseCASecret(secret)
+	if err != nil {
+		return false, err
+	}
+
+	expiring, _, err := pair.IsExpiring()
+	if err != nil {
+		return false, err
+	}
+	if !expiring {
+		return false, nil
+	}
+
+	oldCertificate, err := pair.ParseCertificate()
+	if err != nil {
+		return false, err
+	}
+
+	var organizationalUnit string
+	if len(oldCertificate.Subject.OrganizationalUnit) > 0 {
+		organizationalUnit = oldCertificate.Subject.OrganizationalUnit[0]
+	}
+	newPair, err := CreateRootCA(oldCertificate.Subject.CommonName, organizationalUnit)
+	if err != nil {
+		return false, err
+	}
+
+	oldCACertificate, _, err := splitFirstCertificate(pair.Certificate)
+	if err != nil {
+		return false, err
+	}
+
+	// The old CA certificate stays the first one of the bundle, as it
+	// is the one matching the private key
+	secret.Data[CACertKey] = joinCertificates(oldCACertificate, newPair.Certificate)
+	secret.Data[CANextPrivateKeyKey] = newPair.Private
+	setCARotationPhase(secret, CARotationPhaseTrustBundlePublished)
+	return true, nil
+}
+
+// isTrustBundlePropagated checks if the trust bundle of the passed secret,
+// published at the passed time, is considered propagated. There is no point
+// in waiting when the old CA, which is still signing the certificates,
+// has already expired
+func isTrustBundlePropagated(secret *v1.Secret, publishedAt time.Time) (bool, error) {
+	if !time.Now().Before(publishedAt.Add(getCARotationGracePeriod())) {
+		return true, nil
+	}
+
+	oldCACertificate, _, err := splitFirstCertificate(secret.Data[CACertKey])
+	if err != nil {
+		return false, err
+	}
+
+	oldCA, err := (&KeyPair{Certificate: oldCACertificate}).ParseCertificate()
+	if err != nil {
+		return false, err
+	}
+
+	return time.Now().After(oldCA.NotAfter), nil
+}
+
+// activateNewCA makes the new CA published in the bundle of the passed
+// secret the one signing the certificates
+func activateNewCA(secret *v1.Secret) (bool, error) {
+	newPrivateKey, ok := secret.Data[CANextPrivateKeyKey]
+	if !ok {
+		return false, fmt.Errorf("missing %s secret data", CANextPrivateKeyKey)
+	}
+
+	oldCACertificate, rest, err := splitFirstCertificate(secret.Data[CACertKey])
+	if err != nil {
+		return false, err
+	}
+	newCACertificate, _, err := splitFirstCertificate(rest)
+	if err != nil {
+		return false, err
+	}
+
+	// The new CA certificate must be the first one of the bundle, as it
+	// is the one matching the private key
+	bundle := joinCertificates(newCACertificate, oldCACertificate)
+	if _, err := tls.X509KeyPair(bundle, newPrivateKey); err != nil {
+		return false, err
+	}
+
+	secret.Data[CAPrivateKeyKey] = newPrivateKey
+	secret.Data[CACertKey] = bundle
+	delete(secret.Data, CANextPrivateKeyKey)
+	setCARotationPhase(secret, CARotationPhaseNewCAActive)
+	return true, nil
+}
+
+func setCARotationPhase(secret *v1.Secret, phase CARotationPhase) {
+	if secret.Annotations == nil {
+		secret.Annotations = make(map[string]string)
+	}
+	secret.Annotations[utils.CARotationPhaseAnnotationName] = string(phase)
+	secret.Annotations[utils.CARotationTimestampAnnotationName] = time.Now().Format(time.RFC3339)
+}
+
+// splitFirstCertificate extracts the first certificate from a PEM bundle,
+// returning it together with the rest of the bundle
+func splitFirstCertificate(bundle []byte) ([]byte, []byte, error) {
+	block, rest := pem.Decode(bundle)
+	if block == nil || block.Type != certificatePEMBlockType {
+		return nil, nil, fmt.Errorf("invalid public key PEM block type")
+	}
+
+	return pem.EncodeToMemory(block), rest, nil
+}
+
+// joinCertificates builds a PEM bundle from the passed certificates
+func joinCertificates(first, second []byte) []byte {
+	bundle := make([]byte, 0, len(first)+len(second))
+	bundle = append(bundle, first...)
+	return append(bundle, second...)
+}
@@ -0,0 +1,174 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package certs
+
+import (
+	"crypto/x509"
+	"time"
+
+	"github.com/cloudnative-pg/cloudnative-pg/internal/configuration"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Staged CA rotation", func() {
+	BeforeEach(func() {
+		configuration.Current = configuration.NewConfiguration()
+	})
+
+	It("doesn't rotate a valid CA", func() {
+		ca, err := CreateRootCA("root", "namespace")
+		Expect(err).ToNot(HaveOccurred())
+
+		secret := ca.GenerateCASecret("namespace", "ca")
+		changed, err := ReconcileCARotation(secret)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(changed).To(BeFalse())
+		Expect(secret.Annotations).To(BeEmpty())
+	})
+
+	It("publishes a trust bundle, waits for it to propagate, reissues the leaves and drops the old CA", func() {
+		notAfter := time.Now().Add(time.Hour)
+		notBefore := notAfter.Add(-90 * 24 * time.Hour)
+		oldCA, err := createCAWithValidity(notBefore, notAfter, nil, nil, "root", "namespace")
+		Expect(err).ToNot(HaveOccurred())
+
+		oldLeaf, err := oldCA.CreateAndSignPair("server", CertTypeServer, nil)
+		Expect(err).ToNot(HaveOccurred())
+		leafSecret := oldLeaf.GenerateCertificateSecret("namespace", "server")
+		secret := oldCA.GenerateCASecret("namespace", "ca")
+
+		expireCARotationPhase := func() {
+			secret.Annotations[utils.CARotationTimestampAnnotationName] =
+				time.Now().Add(-getCARotationGracePeriod() - time.Minute).Format(time.RFC3339)
+		}
+
+		By("publishing the trust bundle", func() {
+			changed, err := ReconcileCARotation(secret)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(changed).To(BeTrue())
+
+			phase, since := GetCARotationPhase(secret)
+			Expect(phase).To(Equal(CARotationPhaseTrustBundlePublished))
+			Expect(since).To(BeTemporally("~", time.Now(), time.Minute))
+			Expect(secret.Data[CAPrivateKeyKey]).To(Equal(oldCA.Private))
+			Expect(secret.Data).To(HaveKey(CANextPrivateKeyKey))
+
+			opts := &x509.VerifyOptions{KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}}
+			Expect(oldLeaf.IsValid(&KeyPair{Certificate: secret.Data[CACertKey]}, opts)).To(Succeed())
+		})
+
+		By("keeping the leaves signed by the old CA while the bundle propagates", func() {
+			_, err := RenewLeafCertificate(secret, leafSecret, nil)
+			Expect(err).ToNot(HaveOccurred())
+			leaf, err := ParseServerSecret(leafSecret)
+			Expect(err).ToNot(HaveOccurred())
+			oldCACertificate, err := oldCA.ParseCertificate()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(leaf.IsSignedBy(oldCACertificate)).To(BeTrue())
+			Expect(MarkCALeavesReissued(secret)).To(BeFalse())
+
+			changed, err := ReconcileCARotation(secret)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(changed).To(BeFalse())
+		})
+
+		By("activating the new CA when the grace period expires", func() {
+			newPrivateKey := secret.Data[CANextPrivateKeyKey]
+			expireCARotationPhase()
+
+			changed, err := ReconcileCARotation(secret)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(changed).To(BeTrue())
+
+			phase, _ := GetCARotationPhase(secret)
+			Expect(phase).To(Equal(CARotationPhaseNewCAActive))
+			Expect(secret.Data[CAPrivateKeyKey]).To(Equal(newPrivateKey))
+			Expect(secret.Data).ToNot(HaveKey(CANextPrivateKeyKey))
+			_, err = ParseCASecret(secret)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		var newLeaf *KeyPair
+		By("reissuing the leaves with the new CA", func() {
+			renewed, err := RenewLeafCertificate(secret, leafSecret, nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(renewed).To(BeTrue())
+
+			newLeaf, err = ParseServerSecret(leafSecret)
+			Expect(err).ToNot(HaveOccurred())
+			opts := &x509.VerifyOptions{KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}}
+			Expect(newLeaf.IsValid(&KeyPair{Certificate: secret.Data[CACertKey]}, opts)).To(Succeed())
+			opts = &x509.VerifyOptions{KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}}
+			Expect(oldLeaf.IsValid(&KeyPair{Certificate: secret.Data[CACertKey]}, opts)).To(Succeed())
+		})
+
+		By("recording the reissue of the leaves", func() {
+			Expect(MarkCALeavesReissued(secret)).To(BeTrue())
+			Expect(MarkCALeavesReissued(secret)).To(BeFalse())
+			phase, _ := GetCARotationPhase(secret)
+			Expect(phase).To(Equal(CARotationPhaseLeavesReissued))
+
+			changed, err := ReconcileCARotation(secret)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(changed).To(BeFalse())
+		})
+
+		By("dropping the old CA when the grace period expires", func() {
+			expireCARotationPhase()
+
+			changed, err := ReconcileCARotation(secret)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(changed).To(BeTrue())
+			Expect(secret.Annotations).ToNot(HaveKey(utils.CARotationPhaseAnnotationName))
+
+			caPair, err := ParseCASecret(secret)
+			Expect(err).ToNot(HaveOccurred())
+			opts := &x509.VerifyOptions{KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}}
+			Expect(newLeaf.IsValid(caPair, opts)).To(Succeed())
+			opts = &x509.VerifyOptions{KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}}
+			Expect(oldLeaf.IsValid(caPair, opts)).ToNot(Succeed())
+		})
+	})
+
+	It("activates the new CA immediately when the old one has already expired", func() {
+		notAfter := time.Now().Add(-time.Hour)
+		notBefore := notAfter.Add(-90 * 24 * time.Hour)
+		oldCA, err := createCAWithValidity(notBefore, notAfter, nil, nil, "root", "namespace")
+		Expect(err).ToNot(HaveOccurred())
+
+		secret := oldCA.GenerateCASecret("namespace", "ca")
+		changed, err := ReconcileCARotation(secret)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(changed).To(BeTrue())
+
+		phase, _ := GetCARotationPhase(secret)
+		Expect(phase).To(Equal(CARotationPhaseNewCAActive))
+		Expect(secret.Data[CAPrivateKeyKey]).ToNot(Equal(oldCA.Private))
+		Expect(secret.Data).ToNot(HaveKey(CANextPrivateKeyKey))
+	})
+
+	It("returns the default grace period if the configuration is a negative value", func() {
+		configuration.Current.CARotationGracePeriod = -1
+		Expect(getCARotationGracePeriod()).To(Equal(configuration.CARotationGracePeriod * time.Hour))
+	})
+})
@@ -28,6 +28,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
 
+	pluginClient "github.com/cloudnative-pg/cloudnative-pg/internal/cnpi/plugin/client"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/external/internal/pgpass"
 )
 
@@ -52,13 +53,12 @@ func getExternalSecretsPath() string {
 
 // readSecretKeyRef reads the passed secret selector into a string.
 // This function is mainly useful to get a PostgreSQL's role password
-// from a Kubernetes secret
+// from a Kubernetes secret, or from the plugin supplying its content
 func readSecretKeyRef(
 	ctx context.Context, client ctrl.Client,
 	namespace string, selector *corev1.SecretKeySelector,
 ) (string, error) {
-	var secret corev1.Secret
-	err := client.Get(ctx, ctrl.ObjectKey{Namespace: namespace, Name: selector.Name}, &secret)
+	secret, err := pluginClient.GetSecret(ctx, client, ctrl.ObjectKey{Namespace: namespace, Name: selector.Name})
 	if err != nil {
 		return "", err
 	}
@@ -92,9 +92,7 @@ func dumpSecretKeyRefToFile(
 	ctx context.Context, client ctrl.Client,
 	namespace string, serverName string, selector *corev1.SecretKeySelector,
 ) (string, error) {
-	var secret corev1.Secret
-
-	err := client.Get(ctx, ctrl.ObjectKey{Namespace: namespace, Name: selector.Name}, &secret)
+	secret, err := pluginClient.GetSecret(ctx, client, ctrl.ObjectKey{Namespace: namespace, Name: selector.Name})
 	if err != nil {
 		return "", err
 	}
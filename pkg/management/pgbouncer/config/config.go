@@ -31,6 +31,7 @@ import (
 	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/certs"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/postgres/hba"
 )
 
 const (
@@ -84,7 +85,8 @@ auth_query = {{ .AuthQuery }}
 {{ .Parameters -}}
 `
 	pgbouncerHBAFileTemplateString = `
-local pgbouncer pgbouncer peer
+{{ range $rule := .PrecedingPgHba }}{{ $rule }}
+{{ end }}local pgbouncer pgbouncer peer
 
 {{ range $rule := .PgHba }}
 {{ $rule -}}
@@ -185,12 +187,14 @@ func BuildConfigurationFiles(pooler *apiv1.Pooler, secrets *Secrets) (Configurat
 		parameters["auth_file"] = authFilePath
 	}
 
+	pgHBARules := pooler.Spec.PgBouncer.PgHBARules
 	templateData := struct {
 		Pooler            *apiv1.Pooler
 		AuthQuery         string
 		AuthQueryUser     string
 		AuthQueryPassword string
 		Parameters        string
+		PrecedingPgHba    []string
 		PgHba             []string
 	}{
 		Pooler:            pooler,
@@ -204,8 +208,12 @@ func BuildConfigurationFiles(pooler *apiv1.Pooler, secrets *Secrets) (Configurat
 		//
 		// Also, we want the list of parameters inside the PgBouncer configuration
 		// to be stable.
-		Parameters: stringifyPgBouncerParameters(parameters),
-		PgHba:      pooler.Spec.PgBouncer.PgHBA,
+		Parameters:     stringifyPgBouncerParameters(parameters),
+		PrecedingPgHba: hba.Render(pgHBARules, apiv1.HBARulePositionBeforeOperatorRules),
+		// The structured rules following the operator rules precede the raw ones
+		PgHba: append(
+			hba.Render(pgHBARules, apiv1.HBARulePositionAfterOperatorRules),
+			pooler.Spec.PgBouncer.PgHBA...),
 	}
 
 	err = pgBouncerIniTemplate.Execute(&pgbouncerIni, templateData)
@@ -158,6 +158,14 @@ func internalRun(
 		return err
 	}
 
+	// The backup volume keeps its own WAL archive, regardless of any
+	// other archiving method in use
+	if cluster.GetPersistentVolumeBackupClaimName() != "" {
+		if err := archiveWALToVolume(ctx, pgData, cluster, walName); err != nil {
+			return err
+		}
+	}
+
 	// If the used chosen a plugin to do WAL archiving, we don't
 	// trigger the legacy archiving process.
 	if cluster.GetEnabledWALArchivePluginName() != "" {
@@ -0,0 +1,164 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package archiver
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/cloudnative-pg/machinery/pkg/fileutils"
+	"github.com/cloudnative-pg/machinery/pkg/log"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/specs"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+)
+
+// getVolumeWALArchiveDirectory gets the directory where the WAL files
+// of the cluster are archived in the backup volume
+func getVolumeWALArchiveDirectory(cluster *apiv1.Cluster) string {
+	return path.Join(specs.PersistentVolumeBackupPath, cluster.Name, specs.PersistentVolumeWALArchiveDirectory)
+}
+
+// archiveWALToVolume copies a WAL file into the WAL archive of the
+// cluster in the backup volume
+func archiveWALToVolume(
+	ctx context.Context,
+	pgData string,
+	cluster *apiv1.Cluster,
+	walName string,
+) error {
+	contextLogger := log.FromContext(ctx)
+
+	if !filepath.IsAbs(walName) {
+		walName = filepath.Join(pgData, walName)
+	}
+
+	archiveDirectory := getVolumeWALArchiveDirectory(cluster)
+	if err := fileutils.EnsureDirectoryExists(archiveDirectory); err != nil {
+		return fmt.Errorf("while creating the WAL archive directory: %w", err)
+	}
+
+	if utils.IsEmptyWalArchiveCheckEnabled(&cluster.ObjectMeta) && isCheckWalArchiveFlagFilePresent(ctx, pgData) {
+		if err := checkVolumeWALArchiveIsEmpty(archiveDirectory); err != nil {
+			return err
+		}
+	}
+
+	if err := copyWALFile(walName, path.Join(archiveDirectory, path.Base(walName))); err != nil {
+		return err
+	}
+
+	contextLogger.Info("Archived WAL file to the backup volume",
+		"walName", walName,
+		"currentPrimary", cluster.Status.CurrentPrimary,
+		"targetPrimary", cluster.Status.TargetPrimary)
+	return nil
+}
+
+// ListVolumeWALArchive lists the names of the files in the WAL archive
+// of the cluster in the backup volume
+func ListVolumeWALArchive(cluster *apiv1.Cluster) ([]string, error) {
+	return listWALArchiveDirectory(getVolumeWALArchiveDirectory(cluster))
+}
+
+// listWALArchiveDirectory lists the names of the regular files in a WAL
+// archive directory. A WAL archive which doesn't exist yet is empty
+func listWALArchiveDirectory(archiveDirectory string) ([]string, error) {
+	entries, err := os.ReadDir(archiveDirectory)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("while listing the WAL archive directory: %w", err)
+	}
+
+	result := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if entry.Type().IsRegular() {
+			result = append(result, entry.Name())
+		}
+	}
+	return result, nil
+}
+
+// checkVolumeWALArchiveIsEmpty ensures that the WAL archive directory
+// doesn't contain the WAL files of a different cluster with the same name
+func checkVolumeWALArchiveIsEmpty(archiveDirectory string) error {
+	entries, err := os.ReadDir(archiveDirectory)
+	if err != nil {
+		return fmt.Errorf("while checking the WAL archive directory: %w", err)
+	}
+	if len(entries) > 0 {
+		return fmt.Errorf("the WAL archive directory %s in the backup volume is not empty", archiveDirectory)
+	}
+	return nil
+}
+
+// copyWALFile copies a WAL file in the archive, making sure the content
+// is durable before it becomes visible under its final name. A WAL file
+// that has already been archived with the same content is not an error,
+// as PostgreSQL may retry archiving after a crash
+func copyWALFile(source, destination string) error {
+	content, err := os.ReadFile(source) // #nosec G304
+	if err != nil {
+		return fmt.Errorf("while reading the WAL file: %w", err)
+	}
+
+	existingContent, err := os.ReadFile(destination) // #nosec G304
+	switch {
+	case err == nil && bytes.Equal(content, existingContent):
+		return nil
+	case err == nil:
+		return fmt.Errorf("the WAL file %s is already archived with a different content", path.Base(destination))
+	case !os.IsNotExist(err):
+		return fmt.Errorf("while reading the archived WAL file: %w", err)
+	}
+
+	temporaryFile, err := os.CreateTemp(path.Dir(destination), path.Base(destination)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("while creating the temporary WAL file: %w", err)
+	}
+	defer func() {
+		_ = os.Remove(temporaryFile.Name())
+	}()
+
+	if _, err := temporaryFile.Write(content); err != nil {
+		_ = temporaryFile.Close()
+		return fmt.Errorf("while writing the temporary WAL file: %w", err)
+	}
+	if err := temporaryFile.Sync(); err != nil {
+		_ = temporaryFile.Close()
+		return fmt.Errorf("while syncing the temporary WAL file: %w", err)
+	}
+	if err := temporaryFile.Close(); err != nil {
+		return fmt.Errorf("while closing the temporary WAL file: %w", err)
+	}
+
+	if err := os.Rename(temporaryFile.Name(), destination); err != nil {
+		return fmt.Errorf("while archiving the WAL file: %w", err)
+	}
+
+	return nil
+}
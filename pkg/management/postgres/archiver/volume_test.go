@@ -0,0 +1,79 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package archiver
+
+import (
+	"os"
+	"path"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("WAL archiving to the backup volume", func() {
+	var (
+		tempDir     string
+		source      string
+		destination string
+	)
+
+	BeforeEach(func() {
+		tempDir = GinkgoT().TempDir()
+		source = path.Join(tempDir, "000000010000000000000001")
+		destination = path.Join(tempDir, "archive", "000000010000000000000001")
+		Expect(os.WriteFile(source, []byte("wal content"), 0o600)).To(Succeed())
+		Expect(os.Mkdir(path.Join(tempDir, "archive"), 0o700)).To(Succeed())
+	})
+
+	It("copies the WAL file in the archive", func() {
+		Expect(copyWALFile(source, destination)).To(Succeed())
+		Expect(os.ReadFile(destination)).To(BeEquivalentTo("wal content"))
+
+		entries, err := os.ReadDir(path.Join(tempDir, "archive"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(entries).To(HaveLen(1))
+	})
+
+	It("accepts a WAL file that has already been archived", func() {
+		Expect(copyWALFile(source, destination)).To(Succeed())
+		Expect(copyWALFile(source, destination)).To(Succeed())
+	})
+
+	It("refuses to overwrite a different WAL file", func() {
+		Expect(os.WriteFile(destination, []byte("other content"), 0o600)).To(Succeed())
+		Expect(copyWALFile(source, destination)).ToNot(Succeed())
+		Expect(os.ReadFile(destination)).To(BeEquivalentTo("other content"))
+	})
+
+	It("checks that the archive is empty", func() {
+		Expect(checkVolumeWALArchiveIsEmpty(path.Join(tempDir, "archive"))).To(Succeed())
+		Expect(copyWALFile(source, destination)).To(Succeed())
+		Expect(checkVolumeWALArchiveIsEmpty(path.Join(tempDir, "archive"))).ToNot(Succeed())
+	})
+
+	It("lists the files in the archive", func() {
+		Expect(listWALArchiveDirectory(path.Join(tempDir, "missing"))).To(BeEmpty())
+
+		Expect(copyWALFile(source, destination)).To(Succeed())
+		Expect(os.Mkdir(path.Join(tempDir, "archive", "subdirectory"), 0o700)).To(Succeed())
+		Expect(listWALArchiveDirectory(path.Join(tempDir, "archive"))).
+			To(ConsistOf("000000010000000000000001"))
+	})
+})
@@ -0,0 +1,263 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"strconv"
+
+	"github.com/cloudnative-pg/machinery/pkg/execlog"
+	"github.com/cloudnative-pg/machinery/pkg/fileutils"
+	"github.com/cloudnative-pg/machinery/pkg/log"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+)
+
+// BackupManifestFile is the name of the manifest that pg_basebackup
+// writes in the root of each base backup
+const BackupManifestFile = "backup_manifest"
+
+const (
+	// baseBackupTarFile is the tar file containing the data directory
+	// in a tar format base backup
+	baseBackupTarFile = "base.tar"
+
+	// walTarFile is the tar file containing the streamed WAL files
+	// in a tar format base backup
+	walTarFile = "pg_wal.tar"
+)
+
+// BaseBackupOptions contains the options of a base backup taken
+// with pg_basebackup
+type BaseBackupOptions struct {
+	// The directory where the base backup is written
+	Destination string
+
+	// The directory containing the backup this incremental backup
+	// is based on. Empty for full backups
+	ParentDirectory string
+
+	// The application name used to connect to the instance
+	ApplicationName string
+
+	// The format of the base backup, plain when empty
+	Format apiv1.BaseBackupFormat
+}
+
+// BaseBackupDirectory is a directory containing a base backup
+type BaseBackupDirectory struct {
+	// The directory containing the base backup
+	Path string
+
+	// The format of the base backup, plain when empty
+	Format apiv1.BaseBackupFormat
+}
+
+// BackupManifestWALRange is a range of WAL needed to restore a base backup,
+// as reported by its manifest
+type BackupManifestWALRange struct {
+	Timeline int    `json:"Timeline"`
+	StartLSN string `json:"Start-LSN"`
+	EndLSN   string `json:"End-LSN"`
+}
+
+// TakeBaseBackup takes a base backup of the local instance with
+// pg_basebackup, streaming the WAL files needed to make it consistent.
+// When a parent directory is specified, an incremental backup is taken
+// against its manifest
+func TakeBaseBackup(ctx context.Context, options BaseBackupOptions) error {
+	contextLogger := log.FromContext(ctx)
+
+	// pg_basebackup refuses to write into a non-empty directory, and what
+	// we find here is the leftover of an interrupted attempt
+	if err := os.RemoveAll(options.Destination); err != nil {
+		return fmt.Errorf("while removing the stale base backup: %w", err)
+	}
+	if err := fileutils.EnsureParentDirectoryExists(options.Destination); err != nil {
+		return err
+	}
+
+	formatOption := "-Fp"
+	if options.Format == apiv1.BaseBackupFormatTar {
+		formatOption = "-Ft"
+	}
+
+	pgBaseBackupOptions := []string{
+		"-D", options.Destination,
+		formatOption,
+		"-X", "stream",
+		"-v",
+		"-w",
+		"-d", buildPrimaryConnInfo("localhost", options.ApplicationName),
+	}
+	if options.ParentDirectory != "" {
+		pgBaseBackupOptions = append(pgBaseBackupOptions,
+			"--incremental", path.Join(options.ParentDirectory, BackupManifestFile))
+	}
+
+	contextLogger.Info("Running pg_basebackup",
+		"destination", options.Destination,
+		"format", options.Format,
+		"parentDirectory", options.ParentDirectory)
+	pgBaseBackupCmd := exec.Command(pgBaseBackupName, pgBaseBackupOptions...) // #nosec
+	if err := execlog.RunStreaming(pgBaseBackupCmd, pgBaseBackupName); err != nil {
+		return fmt.Errorf("error in pg_basebackup, %w", err)
+	}
+
+	return nil
+}
+
+// ReadBackupManifestWALRanges reads the ranges of WAL needed to restore
+// the base backup contained in the passed directory
+func ReadBackupManifestWALRanges(directory string) ([]BackupManifestWALRange, error) {
+	content, err := fileutils.ReadFile(path.Join(directory, BackupManifestFile))
+	if err != nil {
+		return nil, err
+	}
+
+	var manifest struct {
+		WALRanges []BackupManifestWALRange `json:"WAL-Ranges"`
+	}
+	if err := json.Unmarshal(content, &manifest); err != nil {
+		return nil, fmt.Errorf("while parsing the backup manifest: %w", err)
+	}
+	if len(manifest.WALRanges) == 0 {
+		return nil, fmt.Errorf("no WAL ranges in the backup manifest of %s", directory)
+	}
+
+	return manifest.WALRanges, nil
+}
+
+// CombineBaseBackups reconstructs a full data directory from a chain of
+// base backups, ordered from the full backup to the last incremental one.
+// A chain made only of the full backup is copied or extracted as is, while
+// tar format backups belonging to a longer chain are extracted in the
+// staging directory before being combined
+func CombineBaseBackups(
+	ctx context.Context,
+	backups []BaseBackupDirectory,
+	pgData string,
+	stagingDirectory string,
+) error {
+	contextLogger := log.FromContext(ctx)
+
+	if len(backups) == 0 {
+		return fmt.Errorf("no base backup to restore")
+	}
+
+	if len(backups) == 1 {
+		if err := restoreBaseBackup(ctx, backups[0], pgData); err != nil {
+			return err
+		}
+		return fileutils.EnsurePgDataPerms(pgData)
+	}
+
+	defer func() {
+		if err := os.RemoveAll(stagingDirectory); err != nil {
+			contextLogger.Error(err, "while removing the staging directory", "directory", stagingDirectory)
+		}
+	}()
+
+	options := make([]string, 0, len(backups)+2)
+	options = append(options, "-o", pgData)
+	for idx, backup := range backups {
+		if backup.Format != apiv1.BaseBackupFormatTar {
+			options = append(options, backup.Path)
+			continue
+		}
+
+		directory := path.Join(stagingDirectory, strconv.Itoa(idx))
+		if err := extractTarBaseBackup(ctx, backup.Path, directory); err != nil {
+			return err
+		}
+		options = append(options, directory)
+	}
+
+	contextLogger.Info("Running pg_combinebackup", "options", options)
+	pgCombineBackupCmd := exec.Command(pgCombineBackup, options...) // #nosec
+	if err := execlog.RunStreaming(pgCombineBackupCmd, pgCombineBackup); err != nil {
+		return fmt.Errorf("error in pg_combinebackup, %w", err)
+	}
+
+	return nil
+}
+
+// restoreBaseBackup copies or extracts a full base backup into the
+// destination directory
+func restoreBaseBackup(ctx context.Context, backup BaseBackupDirectory, destination string) error {
+	if backup.Format == apiv1.BaseBackupFormatTar {
+		return extractTarBaseBackup(ctx, backup.Path, destination)
+	}
+
+	log.FromContext(ctx).Info("Copying the base backup", "directory", backup.Path)
+	if err := fileutils.EnsureDirectoryExists(destination); err != nil {
+		return err
+	}
+	// #nosec G204
+	if err := exec.Command("cp", "-a", backup.Path+"/.", destination).Run(); err != nil {
+		return fmt.Errorf("while copying the base backup: %w", err)
+	}
+	return nil
+}
+
+// extractTarBaseBackup extracts a tar format base backup into the
+// destination directory, together with its WAL files and its manifest,
+// so that the result has the same layout of a plain format one
+func extractTarBaseBackup(ctx context.Context, source, destination string) error {
+	log.FromContext(ctx).Info("Extracting the base backup", "directory", source)
+
+	if err := fileutils.EnsureDirectoryExists(destination); err != nil {
+		return err
+	}
+	// #nosec G204
+	if err := exec.Command("tar", "-xf", path.Join(source, baseBackupTarFile), "-C", destination).Run(); err != nil {
+		return fmt.Errorf("while extracting the base backup: %w", err)
+	}
+
+	walArchive := path.Join(source, walTarFile)
+	walArchiveExists, err := fileutils.FileExists(walArchive)
+	if err != nil {
+		return err
+	}
+	if walArchiveExists {
+		walDirectory := path.Join(destination, pgWalDirectory)
+		if err := fileutils.EnsureDirectoryExists(walDirectory); err != nil {
+			return err
+		}
+		// #nosec G204
+		if err := exec.Command("tar", "-xf", walArchive, "-C", walDirectory).Run(); err != nil {
+			return fmt.Errorf("while extracting the WAL files of the base backup: %w", err)
+		}
+	}
+
+	if err := fileutils.CopyFile(
+		path.Join(source, BackupManifestFile),
+		path.Join(destination, BackupManifestFile),
+	); err != nil {
+		return fmt.Errorf("while copying the backup manifest: %w", err)
+	}
+
+	return nil
+}
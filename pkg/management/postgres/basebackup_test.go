@@ -0,0 +1,140 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package postgres
+
+import (
+	"context"
+	"os"
+	"path"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/internal/scheme"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ReadBackupManifestWALRanges", func() {
+	It("reads the WAL ranges from the backup manifest", func() {
+		directory := GinkgoT().TempDir()
+		manifest := `{
+			"PostgreSQL-Backup-Manifest-Version": 2,
+			"Files": [],
+			"WAL-Ranges": [
+				{"Timeline": 1, "Start-LSN": "0/2000028", "End-LSN": "0/3000000"},
+				{"Timeline": 2, "Start-LSN": "0/3000000", "End-LSN": "0/3000120"}
+			]
+		}`
+		Expect(os.WriteFile(path.Join(directory, BackupManifestFile), []byte(manifest), 0o600)).To(Succeed())
+
+		walRanges, err := ReadBackupManifestWALRanges(directory)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(walRanges).To(Equal([]BackupManifestWALRange{
+			{Timeline: 1, StartLSN: "0/2000028", EndLSN: "0/3000000"},
+			{Timeline: 2, StartLSN: "0/3000000", EndLSN: "0/3000120"},
+		}))
+	})
+
+	It("fails when the manifest has no WAL ranges", func() {
+		directory := GinkgoT().TempDir()
+		Expect(os.WriteFile(path.Join(directory, BackupManifestFile), []byte(`{}`), 0o600)).To(Succeed())
+
+		_, err := ReadBackupManifestWALRanges(directory)
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+var _ = Describe("loadPersistentVolumeBackupChain", func() {
+	const namespace = "default"
+
+	newBackup := func(name, parentName, volumeClaimName string) *apiv1.Backup {
+		return &apiv1.Backup{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec: apiv1.BackupSpec{
+				Cluster: apiv1.LocalObjectReference{Name: "cluster-example"},
+				Method:  apiv1.BackupMethodPersistentVolume,
+			},
+			Status: apiv1.BackupStatus{
+				Phase: apiv1.BackupPhaseCompleted,
+				PersistentVolumeBackupStatus: &apiv1.PersistentVolumeBackupStatus{
+					VolumeClaimName:  volumeClaimName,
+					Path:             path.Join("cluster-example", name),
+					ParentBackupName: parentName,
+				},
+			},
+		}
+	}
+
+	It("returns the chain starting from the full backup", func(ctx context.Context) {
+		full := newBackup("full", "", "base-backups")
+		monday := newBackup("monday", "full", "base-backups")
+		tuesday := newBackup("tuesday", "monday", "base-backups")
+		cli := fake.NewClientBuilder().
+			WithScheme(scheme.BuildWithAllKnownScheme()).
+			WithObjects(full, monday, tuesday).
+			Build()
+
+		chain, err := loadPersistentVolumeBackupChain(ctx, cli, tuesday)
+		Expect(err).ToNot(HaveOccurred())
+		names := make([]string, len(chain))
+		for i := range chain {
+			names[i] = chain[i].Name
+		}
+		Expect(names).To(Equal([]string{"full", "monday", "tuesday"}))
+	})
+
+	It("fails when a backup of the chain is missing", func(ctx context.Context) {
+		monday := newBackup("monday", "full", "base-backups")
+		cli := fake.NewClientBuilder().
+			WithScheme(scheme.BuildWithAllKnownScheme()).
+			WithObjects(monday).
+			Build()
+
+		_, err := loadPersistentVolumeBackupChain(ctx, cli, monday)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("fails when a backup of the chain is stored in another volume", func(ctx context.Context) {
+		full := newBackup("full", "", "other-volume")
+		monday := newBackup("monday", "full", "base-backups")
+		cli := fake.NewClientBuilder().
+			WithScheme(scheme.BuildWithAllKnownScheme()).
+			WithObjects(full, monday).
+			Build()
+
+		_, err := loadPersistentVolumeBackupChain(ctx, cli, monday)
+		Expect(err).To(MatchError(ContainSubstring("different volume")))
+	})
+
+	It("detects loops in the chain", func(ctx context.Context) {
+		first := newBackup("first", "second", "base-backups")
+		second := newBackup("second", "first", "base-backups")
+		cli := fake.NewClientBuilder().
+			WithScheme(scheme.BuildWithAllKnownScheme()).
+			WithObjects(first, second).
+			Build()
+
+		_, err := loadPersistentVolumeBackupChain(ctx, cli, second)
+		Expect(err).To(MatchError(ContainSubstring("loop")))
+	})
+})
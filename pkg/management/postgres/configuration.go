@@ -37,6 +37,7 @@ import (
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres/constants"
 	postgresutils "github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres/utils"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/postgres/hba"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/postgres/replication"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
 )
@@ -110,8 +111,16 @@ func (instance *Instance) GeneratePostgresqlHBA(cluster *apiv1.Cluster, ldapBind
 		defaultAuthenticationMethod = "md5"
 	}
 
+	// The structured rules following the operator rules precede the
+	// raw ones
+	pgHBARules := cluster.Spec.PostgresConfiguration.PgHBARules
+	rules := append(
+		hba.Render(pgHBARules, apiv1.HBARulePositionAfterOperatorRules),
+		cluster.Spec.PostgresConfiguration.PgHBA...)
+
 	return postgres.CreateHBARules(
-		cluster.Spec.PostgresConfiguration.PgHBA,
+		rules,
+		hba.Render(pgHBARules, apiv1.HBARulePositionBeforeOperatorRules),
 		defaultAuthenticationMethod,
 		buildLDAPConfigString(cluster, ldapBindPassword))
 }
@@ -387,12 +396,13 @@ func createPostgresqlConfiguration(
 	info := postgres.ConfigurationInfo{
 		Settings:                         postgres.CnpgConfigurationSettings,
 		Version:                          version.New(majorVersion, 0),
-		UserSettings:                     cluster.Spec.PostgresConfiguration.Parameters,
+		UserSettings:                     cluster.GetPostgresParameters(),
 		IncludingSharedPreloadLibraries:  true,
 		AdditionalSharedPreloadLibraries: cluster.Spec.PostgresConfiguration.AdditionalLibraries,
 		IsReplicaCluster:                 cluster.IsReplica(),
 		IsWalArchivingDisabled:           utils.IsWalArchivingDisabled(&cluster.ObjectMeta),
 		IsAlterSystemEnabled:             cluster.Spec.PostgresConfiguration.EnableAlterSystem,
+		IsWalSummarizationEnabled:        cluster.GetPersistentVolumeBackupClaimName() != "",
 		SynchronousStandbyNames:          replication.GetSynchronousStandbyNames(cluster),
 	}
 
@@ -260,3 +260,45 @@ var _ = Describe("recovery_min_apply_delay", func() {
 		Expect(config).ToNot(ContainSubstring("recovery_min_apply_delay"))
 	})
 })
+
+var _ = Describe("pg_hba.conf generation from the cluster", func() {
+	It("renders the structured rules according to their position", func() {
+		cluster := &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				ImageName: "ghcr.io/cloudnative-pg/postgresql:17.0",
+				PostgresConfiguration: apiv1.PostgresConfiguration{
+					PgHBA: []string{"host all raw 10.0.0.0/8 md5"},
+					PgHBARules: []apiv1.HBARule{
+						{
+							Type:      "hostssl",
+							Databases: []string{"app"},
+							Users:     []string{"app"},
+							Address:   "all",
+							Method:    "cert",
+						},
+						{
+							Type:      "host",
+							Databases: []string{"all"},
+							Users:     []string{"all"},
+							Address:   "192.168.0.0/16",
+							Method:    "reject",
+							Position:  apiv1.HBARulePositionBeforeOperatorRules,
+						},
+					},
+				},
+			},
+		}
+
+		content, err := (&Instance{}).GeneratePostgresqlHBA(cluster, "")
+		Expect(err).ToNot(HaveOccurred())
+
+		rejectIndex := strings.Index(content, "host all all 192.168.0.0/16 reject")
+		fixedIndex := strings.Index(content, "hostssl replication streaming_replica all cert")
+		certIndex := strings.Index(content, "hostssl app app all cert")
+		rawIndex := strings.Index(content, "host all raw 10.0.0.0/8 md5")
+		Expect(rejectIndex).To(BeNumerically(">=", 0))
+		Expect(rejectIndex).To(BeNumerically("<", fixedIndex))
+		Expect(fixedIndex).To(BeNumerically("<", certIndex))
+		Expect(certIndex).To(BeNumerically("<", rawIndex))
+	})
+})
@@ -30,6 +30,7 @@ import (
 	"os/exec"
 	"path"
 	"path/filepath"
+	"slices"
 	"sort"
 	"time"
 
@@ -47,6 +48,7 @@ import (
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres/constants"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres/logicalimport"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres/pool"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/specs"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/system"
 )
 
@@ -529,6 +531,10 @@ func executeLogicalImport(
 	destinationPool := instance.ConnectionPool()
 	defer destinationPool.ShutdownConnections()
 
+	if backupRef := cluster.Spec.Bootstrap.InitDB.Import.Source.Backup; backupRef != nil {
+		return executeLogicalImportFromBackup(ctx, client, cluster, destinationPool, backupRef.Name)
+	}
+
 	originPool, err := getConnectionPoolerForExternalCluster(ctx, cluster, client, cluster.Namespace)
 	if err != nil {
 		return err
@@ -546,6 +552,52 @@ func executeLogicalImport(
 	}
 }
 
+// executeLogicalImportFromBackup imports the databases from the dumps
+// of a logical backup stored in the logical backup volume
+func executeLogicalImportFromBackup(
+	ctx context.Context,
+	client ctrl.Client,
+	cluster *apiv1.Cluster,
+	destinationPool pool.Pooler,
+	backupName string,
+) error {
+	var backup apiv1.Backup
+	if err := client.Get(ctx, ctrl.ObjectKey{Namespace: cluster.Namespace, Name: backupName}, &backup); err != nil {
+		return fmt.Errorf("while getting backup %s: %w", backupName, err)
+	}
+
+	logicalStatus := backup.Status.LogicalBackupStatus
+	switch {
+	case backup.Spec.Method != apiv1.BackupMethodLogical || logicalStatus == nil:
+		return fmt.Errorf("backup %s is not a logical backup", backupName)
+	case backup.Status.Phase != apiv1.BackupPhaseCompleted:
+		return fmt.Errorf("backup %s is not completed", backupName)
+	case logicalStatus.VolumeClaimName == "":
+		return fmt.Errorf("backup %s has been handed over to a plugin and can't be imported", backupName)
+	case logicalStatus.VolumeClaimName != cluster.GetLogicalBackupVolumeClaimName():
+		return fmt.Errorf("backup %s is stored in volume %s, which is not the logical backup volume of the cluster",
+			backupName, logicalStatus.VolumeClaimName)
+	}
+
+	directory := path.Join(specs.LogicalBackupVolumePath, logicalStatus.Path)
+	importSpec := cluster.Spec.Bootstrap.InitDB.Import
+	switch importSpec.Type {
+	case apiv1.MicroserviceSnapshotType:
+		return logicalimport.MicroserviceFromDump(ctx, cluster, destinationPool, directory)
+	case apiv1.MonolithSnapshotType:
+		databases := importSpec.Databases
+		if slices.Contains(databases, "*") {
+			databases = make([]string, len(logicalStatus.Databases))
+			for i, database := range logicalStatus.Databases {
+				databases[i] = database.Name
+			}
+		}
+		return logicalimport.MonolithFromDump(ctx, cluster, destinationPool, directory, databases)
+	default:
+		return fmt.Errorf("unrecognized clone type %s", importSpec.Type)
+	}
+}
+
 func getConnectionPoolerForExternalCluster(
 	ctx context.Context,
 	cluster *apiv1.Cluster,
@@ -22,6 +22,7 @@ package postgres
 import (
 	"context"
 	"crypto/tls"
+	"crypto/x509"
 	"database/sql"
 	"errors"
 	"fmt"
@@ -62,6 +63,7 @@ const (
 	pgCtlName         = "pg_ctl"
 	pgRewindName      = "pg_rewind"
 	pgBaseBackupName  = "pg_basebackup"
+	pgCombineBackup   = "pg_combinebackup"
 	pgIsReady         = "pg_isready"
 	pgCtlTimeout      = "40000000" // greater than one year in seconds, big enough to simulate an infinite timeout
 	pgControlDataName = "pg_controldata"
@@ -198,6 +200,10 @@ type Instance struct {
 	// fenced entails mightBeUnavailable ( entails as in logical consequence)
 	fenced atomic.Bool
 
+	// canaryProbes are the SQL probes to be executed while this instance
+	// is the canary of a rollout
+	canaryProbes atomic.Pointer[[]apiv1.CanaryProbe]
+
 	// slotsReplicatorChan is used to send replication slot configuration to the slot replicator
 	slotsReplicatorChan chan *apiv1.ReplicationSlotsConfiguration
 
@@ -215,6 +221,24 @@ type Instance struct {
 
 	// ServerCertificate is the certificate we use to serve https connections
 	ServerCertificate *tls.Certificate
+
+	// operatorClientCA is the CA used to verify the client certificate
+	// of the operator on the mutating endpoints of the status port.
+	// It's nil until the operator client CA of the cluster has been loaded
+	operatorClientCA atomic.Pointer[x509.CertPool]
+
+	// primaryIsolationTimeout is the amount of time this instance, when
+	// primary, waits before fencing itself after losing contact with the
+	// API server and its replicas. Zero means that it never does
+	primaryIsolationTimeout atomic.Duration
+
+	// walPrefetchStats counts how the WAL files restored through the
+	// plugins have been served by the prefetching
+	walPrefetchStats WALPrefetchStats
+
+	// walArchiveContinuity is the outcome of the last check of the
+	// continuity of the WAL archive, nil until the first check
+	walArchiveContinuity atomic.Pointer[WALArchiveContinuityReport]
 }
 
 // SetPostgreSQLAutoConfWritable allows or deny writes to the
@@ -234,6 +258,62 @@ func (instance *Instance) IsReady() error {
 	return PgIsReady()
 }
 
+// SetCanaryProbes sets the SQL probes to be executed, when reporting
+// the instance status, while this instance is the canary of a rollout
+func (instance *Instance) SetCanaryProbes(probes []apiv1.CanaryProbe) {
+	if len(probes) == 0 {
+		instance.canaryProbes.Store(nil)
+		return
+	}
+
+	instance.canaryProbes.Store(&probes)
+}
+
+// SetPrimaryIsolationTimeout sets the amount of time this instance, when
+// primary, waits before fencing itself after being isolated
+func (instance *Instance) SetPrimaryIsolationTimeout(timeout time.Duration) {
+	instance.primaryIsolationTimeout.Store(timeout)
+}
+
+// GetPrimaryIsolationTimeout gets the amount of time this instance, when
+// primary, waits before fencing itself after being isolated. A zero
+// value means that the instance never fences itself
+func (instance *Instance) GetPrimaryIsolationTimeout() time.Duration {
+	return instance.primaryIsolationTimeout.Load()
+}
+
+// SetOperatorClientCA sets the pool of CAs used to verify the client
+// certificate of the operator
+func (instance *Instance) SetOperatorClientCA(pool *x509.CertPool) {
+	instance.operatorClientCA.Store(pool)
+}
+
+// GetOperatorClientCA gets the pool of CAs used to verify the client
+// certificate of the operator, and whether it has already been loaded
+func (instance *Instance) GetOperatorClientCA() (*x509.CertPool, bool) {
+	pool := instance.operatorClientCA.Load()
+	return pool, pool != nil
+}
+
+// GetWALPrefetchStats gets the counters of the prefetching of the WAL
+// files restored through the plugins
+func (instance *Instance) GetWALPrefetchStats() *WALPrefetchStats {
+	return &instance.walPrefetchStats
+}
+
+// SetWALArchiveContinuity records the outcome of the last check of the
+// continuity of the WAL archive. A nil report means that the check
+// isn't running on this instance
+func (instance *Instance) SetWALArchiveContinuity(report *WALArchiveContinuityReport) {
+	instance.walArchiveContinuity.Store(report)
+}
+
+// GetWALArchiveContinuity gets the outcome of the last check of the
+// continuity of the WAL archive, if any
+func (instance *Instance) GetWALArchiveContinuity() *WALArchiveContinuityReport {
+	return instance.walArchiveContinuity.Load()
+}
+
 // IsFenced checks whether the instance is marked as fenced
 func (instance *Instance) IsFenced() bool {
 	return instance.fenced.Load()
@@ -265,26 +345,37 @@ func (instance *Instance) SetCanCheckReadiness(enabled bool) {
 // CheckHasDiskSpaceForWAL checks if we have enough disk space to store two WAL files,
 // and returns true if we have free disk space for 2 WAL segments, false otherwise
 func (instance *Instance) CheckHasDiskSpaceForWAL(ctx context.Context) (bool, error) {
+	walSegmentSize, err := instance.GetWALSegmentSize()
+	if err != nil {
+		return false, err
+	}
+
+	walDirectory := path.Join(instance.PgData, pgWalDirectory)
+	return fileutils.NewDiskProbe(walDirectory).HasStorageAvailable(ctx, walSegmentSize)
+}
+
+// GetWALSegmentSize gets the size of the WAL segments of the instance,
+// as recorded in the control file
+func (instance *Instance) GetWALSegmentSize() (int, error) {
 	pgControlDataString, err := instance.GetPgControldata()
 	if err != nil {
-		return false, fmt.Errorf("while running pg_controldata to detect WAL segment size: %w", err)
+		return 0, fmt.Errorf("while running pg_controldata to detect WAL segment size: %w", err)
 	}
 
 	pgControlData := utils.ParsePgControldataOutput(pgControlDataString)
 	walSegmentSizeString, ok := pgControlData["Bytes per WAL segment"]
 	if !ok {
-		return false, fmt.Errorf("no 'Bytes per WAL segment' section into pg_controldata output")
+		return 0, fmt.Errorf("no 'Bytes per WAL segment' section into pg_controldata output")
 	}
 
 	walSegmentSize, err := strconv.Atoi(walSegmentSizeString)
 	if err != nil {
-		return false, fmt.Errorf(
+		return 0, fmt.Errorf(
 			"wrong 'Bytes per WAL segment' pg_controldata value (not an integer): '%s' %w",
 			walSegmentSizeString, err)
 	}
 
-	walDirectory := path.Join(instance.PgData, pgWalDirectory)
-	return fileutils.NewDiskProbe(walDirectory).HasStorageAvailable(ctx, walSegmentSize)
+	return walSegmentSize, nil
 }
 
 // SetMightBeUnavailable marks whether the instance being down should be tolerated
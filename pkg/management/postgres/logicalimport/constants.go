@@ -42,8 +42,8 @@ func createDumpsDirectory() error {
 	return fileutils.EnsureDirectoryExists(dumpDirectory)
 }
 
-func generateFileNameForDatabase(database string) string {
-	return fmt.Sprintf("%s/%s.dump", dumpDirectory, database)
+func generateFileNameForDatabase(directory string, database string) string {
+	return fmt.Sprintf("%s/%s.dump", directory, database)
 }
 
 func cleanDumpDirectory() error {
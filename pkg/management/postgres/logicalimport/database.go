@@ -35,6 +35,19 @@ import (
 
 type databaseSnapshotter struct {
 	cluster *apiv1.Cluster
+
+	// The directory containing the dumps. When empty, the
+	// dumps are stored in a temporary directory inside PGDATA
+	dumpDirectory string
+}
+
+// getDumpPath gets the path of the dump of the passed database
+func (ds *databaseSnapshotter) getDumpPath(database string) string {
+	directory := ds.dumpDirectory
+	if directory == "" {
+		directory = dumpDirectory
+	}
+	return generateFileNameForDatabase(directory, database)
 }
 
 func (ds *databaseSnapshotter) getDatabaseList(ctx context.Context, target pool.Pooler) ([]string, error) {
@@ -50,41 +63,7 @@ func (ds *databaseSnapshotter) getDatabaseList(ctx context.Context, target pool.
 		return passedDatabases, nil
 	}
 
-	dbPostgres, err := target.Connection(postgresDatabase)
-	if err != nil {
-		return nil, err
-	}
-	query := `SELECT datname FROM pg_catalog.pg_database d WHERE datallowconn
-              AND NOT datistemplate
-              AND datallowconn
-              AND datname != 'postgres'
-              ORDER BY datname`
-
-	rows, err := dbPostgres.Query(query)
-	if err != nil {
-		return nil, err
-	}
-	defer func() {
-		closeErr := rows.Close()
-		if closeErr != nil {
-			contextLogger.Error(closeErr, "while closing rows: %w")
-		}
-	}()
-
-	var databases []string
-	for rows.Next() {
-		var database string
-		if err := rows.Scan(&database); err != nil {
-			return nil, err
-		}
-		databases = append(databases, database)
-	}
-
-	if err := rows.Err(); err != nil {
-		return nil, err
-	}
-
-	return databases, nil
+	return ListDatabases(ctx, target)
 }
 
 func (ds *databaseSnapshotter) exportDatabases(
@@ -102,22 +81,11 @@ func (ds *databaseSnapshotter) exportDatabases(
 
 	for _, database := range databases {
 		contextLogger.Info("exporting database", "databaseName", database)
-		dsn := target.GetDsn(database)
-		options := []string{
-			"-Fd",
-			"-f", generateFileNameForDatabase(database),
-			"-d", dsn,
-			"-v",
-		}
+		options := make([]string, 0, len(sectionsToExport)+len(extraOptions))
 		options = append(options, sectionsToExport...)
 		options = append(options, extraOptions...)
-
-		contextLogger.Info("Running pg_dump", "cmd", pgDump,
-			"options", options)
-		pgDumpCommand := exec.Command(pgDump, options...) // #nosec
-		err := execlog.RunStreaming(pgDumpCommand, pgDump)
-		if err != nil {
-			return fmt.Errorf("error in pg_dump, %w", err)
+		if err := runPgDump(ctx, target.GetDsn(database), ds.getDumpPath(database), options); err != nil {
+			return err
 		}
 	}
 
@@ -159,7 +127,7 @@ func (ds *databaseSnapshotter) importDatabases(
 				"-U", "postgres",
 				"-d", targetDatabase,
 				"--section", section,
-				generateFileNameForDatabase(database),
+				ds.getDumpPath(database),
 			}
 
 			options = append(options, extraOptions...)
@@ -221,7 +189,7 @@ func (ds *databaseSnapshotter) importDatabaseContent(
 			fmt.Sprintf("--role=%s", owner),
 			"-d", targetDatabase,
 			"--section", section,
-			generateFileNameForDatabase(database),
+			ds.getDumpPath(database),
 		}
 
 		options = append(options, extraOptions...)
@@ -0,0 +1,174 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package logicalimport
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os/exec"
+	"path/filepath"
+	"time"
+
+	"github.com/cloudnative-pg/machinery/pkg/execlog"
+	"github.com/cloudnative-pg/machinery/pkg/fileutils"
+	"github.com/cloudnative-pg/machinery/pkg/log"
+
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres/pool"
+)
+
+// DatabaseDump is the outcome of the dump of a database
+type DatabaseDump struct {
+	// The name of the database
+	Name string
+
+	// The size of the dump, in bytes
+	Size int64
+
+	// How long it took to dump the database
+	Duration time.Duration
+}
+
+// ListDatabases lists the databases accepting connections, excluding
+// the templates and the `postgres` database
+func ListDatabases(ctx context.Context, target pool.Pooler) ([]string, error) {
+	contextLogger := log.FromContext(ctx)
+
+	dbPostgres, err := target.Connection(postgresDatabase)
+	if err != nil {
+		return nil, err
+	}
+	query := `SELECT datname FROM pg_catalog.pg_database d WHERE datallowconn
+              AND NOT datistemplate
+              AND datallowconn
+              AND datname != 'postgres'
+              ORDER BY datname`
+
+	rows, err := dbPostgres.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		closeErr := rows.Close()
+		if closeErr != nil {
+			contextLogger.Error(closeErr, "while closing rows: %w")
+		}
+	}()
+
+	var databases []string
+	for rows.Next() {
+		var database string
+		if err := rows.Scan(&database); err != nil {
+			return nil, err
+		}
+		databases = append(databases, database)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return databases, nil
+}
+
+// Dump dumps the passed databases with pg_dump in directory format,
+// writing one `<database>.dump` directory per database inside the
+// destination directory
+func Dump(
+	ctx context.Context,
+	source pool.Pooler,
+	databases []string,
+	destination string,
+	extraOptions []string,
+) ([]DatabaseDump, error) {
+	contextLogger := log.FromContext(ctx)
+
+	if err := fileutils.EnsureDirectoryExists(destination); err != nil {
+		return nil, err
+	}
+
+	result := make([]DatabaseDump, 0, len(databases))
+	for _, database := range databases {
+		contextLogger.Info("dumping database", "databaseName", database)
+
+		startedAt := time.Now()
+		dumpPath := generateFileNameForDatabase(destination, database)
+		if err := runPgDump(ctx, source.GetDsn(database), dumpPath, extraOptions); err != nil {
+			return nil, err
+		}
+
+		size, err := getDirectorySize(dumpPath)
+		if err != nil {
+			return nil, fmt.Errorf("while computing the size of the dump of %s: %w", database, err)
+		}
+
+		result = append(result, DatabaseDump{
+			Name:     database,
+			Size:     size,
+			Duration: time.Since(startedAt),
+		})
+	}
+
+	return result, nil
+}
+
+// runPgDump runs pg_dump in directory format against the passed DSN
+func runPgDump(ctx context.Context, dsn string, dumpPath string, extraOptions []string) error {
+	contextLogger := log.FromContext(ctx)
+
+	options := []string{
+		"-Fd",
+		"-f", dumpPath,
+		"-d", dsn,
+		"-v",
+	}
+	options = append(options, extraOptions...)
+
+	contextLogger.Info("Running pg_dump", "cmd", pgDump,
+		"options", options)
+	pgDumpCommand := exec.Command(pgDump, options...) // #nosec
+	if err := execlog.RunStreaming(pgDumpCommand, pgDump); err != nil {
+		return fmt.Errorf("error in pg_dump, %w", err)
+	}
+
+	return nil
+}
+
+// getDirectorySize gets the total size of the regular files
+// contained in a directory
+func getDirectorySize(directory string) (int64, error) {
+	var size int64
+	err := filepath.WalkDir(directory, func(_ string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !entry.Type().IsRegular() {
+			return nil
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		size += info.Size()
+		return nil
+	})
+	return size, err
+}
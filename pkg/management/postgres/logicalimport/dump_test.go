@@ -0,0 +1,53 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package logicalimport
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("getDirectorySize", func() {
+	It("sums the size of the regular files in the directory tree", func() {
+		directory := GinkgoT().TempDir()
+		Expect(os.WriteFile(filepath.Join(directory, "toc.dat"), make([]byte, 10), 0o600)).To(Succeed())
+		Expect(os.Mkdir(filepath.Join(directory, "nested"), 0o700)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(directory, "nested", "3001.dat.gz"), make([]byte, 32), 0o600)).
+			To(Succeed())
+
+		size, err := getDirectorySize(directory)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(size).To(BeEquivalentTo(42))
+	})
+
+	It("fails when the directory doesn't exist", func() {
+		_, err := getDirectorySize(filepath.Join(GinkgoT().TempDir(), "missing"))
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+var _ = Describe("generateFileNameForDatabase", func() {
+	It("places the dump inside the passed directory", func() {
+		Expect(generateFileNameForDatabase("/backups/cluster", "app")).To(Equal("/backups/cluster/app.dump"))
+	})
+})
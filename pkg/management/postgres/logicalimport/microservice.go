@@ -55,6 +55,46 @@ func Microservice(
 		return err
 	}
 
+	if err := ds.importMicroservice(ctx, destination, databases[0]); err != nil {
+		return err
+	}
+
+	if err := cleanDumpDirectory(); err != nil {
+		return err
+	}
+
+	return ds.completeMicroservice(ctx, destination)
+}
+
+// MicroserviceFromDump executes the microservice import type, restoring
+// the database from a dump already available in the passed directory
+func MicroserviceFromDump(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+	destination pool.Pooler,
+	directory string,
+) error {
+	contextLogger := log.FromContext(ctx)
+	ds := databaseSnapshotter{cluster: cluster, dumpDirectory: directory}
+
+	contextLogger.Info("starting microservice import process from dump", "directory", directory)
+
+	if err := ds.importMicroservice(ctx, destination, cluster.Spec.Bootstrap.InitDB.Import.Databases[0]); err != nil {
+		return err
+	}
+
+	return ds.completeMicroservice(ctx, destination)
+}
+
+// importMicroservice restores the dump of the passed database
+// into the application database
+func (ds *databaseSnapshotter) importMicroservice(
+	ctx context.Context,
+	destination pool.Pooler,
+	database string,
+) error {
+	initDB := ds.cluster.Spec.Bootstrap.InitDB
+
 	if err := ds.dropExtensionsFromDatabase(
 		ctx,
 		destination,
@@ -63,20 +103,20 @@ func Microservice(
 		return err
 	}
 
-	if err := ds.importDatabaseContent(
+	return ds.importDatabaseContent(
 		ctx,
 		destination,
-		databases[0],
+		database,
 		initDB.Database,
 		initDB.Owner,
 		initDB.Import.PgRestoreExtraOptions,
-	); err != nil {
-		return err
-	}
+	)
+}
 
-	if err := cleanDumpDirectory(); err != nil {
-		return err
-	}
+// completeMicroservice executes the post import queries and
+// analyzes the application database
+func (ds *databaseSnapshotter) completeMicroservice(ctx context.Context, destination pool.Pooler) error {
+	initDB := ds.cluster.Spec.Bootstrap.InitDB
 
 	if err := ds.executePostImportQueries(
 		ctx,
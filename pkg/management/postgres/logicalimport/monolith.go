@@ -81,3 +81,28 @@ func Monolith(
 
 	return ds.analyze(ctx, destination, databases)
 }
+
+// MonolithFromDump executes the monolith import type, restoring the passed
+// databases from the dumps already available in the passed directory
+func MonolithFromDump(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+	destination pool.Pooler,
+	directory string,
+	databases []string,
+) error {
+	contextLogger := log.FromContext(ctx)
+	contextLogger.Info("starting monolith import process from dump", "directory", directory)
+
+	ds := databaseSnapshotter{cluster: cluster, dumpDirectory: directory}
+	if err := ds.importDatabases(
+		ctx,
+		destination,
+		databases,
+		cluster.Spec.Bootstrap.InitDB.Import.PgRestoreExtraOptions,
+	); err != nil {
+		return err
+	}
+
+	return ds.analyze(ctx, destination, databases)
+}
@@ -0,0 +1,49 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package logpipe
+
+import "sync/atomic"
+
+// errorSeverityField is the position of the error_severity field
+// in the logging_collector CSV format
+const errorSeverityField = 11
+
+// errorRecordsCount is the number of PostgreSQL log records with
+// severity ERROR or higher collected by the log pipe
+var errorRecordsCount atomic.Int64
+
+// GetErrorRecordsCount returns the number of PostgreSQL log records with
+// severity ERROR or higher collected since the instance manager started
+func GetErrorRecordsCount() int64 {
+	return errorRecordsCount.Load()
+}
+
+// countErrorRecord increments the error records count if the passed
+// logging_collector CSV record has severity ERROR or higher
+func countErrorRecord(content []string) {
+	if len(content) <= errorSeverityField {
+		return
+	}
+
+	switch content[errorSeverityField] {
+	case "ERROR", "FATAL", "PANIC":
+		errorRecordsCount.Add(1)
+	}
+}
@@ -201,6 +201,7 @@ func (p *LogPipe) streamLogFromCSVFile(ctx context.Context, inputFile io.Reader,
 		err.Fields = content
 		return err
 	}
+	countErrorRecord(content)
 	writer.Write(p.record.FromCSV(content))
 
 reader:
@@ -234,6 +235,7 @@ reader:
 			}
 		}
 
+		countErrorRecord(content)
 		writer.Write(p.record.FromCSV(content))
 	}
 
@@ -183,6 +183,22 @@ var _ = Describe("CSV file reader", func() {
 			})
 		})
 
+		It("counts the records with severity ERROR or higher", func(ctx SpecContext) {
+			inputBuffer, err := os.ReadFile("testdata/two_lines.csv")
+			Expect(err).ToNot(HaveOccurred())
+			input := strings.Replace(string(inputBuffer), ",LOG,", ",ERROR,", 1)
+
+			spy := SpyRecordWriter{}
+			p := LogPipe{
+				record:          &LoggingRecord{},
+				fieldsValidator: LogFieldValidator,
+			}
+			before := GetErrorRecordsCount()
+			Expect(p.streamLogFromCSVFile(ctx, strings.NewReader(input), &spy)).To(Succeed())
+			Expect(spy.records).To(HaveLen(2))
+			Expect(GetErrorRecordsCount() - before).To(BeEquivalentTo(1))
+		})
+
 		It("correctly handles an empty stream", func(ctx SpecContext) {
 			spy := SpyRecordWriter{}
 			p := LogPipe{
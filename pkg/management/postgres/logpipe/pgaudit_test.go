@@ -23,8 +23,14 @@ import (
 	"bytes"
 	"encoding/csv"
 	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
 
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 )
@@ -429,3 +435,71 @@ func writePgAuditMessage(content []string) string {
 	writer.Flush()
 	return fmt.Sprintf("AUDIT: %s", strings.TrimSuffix(buffer.String(), "\n"))
 }
+
+var _ = Describe("pgAudit output stream", func() {
+	BeforeEach(func() {
+		originalLogFile := pgAuditLogFile
+		pgAuditLogFile = filepath.Join(GinkgoT().TempDir(), PgAuditLogFileName)
+		DeferCleanup(func() {
+			SetPgAuditOutputSeparate(false)
+			pgAuditLogFile = originalLogFile
+		})
+		SetPgAuditOutputSeparate(true)
+	})
+
+	readLogFile := func() string {
+		content, err := os.ReadFile(pgAuditLogFile) // #nosec
+		if os.IsNotExist(err) {
+			return ""
+		}
+		Expect(err).ToNot(HaveOccurred())
+		return string(content)
+	}
+
+	It("writes the pgaudit records to the dedicated file", func() {
+		record := NewPgAuditLoggingDecorator()
+		record.Audit.Statement = "SELECT 1"
+
+		writer := LogRecordWriter{}
+		writer.Write(record)
+
+		content := readLogFile()
+		Expect(content).To(ContainSubstring(`"logger":"pgaudit"`))
+		Expect(content).To(ContainSubstring(`"level":"info"`))
+		Expect(content).To(ContainSubstring("SELECT 1"))
+	})
+
+	It("keeps the other records in the instance logs", func() {
+		writer := LogRecordWriter{}
+		writer.Write(&LoggingRecord{Message: "checkpoint starting"})
+
+		Expect(readLogFile()).To(BeEmpty())
+	})
+
+	It("stops using the dedicated file when disabled", func() {
+		SetPgAuditOutputSeparate(false)
+		Expect(pgAuditOutput.Load()).To(BeNil())
+
+		SetPgAuditOutputSeparate(true)
+		Expect(pgAuditOutput.Load()).ToNot(BeNil())
+	})
+})
+
+var _ = Describe("pgAudit logger", func() {
+	It("uses the field names requested for the instance manager logs", func() {
+		flags := log.NewFlags(zap.Options{})
+		flagSet := (&cobra.Command{}).Flags()
+		flags.AddFlags(flagSet)
+		Expect(flagSet.Parse([]string{"--log-field-level=severity", "--log-field-timestamp=time"})).To(Succeed())
+		DeferCleanup(func() {
+			Expect(flagSet.Parse([]string{"--log-field-level=", "--log-field-timestamp="})).To(Succeed())
+		})
+
+		buffer := &bytes.Buffer{}
+		newPgAuditLogger(buffer).Info("record")
+
+		Expect(buffer.String()).To(ContainSubstring(`"severity":"info"`))
+		Expect(buffer.String()).To(ContainSubstring(`"time":`))
+		Expect(buffer.String()).ToNot(ContainSubstring(`"level":`))
+	})
+})
@@ -0,0 +1,118 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package logpipe
+
+import (
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/cloudnative-pg/machinery/pkg/fileutils"
+)
+
+// rotatingFile is a file that is rotated when it exceeds a maximum size,
+// keeping a single previous file with the `.1` suffix
+type rotatingFile struct {
+	fileName string
+	maxSize  int64
+
+	mu   sync.Mutex
+	file *os.File
+	size int64
+}
+
+// newRotatingFile creates a rotating file, which is opened at the first write
+func newRotatingFile(fileName string, maxSize int64) *rotatingFile {
+	return &rotatingFile{
+		fileName: fileName,
+		maxSize:  maxSize,
+	}
+}
+
+// Write implements the io.Writer interface, rotating the file when
+// the content would exceed the maximum size
+func (f *rotatingFile) Write(content []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.file != nil && f.size > 0 && f.size+int64(len(content)) > f.maxSize {
+		if err := f.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	if f.file == nil {
+		if err := f.open(); err != nil {
+			return 0, err
+		}
+	}
+
+	written, err := f.file.Write(content)
+	f.size += int64(written)
+	return written, err
+}
+
+// Close closes the file, which will be opened again at the next write
+func (f *rotatingFile) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.file == nil {
+		return nil
+	}
+
+	err := f.file.Close()
+	f.file = nil
+	return err
+}
+
+func (f *rotatingFile) open() error {
+	if err := fileutils.EnsureParentDirectoryExists(f.fileName); err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(f.fileName, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o600) //#nosec
+	if err != nil {
+		return fmt.Errorf("while opening %s: %w", f.fileName, err)
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return err
+	}
+
+	f.file = file
+	f.size = info.Size()
+	return nil
+}
+
+func (f *rotatingFile) rotate() error {
+	if err := f.file.Close(); err != nil {
+		return err
+	}
+	f.file = nil
+
+	if err := os.Rename(f.fileName, f.fileName+".1"); err != nil {
+		return fmt.Errorf("while rotating %s: %w", f.fileName, err)
+	}
+
+	return f.open()
+}
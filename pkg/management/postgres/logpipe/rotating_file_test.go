@@ -0,0 +1,69 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package logpipe
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Rotating file", func() {
+	It("creates the file and its parent directory at the first write", func() {
+		fileName := filepath.Join(GinkgoT().TempDir(), "log", "audit.json")
+		file := newRotatingFile(fileName, 1024)
+		DeferCleanup(file.Close)
+
+		_, err := file.Write([]byte("first\n"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(os.ReadFile(fileName)).To(BeEquivalentTo("first\n"))
+	})
+
+	It("keeps the previous content when exceeding the maximum size", func() {
+		fileName := filepath.Join(GinkgoT().TempDir(), "audit.json")
+		file := newRotatingFile(fileName, 10)
+		DeferCleanup(file.Close)
+
+		for _, line := range []string{"first\n", "second\n", "third\n"} {
+			_, err := file.Write([]byte(line))
+			Expect(err).ToNot(HaveOccurred())
+		}
+
+		Expect(os.ReadFile(fileName)).To(BeEquivalentTo("third\n"))
+		Expect(os.ReadFile(fileName + ".1")).To(BeEquivalentTo("second\n"))
+	})
+
+	It("appends to the existing file after being closed", func() {
+		fileName := filepath.Join(GinkgoT().TempDir(), "audit.json")
+		file := newRotatingFile(fileName, 1024)
+
+		_, err := file.Write([]byte("first\n"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(file.Close()).To(Succeed())
+
+		_, err = file.Write([]byte("second\n"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(file.Close()).To(Succeed())
+
+		Expect(os.ReadFile(fileName)).To(BeEquivalentTo("first\nsecond\n"))
+	})
+})
@@ -20,13 +20,98 @@ SPDX-License-Identifier: Apache-2.0
 package logpipe
 
 import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+
 	"github.com/cloudnative-pg/machinery/pkg/log"
+	"github.com/go-logr/logr"
+	"go.uber.org/zap/zapcore"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
 )
 
 const (
 	logRecordKey = "record"
 )
 
+const (
+	// PgAuditLogFileName is the name of the file where the pgaudit records
+	// are written when they are separated from the other logs of the instance
+	PgAuditLogFileName = "pgaudit.json"
+
+	// pgAuditLogFileMaxSize is the size after which the file containing
+	// the pgaudit records is rotated
+	pgAuditLogFileMaxSize = 100 * 1024 * 1024
+)
+
+// pgAuditLogFile is the path of the file where the pgaudit records are
+// written when they are separated from the other logs of the instance.
+// It is inside the scratch data volume, so that sidecars can read it
+var pgAuditLogFile = filepath.Join(postgres.LogPath, PgAuditLogFileName)
+
+// pgAuditSink is the destination of the pgaudit records when they are
+// separated from the other logs of the instance
+type pgAuditSink struct {
+	logger logr.Logger
+	file   io.Closer
+}
+
+// pgAuditOutput is where the pgaudit records are written when they need
+// to be separated from the other logs of the instance. When nil, the
+// pgaudit records are written together with the other logs
+var pgAuditOutput atomic.Pointer[pgAuditSink]
+
+// SetPgAuditOutputSeparate sets whether the pgaudit records are written
+// to a dedicated file, separately from the other logs of the instance
+func SetPgAuditOutputSeparate(separate bool) {
+	if !separate {
+		if sink := pgAuditOutput.Swap(nil); sink != nil {
+			_ = sink.file.Close()
+		}
+		return
+	}
+
+	if pgAuditOutput.Load() != nil {
+		return
+	}
+
+	file := newRotatingFile(pgAuditLogFile, pgAuditLogFileMaxSize)
+	sink := &pgAuditSink{logger: newPgAuditLogger(file), file: file}
+	if !pgAuditOutput.CompareAndSwap(nil, sink) {
+		_ = file.Close()
+	}
+}
+
+// newPgAuditLogger creates a JSON logger writing to the passed destination,
+// using the same fields of the instance manager logs
+func newPgAuditLogger(destination io.Writer) logr.Logger {
+	return zap.New(
+		zap.WriteTo(destination),
+		func(o *zap.Options) {
+			o.TimeEncoder = zapcore.RFC3339NanoTimeEncoder
+			o.EncoderConfigOptions = append(o.EncoderConfigOptions, remapPgAuditLogFields)
+		},
+	).WithValues("logging_pod", os.Getenv("POD_NAME"))
+}
+
+// remapPgAuditLogFields applies the names of the level and timestamp
+// fields requested for the logs of the instance manager
+func remapPgAuditLogFields(config *zapcore.EncoderConfig) {
+	for _, flag := range log.GetFieldsRemapFlags() {
+		name, value, _ := strings.Cut(strings.TrimPrefix(flag, "--"), "=")
+		switch name {
+		case "log-field-level":
+			config.LevelKey = value
+		case "log-field-timestamp":
+			config.TimeKey = value
+		}
+	}
+}
+
 // RecordWriter is the interface
 type RecordWriter interface {
 	Write(r NamedRecord)
@@ -36,7 +121,15 @@ type RecordWriter interface {
 // instance manager logger
 type LogRecordWriter struct{}
 
-// Write writes the PostgreSQL log record to the instance manager logger
+// Write writes the PostgreSQL log record to the instance manager logger,
+// or to the pgaudit logger for audit records when it is configured
 func (writer *LogRecordWriter) Write(record NamedRecord) {
+	if record.GetName() == PgAuditRecordName {
+		if sink := pgAuditOutput.Load(); sink != nil {
+			sink.logger.WithName(record.GetName()).Info(logRecordKey, logRecordKey, record)
+			return
+		}
+	}
+
 	log.WithName(record.GetName()).Info(logRecordKey, logRecordKey, record)
 }
@@ -20,11 +20,13 @@ SPDX-License-Identifier: Apache-2.0
 package postgres
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"fmt"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/cloudnative-pg/machinery/pkg/fileutils"
 	"github.com/cloudnative-pg/machinery/pkg/log"
@@ -33,11 +35,15 @@ import (
 
 	v1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/executablehash"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres/logpipe"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/specs"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/versions"
 )
 
+// canaryProbeTimeout is the maximum time a canary SQL probe can take
+const canaryProbeTimeout = 10 * time.Second
+
 // GetStatus Extract the status of this PostgreSQL database
 func (instance *Instance) GetStatus() (result *postgres.PostgresqlStatus, err error) {
 	result = &postgres.PostgresqlStatus{
@@ -122,10 +128,52 @@ func (instance *Instance) GetStatus() (result *postgres.PostgresqlStatus, err er
 	}
 
 	result.IsInstanceManagerUpgrading = instance.InstanceManagerIsUpgrading.Load()
+	result.LogErrorsCount = logpipe.GetErrorRecordsCount()
+	result.CanaryProbeFailures = instance.runCanaryProbes()
 
 	return result, nil
 }
 
+// runCanaryProbes executes the SQL probes defined while this instance is
+// the canary of a rollout, returning the description of the failed ones
+func (instance *Instance) runCanaryProbes() []string {
+	probes := instance.canaryProbes.Load()
+	if probes == nil {
+		return nil
+	}
+
+	var failures []string
+	for _, probe := range *probes {
+		if err := instance.runCanaryProbe(probe); err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %s", probe.Name, err.Error()))
+		}
+	}
+
+	return failures
+}
+
+// runCanaryProbe executes a SQL probe, which is successful only
+// when it returns `true`
+func (instance *Instance) runCanaryProbe(probe v1.CanaryProbe) error {
+	db, err := instance.ConnectionPool().Connection(probe.Database)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), canaryProbeTimeout)
+	defer cancel()
+
+	var result bool
+	if err := db.QueryRowContext(ctx, probe.Query).Scan(&result); err != nil {
+		return err
+	}
+	if !result {
+		return errors.New("the query returned false")
+	}
+
+	return nil
+}
+
 // updateResultForDecrease updates the given postgres.PostgresqlStatus
 // in case of pending restart, by checking whether the restart is due to hot standby
 // sensible parameters being decreased
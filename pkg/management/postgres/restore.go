@@ -30,6 +30,7 @@ import (
 	"os/exec"
 	"path"
 	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -59,6 +60,7 @@ import (
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/external"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres/constants"
 	postgresSpec "github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/specs"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/system"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
 )
@@ -256,6 +258,11 @@ func (info InitInfo) Restore(ctx context.Context, cli client.Client) error {
 	var envs []string
 	var config string
 
+	volumeBackup, err := info.loadPersistentVolumeBackup(ctx, cli, cluster)
+	if err != nil {
+		return err
+	}
+
 	// nolint:nestif
 	if pluginConfiguration := cluster.GetRecoverySourcePlugin(); pluginConfiguration != nil {
 		contextLogger.Info("Restore through plugin detected, proceeding...")
@@ -279,6 +286,22 @@ func (info InitInfo) Restore(ctx context.Context, cli client.Client) error {
 
 		envs = envmap.Merge(processEnvironment, pluginEnvironment).StringSlice()
 		config = res.RestoreConfig
+	} else if volumeBackup != nil {
+		contextLogger.Info("Restore from the backup volume detected, proceeding...")
+		if err := info.checkBackupDestination(ctx, cli, cluster); err != nil {
+			return err
+		}
+
+		if err := info.restoreDataDirFromVolume(ctx, cli, volumeBackup); err != nil {
+			return err
+		}
+
+		if _, err := info.restoreCustomWalDir(ctx); err != nil {
+			return err
+		}
+
+		config = getPersistentVolumeRestoreWalConfig(volumeBackup)
+		envs = os.Environ()
 	} else {
 		// Before starting the restore we check if the archive destination is safe to use
 		// otherwise, we stop creating the cluster
@@ -468,6 +491,107 @@ func (info InitInfo) restoreDataDir(ctx context.Context, backup *apiv1.Backup, e
 	return nil
 }
 
+// loadPersistentVolumeBackup loads the backup referenced by the recovery
+// section of the cluster when it's stored in the backup volume, returning
+// nil for any other kind of recovery
+func (info InitInfo) loadPersistentVolumeBackup(
+	ctx context.Context,
+	typedClient client.Client,
+	cluster *apiv1.Cluster,
+) (*apiv1.Backup, error) {
+	recovery := cluster.Spec.Bootstrap.Recovery
+	if recovery == nil || recovery.Backup == nil {
+		return nil, nil
+	}
+
+	var backup apiv1.Backup
+	if err := typedClient.Get(
+		ctx,
+		client.ObjectKey{Namespace: info.Namespace, Name: recovery.Backup.Name},
+		&backup,
+	); err != nil {
+		return nil, err
+	}
+
+	if backup.Spec.Method != apiv1.BackupMethodPersistentVolume {
+		return nil, nil
+	}
+
+	return &backup, nil
+}
+
+// restoreDataDirFromVolume restores PGDATA from a base backup stored in the
+// backup volume, combining it with the backups it is based on when it is
+// an incremental one
+func (info InitInfo) restoreDataDirFromVolume(
+	ctx context.Context,
+	typedClient client.Client,
+	backup *apiv1.Backup,
+) error {
+	chain, err := loadPersistentVolumeBackupChain(ctx, typedClient, backup)
+	if err != nil {
+		return err
+	}
+
+	directories := make([]BaseBackupDirectory, len(chain))
+	for i := range chain {
+		directories[i] = BaseBackupDirectory{
+			Path:   path.Join(specs.RecoveryBackupVolumePath, chain[i].Status.PersistentVolumeBackupStatus.Path),
+			Format: chain[i].Status.PersistentVolumeBackupStatus.Format,
+		}
+	}
+
+	log.FromContext(ctx).Info("Restoring the base backup chain", "directories", directories)
+	return CombineBaseBackups(ctx, directories, info.PgData, path.Join(path.Dir(info.PgData), "restore-staging"))
+}
+
+// loadPersistentVolumeBackupChain loads the chain of base backups needed to
+// restore the passed one, starting from the full backup
+func loadPersistentVolumeBackupChain(
+	ctx context.Context,
+	typedClient client.Client,
+	backup *apiv1.Backup,
+) ([]*apiv1.Backup, error) {
+	if !backup.IsCompletedPersistentVolumeBackup() {
+		return nil, fmt.Errorf("backup %s is not completed", backup.Name)
+	}
+
+	volumeClaimName := backup.Status.PersistentVolumeBackupStatus.VolumeClaimName
+	chain := []*apiv1.Backup{backup}
+	visited := map[string]bool{backup.Name: true}
+	for current := backup; current.Status.PersistentVolumeBackupStatus.ParentBackupName != ""; {
+		parentName := current.Status.PersistentVolumeBackupStatus.ParentBackupName
+		if visited[parentName] {
+			return nil, fmt.Errorf("the chain of backup %s contains a loop on %s", backup.Name, parentName)
+		}
+		visited[parentName] = true
+
+		var parent apiv1.Backup
+		if err := typedClient.Get(
+			ctx,
+			client.ObjectKey{Namespace: backup.Namespace, Name: parentName},
+			&parent,
+		); err != nil {
+			return nil, fmt.Errorf("while getting backup %s, part of the chain of %s: %w",
+				parentName, backup.Name, err)
+		}
+
+		if !parent.IsCompletedPersistentVolumeBackup() {
+			return nil, fmt.Errorf("backup %s, part of the chain of %s, is not completed", parentName, backup.Name)
+		}
+		if parent.Status.PersistentVolumeBackupStatus.VolumeClaimName != volumeClaimName {
+			return nil, fmt.Errorf("backup %s, part of the chain of %s, is stored in a different volume",
+				parentName, backup.Name)
+		}
+
+		chain = append(chain, &parent)
+		current = &parent
+	}
+
+	slices.Reverse(chain)
+	return chain, nil
+}
+
 // loadCluster loads the cluster definition from the API server
 func (info InitInfo) loadCluster(ctx context.Context, typedClient client.Client) (*apiv1.Cluster, error) {
 	var cluster apiv1.Cluster
@@ -649,6 +773,22 @@ func (info InitInfo) writeCustomRestoreWalConfig(cluster *apiv1.Cluster, conf st
 	return info.writeRecoveryConfiguration(cluster, recoveryFileContents)
 }
 
+// getPersistentVolumeRestoreWalConfig obtains the content to append to
+// `custom.conf` allowing PostgreSQL to complete the WAL recovery from the
+// WAL archive of the source cluster in the backup volume, and then start
+// as a new primary
+func getPersistentVolumeRestoreWalConfig(backup *apiv1.Backup) string {
+	walArchive := path.Join(
+		specs.RecoveryBackupVolumePath,
+		path.Dir(backup.Status.PersistentVolumeBackupStatus.Path),
+		specs.PersistentVolumeWALArchiveDirectory)
+
+	return fmt.Sprintf(
+		"recovery_target_action = promote\n"+
+			"restore_command = 'cp %s/%%f %%p'\n",
+		walArchive)
+}
+
 // getRestoreWalConfig obtains the content to append to `custom.conf` allowing PostgreSQL
 // to complete the WAL recovery from the object storage and then start
 // as a new primary
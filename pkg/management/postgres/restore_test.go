@@ -207,3 +207,20 @@ var _ = Describe("testing restore InitInfo methods", func() {
 		Expect(enforcedParamsInPGData["max_connections"]).To(Equal(200))
 	})
 })
+
+var _ = Describe("restore from the backup volume", func() {
+	It("fetches the WAL files from the archive of the source cluster", func() {
+		backup := &apiv1.Backup{
+			Status: apiv1.BackupStatus{
+				PersistentVolumeBackupStatus: &apiv1.PersistentVolumeBackupStatus{
+					VolumeClaimName: "source-backups",
+					Path:            "source/backup-1",
+				},
+			},
+		}
+
+		Expect(getPersistentVolumeRestoreWalConfig(backup)).To(Equal(
+			"recovery_target_action = promote\n" +
+				"restore_command = 'cp /var/lib/postgresql/recovery-backups/source/wal_archive/%f %p'\n"))
+	})
+})
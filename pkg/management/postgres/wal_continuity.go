@@ -0,0 +1,42 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package postgres
+
+import (
+	"time"
+
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
+)
+
+// WALArchiveContinuityReport is the outcome of a check of the continuity
+// of the WAL archive
+type WALArchiveContinuityReport struct {
+	// Continuity describes the content of the WAL archive, and is nil
+	// when the WAL archive contains no WAL segment
+	Continuity *postgres.WALArchiveContinuity
+
+	// FirstRecoverabilityPoint is the end time of the oldest backup which
+	// can be recovered up to the newest WAL segment in the WAL archive,
+	// nil if there's no such backup
+	FirstRecoverabilityPoint *time.Time
+
+	// CheckedAt is when the WAL archive has been checked
+	CheckedAt time.Time
+}
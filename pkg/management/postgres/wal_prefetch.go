@@ -0,0 +1,61 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package postgres
+
+import (
+	"go.uber.org/atomic"
+)
+
+// WALPrefetchStats counts how the WAL files requested by PostgreSQL have
+// been served when prefetching the WAL files restored through the plugins
+type WALPrefetchStats struct {
+	hits       atomic.Uint64
+	misses     atomic.Uint64
+	prefetched atomic.Uint64
+}
+
+// Record records a WAL file requested by PostgreSQL, which has been found
+// in the spool or not, and the number of WAL files prefetched afterwards
+func (stats *WALPrefetchStats) Record(hit bool, prefetched int) {
+	if hit {
+		stats.hits.Inc()
+	} else {
+		stats.misses.Inc()
+	}
+	if prefetched > 0 {
+		stats.prefetched.Add(uint64(prefetched))
+	}
+}
+
+// Hits is the number of WAL files that were found in the spool
+func (stats *WALPrefetchStats) Hits() uint64 {
+	return stats.hits.Load()
+}
+
+// Misses is the number of WAL files that had to be restored on request
+func (stats *WALPrefetchStats) Misses() uint64 {
+	return stats.misses.Load()
+}
+
+// Prefetched is the number of WAL files restored into the spool ahead of
+// the PostgreSQL requests
+func (stats *WALPrefetchStats) Prefetched() uint64 {
+	return stats.prefetched.Load()
+}
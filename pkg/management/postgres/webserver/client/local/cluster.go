@@ -37,6 +37,12 @@ type ClusterClient interface {
 	// An empty errMessage means that the archive process was successful.
 	// Returns any error encountered during the request.
 	SetWALArchiveStatusCondition(ctx context.Context, errMessage string) error
+
+	// RecordWALPrefetch records whether a WAL file restored through the
+	// plugins was found in the spool, and how many WAL files have been
+	// prefetched afterwards.
+	// Returns any error encountered during the request.
+	RecordWALPrefetch(ctx context.Context, hit bool, prefetched int) error
 }
 
 // clusterClientImpl a client to interact with the uncategorized endpoints
@@ -72,3 +78,33 @@ func (c *clusterClientImpl) SetWALArchiveStatusCondition(ctx context.Context, er
 
 	return nil
 }
+
+func (c *clusterClientImpl) RecordWALPrefetch(ctx context.Context, hit bool, prefetched int) error {
+	contextLogger := log.FromContext(ctx).WithValues("endpoint", url.PathWALPrefetchStats)
+
+	request := webserver.WALPrefetchRequest{
+		Hit:        hit,
+		Prefetched: prefetched,
+	}
+
+	encoded, err := json.Marshal(&request)
+	if err != nil {
+		return err
+	}
+
+	resp, err := http.Post(
+		url.Local(url.PathWALPrefetchStats, url.LocalPort),
+		"application/json",
+		bytes.NewBuffer(encoded),
+	)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if errClose := resp.Body.Close(); errClose != nil {
+			contextLogger.Error(errClose, "while closing response body")
+		}
+	}()
+
+	return nil
+}
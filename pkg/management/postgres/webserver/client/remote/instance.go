@@ -20,6 +20,7 @@ SPDX-License-Identifier: Apache-2.0
 package remote
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
@@ -37,6 +38,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/util/retry"
 
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres/webserver"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/url"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/specs"
@@ -83,6 +85,10 @@ type InstanceClient interface {
 	// ArchivePartialWAL trigger the archiver for the latest partial WAL
 	// file created in a specific Pod
 	ArchivePartialWAL(context.Context, *corev1.Pod) (string, error)
+
+	// CreateRestorePoint creates a named restore point on the primary
+	// running in the passed Pod, returning its LSN
+	CreateRestorePoint(ctx context.Context, pod *corev1.Pod, name string) (string, error)
 }
 
 type instanceClientImpl struct {
@@ -411,3 +417,28 @@ func (r *instanceClientImpl) ArchivePartialWAL(ctx context.Context, pod *corev1.
 
 	return result.Data, nil
 }
+
+func (r *instanceClientImpl) CreateRestorePoint(ctx context.Context, pod *corev1.Pod, name string) (string, error) {
+	jsonBody, err := json.Marshal(webserver.RestorePointRequest{Name: name})
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal restore point payload: %w", err)
+	}
+
+	httpURL := url.Build(
+		GetStatusSchemeFromPod(pod).ToString(), pod.Status.PodIP, url.PathPgRestorePoint, url.StatusPort)
+	req, err := http.NewRequestWithContext(ctx, "POST", httpURL, bytes.NewReader(jsonBody))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	result, err := executeRequestWithError[webserver.RestorePointResultData](ctx, r.Client, req, false)
+	if err != nil {
+		return "", err
+	}
+	if err := result.GetError(); err != nil {
+		return "", err
+	}
+
+	return result.Data.LSN, nil
+}
@@ -64,6 +64,7 @@ func NewLocalWebServer(
 	serveMux.HandleFunc(url.PathCache, endpoints.serveCache)
 	serveMux.HandleFunc(url.PathPgBackup, endpoints.requestBackup)
 	serveMux.HandleFunc(url.PathWALArchiveStatusCondition, endpoints.setWALArchiveStatusCondition)
+	serveMux.HandleFunc(url.PathWALPrefetchStats, endpoints.recordWALPrefetch)
 
 	server := &http.Server{
 		Addr:              fmt.Sprintf("localhost:%d", url.LocalPort),
@@ -186,6 +187,24 @@ func (ws *localWebserverEndpoints) requestBackup(w http.ResponseWriter, r *http.
 		ws.startPluginBackup(ctx, cluster, &backup)
 		_, _ = fmt.Fprint(w, "OK")
 
+	case apiv1.BackupMethodLogical:
+		if !backup.IsPluginBased() && cluster.GetLogicalBackupVolumeClaimName() == "" {
+			http.Error(w, "Logical backup not configured in the cluster", http.StatusConflict)
+			return
+		}
+
+		ws.startLogicalBackup(ctx, cluster, &backup)
+		_, _ = fmt.Fprint(w, "OK")
+
+	case apiv1.BackupMethodPersistentVolume:
+		if cluster.GetPersistentVolumeBackupClaimName() == "" {
+			http.Error(w, "Backup volume not configured in the cluster", http.StatusConflict)
+			return
+		}
+
+		NewPersistentVolumeBackupCommand(cluster, &backup, ws.typedClient, ws.eventRecorder).Start(ctx)
+		_, _ = fmt.Fprint(w, "OK")
+
 	default:
 		http.Error(
 			w,
@@ -243,6 +262,16 @@ func (ws *localWebserverEndpoints) startPluginBackup(
 	NewPluginBackupCommand(cluster, backup, ws.typedClient, ws.eventRecorder).Start(ctx)
 }
 
+func (ws *localWebserverEndpoints) startLogicalBackup(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+	backup *apiv1.Backup,
+) {
+	// TODO: timeout should be configurable by the user
+	ctx = context.WithValue(ctx, utils.GRPCTimeoutKey, 100*time.Minute)
+	NewLogicalBackupCommand(cluster, backup, ws.typedClient, ws.eventRecorder, ws.instance).Start(ctx)
+}
+
 // ArchiveStatusRequest is the request body for the archive status endpoint
 type ArchiveStatusRequest struct {
 	Error string `json:"error,omitempty"`
@@ -303,3 +332,32 @@ func (ws *localWebserverEndpoints) setWALArchiveStatusCondition(w http.ResponseW
 
 	_, _ = fmt.Fprint(w, "OK")
 }
+
+// WALPrefetchRequest is the request body of the endpoint recording how a
+// WAL file restored through the plugins has been served by the prefetching
+type WALPrefetchRequest struct {
+	// Hit is true when the WAL file was found in the spool
+	Hit bool `json:"hit"`
+
+	// Prefetched is the number of WAL files restored into the spool
+	// afterwards
+	Prefetched int `json:"prefetched"`
+}
+
+// recordWALPrefetch records how a WAL file restored through the plugins
+// has been served, to be exposed by the metrics exporter
+func (ws *localWebserverEndpoints) recordWALPrefetch(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	var request WALPrefetchRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, fmt.Sprintf("error while decoding request: %v", err.Error()), http.StatusBadRequest)
+		return
+	}
+
+	ws.instance.GetWALPrefetchStats().Record(request.Hit, request.Prefetched)
+	_, _ = fmt.Fprint(w, "OK")
+}
@@ -0,0 +1,200 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package webserver
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"os"
+	"path"
+
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres/logicalimport"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/resources/status"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/specs"
+)
+
+// LogicalBackupDirectoryParameter is the name of the plugin parameter
+// containing the directory where the dumps of a logical backup are stored,
+// when they are handed over to a plugin
+const LogicalBackupDirectoryParameter = "cnpg.io/logicalBackupDirectory"
+
+// LogicalBackupCommand represents a logical backup that is being executed
+type LogicalBackupCommand struct {
+	*PluginBackupCommand
+
+	Instance *postgres.Instance
+}
+
+// NewLogicalBackupCommand initializes a LogicalBackupCommand object, taking
+// a logical backup of the databases with pg_dump
+func NewLogicalBackupCommand(
+	cluster *apiv1.Cluster,
+	backup *apiv1.Backup,
+	client client.Client,
+	recorder record.EventRecorder,
+	instance *postgres.Instance,
+) *LogicalBackupCommand {
+	return &LogicalBackupCommand{
+		PluginBackupCommand: NewPluginBackupCommand(cluster, backup, client, recorder),
+		Instance:            instance,
+	}
+}
+
+// Start starts the logical backup
+func (b *LogicalBackupCommand) Start(ctx context.Context) {
+	go b.run(ctx)
+}
+
+func (b *LogicalBackupCommand) run(ctx context.Context) {
+	contextLogger := log.FromContext(ctx).WithValues(
+		"backupName", b.Backup.Name,
+		"backupNamespace", b.Backup.Namespace)
+
+	contextLogger.Info("Logical backup started")
+	b.Recorder.Event(b.Backup, "Normal", "Starting", "Backup started")
+
+	b.Backup.Status.Phase = apiv1.BackupPhaseRunning
+	b.Backup.Status.StartedAt = ptr.To(metav1.Now())
+	if err := postgres.PatchBackupStatusAndRetry(ctx, b.Client, b.Backup); err != nil {
+		contextLogger.Error(err, "can't set backup as running")
+	}
+
+	if err := b.retryWithRefreshedCluster(ctx, func() error {
+		return status.PatchConditionsWithOptimisticLock(ctx, b.Client, b.Cluster, apiv1.BackupStartingCondition)
+	}); err != nil {
+		contextLogger.Error(err, "Error changing backup condition (backup started)")
+	}
+
+	if err := b.takeBackup(ctx); err != nil {
+		b.markBackupAsFailed(ctx, err)
+		return
+	}
+
+	contextLogger.Info("Backup completed")
+	b.Recorder.Event(b.Backup, "Normal", "Completed", "Backup completed")
+
+	b.Backup.Status.SetAsCompleted()
+	if err := postgres.PatchBackupStatusAndRetry(ctx, b.Client, b.Backup); err != nil {
+		contextLogger.Error(err, "Can't set backup status as completed")
+	}
+
+	if err := b.retryWithRefreshedCluster(ctx, func() error {
+		return status.PatchConditionsWithOptimisticLock(ctx, b.Client, b.Cluster, apiv1.BackupSucceededCondition)
+	}); err != nil {
+		contextLogger.Error(err, "Can't update the cluster with the completed backup data")
+	}
+}
+
+// takeBackup dumps the databases and, when requested, hands
+// the dumps over to the plugin managing the backup
+func (b *LogicalBackupCommand) takeBackup(ctx context.Context) error {
+	var options apiv1.LogicalBackupOptions
+	if b.Backup.Spec.Logical != nil {
+		options = *b.Backup.Spec.Logical
+	}
+
+	connectionPool := b.Instance.ConnectionPool()
+	databases := options.Databases
+	if len(databases) == 0 {
+		var err error
+		if databases, err = logicalimport.ListDatabases(ctx, connectionPool); err != nil {
+			return fmt.Errorf("while listing the databases: %w", err)
+		}
+	}
+
+	relativePath := path.Join(b.Cluster.Name, b.Backup.Name)
+	destination := path.Join(specs.LogicalBackupVolumePath, relativePath)
+	if b.Backup.IsPluginBased() {
+		// The dumps are staged in a dedicated ephemeral volume, so that they
+		// can't fill the volumes used by PostgreSQL
+		if _, err := os.Stat(specs.LogicalBackupStagingPath); err != nil {
+			return fmt.Errorf("the logical backup staging volume is not available: %w", err)
+		}
+
+		destination = path.Join(specs.LogicalBackupStagingPath, b.Backup.Name)
+		defer func() {
+			if err := os.RemoveAll(destination); err != nil {
+				log.FromContext(ctx).Error(err, "while removing the staged logical backup",
+					"directory", destination)
+			}
+		}()
+	}
+
+	dumps, err := logicalimport.Dump(ctx, connectionPool, databases, destination, options.PgDumpExtraOptions)
+	if err != nil {
+		return err
+	}
+
+	logicalStatus := &apiv1.LogicalBackupStatus{
+		Databases: make([]apiv1.LogicalBackupDatabaseStatus, len(dumps)),
+	}
+	for i, dump := range dumps {
+		logicalStatus.Databases[i] = apiv1.LogicalBackupDatabaseStatus{
+			Name:     dump.Name,
+			Size:     dump.Size,
+			Duration: metav1.Duration{Duration: dump.Duration},
+		}
+	}
+	b.Backup.Status.LogicalBackupStatus = logicalStatus
+
+	if !b.Backup.IsPluginBased() {
+		logicalStatus.VolumeClaimName = b.Cluster.GetLogicalBackupVolumeClaimName()
+		logicalStatus.Path = relativePath
+		return nil
+	}
+
+	return b.handOverToPlugin(ctx, destination)
+}
+
+// handOverToPlugin invokes the plugin managing the backup, passing
+// the directory containing the dumps
+func (b *LogicalBackupCommand) handOverToPlugin(ctx context.Context, directory string) error {
+	pluginName := b.Backup.Spec.PluginConfiguration.Name
+	cli, closePlugins, err := loadBackupPlugins(ctx, b.Cluster, pluginName)
+	if err != nil {
+		return err
+	}
+	defer closePlugins()
+
+	parameters := maps.Clone(b.Backup.Spec.PluginConfiguration.Parameters)
+	if parameters == nil {
+		parameters = make(map[string]string, 1)
+	}
+	parameters[LogicalBackupDirectoryParameter] = directory
+
+	response, err := cli.Backup(ctx, b.Cluster, b.Backup, pluginName, parameters)
+	if err != nil {
+		return fmt.Errorf("while handing over the logical backup to plugin %s: %w", pluginName, err)
+	}
+
+	b.Backup.Status.BackupID = response.BackupID
+	b.Backup.Status.BackupName = response.BackupName
+	b.Backup.Status.PluginMetadata = response.Metadata
+	return nil
+}
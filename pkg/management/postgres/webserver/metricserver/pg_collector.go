@@ -76,6 +76,26 @@ type metrics struct {
 	FencingOn                    prometheus.Gauge
 	PgStatWalMetrics             PgStatWalMetrics
 	NodesUsed                    prometheus.Gauge
+	WALPrefetch                  WALPrefetchMetrics
+	WALArchiveContinuity         WALArchiveContinuityMetrics
+}
+
+// WALArchiveContinuityMetrics describe the outcome of the last check of
+// the continuity of the WAL archive, which is kept by the instance
+type WALArchiveContinuityMetrics struct {
+	Gaps                     *prometheus.Desc
+	MissingSegments          *prometheus.Desc
+	MissingHistoryFiles      *prometheus.Desc
+	FirstRecoverabilityPoint *prometheus.Desc
+	LastCheckTimestamp       *prometheus.Desc
+}
+
+// WALPrefetchMetrics are the counters of the prefetching of the WAL files
+// restored through the plugins, which are kept by the instance
+type WALPrefetchMetrics struct {
+	Hits       *prometheus.Desc
+	Misses     *prometheus.Desc
+	Prefetched *prometheus.Desc
 }
 
 // PgStatWalMetrics is available from PG14+
@@ -206,6 +226,43 @@ func newMetrics() *metrics {
 				"implying the absence of High Availability (HA). Ideally this value " +
 				"should match the number of instances in the cluster.",
 		}),
+		WALPrefetch: WALPrefetchMetrics{
+			Hits: prometheus.NewDesc(
+				prometheus.BuildFQName(PrometheusNamespace, subsystem, "wal_prefetch_hits_total"),
+				"Number of WAL files restored through the plugins that were found in the spool.",
+				nil, nil),
+			Misses: prometheus.NewDesc(
+				prometheus.BuildFQName(PrometheusNamespace, subsystem, "wal_prefetch_misses_total"),
+				"Number of WAL files restored through the plugins that were not found in the spool.",
+				nil, nil),
+			Prefetched: prometheus.NewDesc(
+				prometheus.BuildFQName(PrometheusNamespace, subsystem, "wal_prefetch_prefetched_total"),
+				"Number of WAL files prefetched into the spool through the plugins.",
+				nil, nil),
+		},
+		WALArchiveContinuity: WALArchiveContinuityMetrics{
+			Gaps: prometheus.NewDesc(
+				prometheus.BuildFQName(PrometheusNamespace, subsystem, "wal_archive_gaps"),
+				"Number of gaps found in the WAL archive by the last continuity check.",
+				nil, nil),
+			MissingSegments: prometheus.NewDesc(
+				prometheus.BuildFQName(PrometheusNamespace, subsystem, "wal_archive_missing_segments"),
+				"Number of WAL segments missing from the WAL archive according to the last continuity check.",
+				nil, nil),
+			MissingHistoryFiles: prometheus.NewDesc(
+				prometheus.BuildFQName(PrometheusNamespace, subsystem, "wal_archive_missing_history_files"),
+				"Number of timeline history files missing from the WAL archive according to the last continuity check.",
+				nil, nil),
+			FirstRecoverabilityPoint: prometheus.NewDesc(
+				prometheus.BuildFQName(PrometheusNamespace, subsystem, "wal_archive_first_recoverability_point"),
+				"The end time of the oldest backup which can be recovered up to the newest archived WAL segment, "+
+					"according to the last continuity check.",
+				nil, nil),
+			LastCheckTimestamp: prometheus.NewDesc(
+				prometheus.BuildFQName(PrometheusNamespace, subsystem, "wal_archive_last_continuity_check_timestamp"),
+				"The time of the last continuity check of the WAL archive.",
+				nil, nil),
+		},
 		PgStatWalMetrics: PgStatWalMetrics{
 			WalRecords: prometheus.NewGaugeVec(prometheus.GaugeOpts{
 				Namespace: PrometheusNamespace,
@@ -285,6 +342,14 @@ func (e *Exporter) Describe(ch chan<- *prometheus.Desc) {
 	e.Metrics.LastFailedBackupTimestamp.Describe(ch)
 	e.Metrics.LastAvailableBackupTimestamp.Describe(ch)
 	e.Metrics.NodesUsed.Describe(ch)
+	ch <- e.Metrics.WALPrefetch.Hits
+	ch <- e.Metrics.WALPrefetch.Misses
+	ch <- e.Metrics.WALPrefetch.Prefetched
+	ch <- e.Metrics.WALArchiveContinuity.Gaps
+	ch <- e.Metrics.WALArchiveContinuity.MissingSegments
+	ch <- e.Metrics.WALArchiveContinuity.MissingHistoryFiles
+	ch <- e.Metrics.WALArchiveContinuity.FirstRecoverabilityPoint
+	ch <- e.Metrics.WALArchiveContinuity.LastCheckTimestamp
 
 	if e.queries != nil {
 		e.queries.Describe(ch)
@@ -325,6 +390,8 @@ func (e *Exporter) Collect(ch chan<- prometheus.Metric) {
 	e.Metrics.LastFailedBackupTimestamp.Collect(ch)
 	e.Metrics.LastAvailableBackupTimestamp.Collect(ch)
 	e.Metrics.NodesUsed.Collect(ch)
+	e.collectWALPrefetchMetrics(ch)
+	e.collectWALArchiveContinuityMetrics(ch)
 
 	if version, _ := e.instance.GetPgVersion(); version.Major >= 14 {
 		e.Metrics.PgStatWalMetrics.WalRecords.Collect(ch)
@@ -340,6 +407,48 @@ func (e *Exporter) Collect(ch chan<- prometheus.Metric) {
 	}
 }
 
+// collectWALPrefetchMetrics exposes the counters of the prefetching of
+// the WAL files restored through the plugins. They are available even when
+// PostgreSQL can't be accessed, as it happens while recovering
+func (e *Exporter) collectWALPrefetchMetrics(ch chan<- prometheus.Metric) {
+	stats := e.instance.GetWALPrefetchStats()
+	ch <- prometheus.MustNewConstMetric(e.Metrics.WALPrefetch.Hits, prometheus.CounterValue, float64(stats.Hits()))
+	ch <- prometheus.MustNewConstMetric(e.Metrics.WALPrefetch.Misses, prometheus.CounterValue, float64(stats.Misses()))
+	ch <- prometheus.MustNewConstMetric(
+		e.Metrics.WALPrefetch.Prefetched, prometheus.CounterValue, float64(stats.Prefetched()))
+}
+
+// collectWALArchiveContinuityMetrics exposes the outcome of the last
+// check of the continuity of the WAL archive, when the check is running
+// on this instance
+func (e *Exporter) collectWALArchiveContinuityMetrics(ch chan<- prometheus.Metric) {
+	report := e.instance.GetWALArchiveContinuity()
+	if report == nil {
+		return
+	}
+
+	var gaps, missingSegments, missingHistoryFiles float64
+	if report.Continuity != nil {
+		gaps = float64(len(report.Continuity.Gaps))
+		missingSegments = float64(report.Continuity.MissingSegments())
+		missingHistoryFiles = float64(len(report.Continuity.MissingHistoryFiles))
+	}
+
+	var firstRecoverabilityPoint float64
+	if report.FirstRecoverabilityPoint != nil {
+		firstRecoverabilityPoint = float64(report.FirstRecoverabilityPoint.Unix())
+	}
+
+	metrics := e.Metrics.WALArchiveContinuity
+	ch <- prometheus.MustNewConstMetric(metrics.Gaps, prometheus.GaugeValue, gaps)
+	ch <- prometheus.MustNewConstMetric(metrics.MissingSegments, prometheus.GaugeValue, missingSegments)
+	ch <- prometheus.MustNewConstMetric(metrics.MissingHistoryFiles, prometheus.GaugeValue, missingHistoryFiles)
+	ch <- prometheus.MustNewConstMetric(
+		metrics.FirstRecoverabilityPoint, prometheus.GaugeValue, firstRecoverabilityPoint)
+	ch <- prometheus.MustNewConstMetric(
+		metrics.LastCheckTimestamp, prometheus.GaugeValue, float64(report.CheckedAt.Unix()))
+}
+
 func (e *Exporter) collectPgMetrics(ch chan<- prometheus.Metric) {
 	e.Metrics.CollectionsTotal.Inc()
 	collectionStart := time.Now()
@@ -292,6 +292,106 @@ var _ = Describe("test metrics parsing", func() {
 	})
 })
 
+// walPrefetchCollector collects only the WAL prefetch metrics of an exporter
+type walPrefetchCollector struct {
+	exporter *Exporter
+}
+
+func (c walPrefetchCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.exporter.Metrics.WALPrefetch.Hits
+	ch <- c.exporter.Metrics.WALPrefetch.Misses
+	ch <- c.exporter.Metrics.WALPrefetch.Prefetched
+}
+
+func (c walPrefetchCollector) Collect(ch chan<- prometheus.Metric) {
+	c.exporter.collectWALPrefetchMetrics(ch)
+}
+
+var _ = Describe("WAL prefetch metrics", func() {
+	It("exposes the counters kept by the instance", func() {
+		instance := postgres.NewInstance()
+		instance.GetWALPrefetchStats().Record(false, 3)
+		instance.GetWALPrefetchStats().Record(true, 0)
+		instance.GetWALPrefetchStats().Record(true, 1)
+
+		registry := prometheus.NewRegistry()
+		registry.MustRegister(walPrefetchCollector{exporter: NewExporter(instance)})
+		metrics, err := registry.Gather()
+		Expect(err).ToNot(HaveOccurred())
+
+		expected := map[string]float64{
+			"cnpg_collector_wal_prefetch_hits_total":       2,
+			"cnpg_collector_wal_prefetch_misses_total":     1,
+			"cnpg_collector_wal_prefetch_prefetched_total": 4,
+		}
+		for name, value := range expected {
+			metric := getMetric(metrics, name)
+			Expect(metric).ToNot(BeNil(), name)
+			Expect(metric.GetMetric()[0].GetCounter().GetValue()).To(BeEquivalentTo(value), name)
+		}
+	})
+})
+
+// walArchiveContinuityCollector collects only the WAL archive continuity
+// metrics of an exporter
+type walArchiveContinuityCollector struct {
+	exporter *Exporter
+}
+
+func (c walArchiveContinuityCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.exporter.Metrics.WALArchiveContinuity.Gaps
+	ch <- c.exporter.Metrics.WALArchiveContinuity.MissingSegments
+	ch <- c.exporter.Metrics.WALArchiveContinuity.MissingHistoryFiles
+	ch <- c.exporter.Metrics.WALArchiveContinuity.FirstRecoverabilityPoint
+	ch <- c.exporter.Metrics.WALArchiveContinuity.LastCheckTimestamp
+}
+
+func (c walArchiveContinuityCollector) Collect(ch chan<- prometheus.Metric) {
+	c.exporter.collectWALArchiveContinuityMetrics(ch)
+}
+
+var _ = Describe("WAL archive continuity metrics", func() {
+	It("doesn't expose anything when the check isn't running on the instance", func() {
+		registry := prometheus.NewRegistry()
+		registry.MustRegister(walArchiveContinuityCollector{exporter: NewExporter(postgres.NewInstance())})
+		metrics, err := registry.Gather()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(metrics).To(BeEmpty())
+	})
+
+	It("exposes the outcome of the last check", func() {
+		firstRecoverabilityPoint := time.Unix(1700000000, 0)
+		instance := postgres.NewInstance()
+		instance.SetWALArchiveContinuity(&postgres.WALArchiveContinuityReport{
+			Continuity: postgresconf.CheckWALArchiveContinuity([]string{
+				"000000010000000000000001",
+				"000000010000000000000004",
+				"000000020000000000000005",
+			}, postgresconf.DefaultWALSegmentSize),
+			FirstRecoverabilityPoint: &firstRecoverabilityPoint,
+			CheckedAt:                time.Unix(1700000600, 0),
+		})
+
+		registry := prometheus.NewRegistry()
+		registry.MustRegister(walArchiveContinuityCollector{exporter: NewExporter(instance)})
+		metrics, err := registry.Gather()
+		Expect(err).ToNot(HaveOccurred())
+
+		expected := map[string]float64{
+			"cnpg_collector_wal_archive_gaps":                            1,
+			"cnpg_collector_wal_archive_missing_segments":                2,
+			"cnpg_collector_wal_archive_missing_history_files":           1,
+			"cnpg_collector_wal_archive_first_recoverability_point":      1700000000,
+			"cnpg_collector_wal_archive_last_continuity_check_timestamp": 1700000600,
+		}
+		for name, value := range expected {
+			metric := getMetric(metrics, name)
+			Expect(metric).ToNot(BeNil(), name)
+			Expect(metric.GetMetric()[0].GetGauge().GetValue()).To(BeEquivalentTo(value), name)
+		}
+	})
+})
+
 type nameGetter interface {
 	GetName() string
 }
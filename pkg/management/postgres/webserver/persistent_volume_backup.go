@@ -0,0 +1,166 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package webserver
+
+import (
+	"context"
+	"fmt"
+	"path"
+
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/resources/status"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/specs"
+)
+
+// PersistentVolumeBackupCommand represents a base backup, stored in the
+// backup volume of the cluster, that is being executed
+type PersistentVolumeBackupCommand struct {
+	*PluginBackupCommand
+}
+
+// NewPersistentVolumeBackupCommand initializes a PersistentVolumeBackupCommand
+// object, taking a base backup with pg_basebackup
+func NewPersistentVolumeBackupCommand(
+	cluster *apiv1.Cluster,
+	backup *apiv1.Backup,
+	client client.Client,
+	recorder record.EventRecorder,
+) *PersistentVolumeBackupCommand {
+	return &PersistentVolumeBackupCommand{
+		PluginBackupCommand: NewPluginBackupCommand(cluster, backup, client, recorder),
+	}
+}
+
+// Start starts the base backup
+func (b *PersistentVolumeBackupCommand) Start(ctx context.Context) {
+	go b.run(ctx)
+}
+
+func (b *PersistentVolumeBackupCommand) run(ctx context.Context) {
+	contextLogger := log.FromContext(ctx).WithValues(
+		"backupName", b.Backup.Name,
+		"backupNamespace", b.Backup.Namespace)
+
+	contextLogger.Info("Base backup started")
+	b.Recorder.Event(b.Backup, "Normal", "Starting", "Backup started")
+
+	b.Backup.Status.Phase = apiv1.BackupPhaseRunning
+	b.Backup.Status.StartedAt = ptr.To(metav1.Now())
+	if err := postgres.PatchBackupStatusAndRetry(ctx, b.Client, b.Backup); err != nil {
+		contextLogger.Error(err, "can't set backup as running")
+	}
+
+	if err := b.retryWithRefreshedCluster(ctx, func() error {
+		return status.PatchConditionsWithOptimisticLock(ctx, b.Client, b.Cluster, apiv1.BackupStartingCondition)
+	}); err != nil {
+		contextLogger.Error(err, "Error changing backup condition (backup started)")
+	}
+
+	if err := b.takeBackup(ctx); err != nil {
+		b.markBackupAsFailed(ctx, err)
+		return
+	}
+
+	contextLogger.Info("Backup completed")
+	b.Recorder.Event(b.Backup, "Normal", "Completed", "Backup completed")
+
+	b.Backup.Status.SetAsCompleted()
+	if err := postgres.PatchBackupStatusAndRetry(ctx, b.Client, b.Backup); err != nil {
+		contextLogger.Error(err, "Can't set backup status as completed")
+	}
+
+	if err := b.retryWithRefreshedCluster(ctx, func() error {
+		return status.PatchConditionsWithOptimisticLock(ctx, b.Client, b.Cluster, apiv1.BackupSucceededCondition)
+	}); err != nil {
+		contextLogger.Error(err, "Can't update the cluster with the completed backup data")
+	}
+}
+
+// takeBackup takes the base backup, incrementally when the backup
+// references a parent one
+func (b *PersistentVolumeBackupCommand) takeBackup(ctx context.Context) error {
+	relativePath := path.Join(b.Cluster.Name, b.Backup.Name)
+	backupStatus := &apiv1.PersistentVolumeBackupStatus{
+		VolumeClaimName: b.Cluster.GetPersistentVolumeBackupClaimName(),
+		Path:            relativePath,
+		Format:          b.Cluster.GetPersistentVolumeBackupFormat(),
+	}
+	options := postgres.BaseBackupOptions{
+		Destination:     path.Join(specs.PersistentVolumeBackupPath, relativePath),
+		ApplicationName: b.Backup.Name,
+		Format:          backupStatus.Format,
+	}
+
+	if b.Backup.Spec.ParentBackup != nil {
+		parentBackup, err := b.getParentBackup(ctx)
+		if err != nil {
+			return err
+		}
+		backupStatus.ParentBackupName = parentBackup.Name
+		options.ParentDirectory = path.Join(
+			specs.PersistentVolumeBackupPath,
+			parentBackup.Status.PersistentVolumeBackupStatus.Path)
+	}
+
+	if err := postgres.TakeBaseBackup(ctx, options); err != nil {
+		return err
+	}
+
+	walRanges, err := postgres.ReadBackupManifestWALRanges(options.Destination)
+	if err != nil {
+		return err
+	}
+
+	b.Backup.Status.PersistentVolumeBackupStatus = backupStatus
+	b.Backup.Status.BeginLSN = walRanges[0].StartLSN
+	b.Backup.Status.EndLSN = walRanges[len(walRanges)-1].EndLSN
+	return nil
+}
+
+// getParentBackup gets the backup this incremental backup is based on,
+// checking that its manifest is available in the backup volume
+func (b *PersistentVolumeBackupCommand) getParentBackup(ctx context.Context) (*apiv1.Backup, error) {
+	var parentBackup apiv1.Backup
+	if err := b.Client.Get(ctx, client.ObjectKey{
+		Namespace: b.Backup.Namespace,
+		Name:      b.Backup.Spec.ParentBackup.Name,
+	}, &parentBackup); err != nil {
+		return nil, fmt.Errorf("while getting the parent backup: %w", err)
+	}
+
+	if !parentBackup.IsCompletedPersistentVolumeBackup() {
+		return nil, fmt.Errorf("parent backup %s is not a completed persistentVolume backup", parentBackup.Name)
+	}
+
+	if parentBackup.Status.PersistentVolumeBackupStatus.VolumeClaimName !=
+		b.Cluster.GetPersistentVolumeBackupClaimName() {
+		return nil, fmt.Errorf("parent backup %s is stored in the %s volume, not in the backup volume of the cluster",
+			parentBackup.Name, parentBackup.Status.PersistentVolumeBackupStatus.VolumeClaimName)
+	}
+
+	return &parentBackup, nil
+}
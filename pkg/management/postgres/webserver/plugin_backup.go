@@ -79,37 +79,12 @@ func (b *PluginBackupCommand) invokeStart(ctx context.Context) {
 		"backupName", b.Backup.Name,
 		"backupNamespace", b.Backup.Name)
 
-	plugins := repository.New()
-	availablePlugins, err := plugins.RegisterUnixSocketPluginsInPath(configuration.Current.PluginSocketDir)
-	if err != nil {
-		contextLogger.Error(err, "Error while discovering plugins")
-	}
-	defer plugins.Close()
-
-	availablePluginNamesSet := stringset.From(availablePlugins)
-
-	enabledPluginNamesSet := stringset.From(
-		apiv1.GetPluginConfigurationEnabledPluginNames(b.Cluster.Spec.Plugins))
-	availableAndEnabled := stringset.From(availablePluginNamesSet.Intersect(enabledPluginNamesSet).ToList())
-
-	if !availableAndEnabled.Has(b.Backup.Spec.PluginConfiguration.Name) {
-		b.markBackupAsFailed(
-			ctx,
-			fmt.Errorf("requested plugin is not available: %s", b.Backup.Spec.PluginConfiguration.Name),
-		)
-		return
-	}
-
-	cli, err := pluginClient.WithPlugins(
-		ctx,
-		plugins,
-		availableAndEnabled.ToList()...,
-	)
+	cli, closePlugins, err := loadBackupPlugins(ctx, b.Cluster, b.Backup.Spec.PluginConfiguration.Name)
 	if err != nil {
 		b.markBackupAsFailed(ctx, err)
 		return
 	}
-	defer cli.Close(ctx)
+	defer closePlugins()
 
 	// record the backup beginning
 	contextLogger.Info("Plugin backup started")
@@ -173,6 +148,49 @@ func (b *PluginBackupCommand) invokeStart(ctx context.Context) {
 	}
 }
 
+// loadBackupPlugins loads the plugins enabled in the cluster, checking that
+// the one managing the backup is available. The returned function releases
+// the plugins and needs to be called when the backup is done
+func loadBackupPlugins(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+	pluginName string,
+) (pluginClient.Client, func(), error) {
+	contextLogger := log.FromContext(ctx)
+
+	plugins := repository.New()
+	availablePlugins, err := plugins.RegisterUnixSocketPluginsInPath(configuration.Current.PluginSocketDir)
+	if err != nil {
+		contextLogger.Error(err, "Error while discovering plugins")
+	}
+
+	availablePluginNamesSet := stringset.From(availablePlugins)
+
+	enabledPluginNamesSet := stringset.From(
+		apiv1.GetPluginConfigurationEnabledPluginNames(cluster.Spec.Plugins))
+	availableAndEnabled := stringset.From(availablePluginNamesSet.Intersect(enabledPluginNamesSet).ToList())
+
+	if !availableAndEnabled.Has(pluginName) {
+		plugins.Close()
+		return nil, nil, fmt.Errorf("requested plugin is not available: %s", pluginName)
+	}
+
+	cli, err := pluginClient.WithPlugins(
+		ctx,
+		plugins,
+		availableAndEnabled.ToList()...,
+	)
+	if err != nil {
+		plugins.Close()
+		return nil, nil, err
+	}
+
+	return cli, func() {
+		cli.Close(ctx)
+		plugins.Close()
+	}, nil
+}
+
 func (b *PluginBackupCommand) markBackupAsFailed(ctx context.Context, failure error) {
 	contextLogger := log.FromContext(ctx)
 
@@ -0,0 +1,179 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package probes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres"
+)
+
+// apiServerCheckTimeout is the time we wait for the API server to answer
+// before considering it unreachable. It must be well below the timeout
+// of the liveness probe, otherwise a slow API server would make the
+// kubelet restart a healthy instance
+const apiServerCheckTimeout = 2 * time.Second
+
+// livenessExecutor is the liveness probe checker. When the quorum-based
+// failover is enabled, it makes a primary instance that lost contact with
+// both the Kubernetes API server and all its replicas fail the probe, so
+// that the kubelet fences it by restarting the container.
+type livenessExecutor struct {
+	cli      client.Client
+	instance *postgres.Instance
+
+	// countStreamingReplicas returns the number of replicas that are
+	// currently streaming from this instance
+	countStreamingReplicas func(ctx context.Context, instance *postgres.Instance) (int, error)
+
+	m sync.Mutex
+
+	// isolatedSince is the moment in which this primary lost contact with
+	// both the API server and its replicas
+	isolatedSince time.Time
+}
+
+// NewLivenessChecker creates a new instance of the liveness probe checker
+func NewLivenessChecker(
+	cli client.Client,
+	instance *postgres.Instance,
+) Checker {
+	return &livenessExecutor{
+		cli:                    cli,
+		instance:               instance,
+		countStreamingReplicas: countStreamingReplicas,
+	}
+}
+
+// IsHealthy implements the Checker interface
+func (e *livenessExecutor) IsHealthy(
+	ctx context.Context,
+	w http.ResponseWriter,
+) {
+	if err := e.checkIsolation(ctx); err != nil {
+		log.FromContext(ctx).Warning("liveness probe failing", "err", err.Error())
+		http.Error(
+			w,
+			fmt.Sprintf("liveness check failed: %s", err.Error()),
+			http.StatusInternalServerError,
+		)
+		return
+	}
+
+	_, _ = fmt.Fprint(w, "OK")
+}
+
+// checkIsolation returns an error when this instance is an isolated primary
+// and the isolation lasted longer than the configured timeout. The API
+// server and the replicas are contacted only when the primary isolation
+// timeout is set, that is when the quorum-based failover is enabled
+func (e *livenessExecutor) checkIsolation(ctx context.Context) error {
+	contextLogger := log.FromContext(ctx)
+
+	e.m.Lock()
+	defer e.m.Unlock()
+
+	timeout := e.instance.GetPrimaryIsolationTimeout()
+	if timeout == 0 {
+		e.isolatedSince = time.Time{}
+		return nil
+	}
+
+	if isPrimary, err := e.instance.IsPrimary(); err != nil || !isPrimary {
+		e.isolatedSince = time.Time{}
+		return nil
+	}
+
+	if e.isAPIServerReachable(ctx) {
+		e.isolatedSince = time.Time{}
+		return nil
+	}
+
+	replicas, err := e.countStreamingReplicas(ctx, e.instance)
+	if err == nil && replicas > 0 {
+		e.isolatedSince = time.Time{}
+		return nil
+	}
+
+	if e.isolatedSince.IsZero() {
+		e.isolatedSince = time.Now()
+	}
+
+	isolatedFor := time.Since(e.isolatedSince)
+	contextLogger.Warning(
+		"Primary instance cannot reach the API server nor any replica",
+		"isolatedSince", e.isolatedSince,
+		"primaryIsolationTimeout", timeout)
+	if isolatedFor < timeout {
+		return nil
+	}
+
+	return fmt.Errorf(
+		"primary instance is isolated from the API server and from its replicas since %s",
+		e.isolatedSince.Format(time.RFC3339))
+}
+
+// isAPIServerReachable checks if the API server is answering our requests
+func (e *livenessExecutor) isAPIServerReachable(ctx context.Context) bool {
+	timeoutContext, cancel := context.WithTimeout(ctx, apiServerCheckTimeout)
+	defer cancel()
+
+	var cluster apiv1.Cluster
+	err := e.cli.Get(
+		timeoutContext,
+		client.ObjectKey{Namespace: e.instance.GetNamespaceName(), Name: e.instance.GetClusterName()},
+		&cluster,
+	)
+	if err == nil {
+		return true
+	}
+
+	// Any answer coming from the API server, even an error one, means
+	// that we are not isolated from it
+	var statusErr apierrs.APIStatus
+	return errors.As(err, &statusErr)
+}
+
+func countStreamingReplicas(ctx context.Context, instance *postgres.Instance) (int, error) {
+	superUserDB, err := instance.GetSuperUserDB()
+	if err != nil {
+		return 0, fmt.Errorf("while getting superuser connection pool: %w", err)
+	}
+
+	var replicas int
+	row := superUserDB.QueryRowContext(
+		ctx,
+		"SELECT pg_catalog.count(*) FROM pg_catalog.pg_stat_replication WHERE state = 'streaming'")
+	if err := row.Scan(&replicas); err != nil {
+		return 0, fmt.Errorf("while counting streaming replicas: %w", err)
+	}
+
+	return replicas, nil
+}
@@ -0,0 +1,121 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package probes
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/internal/scheme"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Liveness probe isolation check", func() {
+	var (
+		ctx      context.Context
+		instance *postgres.Instance
+		executor *livenessExecutor
+		apiCalls int
+		apiDown  bool
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		apiCalls = 0
+		apiDown = false
+
+		instance = postgres.NewInstance().
+			WithNamespace("default").
+			WithClusterName("cluster-example").
+			WithPodName("cluster-example-1")
+		instance.PgData = GinkgoT().TempDir()
+
+		cli := fake.NewClientBuilder().
+			WithScheme(scheme.BuildWithAllKnownScheme()).
+			WithObjects(&apiv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "cluster-example", Namespace: "default"},
+			}).
+			WithInterceptorFuncs(interceptor.Funcs{
+				Get: func(
+					ctx context.Context,
+					cli client.WithWatch,
+					key client.ObjectKey,
+					obj client.Object,
+					opts ...client.GetOption,
+				) error {
+					apiCalls++
+					if apiDown {
+						return errors.New("connection refused")
+					}
+					return cli.Get(ctx, key, obj, opts...)
+				},
+			}).
+			Build()
+
+		executor = &livenessExecutor{
+			cli:      cli,
+			instance: instance,
+			countStreamingReplicas: func(context.Context, *postgres.Instance) (int, error) {
+				return 0, nil
+			},
+		}
+	})
+
+	It("doesn't contact the API server when the primary isolation timeout is not set", func() {
+		apiDown = true
+		Expect(executor.checkIsolation(ctx)).To(Succeed())
+		Expect(apiCalls).To(BeZero())
+	})
+
+	It("doesn't contact the API server on replicas", func() {
+		instance.SetPrimaryIsolationTimeout(time.Nanosecond)
+		Expect(os.WriteFile(path.Join(instance.PgData, "standby.signal"), nil, 0o600)).To(Succeed())
+
+		apiDown = true
+		Expect(executor.checkIsolation(ctx)).To(Succeed())
+		Expect(apiCalls).To(BeZero())
+	})
+
+	It("fails when the primary is isolated for longer than the timeout", func() {
+		instance.SetPrimaryIsolationTimeout(time.Minute)
+		Expect(executor.checkIsolation(ctx)).To(Succeed())
+		Expect(apiCalls).To(Equal(1))
+
+		apiDown = true
+		Expect(executor.checkIsolation(ctx)).To(Succeed())
+		executor.isolatedSince = time.Now().Add(-2 * time.Minute)
+		Expect(executor.checkIsolation(ctx)).ToNot(Succeed())
+
+		apiDown = false
+		Expect(executor.checkIsolation(ctx)).To(Succeed())
+	})
+})
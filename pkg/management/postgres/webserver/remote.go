@@ -22,6 +22,7 @@ package webserver
 import (
 	"context"
 	"crypto/tls"
+	"crypto/x509"
 	"database/sql"
 	"encoding/json"
 	"errors"
@@ -41,6 +42,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/certs"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/concurrency"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/management"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres"
@@ -51,7 +53,11 @@ import (
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
 )
 
-const errCodeAnotherRequestInProgress = "ANOTHER_REQUEST_IN_PROGRESS"
+const (
+	errCodeAnotherRequestInProgress = "ANOTHER_REQUEST_IN_PROGRESS"
+	errCodeClientCANotLoaded        = "CLIENT_CA_NOT_LOADED"
+	errCodeUnauthorized             = "UNAUTHORIZED"
+)
 
 // IsRetryableError checks if the error is retryable
 func IsRetryableError(err *Error) bool {
@@ -66,6 +72,7 @@ type remoteWebserverEndpoints struct {
 	instance             *postgres.Instance
 	currentBackup        *backupConnection
 	ongoingBackupRequest sync.Mutex
+	livenessChecker      probes.Checker
 }
 
 // StartBackupRequest the required data to execute the pg_start_backup
@@ -80,6 +87,17 @@ type StopBackupRequest struct {
 	BackupName string `json:"backupName"`
 }
 
+// RestorePointRequest the required data to create a named restore point
+type RestorePointRequest struct {
+	Name string `json:"name"`
+}
+
+// RestorePointResultData is the result of the creation of a restore point
+type RestorePointResultData struct {
+	// The LSN of the restore point
+	LSN string `json:"lsn"`
+}
+
 // NewStopBackupRequest constructor
 func NewStopBackupRequest(backupName string) *StopBackupRequest {
 	return &StopBackupRequest{BackupName: backupName}
@@ -97,19 +115,24 @@ func NewRemoteWebServer(
 	}
 
 	endpoints := remoteWebserverEndpoints{
-		typedClient: typedClient,
-		instance:    instance,
+		typedClient:     typedClient,
+		instance:        instance,
+		livenessChecker: probes.NewLivenessChecker(typedClient, instance),
 	}
 
 	serveMux := http.NewServeMux()
-	serveMux.HandleFunc(url.PathPgModeBackup, endpoints.backup)
+	serveMux.HandleFunc(url.PathPgModeBackup, endpoints.requireOperatorClientCertificate(endpoints.backup))
 	serveMux.HandleFunc(url.PathHealth, endpoints.isServerHealthy)
 	serveMux.HandleFunc(url.PathReady, endpoints.isServerReady)
 	serveMux.HandleFunc(url.PathStartup, endpoints.isServerStartedUp)
 	serveMux.HandleFunc(url.PathPgStatus, endpoints.pgStatus)
-	serveMux.HandleFunc(url.PathPgArchivePartial, endpoints.pgArchivePartial)
+	serveMux.HandleFunc(url.PathPgArchivePartial, endpoints.requireOperatorClientCertificate(endpoints.pgArchivePartial))
+	serveMux.HandleFunc(url.PathPgRestorePoint, endpoints.requireOperatorClientCertificate(endpoints.pgRestorePoint))
 	serveMux.HandleFunc(url.PathPGControlData, endpoints.pgControlData)
-	serveMux.HandleFunc(url.PathUpdate, endpoints.updateInstanceManager(cancelFunc, exitedConditions))
+	serveMux.HandleFunc(
+		url.PathUpdate,
+		endpoints.requireOperatorClientCertificate(endpoints.updateInstanceManager(cancelFunc, exitedConditions)),
+	)
 
 	server := &http.Server{
 		Addr:              fmt.Sprintf(":%d", url.StatusPort),
@@ -124,6 +147,10 @@ func NewRemoteWebServer(
 			GetCertificate: func(_ *tls.ClientHelloInfo) (*tls.Certificate, error) {
 				return instance.ServerCertificate, nil
 			},
+			// The client certificate is requested but not required, as the
+			// probes executed by the kubelet don't have one. It will be
+			// verified by the endpoints requiring it
+			ClientAuth: tls.RequestClientCert,
 		}
 	}
 
@@ -134,6 +161,47 @@ func NewRemoteWebServer(
 	return srv, nil
 }
 
+// requireOperatorClientCertificate wraps the handler of a mutating endpoint,
+// rejecting the requests that are not authenticated with the client
+// certificate issued to the operator
+func (ws *remoteWebserverEndpoints) requireOperatorClientCertificate(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		if !ws.instance.StatusPortTLS {
+			next(w, req)
+			return
+		}
+
+		pool, loaded := ws.instance.GetOperatorClientCA()
+		if !loaded {
+			sendJSONResponse(w, http.StatusServiceUnavailable, Response[any]{
+				Error: &Error{
+					Code:    errCodeClientCANotLoaded,
+					Message: "the operator client CA has not been loaded yet",
+				},
+			})
+			return
+		}
+
+		var chain []*x509.Certificate
+		if req.TLS != nil {
+			chain = req.TLS.PeerCertificates
+		}
+		if err := certs.VerifyOperatorClientCertificate(chain, pool); err != nil {
+			log.Info("Rejected unauthenticated request to the status port",
+				"path", req.URL.Path, "remoteAddr", req.RemoteAddr, "reason", err.Error())
+			sendJSONResponse(w, http.StatusUnauthorized, Response[any]{
+				Error: &Error{
+					Code:    errCodeUnauthorized,
+					Message: err.Error(),
+				},
+			})
+			return
+		}
+
+		next(w, req)
+	}
+}
+
 func (ws *remoteWebserverEndpoints) cleanupStaleCollections(ctx context.Context) {
 	closeBackupConnection := func(bc *backupConnection) {
 		log := log.WithValues(
@@ -222,8 +290,9 @@ func (ws *remoteWebserverEndpoints) isServerStartedUp(w http.ResponseWriter, req
 	checker.IsHealthy(req.Context(), w)
 }
 
-func (ws *remoteWebserverEndpoints) isServerHealthy(w http.ResponseWriter, _ *http.Request) {
-	_, _ = fmt.Fprint(w, "OK")
+// This is the liveness probe
+func (ws *remoteWebserverEndpoints) isServerHealthy(w http.ResponseWriter, req *http.Request) {
+	ws.livenessChecker.IsHealthy(req.Context(), w)
 }
 
 // This is the readiness probe
@@ -518,3 +587,54 @@ func (ws *remoteWebserverEndpoints) pgArchivePartial(w http.ResponseWriter, req
 
 	sendJSONResponseWithData(w, 200, walFile)
 }
+
+// pgRestorePoint creates a named restore point on the primary, and
+// switches to a new WAL file to get it archived without waiting for
+// archive_timeout
+func (ws *remoteWebserverEndpoints) pgRestorePoint(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		http.Error(w, "wrong method used", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var p RestorePointRequest
+	if err := json.NewDecoder(req.Body).Decode(&p); err != nil || p.Name == "" {
+		sendBadRequestJSONResponse(w, "FAILED_TO_PARSE_REQUEST", "Failed to parse request body")
+		return
+	}
+	defer func() {
+		if err := req.Body.Close(); err != nil {
+			log.Error(err, "while closing the body")
+		}
+	}()
+
+	isPrimary, err := ws.instance.IsPrimary()
+	if err != nil {
+		sendUnprocessableEntityJSONResponse(w, "CANNOT_DETECT_ROLE", err.Error())
+		return
+	}
+	if !isPrimary {
+		sendBadRequestJSONResponse(w, "NOT_PRIMARY", "")
+		return
+	}
+
+	db, err := ws.instance.GetSuperUserDB()
+	if err != nil {
+		sendUnprocessableEntityJSONResponse(w, "CANNOT_CONNECT", err.Error())
+		return
+	}
+
+	var result RestorePointResultData
+	if err := db.QueryRowContext(req.Context(), "SELECT pg_catalog.pg_create_restore_point($1)", p.Name).
+		Scan(&result.LSN); err != nil {
+		sendUnprocessableEntityJSONResponse(w, "CANNOT_CREATE_RESTORE_POINT", err.Error())
+		return
+	}
+
+	if _, err := db.ExecContext(req.Context(), "SELECT pg_catalog.pg_switch_wal()"); err != nil {
+		sendUnprocessableEntityJSONResponse(w, "CANNOT_SWITCH_WAL", err.Error())
+		return
+	}
+
+	sendJSONResponseWithData(w, http.StatusOK, result)
+}
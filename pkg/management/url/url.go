@@ -52,6 +52,10 @@ const (
 	// PathWALArchiveStatusCondition is the URL path for setting the wal-archive condition on the Cluster object
 	PathWALArchiveStatusCondition string = "/cluster/status/condition/wal/archive"
 
+	// PathWALPrefetchStats is the URL path for recording how a WAL file
+	// restored through the plugins has been served by the prefetching
+	PathWALPrefetchStats string = "/wal/restore/prefetch"
+
 	// PathPgBackup is the URL path for PostgreSQL Backup
 	PathPgBackup string = "/pg/backup"
 
@@ -61,6 +65,9 @@ const (
 	// PathPgArchivePartial is the URL path to interact with the partial wal archive
 	PathPgArchivePartial string = "/pg/archive/partial"
 
+	// PathPgRestorePoint is the URL path to create a named restore point
+	PathPgRestorePoint string = "/pg/restore-point"
+
 	// PathMetrics is the URL path for Metrics
 	PathMetrics string = "/metrics"
 
@@ -50,6 +50,14 @@ const (
 
 	// ParameterRecoveyMinApplyDelay is the configuration key containing the recovery_min_apply_delay parameter
 	ParameterRecoveyMinApplyDelay = "recovery_min_apply_delay"
+
+	// ParameterSummarizeWal is the configuration key enabling the WAL
+	// summarizer, needed by incremental base backups
+	ParameterSummarizeWal = "summarize_wal"
+
+	// ParameterPgAuditLog is the configuration key containing the classes
+	// of statements logged by pgaudit
+	ParameterPgAuditLog = "pgaudit.log"
 )
 
 // An acceptable wal_level value
@@ -84,6 +92,15 @@ const (
 	// hbaTemplateString is the template used to generate the pg_hba.conf
 	// configuration file
 	hbaTemplateString = `
+{{ if .PrecedingUserRules -}}
+#
+# USER-DEFINED RULES PRECEDING THE FIXED RULES
+#
+{{ range $rule := .PrecedingUserRules }}
+{{ $rule -}}
+{{ end }}
+
+{{ end -}}
 #
 # FIXED RULES
 #
@@ -331,6 +348,10 @@ type ConfigurationInfo struct {
 	// IsAlterSystemEnabled is true when 'allow_alter_system' should be set to on
 	IsAlterSystemEnabled bool
 
+	// IsWalSummarizationEnabled is true when the WAL summarizer should be
+	// enabled to support incremental base backups
+	IsWalSummarizationEnabled bool
+
 	// Minimum apply delay of transaction
 	RecoveryMinApplyDelay time.Duration
 }
@@ -531,17 +552,21 @@ var (
 )
 
 // CreateHBARules will create the content of pg_hba.conf file given
-// the rules set by the cluster spec
-func CreateHBARules(hba []string,
+// the rules set by the cluster spec. The preceding rules are placed
+// before the fixed rules generated by the operator, while the other
+// ones are placed after them
+func CreateHBARules(hba []string, precedingHBA []string,
 	defaultAuthenticationMethod, ldapConfigString string,
 ) (string, error) {
 	var hbaContent bytes.Buffer
 
 	templateData := struct {
+		PrecedingUserRules          []string
 		UserRules                   []string
 		LDAPConfiguration           string
 		DefaultAuthenticationMethod string
 	}{
+		PrecedingUserRules:          precedingHBA,
 		UserRules:                   hba,
 		LDAPConfiguration:           ldapConfigString,
 		DefaultAuthenticationMethod: defaultAuthenticationMethod,
@@ -671,6 +696,11 @@ func CreatePostgresqlConfiguration(info ConfigurationInfo) *PgConfiguration {
 		configuration.OverwriteConfig("archive_mode", "on")
 	}
 
+	// Enable the WAL summarizer, needed by incremental base backups
+	if info.IsWalSummarizationEnabled && info.Version.Major() >= 17 {
+		configuration.OverwriteConfig(ParameterSummarizeWal, "on")
+	}
+
 	// Apply the synchronous replication settings
 	syncStandbyNames := info.SynchronousStandbyNames
 	if len(syncStandbyNames) > 0 {
@@ -195,6 +195,28 @@ var _ = Describe("PostgreSQL configuration creation", func() {
 		})
 	})
 
+	Context("summarize_wal", func() {
+		It("enables the WAL summarizer with PostgreSQL >= 17", func() {
+			info := ConfigurationInfo{
+				IsWalSummarizationEnabled: true,
+				Version:                   version.New(17, 0),
+				UserSettings:              map[string]string{"summarize_wal": "off"},
+			}
+			config := CreatePostgresqlConfiguration(info)
+			Expect(config.GetConfig(ParameterSummarizeWal)).To(Equal("on"))
+		})
+
+		It("doesn't set summarize_wal with PostgreSQL < 17", func() {
+			info := ConfigurationInfo{
+				IsWalSummarizationEnabled: true,
+				Version:                   version.New(16, 0),
+			}
+			config := CreatePostgresqlConfiguration(info)
+			_, ok := config.configs[ParameterSummarizeWal]
+			Expect(ok).To(BeFalse())
+		})
+	})
+
 	Context("allow_alter_system", func() {
 		When("PostgreSQL >= 17", func() {
 			It("can properly set allow_alter_system to on", func() {
@@ -253,19 +275,31 @@ var _ = Describe("pg_hba.conf generation", func() {
 	}
 
 	It("insert the spec configuration between an header and a footer when the version can not be parsed", func() {
-		Expect(CreateHBARules(specRules, "md5", "")).To(
+		Expect(CreateHBARules(specRules, nil, "md5", "")).To(
 			ContainSubstring("\ntwo\n"))
 	})
 
 	It("really use the passed default authentication method", func() {
-		Expect(CreateHBARules(specRules, "this-one", "")).To(
+		Expect(CreateHBARules(specRules, nil, "this-one", "")).To(
 			ContainSubstring("\nhost all all all this-one\n"))
 	})
 
 	It("really uses the ldapConfigString", func() {
-		Expect(CreateHBARules(specRules, "defaultAuthenticationMethod", "ldapConfigString")).To(
+		Expect(CreateHBARules(specRules, nil, "defaultAuthenticationMethod", "ldapConfigString")).To(
 			ContainSubstring("\nldapConfigString\n"))
 	})
+
+	It("places the preceding rules before the fixed rules", func() {
+		content, err := CreateHBARules(specRules, []string{"zero"}, "md5", "")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(strings.Index(content, "\nzero\n")).To(BeNumerically(">", 0))
+		Expect(strings.Index(content, "\nzero\n")).To(BeNumerically("<", strings.Index(content, "\n# FIXED RULES\n")))
+		Expect(strings.Index(content, "\none\n")).To(BeNumerically(">", strings.Index(content, "\n# FIXED RULES\n")))
+	})
+
+	It("omits the preceding rules section when there are no such rules", func() {
+		Expect(CreateHBARules(specRules, nil, "md5", "")).ToNot(ContainSubstring("PRECEDING"))
+	})
 })
 
 var _ = Describe("pg_ident.conf generation", func() {
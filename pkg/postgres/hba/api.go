@@ -0,0 +1,49 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package hba
+
+import (
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+)
+
+// FromAPI converts a rule of the API to the format used to render
+// pg_hba.conf
+func FromAPI(rule apiv1.HBARule) Rule {
+	return Rule{
+		Type:      rule.Type,
+		Databases: rule.Databases,
+		Users:     rule.Users,
+		Address:   rule.Address,
+		Method:    rule.Method,
+		Options:   rule.Options,
+	}
+}
+
+// Render renders the rules having the passed position as pg_hba.conf
+// lines, keeping their order
+func Render(rules []apiv1.HBARule, position apiv1.HBARulePosition) []string {
+	var result []string
+	for _, rule := range rules {
+		if rule.GetPosition() == position {
+			result = append(result, FromAPI(rule).String())
+		}
+	}
+	return result
+}
@@ -0,0 +1,54 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package hba
+
+import (
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("HBA rules of the API", func() {
+	It("renders the rules having the requested position keeping their order", func() {
+		rules := []apiv1.HBARule{
+			{
+				Type: "hostssl", Databases: []string{"app"}, Users: []string{"app"},
+				Address: "10.0.0.0/8", Method: "scram-sha-256",
+			},
+			{
+				Type: "host", Databases: []string{"all"}, Users: []string{"reporting"},
+				Address: "all", Method: "reject", Position: apiv1.HBARulePositionBeforeOperatorRules,
+			},
+			{
+				Type: "hostssl", Databases: []string{"all"}, Users: []string{"all"},
+				Address: "all", Method: "cert", Position: apiv1.HBARulePositionAfterOperatorRules,
+			},
+		}
+
+		Expect(Render(rules, apiv1.HBARulePositionBeforeOperatorRules)).To(Equal([]string{
+			"host all reporting all reject",
+		}))
+		Expect(Render(rules, apiv1.HBARulePositionAfterOperatorRules)).To(Equal([]string{
+			"hostssl app app 10.0.0.0/8 scram-sha-256",
+			"hostssl all all all cert",
+		}))
+	})
+})
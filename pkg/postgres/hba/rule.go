@@ -0,0 +1,226 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package hba
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// Rule is a structured Host Based Authentication rule, that can be
+// rendered as a line of the pg_hba.conf file
+type Rule struct {
+	// The connection type, i.e. `hostssl`
+	Type string
+
+	// The databases the rule applies to
+	Databases []string
+
+	// The users the rule applies to
+	Users []string
+
+	// The client address, as a CIDR, a host name or a keyword, not used
+	// with `local` connections
+	Address string
+
+	// The authentication method
+	Method string
+
+	// The options of the authentication method
+	Options map[string]string
+}
+
+var (
+	// connectionTypes maps the connection types supported in pg_hba.conf
+	// to whether they are supported by PgBouncer too
+	connectionTypes = map[string]bool{
+		"local":        true,
+		"host":         true,
+		"hostssl":      true,
+		"hostnossl":    true,
+		"hostgssenc":   false,
+		"hostnogssenc": false,
+	}
+
+	// authenticationMethods maps the authentication methods supported in
+	// pg_hba.conf to whether they are supported by PgBouncer too
+	authenticationMethods = map[string]bool{
+		"trust":         true,
+		"reject":        true,
+		"scram-sha-256": true,
+		"md5":           true,
+		"password":      true,
+		"gss":           false,
+		"sspi":          false,
+		"ident":         false,
+		"peer":          true,
+		"ldap":          false,
+		"radius":        false,
+		"cert":          true,
+		"pam":           false,
+		"bsd":           false,
+	}
+
+	addressKeywords = []string{"all", "samehost", "samenet"}
+
+	hostNameRegex = regexp.MustCompile(
+		`^\.?[a-zA-Z0-9]([a-zA-Z0-9-]*[a-zA-Z0-9])?(\.[a-zA-Z0-9]([a-zA-Z0-9-]*[a-zA-Z0-9])?)*$`)
+
+	optionNameRegex = regexp.MustCompile(`^[a-z][a-z_]*$`)
+)
+
+// Validate checks the rule for errors that would make PostgreSQL reject it
+func (rule Rule) Validate() error {
+	var errs []error
+
+	if _, ok := connectionTypes[rule.Type]; !ok {
+		errs = append(errs, fmt.Errorf("unknown connection type %q", rule.Type))
+	}
+
+	if _, ok := authenticationMethods[rule.Method]; !ok {
+		errs = append(errs, fmt.Errorf("unknown authentication method %q", rule.Method))
+	}
+
+	errs = append(errs, validateNames("database", rule.Databases)...)
+	errs = append(errs, validateNames("user", rule.Users)...)
+
+	if rule.Type == "local" {
+		if rule.Address != "" {
+			errs = append(errs, errors.New("local rules cannot have an address"))
+		}
+	} else if err := validateAddress(rule.Address); err != nil {
+		errs = append(errs, err)
+	}
+
+	if rule.Method == "cert" && rule.Type != "hostssl" {
+		errs = append(errs, errors.New("the cert authentication method requires the hostssl connection type"))
+	}
+	if rule.Method == "peer" && rule.Type != "local" {
+		errs = append(errs, errors.New("the peer authentication method requires the local connection type"))
+	}
+
+	for name, value := range rule.Options {
+		if !optionNameRegex.MatchString(name) {
+			errs = append(errs, fmt.Errorf("invalid option name %q", name))
+		}
+		if strings.ContainsAny(value, "\"\n\r") {
+			errs = append(errs, fmt.Errorf("invalid value for option %q", name))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// ValidateForPgBouncer checks the rule for the connection types and the
+// authentication methods that PgBouncer doesn't support
+func (rule Rule) ValidateForPgBouncer() error {
+	var errs []error
+
+	if supported, ok := connectionTypes[rule.Type]; ok && !supported {
+		errs = append(errs, fmt.Errorf("connection type %q not supported by PgBouncer", rule.Type))
+	}
+
+	if supported, ok := authenticationMethods[rule.Method]; ok && !supported {
+		errs = append(errs, fmt.Errorf("authentication method %q not supported by PgBouncer", rule.Method))
+	}
+
+	return errors.Join(errs...)
+}
+
+// String renders the rule as a line of the pg_hba.conf file
+func (rule Rule) String() string {
+	fields := []string{
+		rule.Type,
+		joinNames(rule.Databases),
+		joinNames(rule.Users),
+	}
+	if rule.Type != "local" {
+		fields = append(fields, rule.Address)
+	}
+	fields = append(fields, rule.Method)
+
+	optionNames := make([]string, 0, len(rule.Options))
+	for name := range rule.Options {
+		optionNames = append(optionNames, name)
+	}
+	sort.Strings(optionNames)
+	for _, name := range optionNames {
+		fields = append(fields, fmt.Sprintf("%s=%s", name, quoteValue(rule.Options[name])))
+	}
+
+	return strings.Join(fields, " ")
+}
+
+func validateNames(kind string, names []string) []error {
+	if len(names) == 0 {
+		return []error{fmt.Errorf("at least one %s is required", kind)}
+	}
+
+	var errs []error
+	for _, name := range names {
+		if name == "" || strings.ContainsAny(name, "\"\n\r") {
+			errs = append(errs, fmt.Errorf("invalid %s name %q", kind, name))
+		}
+	}
+	return errs
+}
+
+func validateAddress(address string) error {
+	switch {
+	case address == "":
+		return errors.New("an address is required for non-local rules")
+	case slices.Contains(addressKeywords, address):
+		return nil
+	case net.ParseIP(address) != nil:
+		return fmt.Errorf("the address %q requires a CIDR mask, i.e. %s/32", address, address)
+	case strings.Contains(address, "/"):
+		if _, _, err := net.ParseCIDR(address); err != nil {
+			return fmt.Errorf("invalid CIDR address %q", address)
+		}
+		return nil
+	case hostNameRegex.MatchString(address):
+		return nil
+	default:
+		return fmt.Errorf("invalid address %q", address)
+	}
+}
+
+func joinNames(names []string) string {
+	quotedNames := make([]string, len(names))
+	for i, name := range names {
+		quotedNames[i] = quoteValue(name)
+	}
+	return strings.Join(quotedNames, ",")
+}
+
+// quoteValue quotes a value when it contains characters having a
+// special meaning in pg_hba.conf. Keywords like `all` must not be quoted,
+// as quoting them makes PostgreSQL match a name instead
+func quoteValue(value string) string {
+	if strings.ContainsAny(value, " \t,#") {
+		return `"` + value + `"`
+	}
+	return value
+}
@@ -0,0 +1,106 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package hba
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("HBA rules", func() {
+	It("renders a host rule with sorted options", func() {
+		rule := Rule{
+			Type:      "hostssl",
+			Databases: []string{"app", "my db"},
+			Users:     []string{"all"},
+			Address:   "10.0.0.0/8",
+			Method:    "ldap",
+			Options: map[string]string{
+				"ldapserver": "ldap.example.com",
+				"ldapprefix": "cn=",
+				"ldapsuffix": ", dc=example, dc=com",
+			},
+		}
+		Expect(rule.Validate()).To(Succeed())
+		Expect(rule.String()).To(Equal(`hostssl app,"my db" all 10.0.0.0/8 ldap ` +
+			`ldapprefix=cn= ldapserver=ldap.example.com ldapsuffix=", dc=example, dc=com"`))
+	})
+
+	It("renders a local rule without an address", func() {
+		rule := Rule{
+			Type:      "local",
+			Databases: []string{"all"},
+			Users:     []string{"+admins"},
+			Method:    "peer",
+		}
+		Expect(rule.Validate()).To(Succeed())
+		Expect(rule.String()).To(Equal("local all +admins peer"))
+	})
+
+	DescribeTable("rejects invalid rules",
+		func(rule Rule, message string) {
+			Expect(rule.Validate()).To(MatchError(ContainSubstring(message)))
+		},
+		Entry("unknown type", Rule{
+			Type: "hostx", Databases: []string{"all"}, Users: []string{"all"}, Address: "all", Method: "trust",
+		}, "unknown connection type"),
+		Entry("unknown method", Rule{
+			Type: "host", Databases: []string{"all"}, Users: []string{"all"}, Address: "all", Method: "magic",
+		}, "unknown authentication method"),
+		Entry("missing databases", Rule{
+			Type: "host", Users: []string{"all"}, Address: "all", Method: "trust",
+		}, "at least one database"),
+		Entry("missing address", Rule{
+			Type: "host", Databases: []string{"all"}, Users: []string{"all"}, Method: "trust",
+		}, "an address is required"),
+		Entry("address without mask", Rule{
+			Type: "host", Databases: []string{"all"}, Users: []string{"all"}, Address: "10.0.0.1", Method: "trust",
+		}, "requires a CIDR mask"),
+		Entry("invalid CIDR", Rule{
+			Type: "host", Databases: []string{"all"}, Users: []string{"all"}, Address: "10.0.0.0/99", Method: "trust",
+		}, "invalid CIDR"),
+		Entry("local with address", Rule{
+			Type: "local", Databases: []string{"all"}, Users: []string{"all"}, Address: "all", Method: "trust",
+		}, "cannot have an address"),
+		Entry("cert without SSL", Rule{
+			Type: "host", Databases: []string{"all"}, Users: []string{"all"}, Address: "all", Method: "cert",
+		}, "requires the hostssl"),
+		Entry("quotes in names", Rule{
+			Type: "host", Databases: []string{`a"b`}, Users: []string{"all"}, Address: "all", Method: "trust",
+		}, "invalid database name"),
+		Entry("invalid option name", Rule{
+			Type: "host", Databases: []string{"all"}, Users: []string{"all"}, Address: "all", Method: "ldap",
+			Options: map[string]string{"ldap server": "x"},
+		}, "invalid option name"),
+	)
+
+	It("checks the features supported by PgBouncer", func() {
+		rule := Rule{
+			Type: "hostssl", Databases: []string{"all"}, Users: []string{"app"}, Address: "all", Method: "cert",
+		}
+		Expect(rule.ValidateForPgBouncer()).To(Succeed())
+
+		rule.Type = "hostgssenc"
+		rule.Method = "ldap"
+		err := rule.ValidateForPgBouncer()
+		Expect(err).To(MatchError(ContainSubstring(`connection type "hostgssenc"`)))
+		Expect(err).To(MatchError(ContainSubstring(`authentication method "ldap"`)))
+	})
+})
@@ -88,6 +88,14 @@ type PostgresqlStatus struct {
 	InstanceArch               string `json:"instanceArch"`
 	IsInstanceManagerUpgrading bool   `json:"isInstanceManagerUpgrading"`
 
+	// The number of PostgreSQL log records with severity ERROR or higher
+	// collected since the instance manager started
+	LogErrorsCount int64 `json:"logErrorsCount,omitempty"`
+
+	// The failures of the SQL probes executed while this instance is
+	// the canary of a rollout
+	CanaryProbeFailures []string `json:"canaryProbeFailures,omitempty"`
+
 	// This field represents the Kubelet point-of-view of the readiness
 	// status of this instance and may be slightly stale when the Kubelet has
 	// not still invoked the readiness probe.
@@ -323,6 +331,25 @@ func (list PostgresqlStatusList) AreWalReceiversDown(primaryName string) bool {
 	return true
 }
 
+// HasFailoverQuorum checks if the majority of the instances confirm
+// that the primary is lost. An instance confirms it when it is reporting
+// its status and its WAL receiver is down. The primary itself, and every
+// instance that cannot be reached, don't count towards the quorum.
+func (list PostgresqlStatusList) HasFailoverQuorum(primaryName string, instances int) bool {
+	votes := 0
+	for idx := range list.Items {
+		item := &list.Items[idx]
+		if item.Pod == nil || item.Pod.Name == primaryName {
+			continue
+		}
+		if item.HasHTTPStatus() && !item.IsPrimary && !item.IsWalReceiverActive {
+			votes++
+		}
+	}
+
+	return votes > instances/2
+}
+
 // IsPodReporting if a pod is ready
 func (list PostgresqlStatusList) IsPodReporting(podname string) bool {
 	for _, item := range list.Items {
@@ -137,6 +137,32 @@ var _ = Describe("PostgreSQL status", func() {
 		Expect(podList.InstancesReportingStatus()).To(BeEquivalentTo(2))
 	})
 
+	It("checks for the failover quorum", func() {
+		podList := PostgresqlStatusList{
+			Items: []PostgresqlStatus{
+				{
+					Pod:   &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "server-1"}},
+					Error: errCannotConnectToPostgres,
+				},
+				{
+					Pod:                 &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "server-2"}},
+					IsWalReceiverActive: true,
+				},
+				{
+					Pod:                 &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "server-3"}},
+					IsWalReceiverActive: true,
+				},
+			},
+		}
+		Expect(podList.HasFailoverQuorum("server-1", 3)).To(BeFalse())
+		podList.Items[1].IsWalReceiverActive = false
+		Expect(podList.HasFailoverQuorum("server-1", 3)).To(BeFalse())
+		podList.Items[2].IsWalReceiverActive = false
+		Expect(podList.HasFailoverQuorum("server-1", 3)).To(BeTrue())
+		podList.Items[2].Error = errCannotConnectToPostgres
+		Expect(podList.HasFailoverQuorum("server-1", 3)).To(BeFalse())
+	})
+
 	Describe("when sorted", func() {
 		sort.Sort(&list)
 
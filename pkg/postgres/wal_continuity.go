@@ -0,0 +1,247 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package postgres
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"slices"
+	"strconv"
+)
+
+// walHistoryFileRe matches the name of a timeline history file
+var walHistoryFileRe = regexp.MustCompile(`^` + WALTimeLineRe + `\.history$`)
+
+// WALArchiveGap is a range of consecutive WAL segments which are
+// missing from a WAL archive
+type WALArchiveGap struct {
+	// First is the first missing WAL segment
+	First Segment
+
+	// Last is the last missing WAL segment
+	Last Segment
+
+	// Segments is the number of missing WAL segments
+	Segments int64
+}
+
+// WALArchiveContinuity describes which part of the WAL stream can be
+// found in a WAL archive
+type WALArchiveContinuity struct {
+	// FirstSegment is the oldest WAL segment in the archive
+	FirstSegment Segment
+
+	// LastSegment is the newest WAL segment in the archive
+	LastSegment Segment
+
+	// ContinuousSince is the WAL segment from which the archive is
+	// continuous up to LastSegment, that is the first point from which
+	// a recovery can reach the end of the archive
+	ContinuousSince Segment
+
+	// Gaps are the ranges of WAL segments missing between FirstSegment
+	// and LastSegment, sorted by position in the WAL stream
+	Gaps []WALArchiveGap
+
+	// MissingHistoryFiles are the history files of the timelines which
+	// have archived WAL segments but no history file in the archive
+	MissingHistoryFiles []string
+
+	// GapDetection is true when the content of the archive has been
+	// listed and the gaps have been looked for. Otherwise, only the
+	// oldest and the newest WAL segments are known, and the archive is
+	// assumed to be continuous
+	GapDetection bool
+
+	segmentsPerLog int64
+}
+
+// NewWALArchiveRange creates the description of a WAL archive whose
+// content can't be listed, and where only the oldest and the newest
+// WAL segments are known
+func NewWALArchiveRange(firstSegment, lastSegment Segment, walSegmentSize int64) *WALArchiveContinuity {
+	return &WALArchiveContinuity{
+		FirstSegment:    firstSegment,
+		LastSegment:     lastSegment,
+		ContinuousSince: firstSegment,
+		segmentsPerLog:  segmentsPerLog(walSegmentSize),
+	}
+}
+
+// CheckWALArchiveContinuity looks for gaps in the WAL stream stored in
+// a WAL archive, given the names of the archived files. Partial WAL
+// files, backup labels and any other file are ignored.
+// WAL segments are compared by their position in the WAL stream, so
+// that the first segment of a new timeline follows the last segment
+// of its parent timeline.
+// A nil result is returned when the archive contains no WAL segment
+func CheckWALArchiveContinuity(fileNames []string, walSegmentSize int64) *WALArchiveContinuity {
+	result := &WALArchiveContinuity{
+		GapDetection:   true,
+		segmentsPerLog: segmentsPerLog(walSegmentSize),
+	}
+
+	segments := make(map[int64]Segment)
+	historyFiles := make(map[int32]bool)
+	for _, fileName := range fileNames {
+		baseName := path.Base(fileName)
+
+		if walHistoryFileRe.MatchString(baseName) {
+			if tli, err := strconv.ParseInt(baseName[:8], 16, 32); err == nil {
+				historyFiles[int32(tli)] = true
+			}
+			continue
+		}
+
+		if !IsWALFile(baseName) {
+			continue
+		}
+
+		segment, err := SegmentFromName(baseName)
+		if err != nil {
+			continue
+		}
+
+		// When more timelines share the same position, the
+		// newest one is what a recovery would use
+		position := result.position(segment)
+		if existing, ok := segments[position]; !ok || existing.Tli < segment.Tli {
+			segments[position] = segment
+		}
+	}
+
+	if len(segments) == 0 {
+		return nil
+	}
+
+	positions := make([]int64, 0, len(segments))
+	for position := range segments {
+		positions = append(positions, position)
+	}
+	slices.Sort(positions)
+
+	result.FirstSegment = segments[positions[0]]
+	result.LastSegment = segments[positions[len(positions)-1]]
+	result.ContinuousSince = result.FirstSegment
+
+	missingTimelines := make(map[int32]bool)
+	for idx, position := range positions {
+		segment := segments[position]
+		if segment.Tli > 1 && !historyFiles[segment.Tli] {
+			missingTimelines[segment.Tli] = true
+		}
+
+		if idx == 0 || position == positions[idx-1]+1 {
+			continue
+		}
+
+		previous := segments[positions[idx-1]]
+		result.Gaps = append(result.Gaps, WALArchiveGap{
+			First:    result.segment(previous.Tli, positions[idx-1]+1),
+			Last:     result.segment(previous.Tli, position-1),
+			Segments: position - positions[idx-1] - 1,
+		})
+		result.ContinuousSince = segment
+	}
+
+	for tli := range missingTimelines {
+		result.MissingHistoryFiles = append(result.MissingHistoryFiles, fmt.Sprintf("%08X.history", tli))
+	}
+	slices.Sort(result.MissingHistoryFiles)
+
+	return result
+}
+
+// IsContinuous is true when no gap and no missing history file has
+// been found in the WAL archive
+func (continuity *WALArchiveContinuity) IsContinuous() bool {
+	return len(continuity.Gaps) == 0 && len(continuity.MissingHistoryFiles) == 0
+}
+
+// MissingSegments is the number of WAL segments missing between the
+// oldest and the newest WAL segment in the archive
+func (continuity *WALArchiveContinuity) MissingSegments() int64 {
+	var result int64
+	for _, gap := range continuity.Gaps {
+		result += gap.Segments
+	}
+	return result
+}
+
+// FirstMissingSegment gets the first WAL segment between begin and end
+// which can't be found in the WAL archive, if any. WAL segments newer
+// than the last archived one are not considered missing, as they may
+// have not been archived yet
+func (continuity *WALArchiveContinuity) FirstMissingSegment(begin, end Segment) (Segment, bool) {
+	beginPosition := continuity.position(begin)
+	endPosition := continuity.position(end)
+
+	if beginPosition < continuity.position(continuity.FirstSegment) {
+		return begin, true
+	}
+
+	for _, gap := range continuity.Gaps {
+		firstPosition := continuity.position(gap.First)
+		lastPosition := continuity.position(gap.Last)
+		if lastPosition < beginPosition || firstPosition > endPosition {
+			continue
+		}
+
+		if firstPosition < beginPosition {
+			return begin, true
+		}
+		return gap.First, true
+	}
+
+	return Segment{}, false
+}
+
+// CanRecoverFrom is true when a backup starting at begin and ending at
+// end can be restored, and recovered up to the newest WAL segment in the
+// archive without crossing any gap
+func (continuity *WALArchiveContinuity) CanRecoverFrom(begin, end Segment) bool {
+	return continuity.position(begin) >= continuity.position(continuity.ContinuousSince) &&
+		continuity.position(end) <= continuity.position(continuity.LastSegment)
+}
+
+// position gets the position of a WAL segment in the WAL stream,
+// regardless of its timeline
+func (continuity *WALArchiveContinuity) position(segment Segment) int64 {
+	return int64(segment.Log)*continuity.segmentsPerLog + int64(segment.Seg)
+}
+
+// segment gets the WAL segment in the passed position and timeline
+func (continuity *WALArchiveContinuity) segment(tli int32, position int64) Segment {
+	return Segment{
+		Tli: tli,
+		Log: int32(position / continuity.segmentsPerLog), //nolint:gosec
+		Seg: int32(position % continuity.segmentsPerLog), //nolint:gosec
+	}
+}
+
+// segmentsPerLog gets the number of WAL segments sharing the same log
+// number, given the WAL segment size
+func segmentsPerLog(walSegmentSize int64) int64 {
+	if walSegmentSize <= 0 {
+		walSegmentSize = DefaultWALSegmentSize
+	}
+	return int64(WalSegmentsPerFile(walSegmentSize)) + 1
+}
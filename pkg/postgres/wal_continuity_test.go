@@ -0,0 +1,158 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package postgres
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("WAL archive continuity", func() {
+	It("returns nil when the archive contains no WAL segment", func() {
+		Expect(CheckWALArchiveContinuity(nil, DefaultWALSegmentSize)).To(BeNil())
+		Expect(CheckWALArchiveContinuity(
+			[]string{"00000002.history", "000000010000000000000001.partial"},
+			DefaultWALSegmentSize,
+		)).To(BeNil())
+	})
+
+	It("detects a continuous archive", func() {
+		continuity := CheckWALArchiveContinuity([]string{
+			"/archive/000000010000000000000003",
+			"/archive/0000000100000000000000FF",
+			"/archive/000000010000000100000000",
+			"/archive/000000010000000000000004.00000028.backup",
+			"/archive/000000010000000000000004",
+		}, DefaultWALSegmentSize)
+		Expect(continuity).ToNot(BeNil())
+		Expect(continuity.GapDetection).To(BeTrue())
+		Expect(continuity.FirstSegment.Name()).To(Equal("000000010000000000000003"))
+		Expect(continuity.LastSegment.Name()).To(Equal("000000010000000100000000"))
+		Expect(continuity.Gaps).To(HaveLen(1))
+		Expect(continuity.Gaps[0].First.Name()).To(Equal("000000010000000000000005"))
+		Expect(continuity.Gaps[0].Last.Name()).To(Equal("0000000100000000000000FE"))
+		Expect(continuity.MissingSegments()).To(BeEquivalentTo(250))
+		Expect(continuity.ContinuousSince.Name()).To(Equal("0000000100000000000000FF"))
+		Expect(continuity.IsContinuous()).To(BeFalse())
+	})
+
+	It("follows the WAL stream across timeline switches", func() {
+		continuity := CheckWALArchiveContinuity([]string{
+			"000000010000000000000001",
+			"000000010000000000000002",
+			"000000010000000000000003.partial",
+			"00000002.history",
+			"000000020000000000000003",
+			"000000020000000000000004",
+		}, DefaultWALSegmentSize)
+		Expect(continuity.IsContinuous()).To(BeTrue())
+		Expect(continuity.ContinuousSince.Name()).To(Equal("000000010000000000000001"))
+		Expect(continuity.LastSegment.Name()).To(Equal("000000020000000000000004"))
+	})
+
+	It("reports the missing history files", func() {
+		continuity := CheckWALArchiveContinuity([]string{
+			"000000010000000000000001",
+			"000000020000000000000002",
+			"000000030000000000000003",
+			"00000003.history",
+		}, DefaultWALSegmentSize)
+		Expect(continuity.Gaps).To(BeEmpty())
+		Expect(continuity.MissingHistoryFiles).To(ConsistOf("00000002.history"))
+		Expect(continuity.IsContinuous()).To(BeFalse())
+	})
+
+	It("finds the first WAL segment a backup is missing", func() {
+		continuity := CheckWALArchiveContinuity([]string{
+			"000000010000000000000002",
+			"000000010000000000000003",
+			"000000010000000000000006",
+			"000000010000000000000007",
+		}, DefaultWALSegmentSize)
+
+		By("being older than the archive", func() {
+			segment, missing := continuity.FirstMissingSegment(
+				MustSegmentFromName("000000010000000000000001"),
+				MustSegmentFromName("000000010000000000000002"))
+			Expect(missing).To(BeTrue())
+			Expect(segment.Name()).To(Equal("000000010000000000000001"))
+		})
+
+		By("overlapping a gap", func() {
+			segment, missing := continuity.FirstMissingSegment(
+				MustSegmentFromName("000000010000000000000003"),
+				MustSegmentFromName("000000010000000000000006"))
+			Expect(missing).To(BeTrue())
+			Expect(segment.Name()).To(Equal("000000010000000000000004"))
+
+			segment, missing = continuity.FirstMissingSegment(
+				MustSegmentFromName("000000010000000000000005"),
+				MustSegmentFromName("000000010000000000000006"))
+			Expect(missing).To(BeTrue())
+			Expect(segment.Name()).To(Equal("000000010000000000000005"))
+		})
+
+		By("being fully archived, or not archived yet", func() {
+			_, missing := continuity.FirstMissingSegment(
+				MustSegmentFromName("000000010000000000000002"),
+				MustSegmentFromName("000000010000000000000003"))
+			Expect(missing).To(BeFalse())
+
+			_, missing = continuity.FirstMissingSegment(
+				MustSegmentFromName("000000010000000000000007"),
+				MustSegmentFromName("000000010000000000000009"))
+			Expect(missing).To(BeFalse())
+		})
+	})
+
+	It("checks whether a backup can be recovered up to the end of the archive", func() {
+		continuity := CheckWALArchiveContinuity([]string{
+			"000000010000000000000002",
+			"000000010000000000000003",
+			"000000010000000000000006",
+			"000000010000000000000007",
+		}, DefaultWALSegmentSize)
+
+		Expect(continuity.CanRecoverFrom(
+			MustSegmentFromName("000000010000000000000002"),
+			MustSegmentFromName("000000010000000000000003"))).To(BeFalse())
+		Expect(continuity.CanRecoverFrom(
+			MustSegmentFromName("000000010000000000000006"),
+			MustSegmentFromName("000000010000000000000007"))).To(BeTrue())
+		Expect(continuity.CanRecoverFrom(
+			MustSegmentFromName("000000010000000000000007"),
+			MustSegmentFromName("000000010000000000000008"))).To(BeFalse())
+	})
+
+	It("only knows the range of a WAL archive which can't be listed", func() {
+		continuity := NewWALArchiveRange(
+			MustSegmentFromName("000000010000000000000002"),
+			MustSegmentFromName("000000010000000000000009"),
+			DefaultWALSegmentSize)
+		Expect(continuity.GapDetection).To(BeFalse())
+		Expect(continuity.IsContinuous()).To(BeTrue())
+		Expect(continuity.ContinuousSince.Name()).To(Equal("000000010000000000000002"))
+
+		_, missing := continuity.FirstMissingSegment(
+			MustSegmentFromName("000000010000000000000001"),
+			MustSegmentFromName("000000010000000000000002"))
+		Expect(missing).To(BeTrue())
+	})
+})
@@ -0,0 +1,125 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package persistentvolumeclaim
+
+import (
+	"context"
+
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	corev1 "k8s.io/api/core/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/resources"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+)
+
+// BuildBackupVolume builds the PVC dedicated to the base backups of the
+// cluster, when its storage is managed by the operator
+func BuildBackupVolume(cluster *apiv1.Cluster) (*corev1.PersistentVolumeClaim, error) {
+	storage := cluster.Spec.Backup.PersistentVolume.Storage
+
+	builder := resources.NewPersistentVolumeClaimBuilder().
+		BeginMetadata().
+		WithNamespacedName(cluster.GetPersistentVolumeBackupClaimName(), cluster.Namespace).
+		WithLabels(map[string]string{
+			utils.ClusterLabelName: cluster.Name,
+			utils.PvcRoleLabelName: string(utils.PVCRolePgBackup),
+		}).
+		WithClusterInheritance(cluster).
+		EndMetadata().
+		WithSpec(storage.PersistentVolumeClaimTemplate).
+		// Every instance mounts the backup volume
+		WithDefaultAccessMode(corev1.ReadWriteMany)
+
+	if storage.StorageClass != nil {
+		builder = builder.WithStorageClass(storage.StorageClass)
+	}
+
+	if storage.Size != "" {
+		parsedSize := storage.GetSizeOrNil()
+		if parsedSize == nil {
+			return nil, ErrorInvalidSize
+		}
+		builder = builder.WithRequests(corev1.ResourceList{
+			"storage": *parsedSize,
+		})
+	}
+
+	pvc := builder.Build()
+	if pvc.Spec.Resources.Requests.Storage().IsZero() {
+		return nil, ErrorInvalidSize
+	}
+
+	return pvc, nil
+}
+
+// ReconcileBackupVolume creates the PVC dedicated to the base backups of the
+// cluster when it doesn't exist, and aligns its storage requests
+func ReconcileBackupVolume(
+	ctx context.Context,
+	c client.Client,
+	cluster *apiv1.Cluster,
+) error {
+	if !cluster.IsPersistentVolumeBackupOwned() {
+		return nil
+	}
+
+	contextLogger := log.FromContext(ctx)
+
+	expectedPVC, err := BuildBackupVolume(cluster)
+	if err != nil {
+		return err
+	}
+
+	var pvc corev1.PersistentVolumeClaim
+	err = c.Get(ctx, client.ObjectKeyFromObject(expectedPVC), &pvc)
+	if apierrs.IsNotFound(err) {
+		contextLogger.Info("Creating backup volume", "pvcName", expectedPVC.Name)
+		return c.Create(ctx, expectedPVC)
+	}
+	if err != nil {
+		return err
+	}
+
+	if !cluster.ShouldResizeInUseVolumes() {
+		return nil
+	}
+
+	currentSize := pvc.Spec.Resources.Requests["storage"]
+	expectedSize := expectedPVC.Spec.Resources.Requests["storage"]
+	switch currentSize.AsDec().Cmp(expectedSize.AsDec()) {
+	case 0:
+		return nil
+	case 1:
+		contextLogger.Warning("cannot decrease storage requirement",
+			"from", currentSize, "to", expectedSize,
+			"pvcName", pvc.Name)
+		return nil
+	}
+
+	oldPVC := pvc.DeepCopy()
+	updatedPVC := resources.NewPersistentVolumeClaimBuilderFromPVC(&pvc).
+		WithRequests(corev1.ResourceList{"storage": expectedSize}).
+		Build()
+
+	return c.Patch(ctx, updatedPVC, client.MergeFrom(oldPVC))
+}
@@ -0,0 +1,102 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package persistentvolumeclaim
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/internal/scheme"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Backup volume", func() {
+	newCluster := func(size string) *apiv1.Cluster {
+		return &apiv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster-example", Namespace: "default"},
+			Spec: apiv1.ClusterSpec{
+				Backup: &apiv1.BackupConfiguration{
+					PersistentVolume: &apiv1.PersistentVolumeBackupConfiguration{
+						Storage: &apiv1.StorageConfiguration{Size: size},
+					},
+				},
+			},
+		}
+	}
+
+	It("is built as a ReadWriteMany volume owned by the cluster", func() {
+		pvc, err := BuildBackupVolume(newCluster("10Gi"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(pvc.Name).To(Equal("cluster-example-backups"))
+		Expect(pvc.Labels).To(HaveKeyWithValue(utils.PvcRoleLabelName, string(utils.PVCRolePgBackup)))
+		Expect(pvc.Labels).To(HaveKeyWithValue(utils.ClusterLabelName, "cluster-example"))
+		Expect(pvc.OwnerReferences).To(HaveLen(1))
+		Expect(pvc.Spec.AccessModes).To(ConsistOf(corev1.ReadWriteMany))
+		Expect(pvc.Spec.Resources.Requests.Storage().String()).To(Equal("10Gi"))
+	})
+
+	It("fails without a size", func() {
+		_, err := BuildBackupVolume(newCluster(""))
+		Expect(err).To(MatchError(ErrorInvalidSize))
+	})
+
+	It("is created and then enlarged", func(ctx SpecContext) {
+		cli := fake.NewClientBuilder().WithScheme(scheme.BuildWithAllKnownScheme()).Build()
+
+		cluster := newCluster("10Gi")
+		Expect(ReconcileBackupVolume(ctx, cli, cluster)).To(Succeed())
+
+		var pvc corev1.PersistentVolumeClaim
+		key := client.ObjectKey{Namespace: "default", Name: "cluster-example-backups"}
+		Expect(cli.Get(ctx, key, &pvc)).To(Succeed())
+		Expect(pvc.Spec.Resources.Requests.Storage().String()).To(Equal("10Gi"))
+
+		cluster.Spec.Backup.PersistentVolume.Storage.Size = "20Gi"
+		Expect(ReconcileBackupVolume(ctx, cli, cluster)).To(Succeed())
+		Expect(cli.Get(ctx, key, &pvc)).To(Succeed())
+		Expect(pvc.Spec.Resources.Requests.Storage().Equal(resource.MustParse("20Gi"))).To(BeTrue())
+
+		cluster.Spec.Backup.PersistentVolume.Storage.Size = "5Gi"
+		Expect(ReconcileBackupVolume(ctx, cli, cluster)).To(Succeed())
+		Expect(cli.Get(ctx, key, &pvc)).To(Succeed())
+		Expect(pvc.Spec.Resources.Requests.Storage().Equal(resource.MustParse("20Gi"))).To(BeTrue())
+	})
+
+	It("is not created for an existing volume claim", func(ctx SpecContext) {
+		cli := fake.NewClientBuilder().WithScheme(scheme.BuildWithAllKnownScheme()).Build()
+
+		cluster := newCluster("")
+		cluster.Spec.Backup.PersistentVolume = &apiv1.PersistentVolumeBackupConfiguration{
+			VolumeClaimName: "shared-backups",
+		}
+		Expect(ReconcileBackupVolume(ctx, cli, cluster)).To(Succeed())
+
+		var pvcs corev1.PersistentVolumeClaimList
+		Expect(cli.List(ctx, &pvcs)).To(Succeed())
+		Expect(pvcs.Items).To(BeEmpty())
+	})
+})
@@ -55,6 +55,40 @@ func SetClusterReadyCondition(cluster *apiv1.Cluster) {
 	meta.SetStatusCondition(&cluster.Status.Conditions, condition)
 }
 
+// SetCanaryRolloutCondition updates the cluster's canary rollout condition
+// according to the status of the latest canary rollout
+func SetCanaryRolloutCondition(cluster *apiv1.Cluster) {
+	canary := cluster.Status.CanaryRollout
+	if canary == nil {
+		return
+	}
+
+	condition := metav1.Condition{
+		Type:    string(apiv1.ConditionCanaryRollout),
+		Status:  metav1.ConditionUnknown,
+		Reason:  string(apiv1.ConditionReasonCanaryInProgress),
+		Message: canary.Message,
+	}
+
+	switch canary.Phase {
+	case apiv1.CanaryRolloutPhaseSucceeded:
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = string(apiv1.ConditionReasonCanarySucceeded)
+	case apiv1.CanaryRolloutPhaseRolledBack:
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = string(apiv1.ConditionReasonCanaryRolledBack)
+	}
+
+	meta.SetStatusCondition(&cluster.Status.Conditions, condition)
+}
+
+// SetCanaryRollout is a transaction that sets the status of the canary rollout
+func SetCanaryRollout(canary *apiv1.CanaryRolloutStatus) Transaction {
+	return func(cluster *apiv1.Cluster) {
+		cluster.Status.CanaryRollout = canary
+	}
+}
+
 // SetPhase is a transaction that sets the cluster phase and reason
 func SetPhase(phase string, reason string) Transaction {
 	return func(cluster *apiv1.Cluster) {
@@ -216,10 +216,39 @@ func CreatePrimaryJobViaRecovery(cluster apiv1.Cluster, nodeSerial int, backup *
 	job := CreatePrimaryJob(cluster, nodeSerial, jobRoleFullRecovery, initCommand)
 
 	addBarmanEndpointCAToJobFromCluster(cluster, backup, job)
+	addPersistentVolumeBackupToJob(backup, job)
 
 	return job
 }
 
+// addPersistentVolumeBackupToJob mounts the volume containing the base
+// backup to recover from, when it is stored in a backup volume
+func addPersistentVolumeBackupToJob(backup *apiv1.Backup, job *batchv1.Job) {
+	if backup == nil || backup.Status.PersistentVolumeBackupStatus == nil {
+		return
+	}
+
+	job.Spec.Template.Spec.Volumes = append(job.Spec.Template.Spec.Volumes,
+		corev1.Volume{
+			Name: "recovery-backups",
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+					ClaimName: backup.Status.PersistentVolumeBackupStatus.VolumeClaimName,
+				},
+			},
+		})
+
+	for idx := range job.Spec.Template.Spec.Containers {
+		job.Spec.Template.Spec.Containers[idx].VolumeMounts = append(
+			job.Spec.Template.Spec.Containers[idx].VolumeMounts,
+			corev1.VolumeMount{
+				Name:      "recovery-backups",
+				MountPath: RecoveryBackupVolumePath,
+				ReadOnly:  true,
+			})
+	}
+}
+
 func addBarmanEndpointCAToJobFromCluster(cluster apiv1.Cluster, backup *apiv1.Backup, job *batchv1.Job) {
 	var credentials apiv1.BarmanCredentials
 	var endpointCA *apiv1.SecretKeySelector
@@ -321,6 +350,12 @@ func CreatePrimaryJob(cluster apiv1.Cluster, nodeSerial int, role jobRole, initC
 
 	envConfig := CreatePodEnvConfig(cluster, jobName)
 
+	affinity := CreateAffinitySection(cluster.Name, cluster.Spec.Affinity)
+	if role != jobRoleJoin && cluster.Status.CurrentPrimary == "" {
+		// This job is bootstrapping the first primary instance
+		affinity = CreatePrimaryAffinitySection(cluster)
+	}
+
 	job := &batchv1.Job{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      jobName,
@@ -364,7 +399,7 @@ func CreatePrimaryJob(cluster apiv1.Cluster, nodeSerial int, role jobRole, initC
 						cluster.GetSeccompProfile(),
 						cluster.GetPostgresUID(),
 						cluster.GetPostgresGID()),
-					Affinity:                  CreateAffinitySection(cluster.Name, cluster.Spec.Affinity),
+					Affinity:                  affinity,
 					Tolerations:               cluster.Spec.Affinity.Tolerations,
 					ServiceAccountName:        cluster.Name,
 					RestartPolicy:             corev1.RestartPolicyNever,
@@ -140,6 +140,57 @@ var _ = Describe("Barman endpoint CA", func() {
 	})
 })
 
+var _ = Describe("Recovery from a backup volume", func() {
+	It("mounts the volume containing the base backup", func() {
+		backup := apiv1.Backup{
+			Spec: apiv1.BackupSpec{
+				Method: apiv1.BackupMethodPersistentVolume,
+			},
+			Status: apiv1.BackupStatus{
+				PersistentVolumeBackupStatus: &apiv1.PersistentVolumeBackupStatus{
+					VolumeClaimName: "source-backups",
+					Path:            "source/backup-1",
+				},
+			},
+		}
+		job := batchv1.Job{
+			Spec: batchv1.JobSpec{
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{{}},
+					},
+				},
+			},
+		}
+
+		addPersistentVolumeBackupToJob(&backup, &job)
+		Expect(job.Spec.Template.Spec.Volumes).To(HaveLen(1))
+		Expect(job.Spec.Template.Spec.Volumes[0].PersistentVolumeClaim.ClaimName).To(Equal("source-backups"))
+		Expect(job.Spec.Template.Spec.Containers[0].VolumeMounts).To(ConsistOf(corev1.VolumeMount{
+			Name:      "recovery-backups",
+			MountPath: RecoveryBackupVolumePath,
+			ReadOnly:  true,
+		}))
+	})
+
+	It("doesn't change the job for other kinds of backup", func() {
+		job := batchv1.Job{
+			Spec: batchv1.JobSpec{
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{{}},
+					},
+				},
+			},
+		}
+
+		addPersistentVolumeBackupToJob(nil, &job)
+		addPersistentVolumeBackupToJob(&apiv1.Backup{}, &job)
+		Expect(job.Spec.Template.Spec.Volumes).To(BeEmpty())
+		Expect(job.Spec.Template.Spec.Containers[0].VolumeMounts).To(BeEmpty())
+	})
+})
+
 var _ = Describe("Job created via InitDB", func() {
 	It("contain cluster post-init SQL instructions", func() {
 		cluster := apiv1.Cluster{
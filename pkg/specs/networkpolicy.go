@@ -0,0 +1,142 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package specs
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/internal/configuration"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/url"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+)
+
+// namespaceNameLabel is the label automatically set by Kubernetes
+// on every namespace, containing its name
+const namespaceNameLabel = "kubernetes.io/metadata.name"
+
+// ClusterNetworkPolicyManager builds the NetworkPolicy for the cluster resource
+type ClusterNetworkPolicyManager struct {
+	cluster *apiv1.Cluster
+}
+
+// NewClusterNetworkPolicyManager returns a new instance of ClusterNetworkPolicyManager
+func NewClusterNetworkPolicyManager(cluster *apiv1.Cluster) *ClusterNetworkPolicyManager {
+	return &ClusterNetworkPolicyManager{cluster: cluster}
+}
+
+// IsNetworkPolicyEnabled returns a boolean indicating if the NetworkPolicy should exist or not
+func (c ClusterNetworkPolicyManager) IsNetworkPolicyEnabled() bool {
+	return c.cluster.IsNetworkPolicyEnabled()
+}
+
+// BuildNetworkPolicy builds a new NetworkPolicy object allowing:
+//
+//   - every connection between the pods belonging to the cluster, including
+//     the jobs and the poolers, as required by replication and cloning;
+//   - the connections from the operator namespace to the status port of
+//     the instance manager and to the PostgreSQL port;
+//   - the connections to the PostgreSQL port from the declared clients;
+//   - the connections to the metrics port from the declared monitoring clients.
+func (c ClusterNetworkPolicyManager) BuildNetworkPolicy() *networkingv1.NetworkPolicy {
+	meta := metav1.ObjectMeta{
+		Namespace: c.cluster.Namespace,
+		Name:      c.cluster.Name,
+	}
+	c.cluster.SetInheritedDataAndOwnership(&meta)
+
+	ingress := []networkingv1.NetworkPolicyIngressRule{
+		{
+			From: []networkingv1.NetworkPolicyPeer{
+				{
+					PodSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{
+							utils.ClusterLabelName: c.cluster.Name,
+						},
+					},
+				},
+			},
+		},
+		{
+			From: []networkingv1.NetworkPolicyPeer{
+				{
+					NamespaceSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{
+							namespaceNameLabel: configuration.Current.OperatorNamespace,
+						},
+					},
+				},
+			},
+			Ports: []networkingv1.NetworkPolicyPort{
+				TCPNetworkPolicyPort(url.StatusPort),
+				TCPNetworkPolicyPort(postgres.ServerPort),
+			},
+		},
+	}
+
+	var clients []networkingv1.NetworkPolicyPeer
+	var monitoringClients []networkingv1.NetworkPolicyPeer
+	if c.cluster.Spec.NetworkPolicy != nil {
+		clients = c.cluster.Spec.NetworkPolicy.Clients
+		monitoringClients = c.cluster.Spec.NetworkPolicy.MonitoringClients
+	}
+
+	if len(clients) > 0 {
+		ingress = append(ingress, networkingv1.NetworkPolicyIngressRule{
+			From:  clients,
+			Ports: []networkingv1.NetworkPolicyPort{TCPNetworkPolicyPort(postgres.ServerPort)},
+		})
+	}
+
+	if len(monitoringClients) > 0 {
+		ingress = append(ingress, networkingv1.NetworkPolicyIngressRule{
+			From:  monitoringClients,
+			Ports: []networkingv1.NetworkPolicyPort{TCPNetworkPolicyPort(url.PostgresMetricsPort)},
+		})
+	}
+
+	return &networkingv1.NetworkPolicy{
+		ObjectMeta: meta,
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					utils.ClusterLabelName: c.cluster.Name,
+					utils.PodRoleLabelName: string(utils.PodRoleInstance),
+				},
+			},
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+			Ingress:     ingress,
+		},
+	}
+}
+
+// TCPNetworkPolicyPort returns a NetworkPolicyPort allowing the TCP traffic
+// directed to the passed port
+func TCPNetworkPolicyPort(port int32) networkingv1.NetworkPolicyPort {
+	return networkingv1.NetworkPolicyPort{
+		Protocol: ptr.To(corev1.ProtocolTCP),
+		Port:     ptr.To(intstr.FromInt32(port)),
+	}
+}
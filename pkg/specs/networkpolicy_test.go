@@ -0,0 +1,114 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package specs
+
+import (
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/internal/configuration"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/url"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Cluster NetworkPolicy", func() {
+	var cluster *apiv1.Cluster
+
+	appClients := networkingv1.NetworkPolicyPeer{
+		PodSelector: &metav1.LabelSelector{
+			MatchLabels: map[string]string{"app": "frontend"},
+		},
+	}
+	prometheus := networkingv1.NetworkPolicyPeer{
+		NamespaceSelector: &metav1.LabelSelector{
+			MatchLabels: map[string]string{namespaceNameLabel: "monitoring"},
+		},
+	}
+
+	BeforeEach(func() {
+		configuration.Current = configuration.NewConfiguration()
+		configuration.Current.OperatorNamespace = "cnpg-system"
+		cluster = &apiv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "cluster-example",
+				Namespace: "default",
+			},
+		}
+	})
+
+	It("is disabled by default", func() {
+		Expect(NewClusterNetworkPolicyManager(cluster).IsNetworkPolicyEnabled()).To(BeFalse())
+
+		cluster.Spec.NetworkPolicy = &apiv1.NetworkPolicyConfiguration{Enabled: true}
+		Expect(NewClusterNetworkPolicyManager(cluster).IsNetworkPolicyEnabled()).To(BeTrue())
+	})
+
+	It("only allows the traffic needed by the operator when no client is declared", func() {
+		cluster.Spec.NetworkPolicy = &apiv1.NetworkPolicyConfiguration{Enabled: true}
+		policy := NewClusterNetworkPolicyManager(cluster).BuildNetworkPolicy()
+
+		Expect(policy.Name).To(Equal(cluster.Name))
+		Expect(policy.Namespace).To(Equal(cluster.Namespace))
+		Expect(policy.Spec.PodSelector.MatchLabels).To(Equal(map[string]string{
+			utils.ClusterLabelName: cluster.Name,
+			utils.PodRoleLabelName: string(utils.PodRoleInstance),
+		}))
+		Expect(policy.Spec.PolicyTypes).To(ConsistOf(networkingv1.PolicyTypeIngress))
+		Expect(policy.Spec.Ingress).To(HaveLen(2))
+
+		By("allowing the traffic between the pods of the cluster", func() {
+			Expect(policy.Spec.Ingress[0].Ports).To(BeEmpty())
+			Expect(policy.Spec.Ingress[0].From[0].PodSelector.MatchLabels).To(Equal(map[string]string{
+				utils.ClusterLabelName: cluster.Name,
+			}))
+		})
+
+		By("allowing the operator to reach the status and PostgreSQL ports", func() {
+			Expect(policy.Spec.Ingress[1].Ports).To(ConsistOf(
+				TCPNetworkPolicyPort(url.StatusPort),
+				TCPNetworkPolicyPort(postgres.ServerPort),
+			))
+			Expect(policy.Spec.Ingress[1].From[0].NamespaceSelector.MatchLabels).To(Equal(map[string]string{
+				namespaceNameLabel: "cnpg-system",
+			}))
+		})
+	})
+
+	It("allows only the declared clients, ignoring the external clusters", func() {
+		cluster.Spec.NetworkPolicy = &apiv1.NetworkPolicyConfiguration{
+			Enabled:           true,
+			Clients:           []networkingv1.NetworkPolicyPeer{appClients},
+			MonitoringClients: []networkingv1.NetworkPolicyPeer{prometheus},
+		}
+		cluster.Spec.ExternalClusters = []apiv1.ExternalCluster{{Name: "cluster-origin"}}
+		policy := NewClusterNetworkPolicyManager(cluster).BuildNetworkPolicy()
+
+		Expect(policy.Spec.Ingress).To(HaveLen(4))
+		Expect(policy.Spec.Ingress[2].Ports).To(ConsistOf(TCPNetworkPolicyPort(postgres.ServerPort)))
+		Expect(policy.Spec.Ingress[2].From).To(ConsistOf(appClients))
+		Expect(policy.Spec.Ingress[3].Ports).To(ConsistOf(TCPNetworkPolicyPort(url.PostgresMetricsPort)))
+		Expect(policy.Spec.Ingress[3].From).To(ConsistOf(prometheus))
+	})
+})
@@ -0,0 +1,242 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package specs
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+)
+
+const (
+	// objectRestoreDumpVolumeName is the name of the volume where the
+	// objects are dumped
+	objectRestoreDumpVolumeName = "dump"
+
+	// objectRestoreDumpPath is the path where the objects are dumped
+	objectRestoreDumpPath = "/var/lib/postgresql/dump"
+
+	objectRestoreTablesDump  = "tables.dump"
+	objectRestoreSchemasDump = "schemas.dump"
+)
+
+// objectRestoreStep is a step of the job dumping the objects from the
+// recovery cluster and restoring them into the cluster
+type objectRestoreStep struct {
+	name    string
+	command []string
+
+	// The secret containing the credentials of the connection and
+	// the name of the service to connect to
+	secretName  string
+	serviceName string
+}
+
+// CreateObjectRestoreJob creates the job dumping the objects from the
+// recovery cluster and restoring them into the cluster, using the passed
+// secret to connect to the cluster
+func CreateObjectRestoreJob(
+	objectRestore *apiv1.ObjectRestore,
+	cluster *apiv1.Cluster,
+	recoveryCluster *apiv1.Cluster,
+	credentialsSecretName string,
+) *batchv1.Job {
+	source := objectRestoreStep{
+		secretName:  recoveryCluster.GetSuperuserSecretName(),
+		serviceName: recoveryCluster.GetServiceReadWriteName(),
+	}
+	target := objectRestoreStep{
+		secretName:  credentialsSecretName,
+		serviceName: cluster.GetServiceReadWriteName(),
+	}
+
+	steps := make([]objectRestoreStep, 0, 6)
+	addStep := func(connection objectRestoreStep, name string, command ...string) {
+		connection.name = name
+		connection.command = command
+		steps = append(steps, connection)
+	}
+
+	addStep(source, "rename", "psql", "-v", "ON_ERROR_STOP=1", "--single-transaction",
+		"-c", getObjectRestoreRenameSQL(objectRestore))
+
+	tables := objectRestore.GetTables()
+	if len(tables) > 0 {
+		dumpCommand := []string{"pg_dump", "-Fc", "-f", path.Join(objectRestoreDumpPath, objectRestoreTablesDump)}
+		for _, table := range tables {
+			dumpCommand = append(dumpCommand, "-t", getObjectRestorePattern(objectRestore.Spec.TargetSchema, table[1]))
+		}
+		addStep(source, "dump-tables", dumpCommand...)
+	}
+
+	if len(objectRestore.Spec.Schemas) > 0 {
+		dumpCommand := []string{"pg_dump", "-Fc", "-f", path.Join(objectRestoreDumpPath, objectRestoreSchemasDump)}
+		for _, schema := range objectRestore.Spec.Schemas {
+			dumpCommand = append(dumpCommand, "-n", getObjectRestorePattern(schema+objectRestore.GetSchemaSuffix()))
+		}
+		addStep(source, "dump-schemas", dumpCommand...)
+	}
+
+	if len(tables) > 0 {
+		addStep(target, "prepare", "psql", "-v", "ON_ERROR_STOP=1",
+			"-c", fmt.Sprintf("CREATE SCHEMA IF NOT EXISTS %s", pgx.Identifier{objectRestore.Spec.TargetSchema}.Sanitize()))
+		addStep(target, "restore-tables", getObjectRestoreRestoreCommand(objectRestore, objectRestoreTablesDump)...)
+	}
+
+	if len(objectRestore.Spec.Schemas) > 0 {
+		addStep(target, "restore-schemas", getObjectRestoreRestoreCommand(objectRestore, objectRestoreSchemasDump)...)
+	}
+
+	containers := make([]corev1.Container, 0, len(steps))
+	for _, step := range steps {
+		containers = append(containers, corev1.Container{
+			Name:            step.name,
+			Image:           cluster.Status.Image,
+			ImagePullPolicy: cluster.Spec.ImagePullPolicy,
+			Command:         step.command,
+			Env:             getObjectRestoreConnectionEnv(step, objectRestore.Spec.Database),
+			VolumeMounts: []corev1.VolumeMount{
+				{Name: objectRestoreDumpVolumeName, MountPath: objectRestoreDumpPath},
+			},
+			SecurityContext: CreateContainerSecurityContext(cluster.GetSeccompProfile()),
+		})
+	}
+
+	labels := map[string]string{
+		utils.ObjectRestoreLabelName: objectRestore.Name,
+	}
+
+	// The steps run in sequence, as init containers followed by the
+	// last step
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      objectRestore.GetJobName(),
+			Namespace: objectRestore.Namespace,
+			Labels:    labels,
+		},
+		Spec: batchv1.JobSpec{
+			// Renaming the objects in the recovery cluster can't be retried
+			BackoffLimit: ptr.To[int32](0),
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: labels,
+				},
+				Spec: corev1.PodSpec{
+					InitContainers: containers[:len(containers)-1],
+					Containers:     containers[len(containers)-1:],
+					Volumes: []corev1.Volume{
+						{
+							Name:         objectRestoreDumpVolumeName,
+							VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+						},
+					},
+					SecurityContext: CreatePodSecurityContext(
+						cluster.GetSeccompProfile(),
+						cluster.GetPostgresUID(),
+						cluster.GetPostgresGID()),
+					// The service account of the cluster holds the pull secrets
+					// of its image
+					ServiceAccountName: cluster.Name,
+					RestartPolicy:      corev1.RestartPolicyNever,
+				},
+			},
+		},
+	}
+}
+
+// getObjectRestoreRenameSQL gets the statements moving the tables to the
+// target schema and renaming the schemas in the recovery cluster, so that
+// they can be dumped with their final names
+func getObjectRestoreRenameSQL(objectRestore *apiv1.ObjectRestore) string {
+	var statements []string
+
+	tables := objectRestore.GetTables()
+	if len(tables) > 0 {
+		targetSchema := pgx.Identifier{objectRestore.Spec.TargetSchema}.Sanitize()
+		statements = append(statements, fmt.Sprintf("CREATE SCHEMA IF NOT EXISTS %s", targetSchema))
+		for _, table := range tables {
+			if table[0] == objectRestore.Spec.TargetSchema {
+				continue
+			}
+			statements = append(statements, fmt.Sprintf("ALTER TABLE %s SET SCHEMA %s",
+				pgx.Identifier{table[0], table[1]}.Sanitize(), targetSchema))
+		}
+	}
+
+	for _, schema := range objectRestore.Spec.Schemas {
+		statements = append(statements, fmt.Sprintf("ALTER SCHEMA %s RENAME TO %s",
+			pgx.Identifier{schema}.Sanitize(),
+			pgx.Identifier{schema + objectRestore.GetSchemaSuffix()}.Sanitize()))
+	}
+
+	return strings.Join(statements, "; ")
+}
+
+// getObjectRestorePattern gets a pg_dump pattern matching exactly the
+// passed identifiers
+func getObjectRestorePattern(identifiers ...string) string {
+	return pgx.Identifier(identifiers).Sanitize()
+}
+
+// getObjectRestoreRestoreCommand gets the command restoring a dump into
+// the cluster
+func getObjectRestoreRestoreCommand(objectRestore *apiv1.ObjectRestore, dumpName string) []string {
+	command := []string{
+		"pg_restore",
+		"--dbname", objectRestore.Spec.Database,
+		"--no-owner",
+		"--exit-on-error",
+		"--single-transaction",
+	}
+	if objectRestore.Spec.Owner != "" {
+		command = append(command, "--role", objectRestore.Spec.Owner)
+	}
+	return append(command, path.Join(objectRestoreDumpPath, dumpName))
+}
+
+// getObjectRestoreConnectionEnv gets the libpq environment variables
+// connecting a step to its database
+func getObjectRestoreConnectionEnv(step objectRestoreStep, database string) []corev1.EnvVar {
+	secretKey := func(key string) *corev1.EnvVarSource {
+		return &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: step.secretName},
+				Key:                  key,
+			},
+		}
+	}
+
+	return []corev1.EnvVar{
+		{Name: "PGHOST", Value: step.serviceName},
+		{Name: "PGDATABASE", Value: database},
+		{Name: "PGSSLMODE", Value: "require"},
+		{Name: "PGUSER", ValueFrom: secretKey(corev1.BasicAuthUsernameKey)},
+		{Name: "PGPASSWORD", ValueFrom: secretKey(corev1.BasicAuthPasswordKey)},
+	}
+}
@@ -0,0 +1,104 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package specs
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Object restore job", func() {
+	cluster := &apiv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "cluster-example"},
+		Status:     apiv1.ClusterStatus{Image: "postgres:17"},
+	}
+	recoveryCluster := &apiv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "restore-recovery"},
+	}
+
+	var objectRestore *apiv1.ObjectRestore
+	BeforeEach(func() {
+		objectRestore = &apiv1.ObjectRestore{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "restore"},
+			Spec: apiv1.ObjectRestoreSpec{
+				ClusterRef:   apiv1.LocalObjectReference{Name: cluster.Name},
+				Database:     "app",
+				Tables:       []string{"sales.orders"},
+				TargetSchema: "restored",
+				Schemas:      []string{"billing"},
+				Owner:        "app",
+			},
+		}
+	})
+
+	It("runs the steps in sequence", func() {
+		job := CreateObjectRestoreJob(objectRestore, cluster, recoveryCluster, "cluster-example-superuser")
+		Expect(job.Name).To(Equal("restore-restore"))
+		Expect(job.Labels).To(HaveKeyWithValue(utils.ObjectRestoreLabelName, "restore"))
+		Expect(*job.Spec.BackoffLimit).To(BeZero())
+
+		podSpec := job.Spec.Template.Spec
+		var names []string
+		for _, container := range podSpec.InitContainers {
+			names = append(names, container.Name)
+		}
+		Expect(names).To(Equal([]string{"rename", "dump-tables", "dump-schemas", "prepare", "restore-tables"}))
+		Expect(podSpec.Containers).To(HaveLen(1))
+		Expect(podSpec.Containers[0].Name).To(Equal("restore-schemas"))
+		Expect(podSpec.Containers[0].Image).To(Equal("postgres:17"))
+		Expect(podSpec.Containers[0].Command).To(ContainElements("--role", "app", "--dbname", "app"))
+	})
+
+	It("connects to the recovery cluster to dump and to the cluster to restore", func() {
+		job := CreateObjectRestoreJob(objectRestore, cluster, recoveryCluster, "cluster-example-superuser")
+		podSpec := job.Spec.Template.Spec
+
+		dump := podSpec.InitContainers[1]
+		Expect(dump.Env[0].Value).To(Equal("restore-recovery-rw"))
+		Expect(dump.Env[3].ValueFrom.SecretKeyRef.Name).To(Equal("restore-recovery-superuser"))
+		Expect(dump.Command).To(ContainElements("-t", `"restored"."orders"`))
+
+		restore := podSpec.Containers[0]
+		Expect(restore.Env[0].Value).To(Equal("cluster-example-rw"))
+		Expect(restore.Env[3].ValueFrom.SecretKeyRef.Name).To(Equal("cluster-example-superuser"))
+	})
+
+	It("only runs the steps needed by the requested objects", func() {
+		objectRestore.Spec.Tables = nil
+		job := CreateObjectRestoreJob(objectRestore, cluster, recoveryCluster, "cluster-example-superuser")
+		podSpec := job.Spec.Template.Spec
+		Expect(podSpec.InitContainers).To(HaveLen(2))
+		Expect(podSpec.InitContainers[1].Command).To(ContainElements("-n", `"billing_restored"`))
+		Expect(podSpec.Containers[0].Name).To(Equal("restore-schemas"))
+	})
+
+	It("moves the tables and renames the schemas in the recovery cluster", func() {
+		objectRestore.Spec.Tables = []string{"sales.orders", "restored.items"}
+		Expect(getObjectRestoreRenameSQL(objectRestore)).To(Equal(
+			`CREATE SCHEMA IF NOT EXISTS "restored"; ` +
+				`ALTER TABLE "sales"."orders" SET SCHEMA "restored"; ` +
+				`ALTER SCHEMA "billing" RENAME TO "billing_restored"`))
+	})
+})
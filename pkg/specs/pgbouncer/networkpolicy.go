@@ -0,0 +1,91 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package pgbouncer
+
+import (
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	pgBouncerConfig "github.com/cloudnative-pg/cloudnative-pg/pkg/management/pgbouncer/config"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/url"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/specs"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+)
+
+// PoolerNetworkPolicyManager builds the NetworkPolicy for the pooler resource
+type PoolerNetworkPolicyManager struct {
+	pooler *apiv1.Pooler
+}
+
+// NewPoolerNetworkPolicyManager returns a new instance of PoolerNetworkPolicyManager
+func NewPoolerNetworkPolicyManager(pooler *apiv1.Pooler) *PoolerNetworkPolicyManager {
+	return &PoolerNetworkPolicyManager{pooler: pooler}
+}
+
+// IsNetworkPolicyEnabled returns a boolean indicating if the NetworkPolicy should exist or not
+func (c PoolerNetworkPolicyManager) IsNetworkPolicyEnabled() bool {
+	return c.pooler.IsNetworkPolicyEnabled()
+}
+
+// BuildNetworkPolicy builds a new NetworkPolicy object allowing the declared
+// clients to reach the PgBouncer port and the declared monitoring clients to
+// reach the metrics port
+func (c PoolerNetworkPolicyManager) BuildNetworkPolicy() *networkingv1.NetworkPolicy {
+	meta := metav1.ObjectMeta{
+		Namespace: c.pooler.Namespace,
+		Name:      c.pooler.Name,
+		Labels: map[string]string{
+			utils.PgbouncerNameLabel: c.pooler.Name,
+		},
+	}
+
+	utils.SetAsOwnedBy(&meta, c.pooler.ObjectMeta, c.pooler.TypeMeta)
+
+	var ingress []networkingv1.NetworkPolicyIngressRule
+	if configuration := c.pooler.Spec.NetworkPolicy; configuration != nil {
+		if len(configuration.Clients) > 0 {
+			ingress = append(ingress, networkingv1.NetworkPolicyIngressRule{
+				From:  configuration.Clients,
+				Ports: []networkingv1.NetworkPolicyPort{specs.TCPNetworkPolicyPort(pgBouncerConfig.PgBouncerPort)},
+			})
+		}
+		if len(configuration.MonitoringClients) > 0 {
+			ingress = append(ingress, networkingv1.NetworkPolicyIngressRule{
+				From:  configuration.MonitoringClients,
+				Ports: []networkingv1.NetworkPolicyPort{specs.TCPNetworkPolicyPort(url.PgBouncerMetricsPort)},
+			})
+		}
+	}
+
+	return &networkingv1.NetworkPolicy{
+		ObjectMeta: meta,
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					utils.PgbouncerNameLabel: c.pooler.Name,
+					utils.PodRoleLabelName:   string(utils.PodRolePooler),
+				},
+			},
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+			Ingress:     ingress,
+		},
+	}
+}
@@ -0,0 +1,84 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package pgbouncer
+
+import (
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	pgBouncerConfig "github.com/cloudnative-pg/cloudnative-pg/pkg/management/pgbouncer/config"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/url"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/specs"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Pooler NetworkPolicy", func() {
+	var pooler *apiv1.Pooler
+
+	BeforeEach(func() {
+		pooler = &apiv1.Pooler{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "pooler-example",
+				Namespace: "default",
+			},
+		}
+	})
+
+	It("denies every connection when no client is declared", func() {
+		pooler.Spec.NetworkPolicy = &apiv1.NetworkPolicyConfiguration{Enabled: true}
+		manager := NewPoolerNetworkPolicyManager(pooler)
+		Expect(manager.IsNetworkPolicyEnabled()).To(BeTrue())
+
+		policy := manager.BuildNetworkPolicy()
+		Expect(policy.Name).To(Equal(pooler.Name))
+		Expect(policy.Spec.PodSelector.MatchLabels).To(Equal(map[string]string{
+			utils.PgbouncerNameLabel: pooler.Name,
+			utils.PodRoleLabelName:   string(utils.PodRolePooler),
+		}))
+		Expect(policy.Spec.PolicyTypes).To(ConsistOf(networkingv1.PolicyTypeIngress))
+		Expect(policy.Spec.Ingress).To(BeEmpty())
+	})
+
+	It("allows the declared clients and monitoring clients", func() {
+		clients := networkingv1.NetworkPolicyPeer{
+			PodSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "frontend"}},
+		}
+		monitoring := networkingv1.NetworkPolicyPeer{
+			PodSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "prometheus"}},
+		}
+		pooler.Spec.NetworkPolicy = &apiv1.NetworkPolicyConfiguration{
+			Enabled:           true,
+			Clients:           []networkingv1.NetworkPolicyPeer{clients},
+			MonitoringClients: []networkingv1.NetworkPolicyPeer{monitoring},
+		}
+
+		policy := NewPoolerNetworkPolicyManager(pooler).BuildNetworkPolicy()
+		Expect(policy.Spec.Ingress).To(HaveLen(2))
+		Expect(policy.Spec.Ingress[0].From).To(ConsistOf(clients))
+		Expect(policy.Spec.Ingress[0].Ports).To(ConsistOf(
+			specs.TCPNetworkPolicyPort(pgBouncerConfig.PgBouncerPort)))
+		Expect(policy.Spec.Ingress[1].From).To(ConsistOf(monitoring))
+		Expect(policy.Spec.Ingress[1].Ports).To(ConsistOf(specs.TCPNetworkPolicyPort(url.PgBouncerMetricsPort)))
+	})
+})
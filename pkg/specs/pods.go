@@ -376,6 +376,53 @@ func CreateAffinitySection(clusterName string, config apiv1.AffinityConfiguratio
 	return affinity
 }
 
+// CreatePrimaryAffinitySection creates the affinity section for a Pod that is
+// going to run the primary instance, adding the primary placement preferences
+// as preferred node affinity terms
+func CreatePrimaryAffinitySection(cluster apiv1.Cluster) *corev1.Affinity {
+	affinity := CreateAffinitySection(cluster.Name, cluster.Spec.Affinity)
+	if cluster.Spec.PrimaryPlacement == nil || len(cluster.Spec.PrimaryPlacement.Preferences) == 0 {
+		return affinity
+	}
+
+	if affinity == nil {
+		affinity = &corev1.Affinity{}
+	}
+
+	// The node affinity may come from the Cluster spec, and we don't want to change it
+	if affinity.NodeAffinity == nil {
+		affinity.NodeAffinity = &corev1.NodeAffinity{}
+	} else {
+		affinity.NodeAffinity = affinity.NodeAffinity.DeepCopy()
+	}
+
+	for _, preference := range cluster.Spec.PrimaryPlacement.Preferences {
+		keys := make([]string, 0, len(preference.NodeLabels))
+		for key := range preference.NodeLabels {
+			keys = append(keys, key)
+		}
+		slices.Sort(keys)
+
+		term := corev1.NodeSelectorTerm{}
+		for _, key := range keys {
+			term.MatchExpressions = append(term.MatchExpressions, corev1.NodeSelectorRequirement{
+				Key:      key,
+				Operator: corev1.NodeSelectorOpIn,
+				Values:   []string{preference.NodeLabels[key]},
+			})
+		}
+
+		affinity.NodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution = append(
+			affinity.NodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution,
+			corev1.PreferredSchedulingTerm{
+				Weight:     preference.Weight,
+				Preference: term,
+			})
+	}
+
+	return affinity
+}
+
 // CreateGeneratedAntiAffinity generates the affinity terms the operator is in charge for if enabled,
 // return nil if disabled or an error occurred, as invalid values should be validated before this method is called
 func CreateGeneratedAntiAffinity(clusterName string, config apiv1.AffinityConfiguration) *corev1.Affinity {
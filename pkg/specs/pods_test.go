@@ -297,6 +297,43 @@ var _ = Describe("Create affinity section", func() {
 				To(BeEquivalentTo([]corev1.NodeSelectorTerm{testNodeSelectorTerm}))
 		})
 	})
+
+	When("given primary placement preferences", func() {
+		It("adds them as preferred node affinity terms without changing the cluster", func() {
+			cluster := v1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{Name: clusterName},
+				Spec: v1.ClusterSpec{
+					Affinity: v1.AffinityConfiguration{
+						NodeAffinity: &corev1.NodeAffinity{
+							RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+								NodeSelectorTerms: []corev1.NodeSelectorTerm{testNodeSelectorTerm},
+							},
+						},
+					},
+					PrimaryPlacement: &v1.PrimaryPlacementConfiguration{
+						Preferences: []v1.PrimaryPlacementPreference{
+							{
+								Weight:     30,
+								NodeLabels: map[string]string{"topology.kubernetes.io/zone": "zone-a"},
+							},
+						},
+					},
+				},
+			}
+			affinity := CreatePrimaryAffinitySection(cluster)
+			Expect(affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms).
+				To(BeEquivalentTo([]corev1.NodeSelectorTerm{testNodeSelectorTerm}))
+			Expect(affinity.NodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution).To(HaveLen(1))
+			preferred := affinity.NodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution[0]
+			Expect(preferred.Weight).To(BeEquivalentTo(30))
+			Expect(preferred.Preference.MatchExpressions).To(ConsistOf(corev1.NodeSelectorRequirement{
+				Key:      "topology.kubernetes.io/zone",
+				Operator: corev1.NodeSelectorOpIn,
+				Values:   []string{"zone-a"},
+			}))
+			Expect(cluster.Spec.Affinity.NodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution).To(BeEmpty())
+		})
+	})
 })
 
 var _ = Describe("EnvConfig", func() {
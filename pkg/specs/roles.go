@@ -228,10 +228,12 @@ func getInvolvedSecretNames(cluster apiv1.Cluster, backupOrigin *apiv1.Backup) [
 	involvedSecretNames := []string{
 		cluster.GetReplicationSecretName(),
 		cluster.GetClientCASecretName(),
+		cluster.GetOperatorClientCASecretName(),
 		cluster.GetServerCASecretName(),
 		cluster.GetServerTLSSecretName(),
 		cluster.GetApplicationSecretName(),
 		cluster.GetSuperuserSecretName(),
+		cluster.GetSuperuserGrantSecretName(),
 		cluster.GetLDAPSecretName(),
 	}
 
@@ -179,10 +179,12 @@ var _ = Describe("Roles", func() {
 		Expect(serviceAccount.Rules[1].ResourceNames).To(ConsistOf(
 			"testReplicationTLSSecret",
 			"testClientCASecret",
+			"thisTest-operator-ca",
 			"testServerCASecret",
 			"testServerTLSSecret",
 			"testSecretBootstrapRecovery",
 			"testSuperUserSecretName",
+			"thisTest-superuser-grant",
 			"testLDAPBindPasswordSecret",
 			"testSecretKeySelector",
 			"testS3Secret",
@@ -284,9 +286,11 @@ var _ = Describe("Secrets", func() {
 		Expect(getInvolvedSecretNames(cluster, nil)).To(Equal([]string{
 			"thisTest-app",
 			"thisTest-ca",
+			"thisTest-operator-ca",
 			"thisTest-replication",
 			"thisTest-server",
 			"thisTest-superuser",
+			"thisTest-superuser-grant",
 		}))
 	})
 
@@ -297,9 +301,11 @@ var _ = Describe("Secrets", func() {
 			"google-application-secret-test",
 			"thisTest-app",
 			"thisTest-ca",
+			"thisTest-operator-ca",
 			"thisTest-replication",
 			"thisTest-server",
 			"thisTest-superuser",
+			"thisTest-superuser-grant",
 		}))
 	})
 })
@@ -40,6 +40,25 @@ const PgWalVolumePgWalPath = "/var/lib/postgresql/wal/pg_wal"
 // PgTablespaceVolumePath is the base path used by tablespace when present
 const PgTablespaceVolumePath = "/var/lib/postgresql/tablespaces"
 
+// LogicalBackupVolumePath is the path where the logical backup volume is mounted
+const LogicalBackupVolumePath = "/var/lib/postgresql/logical-backups"
+
+// LogicalBackupStagingPath is the path where the ephemeral volume containing
+// the logical backups to be handed over to a plugin is mounted
+const LogicalBackupStagingPath = "/var/lib/postgresql/logical-backup-staging"
+
+// PersistentVolumeBackupPath is the path where the volume containing
+// the physical base backups is mounted
+const PersistentVolumeBackupPath = "/var/lib/postgresql/backups"
+
+// RecoveryBackupVolumePath is the path where the volume containing the
+// base backup to recover from is mounted in the full recovery job
+const RecoveryBackupVolumePath = "/var/lib/postgresql/recovery-backups"
+
+// PersistentVolumeWALArchiveDirectory is the directory where the WAL files
+// are archived, relative to the folder of the cluster in the backup volume
+const PersistentVolumeWALArchiveDirectory = "wal_archive"
+
 // MountForTablespace returns the normalized tablespace volume name for a given
 // tablespace, on a cluster pod
 func MountForTablespace(tablespaceName string) string {
@@ -147,6 +166,42 @@ func createPostgresVolumes(cluster *apiv1.Cluster, podName string) []corev1.Volu
 	if cluster.ShouldCreateProjectedVolume() {
 		result = append(result, createProjectedVolume(cluster))
 	}
+
+	if claimName := cluster.GetLogicalBackupVolumeClaimName(); claimName != "" {
+		result = append(result,
+			corev1.Volume{
+				Name: "logical-backups",
+				VolumeSource: corev1.VolumeSource{
+					PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+						ClaimName: claimName,
+					},
+				},
+			})
+	}
+
+	if cluster.ShouldCreateLogicalBackupStagingVolume() {
+		result = append(result,
+			corev1.Volume{
+				Name: "logical-backup-staging",
+				VolumeSource: corev1.VolumeSource{
+					EmptyDir: &corev1.EmptyDirVolumeSource{
+						SizeLimit: cluster.Spec.EphemeralVolumesSizeLimit.GetLogicalBackupStagingLimit(),
+					},
+				},
+			})
+	}
+
+	if claimName := cluster.GetPersistentVolumeBackupClaimName(); claimName != "" {
+		result = append(result,
+			corev1.Volume{
+				Name: "backups",
+				VolumeSource: corev1.VolumeSource{
+					PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+						ClaimName: claimName,
+					},
+				},
+			})
+	}
 	return result
 }
 
@@ -275,6 +330,33 @@ func CreatePostgresVolumeMounts(cluster apiv1.Cluster) []corev1.VolumeMount {
 			)
 		}
 	}
+
+	if cluster.GetLogicalBackupVolumeClaimName() != "" {
+		volumeMounts = append(volumeMounts,
+			corev1.VolumeMount{
+				Name:      "logical-backups",
+				MountPath: LogicalBackupVolumePath,
+			},
+		)
+	}
+
+	if cluster.ShouldCreateLogicalBackupStagingVolume() {
+		volumeMounts = append(volumeMounts,
+			corev1.VolumeMount{
+				Name:      "logical-backup-staging",
+				MountPath: LogicalBackupStagingPath,
+			},
+		)
+	}
+
+	if cluster.GetPersistentVolumeBackupClaimName() != "" {
+		volumeMounts = append(volumeMounts,
+			corev1.VolumeMount{
+				Name:      "backups",
+				MountPath: PersistentVolumeBackupPath,
+			},
+		)
+	}
 	return volumeMounts
 }
 
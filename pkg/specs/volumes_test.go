@@ -385,6 +385,53 @@ var _ = DescribeTable("test creation of volume mounts",
 				SubPathExpr:      "",
 			},
 		}),
+	Entry("creates the logical backup volume mount when logical backups are configured",
+		apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				Instances: 1,
+				Backup: &apiv1.BackupConfiguration{
+					Logical: &apiv1.LogicalBackupConfiguration{
+						VolumeClaimName: "dumps",
+					},
+				},
+			},
+		},
+		[]corev1.VolumeMount{
+			{
+				Name:      "logical-backups",
+				MountPath: "/var/lib/postgresql/logical-backups",
+			},
+		}),
+	Entry("creates the logical backup staging volume mount when a plugin is enabled",
+		apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				Instances: 1,
+				Plugins:   []apiv1.PluginConfiguration{{Name: "backup.example.com"}},
+			},
+		},
+		[]corev1.VolumeMount{
+			{
+				Name:      "logical-backup-staging",
+				MountPath: "/var/lib/postgresql/logical-backup-staging",
+			},
+		}),
+	Entry("creates the backup volume mount when persistent volume backups are configured",
+		apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				Instances: 1,
+				Backup: &apiv1.BackupConfiguration{
+					PersistentVolume: &apiv1.PersistentVolumeBackupConfiguration{
+						VolumeClaimName: "base-backups",
+					},
+				},
+			},
+		},
+		[]corev1.VolumeMount{
+			{
+				Name:      "backups",
+				MountPath: "/var/lib/postgresql/backups",
+			},
+		}),
 )
 
 var _ = DescribeTable("test creation of volumes",
@@ -476,6 +523,68 @@ var _ = DescribeTable("test creation of volumes",
 				},
 			},
 		}),
+	Entry("should create the logical backup volume when logical backups are configured",
+		apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				Instances: 1,
+				Backup: &apiv1.BackupConfiguration{
+					Logical: &apiv1.LogicalBackupConfiguration{
+						VolumeClaimName: "dumps",
+					},
+				},
+			},
+		},
+		[]corev1.Volume{
+			{
+				Name: "logical-backups",
+				VolumeSource: corev1.VolumeSource{
+					PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+						ClaimName: "dumps",
+					},
+				},
+			},
+		}),
+	Entry("should create the logical backup staging volume when a plugin is enabled",
+		apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				Instances: 1,
+				Plugins:   []apiv1.PluginConfiguration{{Name: "backup.example.com"}},
+				EphemeralVolumesSizeLimit: &apiv1.EphemeralVolumesSizeLimitConfiguration{
+					LogicalBackupStaging: ptr.To(resource.MustParse("10Gi")),
+				},
+			},
+		},
+		[]corev1.Volume{
+			{
+				Name: "logical-backup-staging",
+				VolumeSource: corev1.VolumeSource{
+					EmptyDir: &corev1.EmptyDirVolumeSource{
+						SizeLimit: ptr.To(resource.MustParse("10Gi")),
+					},
+				},
+			},
+		}),
+	Entry("should create the backup volume when persistent volume backups are configured",
+		apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				Instances: 1,
+				Backup: &apiv1.BackupConfiguration{
+					PersistentVolume: &apiv1.PersistentVolumeBackupConfiguration{
+						VolumeClaimName: "base-backups",
+					},
+				},
+			},
+		},
+		[]corev1.Volume{
+			{
+				Name: "backups",
+				VolumeSource: corev1.VolumeSource{
+					PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+						ClaimName: "base-backups",
+					},
+				},
+			},
+		}),
 )
 
 var _ = Describe("createEphemeralVolume", func() {
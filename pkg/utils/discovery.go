@@ -42,6 +42,9 @@ var haveSCC bool
 // haveVolumeSnapshot stores the result of the VolumeSnapshotExist function
 var haveVolumeSnapshot bool
 
+// haveCertManager stores the result of the DetectCertManagerExist function
+var haveCertManager bool
+
 // olmPlatform specifies whether we are running on a platform with OLM support
 var olmPlatform bool
 
@@ -175,6 +178,29 @@ func HaveVolumeSnapshot() bool {
 	return haveVolumeSnapshot
 }
 
+// DetectCertManagerExist connects to the discovery API and find out if
+// the cert-manager Certificate CRD exist in the cluster
+func DetectCertManagerExist(client discovery.DiscoveryInterface) (err error) {
+	haveCertManager, err = resourceExist(client, "cert-manager.io/v1", "certificates")
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// SetCertManager set the haveCertManager variable to a specific value for testing purposes
+// IMPORTANT: use it only in the unit tests
+func SetCertManager(value bool) {
+	haveCertManager = value
+}
+
+// HaveCertManager returns true if we're running under a system that implements
+// having the cert-manager Certificate CRD
+func HaveCertManager() bool {
+	return haveCertManager
+}
+
 // PodMonitorExist tries to find the PodMonitor resource in the current cluster
 func PodMonitorExist(client discovery.DiscoveryInterface) (bool, error) {
 	exist, err := resourceExist(client, "monitoring.coreos.com/v1", "podmonitors")
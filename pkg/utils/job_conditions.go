@@ -21,6 +21,7 @@ package utils
 
 import (
 	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
 )
 
 // JobHasOneCompletion Completion check if a certain job is complete
@@ -32,6 +33,16 @@ func JobHasOneCompletion(job batchv1.Job) bool {
 	return job.Status.Succeeded == requestedCompletions
 }
 
+// JobHasFailed checks if a certain job has failed
+func JobHasFailed(job batchv1.Job) bool {
+	for _, condition := range job.Status.Conditions {
+		if condition.Type == batchv1.JobFailed && condition.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
+
 // FilterJobsWithOneCompletion returns jobs that have one completion
 func FilterJobsWithOneCompletion(jobList []batchv1.Job) []batchv1.Job {
 	var result []batchv1.Job
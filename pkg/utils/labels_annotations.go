@@ -76,6 +76,19 @@ const (
 	// scheduled backup if a backup is created by a scheduled backup
 	ParentScheduledBackupLabelName = MetadataNamespace + "/scheduled-backup"
 
+	// BackupTierLabelName is the name of the label applied to the backups
+	// created by a scheduled backup with a retention policy, telling the
+	// retention tier they are assigned to
+	BackupTierLabelName = MetadataNamespace + "/backupTier"
+
+	// ObjectRestoreLabelName is the name of the label applied to the
+	// recovery cluster and to the job of an object restore
+	ObjectRestoreLabelName = MetadataNamespace + "/objectRestore"
+
+	// BackupGroupLabelName is the name of the label applied to the backups
+	// taken by a backup group
+	BackupGroupLabelName = MetadataNamespace + "/backupGroup"
+
 	// WatchedLabelName the name of the label which tells if a resource change will be automatically reloaded by instance
 	// or not, use for Secrets or ConfigMaps
 	WatchedLabelName = MetadataNamespace + "/reload"
@@ -226,6 +239,26 @@ const (
 	// latest required restart time
 	ClusterRestartAnnotationName = "kubectl.kubernetes.io/restartedAt"
 
+	// RunPendingRolloutAnnotationName is the name of the annotation requesting
+	// the pending rollout to be applied outside the maintenance windows. It
+	// contains the time when the operator postponed the pending rollout, as
+	// reported in the cluster status
+	RunPendingRolloutAnnotationName = MetadataNamespace + "/runPendingRolloutAt"
+
+	// SuperuserGrantUntilAnnotationName is the name of the annotation
+	// containing the time until which a temporary superuser access
+	// is requested
+	SuperuserGrantUntilAnnotationName = MetadataNamespace + "/superuserGrantUntil"
+
+	// CARotationPhaseAnnotationName is the name of the annotation containing
+	// the phase of the rotation of the CA stored in a secret
+	CARotationPhaseAnnotationName = MetadataNamespace + "/caRotationPhase"
+
+	// CARotationTimestampAnnotationName is the name of the annotation containing
+	// the time when the rotation of the CA stored in a secret entered its
+	// current phase
+	CARotationTimestampAnnotationName = MetadataNamespace + "/caRotationTimestamp"
+
 	// UpdateStrategyAnnotation is the name of the annotation used to indicate how to update the given resource
 	UpdateStrategyAnnotation = MetadataNamespace + "/updateStrategy"
 
@@ -250,6 +283,22 @@ const (
 	// WebhookValidationAnnotationName is the name of the annotation describing if
 	// the validation webhook should be enabled or disabled
 	WebhookValidationAnnotationName = MetadataNamespace + "/validation"
+
+	// BackupExportedFromAnnotationName is the name of the annotation containing
+	// the source of an exported object: the namespace and the name of the source
+	// backup for backups and snapshots, the name of the source content for
+	// volume snapshot contents
+	BackupExportedFromAnnotationName = MetadataNamespace + "/exportedFrom"
+
+	// BackupExportAllowedSourcesAnnotationName is the name of the annotation
+	// containing the comma-separated list of the namespaces allowed to export
+	// backups into the annotated namespace, or "*" to allow any namespace
+	BackupExportAllowedSourcesAnnotationName = MetadataNamespace + "/backupExportAllowedSources"
+
+	// ExportedDeletionPolicyAnnotationName is the name of the annotation
+	// containing the deletion policy a volume snapshot content had before
+	// being exported, which is restored when no export refers to it anymore
+	ExportedDeletionPolicyAnnotationName = MetadataNamespace + "/exportedDeletionPolicy"
 )
 
 type annotationStatus string
@@ -279,6 +328,8 @@ const (
 	PVCRolePgWal PVCRole = "PG_WAL"
 	// PVCRolePgTablespace the label value for the tablespace PVC role
 	PVCRolePgTablespace PVCRole = "PG_TABLESPACE"
+	// PVCRolePgBackup the label value for the backup PVC role
+	PVCRolePgBackup PVCRole = "PG_BACKUP"
 )
 
 // HibernationAnnotationValue describes the status of the hibernation